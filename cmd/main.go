@@ -2,15 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os/signal"
 	"pleasurelove/config"
 	"pleasurelove/internal/middleware"
 	"pleasurelove/internal/router"
+	"pleasurelove/internal/scheduler"
 	"pleasurelove/internal/seeder"
 	"pleasurelove/internal/utils"
+	"pleasurelove/pkg/invoice"
 	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/media"
+	"pleasurelove/pkg/payment"
 	"pleasurelove/pkg/redis"
+	"pleasurelove/pkg/shipping"
+	"pleasurelove/pkg/storage"
+	"pleasurelove/pkg/tax"
 	"syscall"
 
 	"log"
@@ -37,6 +45,8 @@ func main() {
 	db := config.InitDatabase(cfg)
 	app := fiber.New(fiber.Config{
 		ErrorHandler: middleware.ErrorHandler,
+		// upload gambar produk, batas per file dicek lagi di handler
+		BodyLimit: media.MaxUploadSize + 1<<20,
 	})
 
 	config.RunMigrations()
@@ -46,17 +56,52 @@ func main() {
 		log.Fatalf("Failed to initialize Redis: %v", err)
 	}
 
+	if err := storage.InitStorage(); err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	if err := shipping.InitShipping(); errors.Is(err, shipping.ErrNotConfigured) {
+		log.Printf("Warning: shipping disabled: %v", err)
+	} else if err != nil {
+		log.Fatalf("Failed to initialize shipping: %v", err)
+	}
+
+	if err := payment.InitPayment(); errors.Is(err, payment.ErrNotConfigured) {
+		log.Printf("Warning: %v", err)
+	} else if err != nil {
+		log.Fatalf("Failed to initialize payment: %v", err)
+	}
+
+	if err := tax.InitTax(); err != nil {
+		log.Fatalf("Failed to initialize tax: %v", err)
+	}
+
+	if err := invoice.InitInvoice(); errors.Is(err, invoice.ErrNotConfigured) {
+		log.Printf("Warning: %v", err)
+	} else if err != nil {
+		log.Fatalf("Failed to initialize invoice: %v", err)
+	}
+
 	if err := seeder.SeedSuperAdmin(context.Background(), db); err != nil {
 		logger.Error(context.Background(), "Failed to seed superadmin", err)
 		log.Fatalf("Failed to seed superadmin: %v", err)
 	}
 
+	if err := seeder.SeedRegions(context.Background(), db); err != nil {
+		log.Fatalf("Failed to seed region: %v", err)
+	}
+
 	// Security middleware: Helmet untuk secure headers
 	setupMiddlewares(app)
 
 	utils.InitValidator()
 	router.SetupRoutes(app, db)
 
+	// Background job (perubahan harga terjadwal, dll), berhenti saat shutdown
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	scheduler.Start(jobCtx, scheduler.PriceScheduleJob(db))
+
 	app.Use(func(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error":   "Route not found",
@@ -73,6 +118,7 @@ func main() {
 	go func() {
 		<-c
 		log.Println("Gracefully shutting down...")
+		stopJobs()
 		if err := app.Shutdown(); err != nil {
 			log.Fatalf("Error shutting down server: %v", err)
 		}
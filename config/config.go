@@ -60,7 +60,7 @@ func CorsConfig() fiber.Handler {
 		//AllowOrigins:     "https://example.com, https://www.example.com",
 		AllowOrigins:  "*",
 		AllowMethods:  "GET,POST,PUT,DELETE",
-		AllowHeaders:  "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, X-Guest-Token, Idempotency-Key",
 		ExposeHeaders: "Content-Length",
 		//AllowCredentials: true,
 		MaxAge: 3600,
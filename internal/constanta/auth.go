@@ -16,3 +16,10 @@ const (
 	RoleCodeSuperAdmin = "super-admin"
 	RoleCodeAdmin      = "admin"
 )
+
+// HeaderGuestToken header berisi guest token untuk pengunjung yang belum login (cart, checkout guest)
+const HeaderGuestToken = "X-Guest-Token"
+
+// HeaderIdempotencyKey key unik dari client per percobaan checkout, request ulang dengan key yang sama
+// mengembalikan order yang sudah dibuat
+const HeaderIdempotencyKey = "Idempotency-Key"
@@ -7,6 +7,14 @@ const (
 	MenuGroupPermissions     = "permissions"
 	MenuGroupRolePermissions = "role_permissions"
 	MenuGroupProduct         = "product"
+	MenuGroupPriceList       = "price_list"
+	MenuGroupPromotion       = "promotion"
+	MenuGroupVoucher         = "voucher"
+	MenuGroupCustomer        = "customer"
+	MenuGroupOrder           = "order"
+	MenuGroupPayment         = "payment"
+	MenuGroupTax             = "tax"
+	MenuGroupInvoice         = "invoice"
 )
 
 const (
@@ -39,12 +47,68 @@ const (
 	MenuProductActionRead   = MenuGroupProduct + ":" + AuthActionRead
 	MenuProductActionUpdate = MenuGroupProduct + ":" + AuthActionUpdate
 	MenuProductActionDelete = MenuGroupProduct + ":" + AuthActionDelete
+
+	MenuPriceListActionCreate = MenuGroupPriceList + ":" + AuthActionCreate
+	MenuPriceListActionRead   = MenuGroupPriceList + ":" + AuthActionRead
+	MenuPriceListActionUpdate = MenuGroupPriceList + ":" + AuthActionUpdate
+	MenuPriceListActionDelete = MenuGroupPriceList + ":" + AuthActionDelete
+
+	MenuPromotionActionCreate = MenuGroupPromotion + ":" + AuthActionCreate
+	MenuPromotionActionRead   = MenuGroupPromotion + ":" + AuthActionRead
+	MenuPromotionActionUpdate = MenuGroupPromotion + ":" + AuthActionUpdate
+	MenuPromotionActionDelete = MenuGroupPromotion + ":" + AuthActionDelete
+
+	MenuVoucherActionCreate = MenuGroupVoucher + ":" + AuthActionCreate
+	MenuVoucherActionRead   = MenuGroupVoucher + ":" + AuthActionRead
+	MenuVoucherActionUpdate = MenuGroupVoucher + ":" + AuthActionUpdate
+	MenuVoucherActionDelete = MenuGroupVoucher + ":" + AuthActionDelete
+
+	MenuCustomerActionRead   = MenuGroupCustomer + ":" + AuthActionRead
+	MenuCustomerActionUpdate = MenuGroupCustomer + ":" + AuthActionUpdate
+
+	MenuOrderActionCreate = MenuGroupOrder + ":" + AuthActionCreate
+	MenuOrderActionRead   = MenuGroupOrder + ":" + AuthActionRead
+	MenuOrderActionUpdate = MenuGroupOrder + ":" + AuthActionUpdate
+
+	MenuPaymentActionRead   = MenuGroupPayment + ":" + AuthActionRead
+	MenuPaymentActionUpdate = MenuGroupPayment + ":" + AuthActionUpdate
+
+	MenuTaxActionCreate = MenuGroupTax + ":" + AuthActionCreate
+	MenuTaxActionRead   = MenuGroupTax + ":" + AuthActionRead
+	MenuTaxActionUpdate = MenuGroupTax + ":" + AuthActionUpdate
+	MenuTaxActionDelete = MenuGroupTax + ":" + AuthActionDelete
+
+	MenuInvoiceActionCreate = MenuGroupInvoice + ":" + AuthActionCreate
+	MenuInvoiceActionRead   = MenuGroupInvoice + ":" + AuthActionRead
+	MenuInvoiceActionUpdate = MenuGroupInvoice + ":" + AuthActionUpdate
 )
 
 const (
-	FieldUserID      = "ID"
-	FieldCode        = "CODE"
-	FieldName        = "NAME"
-	FieldPermissions = "PERMISSIONS"
-	FieldCategory    = "CATEGORY"
+	FieldUserID        = "ID"
+	FieldCode          = "CODE"
+	FieldName          = "NAME"
+	FieldPermissions   = "PERMISSIONS"
+	FieldCategory      = "CATEGORY"
+	FieldProduct       = "PRODUCT"
+	FieldVarian        = "VARIAN"
+	FieldParent        = "PARENT"
+	FieldCustomer      = "CUSTOMER"
+	FieldCustomerGroup = "CUSTOMER_GROUP"
+	FieldPromotion     = "PROMOTION"
+	FieldVoucher       = "VOUCHER"
+	FieldStock         = "STOCK"
+	FieldBundle        = "BUNDLE"
+	FieldAttribute     = "ATTRIBUTE"
+	FieldMedia         = "MEDIA"
+	FieldFields        = "FIELDS"
+	FieldOrder         = "ORDER"
+	FieldStatus        = "STATUS"
+	FieldCart          = "CART"
+	FieldAddress       = "ADDRESS"
+	FieldRegion        = "REGION"
+	FieldPostalCode    = "POSTAL_CODE"
+	FieldShipping      = "SHIPPING"
+	FieldPayment       = "PAYMENT"
+	FieldTaxClass      = "TAX_CLASS"
+	FieldInvoice       = "INVOICE"
 )
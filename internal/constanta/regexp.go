@@ -1,7 +1,8 @@
 package constanta
 
 const (
-	EmailRegex    = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
-	UsernameRegex = `^[a-zA-Z0-9_]{3,20}$`
-	PhoneRegex    = `^\+?[0-9]{10,15}$`
+	EmailRegex      = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
+	UsernameRegex   = `^[a-zA-Z0-9_]{3,20}$`
+	PhoneRegex      = `^\+?[0-9]{10,15}$`
+	PostalCodeRegex = `^[0-9]{5}$` // kode pos Indonesia
 )
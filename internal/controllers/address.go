@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AddressController struct {
+	AddressUseCase usecase.AddressUseCase
+}
+
+func NewAddressController(addressUC usecase.AddressUseCase) *AddressController {
+	return &AddressController{AddressUseCase: addressUC}
+}
+
+func (ctrl *AddressController) GetListAddress(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.AddressUseCase.GetListAddress(ctx)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list address")
+	}
+
+	return response.SetResponseOK(c, "success get list address", res)
+}
+
+func (ctrl *AddressController) GetAddressByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.AddressUseCase.GetAddressByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get address")
+	}
+
+	return response.SetResponseOK(c, "success get address", res)
+}
+
+func (ctrl *AddressController) CreateAddress(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqAddress request.ReqAddress
+	if err := c.BodyParser(&reqAddress); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqAddress, request.ReqAddressErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.AddressUseCase.CreateAddress(ctx, &reqAddress)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create address")
+	}
+
+	return response.SetResponseOK(c, "success create address", res)
+}
+
+func (ctrl *AddressController) UpdateAddressByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	var reqAddress request.ReqAddress
+	if err := c.BodyParser(&reqAddress); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqAddress.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqAddress, request.ReqAddressErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.AddressUseCase.UpdateAddressByID(ctx, &reqAddress)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed update address")
+	}
+
+	return response.SetResponseOK(c, "success update address", res)
+}
+
+func (ctrl *AddressController) DeleteAddressByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqData := request.AbstractRequest{}
+	if err := c.BodyParser(&reqData); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.AddressUseCase.DeleteAddressByID(ctx, id, reqData); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed delete address")
+	}
+
+	return response.SetResponseOK(c, "success delete address", nil)
+}
@@ -16,12 +16,14 @@ import (
 
 type AuthController struct {
 	AuthUsecase usecase.AuthUseCase
+	CartUseCase usecase.CartUseCase
 }
 
 func NewAuthController(
 	authUC usecase.AuthUseCase,
+	cartUC usecase.CartUseCase,
 ) *AuthController {
-	return &AuthController{AuthUsecase: authUC}
+	return &AuthController{AuthUsecase: authUC, CartUseCase: cartUC}
 }
 
 func (ctrl *AuthController) Logout(c *fiber.Ctx) error {
@@ -123,5 +125,13 @@ func (ctrl *AuthController) GenerateAccessToken(c *fiber.Ctx) error {
 		return response.SetResponseInternalServerError(c, "Failed to save access token", err)
 	}
 
+	// Keranjang guest digabung ke user, login tetap berhasil walaupun penggabungan gagal
+	if reqToken.GuestToken != "" {
+		err = ctrl.CartUseCase.MergeGuestCart(ctx, user.ID, reqToken.GuestToken)
+		if err != nil {
+			logger.Error(ctx, "Failed to merge guest cart", err)
+		}
+	}
+
 	return response.SetResponseOK(c, "Access token generated", response.ResAuth{Token: accessToken})
 }
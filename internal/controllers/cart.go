@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CartController struct {
+	CartUseCase usecase.CartUseCase
+}
+
+func NewCartController(cartUC usecase.CartUseCase) *CartController {
+	return &CartController{CartUseCase: cartUC}
+}
+
+func (ctrl *CartController) GetCart(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.CartUseCase.GetCart(ctx)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get cart")
+	}
+
+	return response.SetResponseOK(c, "success get cart", res)
+}
+
+func (ctrl *CartController) AddCartItem(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqItem request.ReqCartItem
+	if err := c.BodyParser(&reqItem); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqItem, request.ReqCartItemErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CartUseCase.AddCartItem(ctx, &reqItem)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed add cart item")
+	}
+
+	return response.SetResponseOK(c, "success add cart item", res)
+}
+
+func (ctrl *CartController) UpdateCartItem(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqItem request.ReqCartItem
+	if err := c.BodyParser(&reqItem); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqItem, request.ReqCartItemErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CartUseCase.UpdateCartItem(ctx, &reqItem)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed update cart item")
+	}
+
+	return response.SetResponseOK(c, "success update cart item", res)
+}
+
+// RemoveCartItem id = product id, varian dipilih lewat query varian_id
+func (ctrl *CartController) RemoveCartItem(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	productID, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CartUseCase.RemoveCartItem(ctx, productID, int64(c.QueryInt("varian_id")))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed remove cart item")
+	}
+
+	return response.SetResponseOK(c, "success remove cart item", res)
+}
+
+func (ctrl *CartController) ClearCart(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	err := ctrl.CartUseCase.ClearCart(ctx)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed clear cart")
+	}
+
+	return response.SetResponseOK(c, "success clear cart", nil)
+}
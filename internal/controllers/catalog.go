@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"errors"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CatalogController struct {
+	CatalogUseCase usecase.CatalogUseCase
+}
+
+func NewCatalogController(catalogUC usecase.CatalogUseCase) *CatalogController {
+	return &CatalogController{CatalogUseCase: catalogUC}
+}
+
+func (ctrl *CatalogController) GetListProduct(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.CatalogUseCase.GetListProduct(ctx, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list product")
+	}
+
+	return response.SetResponseOK(c, "success get list product", res)
+}
+
+func (ctrl *CatalogController) SearchProduct(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqSearch request.ReqSearch
+	if err := c.QueryParser(&reqSearch); err != nil {
+		logger.Error(ctx, "Failed to parse query", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CatalogUseCase.SearchProduct(ctx, &reqSearch)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed search product")
+	}
+
+	return response.SetResponseOK(c, "success search product", res)
+}
+
+func (ctrl *CatalogController) GetProductBySlug(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	slug, err := readRequestParamSlug(c)
+	if err != nil {
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CatalogUseCase.GetProductBySlug(ctx, slug)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get product")
+	}
+
+	return response.SetResponseOK(c, "success get product", res)
+}
+
+func (ctrl *CatalogController) GetCategoryTree(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.CatalogUseCase.GetCategoryTree(ctx)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get category")
+	}
+
+	return response.SetResponseOK(c, "success get category", res)
+}
+
+func (ctrl *CatalogController) GetCategoryBySlug(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	slug, err := readRequestParamSlug(c)
+	if err != nil {
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CatalogUseCase.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get category")
+	}
+
+	return response.SetResponseOK(c, "success get category", res)
+}
+
+func readRequestParamSlug(c *fiber.Ctx) (string, error) {
+	slug := strings.ToLower(strings.TrimSpace(c.Params("slug")))
+	if slug == "" || slug != utils.GenerateSlug(slug) {
+		return "", errors.New("invalid request slug")
+	}
+	return slug, nil
+}
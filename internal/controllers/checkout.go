@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"fmt"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CheckoutController struct {
+	CheckoutUseCase usecase.CheckoutUseCase
+}
+
+func NewCheckoutController(checkoutUC usecase.CheckoutUseCase) *CheckoutController {
+	return &CheckoutController{CheckoutUseCase: checkoutUC}
+}
+
+func (ctrl *CheckoutController) Checkout(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqCheckout request.ReqCheckout
+	if err := c.BodyParser(&reqCheckout); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqCheckout.IdempotencyKey = c.Get(constanta.HeaderIdempotencyKey)
+
+	ok, errMsg := utils.ValidateRequest(reqCheckout, request.ReqCheckoutErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CheckoutUseCase.Checkout(ctx, &reqCheckout)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed checkout")
+	}
+
+	return response.SetResponseOK(c, "success checkout", res)
+}
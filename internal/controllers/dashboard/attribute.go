@@ -0,0 +1,124 @@
+package dashboard
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AttributeDashboardController struct {
+	AttributeUseCase usecase.AttributeUseCase
+}
+
+func NewAttributeController(attributeUC usecase.AttributeUseCase) *AttributeDashboardController {
+	return &AttributeDashboardController{AttributeUseCase: attributeUC}
+}
+
+func (ctrl *AttributeDashboardController) CreateAttribute(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqAttribute request.ReqAttribute
+	if err := c.BodyParser(&reqAttribute); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqAttribute, request.ReqAttributeErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.AttributeUseCase.CreateAttribute(ctx, &reqAttribute); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create attribute")
+	}
+
+	return response.SetResponseOK(c, "success create attribute", nil)
+}
+
+func (ctrl *AttributeDashboardController) GetAttributeByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.AttributeUseCase.GetAttributeByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get attribute")
+	}
+
+	return response.SetResponseOK(c, "success get attribute", res)
+}
+
+func (ctrl *AttributeDashboardController) GetListAttribute(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.AttributeUseCase.GetListAttribute(ctx, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list attribute")
+	}
+
+	return response.SetResponseOK(c, "success get list attribute", res)
+}
+
+func (ctrl *AttributeDashboardController) UpdateAttributeByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqUpdate := request.ReqAttributeUpdate{}
+	if err := c.BodyParser(&reqUpdate); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqUpdate.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqUpdate, request.ReqAttributeUpdateErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.AttributeUseCase.UpdateAttributeByID(ctx, &reqUpdate)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed update attribute")
+	}
+
+	return response.SetResponseOK(c, "success update attribute", res)
+}
+
+func (ctrl *AttributeDashboardController) DeleteAttributeByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqData := request.AbstractRequest{}
+	if err := c.BodyParser(&reqData); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	err = ctrl.AttributeUseCase.DeleteAttributeByID(ctx, id, reqData)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed delete attribute")
+	}
+
+	return response.SetResponseOK(c, "success delete attribute", nil)
+}
@@ -123,3 +123,96 @@ func (ctrl *CategoryDashboardController) DeleteCategoryByID(c *fiber.Ctx) error
 
 	return response.SetResponseOK(c, "success delete category", nil)
 }
+
+func (ctrl *CategoryDashboardController) GetCategoryTree(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.CategoryUseCase.GetCategoryTree(ctx, int64(c.QueryInt("root_id", 0)))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get category tree")
+	}
+
+	return response.SetResponseOK(c, "success get category tree", res)
+}
+
+func (ctrl *CategoryDashboardController) MoveCategoryByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqMove := request.ReqCategoryMove{}
+	if err := c.BodyParser(&reqMove); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqMove.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqMove, request.ReqCategoryMoveErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CategoryUseCase.MoveCategoryByID(ctx, &reqMove)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed move category")
+	}
+
+	return response.SetResponseOK(c, "success move category", res)
+}
+
+// ExportListCategory filter dan urutan sama dengan list, format=csv|xlsx, fields=kolom yang diexport (opsional)
+func (ctrl *CategoryDashboardController) ExportListCategory(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqExport request.ReqExport
+	if err := c.QueryParser(&reqExport); err != nil {
+		logger.Error(ctx, "Failed to parse query", err)
+		return response.SetResponseBadRequest(c, errorutils.ErrMessageInvalidRequestData, err)
+	}
+
+	res, err := ctrl.CategoryUseCase.ExportListCategory(ctx, utils.GetFiltersForExport(c), &reqExport)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed export list category")
+	}
+
+	return response.SetResponseFileStream(c, ctx, res)
+}
+
+func (ctrl *CategoryDashboardController) BulkMoveCategory(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqBulk request.ReqCategoryBulkMove
+	if err := c.BodyParser(&reqBulk); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CategoryUseCase.BulkMoveCategory(ctx, &reqBulk)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed bulk move category")
+	}
+
+	return response.SetResponseOK(c, "success bulk move category", res)
+}
+
+func (ctrl *CategoryDashboardController) BulkDeleteCategory(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqBulk request.ReqBulkDelete
+	if err := c.BodyParser(&reqBulk); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CategoryUseCase.BulkDeleteCategory(ctx, &reqBulk)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed bulk delete category")
+	}
+
+	return response.SetResponseOK(c, "success bulk delete category", res)
+}
@@ -0,0 +1,128 @@
+package dashboard
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CustomerDashboardController struct {
+	CustomerUseCase usecase.CustomerUseCase
+	AddressUseCase  usecase.AddressUseCase
+}
+
+func NewCustomerController(customerUC usecase.CustomerUseCase, addressUC usecase.AddressUseCase) *CustomerDashboardController {
+	return &CustomerDashboardController{CustomerUseCase: customerUC, AddressUseCase: addressUC}
+}
+
+func (ctrl *CustomerDashboardController) GetListCustomer(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.CustomerUseCase.GetListCustomer(ctx, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list customer")
+	}
+
+	return response.SetResponseOK(c, "success get list customer", res)
+}
+
+// ExportListCustomer filter dan urutan sama dengan list, format=csv|xlsx, fields=kolom yang diexport (opsional)
+func (ctrl *CustomerDashboardController) ExportListCustomer(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqExport request.ReqExport
+	if err := c.QueryParser(&reqExport); err != nil {
+		logger.Error(ctx, "Failed to parse query", err)
+		return response.SetResponseBadRequest(c, errorutils.ErrMessageInvalidRequestData, err)
+	}
+
+	res, err := ctrl.CustomerUseCase.ExportListCustomer(ctx, utils.GetFiltersForExport(c), &reqExport)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed export list customer")
+	}
+
+	return response.SetResponseFileStream(c, ctx, res)
+}
+
+func (ctrl *CustomerDashboardController) GetListCustomerAddress(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.AddressUseCase.GetListCustomerAddress(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list customer address")
+	}
+
+	return response.SetResponseOK(c, "success get list customer address", res)
+}
+
+func (ctrl *CustomerDashboardController) CreateCustomerAddress(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	var reqAddress request.ReqAddress
+	if err := c.BodyParser(&reqAddress); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqAddress.CustomerID = id
+
+	ok, errMsg := utils.ValidateRequest(reqAddress, request.ReqAddressErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.AddressUseCase.CreateCustomerAddress(ctx, &reqAddress)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create customer address")
+	}
+
+	return response.SetResponseOK(c, "success create customer address", res)
+}
+
+// UpdateCustomerAddressByID id = id alamat
+func (ctrl *CustomerDashboardController) UpdateCustomerAddressByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	var reqAddress request.ReqAddress
+	if err := c.BodyParser(&reqAddress); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqAddress.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqAddress, request.ReqAddressErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.AddressUseCase.UpdateCustomerAddressByID(ctx, &reqAddress)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed update customer address")
+	}
+
+	return response.SetResponseOK(c, "success update customer address", res)
+}
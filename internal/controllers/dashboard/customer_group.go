@@ -0,0 +1,177 @@
+package dashboard
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CustomerGroupDashboardController struct {
+	CustomerGroupUseCase usecase.CustomerGroupUseCase
+}
+
+func NewCustomerGroupController(customerGroupUC usecase.CustomerGroupUseCase) *CustomerGroupDashboardController {
+	return &CustomerGroupDashboardController{CustomerGroupUseCase: customerGroupUC}
+}
+
+func (ctrl *CustomerGroupDashboardController) CreateCustomerGroup(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqGroup request.ReqCustomerGroup
+	if err := c.BodyParser(&reqGroup); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqGroup, request.ReqCustomerGroupErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.CustomerGroupUseCase.CreateCustomerGroup(ctx, &reqGroup); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create customer group")
+	}
+
+	return response.SetResponseOK(c, "success create customer group", nil)
+}
+
+func (ctrl *CustomerGroupDashboardController) GetCustomerGroupByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CustomerGroupUseCase.GetCustomerGroupByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get customer group")
+	}
+
+	return response.SetResponseOK(c, "success get customer group", res)
+}
+
+func (ctrl *CustomerGroupDashboardController) GetListCustomerGroup(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.CustomerGroupUseCase.GetListCustomerGroup(ctx, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list customer group")
+	}
+
+	return response.SetResponseOK(c, "success get list customer group", res)
+}
+
+func (ctrl *CustomerGroupDashboardController) UpdateCustomerGroupByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqUpdate := request.ReqCustomerGroupUpdate{}
+	if err := c.BodyParser(&reqUpdate); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqUpdate.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqUpdate, request.ReqCustomerGroupUpdateErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.CustomerGroupUseCase.UpdateCustomerGroupByID(ctx, &reqUpdate)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed update customer group")
+	}
+
+	return response.SetResponseOK(c, "success update customer group", res)
+}
+
+func (ctrl *CustomerGroupDashboardController) DeleteCustomerGroupByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqData := request.AbstractRequest{}
+	if err := c.BodyParser(&reqData); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	err = ctrl.CustomerGroupUseCase.DeleteCustomerGroupByID(ctx, id, reqData)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed delete customer group")
+	}
+
+	return response.SetResponseOK(c, "success delete customer group", nil)
+}
+
+func (ctrl *CustomerGroupDashboardController) AddCustomerGroupMember(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	reqMember, err := parseCustomerGroupMember(c)
+	if err != nil {
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.CustomerGroupUseCase.AddCustomerGroupMember(ctx, &reqMember); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed add customer group member")
+	}
+
+	return response.SetResponseOK(c, "success add customer group member", nil)
+}
+
+func (ctrl *CustomerGroupDashboardController) RemoveCustomerGroupMember(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	reqMember, err := parseCustomerGroupMember(c)
+	if err != nil {
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.CustomerGroupUseCase.RemoveCustomerGroupMember(ctx, &reqMember); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed remove customer group member")
+	}
+
+	return response.SetResponseOK(c, "success remove customer group member", nil)
+}
+
+func parseCustomerGroupMember(c *fiber.Ctx) (request.ReqCustomerGroupMember, error) {
+	var reqMember request.ReqCustomerGroupMember
+
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		return reqMember, err
+	}
+
+	if err := c.BodyParser(&reqMember); err != nil {
+		return reqMember, err
+	}
+	reqMember.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqMember, request.ReqCustomerGroupMemberErrorMessage)
+	if !ok {
+		return reqMember, fmt.Errorf("%s", errMsg)
+	}
+
+	return reqMember, nil
+}
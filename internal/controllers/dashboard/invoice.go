@@ -0,0 +1,128 @@
+package dashboard
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type InvoiceDashboardController struct {
+	InvoiceUseCase usecase.InvoiceUseCase
+}
+
+func NewInvoiceController(invoiceUC usecase.InvoiceUseCase) *InvoiceDashboardController {
+	return &InvoiceDashboardController{InvoiceUseCase: invoiceUC}
+}
+
+func (ctrl *InvoiceDashboardController) GetListInvoice(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.InvoiceUseCase.GetListInvoice(ctx, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list invoice")
+	}
+
+	return response.SetResponseOK(c, "success get list invoice", res)
+}
+
+func (ctrl *InvoiceDashboardController) GetInvoiceByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.InvoiceUseCase.GetInvoiceByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get invoice")
+	}
+
+	return response.SetResponseOK(c, "success get invoice", res)
+}
+
+func (ctrl *InvoiceDashboardController) GetListInvoiceByOrderID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.InvoiceUseCase.GetListInvoiceByOrderID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get order invoices")
+	}
+
+	return response.SetResponseOK(c, "success get order invoices", res)
+}
+
+func (ctrl *InvoiceDashboardController) IssueInvoice(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.InvoiceUseCase.IssueInvoice(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed issue invoice")
+	}
+
+	return response.SetResponseOK(c, "success issue invoice", res)
+}
+
+// DownloadInvoice GET /invoice/:id/download?format=pdf|escpos58|escpos80
+func (ctrl *InvoiceDashboardController) DownloadInvoice(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	reqDocument, err := readInvoiceDocumentRequest(c)
+	if err != nil {
+		logger.Error(ctx, "error validate request", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.InvoiceUseCase.DownloadInvoice(ctx, &reqDocument)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed download invoice")
+	}
+
+	return response.SetResponseFile(c, res)
+}
+
+// PrintInvoice POST /invoice/:id/print?format=pdf|escpos58|escpos80, cetakan ulang ditandai salinan
+func (ctrl *InvoiceDashboardController) PrintInvoice(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	reqDocument, err := readInvoiceDocumentRequest(c)
+	if err != nil {
+		logger.Error(ctx, "error validate request", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.InvoiceUseCase.PrintInvoice(ctx, &reqDocument)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed print invoice")
+	}
+
+	return response.SetResponseFile(c, res)
+}
+
+func readInvoiceDocumentRequest(c *fiber.Ctx) (request.ReqInvoiceDocument, error) {
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		return request.ReqInvoiceDocument{}, err
+	}
+
+	reqDocument := request.ReqInvoiceDocument{ID: id, Format: c.Query("format")}
+	ok, errMsg := utils.ValidateRequest(reqDocument, request.ReqInvoiceDocumentErrorMessage)
+	if !ok {
+		return request.ReqInvoiceDocument{}, fmt.Errorf("%s", errMsg)
+	}
+	return reqDocument, nil
+}
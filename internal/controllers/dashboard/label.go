@@ -0,0 +1,45 @@
+package dashboard
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LabelDashboardController struct {
+	LabelUseCase usecase.LabelUseCase
+}
+
+func NewLabelController(labelUC usecase.LabelUseCase) *LabelDashboardController {
+	return &LabelDashboardController{LabelUseCase: labelUC}
+}
+
+func (ctrl *LabelDashboardController) GenerateLabel(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqLabel request.ReqLabel
+	if err := c.BodyParser(&reqLabel); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqLabel, request.ReqLabelErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.LabelUseCase.GenerateLabel(ctx, &reqLabel)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed generate label")
+	}
+
+	return response.SetResponseFile(c, res)
+}
@@ -0,0 +1,103 @@
+package dashboard
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OrderDashboardController struct {
+	OrderUseCase usecase.OrderUseCase
+}
+
+func NewOrderController(orderUC usecase.OrderUseCase) *OrderDashboardController {
+	return &OrderDashboardController{OrderUseCase: orderUC}
+}
+
+func (ctrl *OrderDashboardController) CreateOrder(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqOrder request.ReqOrder
+	if err := c.BodyParser(&reqOrder); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqOrder, request.ReqOrderErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.OrderUseCase.CreateOrder(ctx, &reqOrder)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create order")
+	}
+
+	return response.SetResponseOK(c, "success create order", res)
+}
+
+func (ctrl *OrderDashboardController) GetOrderByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.OrderUseCase.GetOrderByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get order")
+	}
+
+	return response.SetResponseOK(c, "success get order", res)
+}
+
+func (ctrl *OrderDashboardController) GetListOrder(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.OrderUseCase.GetListOrder(ctx, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list order")
+	}
+
+	return response.SetResponseOK(c, "success get list order", res)
+}
+
+func (ctrl *OrderDashboardController) UpdateOrderStatusByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqStatus := request.ReqOrderStatus{}
+	if err := c.BodyParser(&reqStatus); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqStatus.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqStatus, request.ReqOrderStatusErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.OrderUseCase.UpdateOrderStatusByID(ctx, &reqStatus)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed update order status")
+	}
+
+	return response.SetResponseOK(c, "success update order status", res)
+}
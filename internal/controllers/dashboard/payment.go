@@ -0,0 +1,124 @@
+package dashboard
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PaymentDashboardController struct {
+	PaymentUseCase usecase.PaymentUseCase
+}
+
+func NewPaymentController(paymentUC usecase.PaymentUseCase) *PaymentDashboardController {
+	return &PaymentDashboardController{PaymentUseCase: paymentUC}
+}
+
+func (ctrl *PaymentDashboardController) GetListPayment(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.PaymentUseCase.GetListPayment(ctx, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list payment")
+	}
+
+	return response.SetResponseOK(c, "success get list payment", res)
+}
+
+func (ctrl *PaymentDashboardController) GetPaymentByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PaymentUseCase.GetPaymentDetailByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get payment")
+	}
+
+	return response.SetResponseOK(c, "success get payment", res)
+}
+
+func (ctrl *PaymentDashboardController) GetListPaymentByOrderID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PaymentUseCase.GetListPaymentByOrderID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get order payments")
+	}
+
+	return response.SetResponseOK(c, "success get order payments", res)
+}
+
+func (ctrl *PaymentDashboardController) ConfirmPayment(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqConfirm := request.ReqPaymentConfirm{}
+	if err := c.BodyParser(&reqConfirm); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqConfirm.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqConfirm, request.ReqPaymentConfirmErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PaymentUseCase.ConfirmPayment(ctx, &reqConfirm)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed confirm payment")
+	}
+
+	return response.SetResponseOK(c, "success confirm payment", res)
+}
+
+func (ctrl *PaymentDashboardController) RefundPayment(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqRefund := request.ReqPaymentRefund{}
+	if err := c.BodyParser(&reqRefund); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqRefund.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqRefund, request.ReqPaymentRefundErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PaymentUseCase.RefundPayment(ctx, &reqRefund)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed refund payment")
+	}
+
+	return response.SetResponseOK(c, "success refund payment", res)
+}
@@ -0,0 +1,153 @@
+package dashboard
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PriceListDashboardController struct {
+	PriceListUseCase usecase.PriceListUseCase
+	PricingUseCase   usecase.PricingUseCase
+}
+
+func NewPriceListController(priceListUC usecase.PriceListUseCase, pricingUC usecase.PricingUseCase) *PriceListDashboardController {
+	return &PriceListDashboardController{
+		PriceListUseCase: priceListUC,
+		PricingUseCase:   pricingUC,
+	}
+}
+
+func (ctrl *PriceListDashboardController) CreatePriceList(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqPriceList request.ReqPriceList
+	if err := c.BodyParser(&reqPriceList); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqPriceList, request.ReqPriceListErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.PriceListUseCase.CreatePriceList(ctx, &reqPriceList); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create price list")
+	}
+
+	return response.SetResponseOK(c, "success create price list", nil)
+}
+
+func (ctrl *PriceListDashboardController) GetPriceListByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PriceListUseCase.GetPriceListByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get price list")
+	}
+
+	return response.SetResponseOK(c, "success get price list", res)
+}
+
+func (ctrl *PriceListDashboardController) GetListPriceList(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.PriceListUseCase.GetListPriceList(ctx, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list price list")
+	}
+
+	return response.SetResponseOK(c, "success get list price list", res)
+}
+
+func (ctrl *PriceListDashboardController) UpdatePriceListByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqUpdate := request.ReqPriceListUpdate{}
+	if err := c.BodyParser(&reqUpdate); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqUpdate.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqUpdate, request.ReqPriceListUpdateErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PriceListUseCase.UpdatePriceListByID(ctx, &reqUpdate)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed update price list")
+	}
+
+	return response.SetResponseOK(c, "success update price list", res)
+}
+
+func (ctrl *PriceListDashboardController) DeletePriceListByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqData := request.AbstractRequest{}
+	if err := c.BodyParser(&reqData); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	err = ctrl.PriceListUseCase.DeletePriceListByID(ctx, id, reqData)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed delete price list")
+	}
+
+	return response.SetResponseOK(c, "success delete price list", nil)
+}
+
+// QuotePrice simulasi harga efektif untuk pelanggan tertentu (customer_id kosong = harga umum)
+func (ctrl *PriceListDashboardController) QuotePrice(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqQuote request.ReqPriceQuote
+	if err := c.BodyParser(&reqQuote); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqQuote, request.ReqPriceQuoteErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PricingUseCase.QuotePrice(ctx, &reqQuote)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed quote price")
+	}
+
+	return response.SetResponseOK(c, "success quote price", res)
+}
@@ -2,22 +2,33 @@ package dashboard
 
 import (
 	"fmt"
+	"io"
 	"pleasurelove/internal/dto/request"
 	"pleasurelove/internal/dto/response"
 	"pleasurelove/internal/usecase"
 	"pleasurelove/internal/utils"
 	"pleasurelove/internal/utils/errorutils"
 	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/media"
+	"pleasurelove/pkg/sheet"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type ProductDashboardController struct {
-	ProductUseCase usecase.ProductUseCase
+	ProductUseCase       usecase.ProductUseCase
+	PriceScheduleUseCase usecase.PriceScheduleUseCase
+	InventoryUseCase     usecase.InventoryUseCase
+	ProductMediaUseCase  usecase.ProductMediaUseCase
 }
 
-func NewProductController(productUC usecase.ProductUseCase) *ProductDashboardController {
-	return &ProductDashboardController{ProductUseCase: productUC}
+func NewProductController(productUC usecase.ProductUseCase, priceScheduleUC usecase.PriceScheduleUseCase, inventoryUC usecase.InventoryUseCase, productMediaUC usecase.ProductMediaUseCase) *ProductDashboardController {
+	return &ProductDashboardController{
+		ProductUseCase:       productUC,
+		PriceScheduleUseCase: priceScheduleUC,
+		InventoryUseCase:     inventoryUC,
+		ProductMediaUseCase:  productMediaUC,
+	}
 }
 
 func (ctrl *ProductDashboardController) CreateProduct(c *fiber.Ctx) error {
@@ -71,6 +82,23 @@ func (ctrl *ProductDashboardController) GetListProduct(c *fiber.Ctx) error {
 	return response.SetResponseOK(c, "success get list product", res)
 }
 
+func (ctrl *ProductDashboardController) SearchProduct(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqSearch request.ReqSearch
+	if err := c.QueryParser(&reqSearch); err != nil {
+		logger.Error(ctx, "Failed to parse query", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.ProductUseCase.SearchProduct(ctx, &reqSearch)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed search product")
+	}
+
+	return response.SetResponseOK(c, "success search product", res)
+}
+
 func (ctrl *ProductDashboardController) UpdateProductByID(c *fiber.Ctx) error {
 	ctx := utils.GetContext(c)
 
@@ -124,3 +152,344 @@ func (ctrl *ProductDashboardController) DeleteProductByID(c *fiber.Ctx) error {
 
 	return response.SetResponseOK(c, "success delete product", nil)
 }
+
+func (ctrl *ProductDashboardController) CreatePriceSchedule(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	var reqSchedule request.ReqPriceSchedule
+	if err := c.BodyParser(&reqSchedule); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqSchedule.ProductID = id
+
+	ok, errMsg := utils.ValidateRequest(reqSchedule, request.ReqPriceScheduleErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PriceScheduleUseCase.CreatePriceSchedule(ctx, &reqSchedule)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create price schedule")
+	}
+
+	return response.SetResponseOK(c, "success create price schedule", res)
+}
+
+func (ctrl *ProductDashboardController) GetListPriceSchedule(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PriceScheduleUseCase.GetListPriceSchedule(ctx, id, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list price schedule")
+	}
+
+	return response.SetResponseOK(c, "success get list price schedule", res)
+}
+
+func (ctrl *ProductDashboardController) CancelPriceSchedule(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqData := request.AbstractRequest{}
+	if err := c.BodyParser(&reqData); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.PriceScheduleUseCase.CancelPriceSchedule(ctx, id, reqData); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed cancel price schedule")
+	}
+
+	return response.SetResponseOK(c, "success cancel price schedule", nil)
+}
+
+func (ctrl *ProductDashboardController) GetPriceTimeline(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PriceScheduleUseCase.GetPriceTimeline(ctx, id, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get price history")
+	}
+
+	return response.SetResponseOK(c, "success get price history", res)
+}
+
+// AdjustStock penyesuaian stok manual produk / varian, stok bundle mengikuti komponen
+func (ctrl *ProductDashboardController) AdjustStock(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	var reqAdjust request.ReqStockAdjust
+	if err := c.BodyParser(&reqAdjust); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqAdjust.ProductID = id
+
+	ok, errMsg := utils.ValidateRequest(reqAdjust, request.ReqStockAdjustErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.InventoryUseCase.AdjustStock(ctx, &reqAdjust); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed adjust stock")
+	}
+
+	return response.SetResponseOK(c, "success adjust stock", nil)
+}
+
+// UploadMedia upload gambar produk (multipart/form-data, field "file")
+func (ctrl *ProductDashboardController) UploadMedia(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	var reqUpload request.ReqProductMediaUpload
+	if err := c.BodyParser(&reqUpload); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqUpload.ProductID = id
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		logger.Error(ctx, "Failed to read form file", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	if fileHeader.Size > media.MaxUploadSize {
+		err := fmt.Errorf("ukuran file maksimal %d MB", media.MaxUploadSize>>20)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error(ctx, "Failed to open form file", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	defer file.Close()
+
+	reqUpload.Data, err = io.ReadAll(io.LimitReader(file, media.MaxUploadSize+1))
+	if err != nil {
+		logger.Error(ctx, "Failed to read form file", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqUpload, request.ReqProductMediaUploadErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.ProductMediaUseCase.UploadMedia(ctx, &reqUpload)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed upload media")
+	}
+
+	return response.SetResponseOK(c, "success upload media", res)
+}
+
+func (ctrl *ProductDashboardController) GetListMedia(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.ProductMediaUseCase.GetListMediaByProductID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list media")
+	}
+
+	return response.SetResponseOK(c, "success get list media", res)
+}
+
+func (ctrl *ProductDashboardController) UpdateMediaByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	var reqUpdate request.ReqProductMediaUpdate
+	if err := c.BodyParser(&reqUpdate); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqUpdate.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqUpdate, request.ReqProductMediaUpdateErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.ProductMediaUseCase.UpdateMediaByID(ctx, &reqUpdate)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed update media")
+	}
+
+	return response.SetResponseOK(c, "success update media", res)
+}
+
+func (ctrl *ProductDashboardController) DeleteMediaByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqData := request.AbstractRequest{}
+	if err := c.BodyParser(&reqData); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.ProductMediaUseCase.DeleteMediaByID(ctx, id, reqData); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed delete media")
+	}
+
+	return response.SetResponseOK(c, "success delete media", nil)
+}
+
+// ImportProduct import produk dari csv / xlsx (multipart/form-data, field "file"), dry_run=true hanya validasi
+func (ctrl *ProductDashboardController) ImportProduct(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqImport request.ReqProductImport
+	if err := c.BodyParser(&reqImport); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		logger.Error(ctx, "Failed to read form file", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	if fileHeader.Size > sheet.MaxFileSize {
+		err := fmt.Errorf("ukuran file maksimal %d MB", sheet.MaxFileSize>>20)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error(ctx, "Failed to open form file", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	defer file.Close()
+
+	reqImport.FileName = fileHeader.Filename
+	reqImport.Data, err = io.ReadAll(io.LimitReader(file, sheet.MaxFileSize))
+	if err != nil {
+		logger.Error(ctx, "Failed to read form file", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.ProductUseCase.ImportProduct(ctx, &reqImport)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed import product")
+	}
+
+	return response.SetResponseOK(c, "success import product", res)
+}
+
+func (ctrl *ProductDashboardController) GetProductImportStatus(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.ProductUseCase.GetProductImportStatus(ctx, c.Params("id"))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get import status")
+	}
+
+	return response.SetResponseOK(c, "success get import status", res)
+}
+
+// ExportListProduct filter dan urutan sama dengan list, format=csv|xlsx, fields=kolom yang diexport (opsional)
+func (ctrl *ProductDashboardController) ExportListProduct(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqExport request.ReqExport
+	if err := c.QueryParser(&reqExport); err != nil {
+		logger.Error(ctx, "Failed to parse query", err)
+		return response.SetResponseBadRequest(c, errorutils.ErrMessageInvalidRequestData, err)
+	}
+
+	res, err := ctrl.ProductUseCase.ExportListProduct(ctx, utils.GetFiltersForExport(c), &reqExport)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed export list product")
+	}
+
+	return response.SetResponseFileStream(c, ctx, res)
+}
+
+func (ctrl *ProductDashboardController) BulkUpdateProduct(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqBulk request.ReqProductBulkUpdate
+	if err := c.BodyParser(&reqBulk); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.ProductUseCase.BulkUpdateProduct(ctx, &reqBulk)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed bulk update product")
+	}
+
+	return response.SetResponseOK(c, "success bulk update product", res)
+}
+
+func (ctrl *ProductDashboardController) BulkDeleteProduct(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqBulk request.ReqBulkDelete
+	if err := c.BodyParser(&reqBulk); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.ProductUseCase.BulkDeleteProduct(ctx, &reqBulk)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed bulk delete product")
+	}
+
+	return response.SetResponseOK(c, "success bulk delete product", res)
+}
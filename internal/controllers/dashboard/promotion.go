@@ -0,0 +1,149 @@
+package dashboard
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PromotionDashboardController struct {
+	PromotionUseCase usecase.PromotionUseCase
+}
+
+func NewPromotionController(promotionUC usecase.PromotionUseCase) *PromotionDashboardController {
+	return &PromotionDashboardController{PromotionUseCase: promotionUC}
+}
+
+func (ctrl *PromotionDashboardController) CreatePromotion(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqPromotion request.ReqPromotion
+	if err := c.BodyParser(&reqPromotion); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqPromotion, request.ReqPromotionErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.PromotionUseCase.CreatePromotion(ctx, &reqPromotion); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create promotion")
+	}
+
+	return response.SetResponseOK(c, "success create promotion", nil)
+}
+
+func (ctrl *PromotionDashboardController) GetPromotionByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PromotionUseCase.GetPromotionByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get promotion")
+	}
+
+	return response.SetResponseOK(c, "success get promotion", res)
+}
+
+func (ctrl *PromotionDashboardController) GetListPromotion(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.PromotionUseCase.GetListPromotion(ctx, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list promotion")
+	}
+
+	return response.SetResponseOK(c, "success get list promotion", res)
+}
+
+func (ctrl *PromotionDashboardController) UpdatePromotionByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqUpdate := request.ReqPromotionUpdate{}
+	if err := c.BodyParser(&reqUpdate); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqUpdate.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqUpdate, request.ReqPromotionUpdateErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PromotionUseCase.UpdatePromotionByID(ctx, &reqUpdate)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed update promotion")
+	}
+
+	return response.SetResponseOK(c, "success update promotion", res)
+}
+
+func (ctrl *PromotionDashboardController) DeletePromotionByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqData := request.AbstractRequest{}
+	if err := c.BodyParser(&reqData); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	err = ctrl.PromotionUseCase.DeletePromotionByID(ctx, id, reqData)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed delete promotion")
+	}
+
+	return response.SetResponseOK(c, "success delete promotion", nil)
+}
+
+// EvaluatePromotion simulasi promosi untuk keranjang pelanggan tertentu (customer_id kosong = guest)
+func (ctrl *PromotionDashboardController) EvaluatePromotion(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqQuote request.ReqPriceQuote
+	if err := c.BodyParser(&reqQuote); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqQuote, request.ReqPriceQuoteErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PromotionUseCase.EvaluatePromotion(ctx, &reqQuote)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed evaluate promotion")
+	}
+
+	return response.SetResponseOK(c, "success evaluate promotion", res)
+}
@@ -124,3 +124,21 @@ func (ctrl *RoleController) DeleteRoleByID(c *fiber.Ctx) error {
 
 	return response.SetResponseOK(c, "success delete role", nil)
 }
+
+// ExportListRole filter dan urutan sama dengan list, format=csv|xlsx, fields=kolom yang diexport (opsional)
+func (ctrl *RoleController) ExportListRole(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqExport request.ReqExport
+	if err := c.QueryParser(&reqExport); err != nil {
+		logger.Error(ctx, "Failed to parse query", err)
+		return response.SetResponseBadRequest(c, errorutils.ErrMessageInvalidRequestData, err)
+	}
+
+	res, err := ctrl.RoleUseCase.ExportListRole(ctx, utils.GetFiltersForExport(c), &reqExport)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed export list role")
+	}
+
+	return response.SetResponseFileStream(c, ctx, res)
+}
@@ -0,0 +1,75 @@
+package dashboard
+
+import (
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ShipmentDashboardController struct {
+	ShippingUseCase usecase.ShippingUseCase
+}
+
+func NewShipmentController(shippingUC usecase.ShippingUseCase) *ShipmentDashboardController {
+	return &ShipmentDashboardController{ShippingUseCase: shippingUC}
+}
+
+func (ctrl *ShipmentDashboardController) CreateShipment(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqShipment := request.ReqShipment{}
+	if err := c.BodyParser(&reqShipment); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqShipment.OrderID = id
+
+	res, err := ctrl.ShippingUseCase.CreateShipment(ctx, &reqShipment)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create shipment")
+	}
+
+	return response.SetResponseOK(c, "success create shipment", res)
+}
+
+func (ctrl *ShipmentDashboardController) GetShipmentTracking(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.ShippingUseCase.GetShipmentTracking(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get shipment tracking")
+	}
+
+	return response.SetResponseOK(c, "success get shipment tracking", res)
+}
+
+func (ctrl *ShipmentDashboardController) GetShipmentLabel(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.ShippingUseCase.GetShipmentLabel(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get shipment label")
+	}
+
+	return response.SetResponseFile(c, res)
+}
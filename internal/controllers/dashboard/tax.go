@@ -0,0 +1,126 @@
+package dashboard
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TaxDashboardController struct {
+	TaxUseCase usecase.TaxUseCase
+}
+
+func NewTaxController(taxUC usecase.TaxUseCase) *TaxDashboardController {
+	return &TaxDashboardController{
+		TaxUseCase: taxUC,
+	}
+}
+
+func (ctrl *TaxDashboardController) CreateTaxClass(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqTaxClass request.ReqTaxClass
+	if err := c.BodyParser(&reqTaxClass); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqTaxClass, request.ReqTaxClassErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.TaxUseCase.CreateTaxClass(ctx, &reqTaxClass); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create tax class")
+	}
+
+	return response.SetResponseOK(c, "success create tax class", nil)
+}
+
+func (ctrl *TaxDashboardController) GetTaxClassByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.TaxUseCase.GetTaxClassByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get tax class")
+	}
+
+	return response.SetResponseOK(c, "success get tax class", res)
+}
+
+func (ctrl *TaxDashboardController) GetListTaxClass(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.TaxUseCase.GetListTaxClass(ctx, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list tax class")
+	}
+
+	return response.SetResponseOK(c, "success get list tax class", res)
+}
+
+func (ctrl *TaxDashboardController) UpdateTaxClassByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqUpdate := request.ReqTaxClassUpdate{}
+	if err := c.BodyParser(&reqUpdate); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqUpdate.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqUpdate, request.ReqTaxClassUpdateErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.TaxUseCase.UpdateTaxClassByID(ctx, &reqUpdate)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed update tax class")
+	}
+
+	return response.SetResponseOK(c, "success update tax class", res)
+}
+
+func (ctrl *TaxDashboardController) DeleteTaxClassByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqData := request.AbstractRequest{}
+	if err := c.BodyParser(&reqData); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	err = ctrl.TaxUseCase.DeleteTaxClassByID(ctx, id, reqData)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed delete tax class")
+	}
+
+	return response.SetResponseOK(c, "success delete tax class", nil)
+}
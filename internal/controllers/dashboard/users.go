@@ -127,3 +127,21 @@ func (ctrl *UserDahboardController) DeleteUserByID(c *fiber.Ctx) error {
 
 	return response.SetResponseOK(c, "success delete user", nil)
 }
+
+// ExportListUser filter dan urutan sama dengan list, format=csv|xlsx, fields=kolom yang diexport (opsional)
+func (ctrl *UserDahboardController) ExportListUser(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqExport request.ReqExport
+	if err := c.QueryParser(&reqExport); err != nil {
+		logger.Error(ctx, "Failed to parse query", err)
+		return response.SetResponseBadRequest(c, errorutils.ErrMessageInvalidRequestData, err)
+	}
+
+	res, err := ctrl.UserDashboardUsecase.ExportListUser(ctx, utils.GetFiltersForExport(c), &reqExport)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed export list user")
+	}
+
+	return response.SetResponseFileStream(c, ctx, res)
+}
@@ -0,0 +1,225 @@
+package dashboard
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type VoucherDashboardController struct {
+	VoucherUseCase usecase.VoucherUseCase
+}
+
+func NewVoucherController(voucherUC usecase.VoucherUseCase) *VoucherDashboardController {
+	return &VoucherDashboardController{VoucherUseCase: voucherUC}
+}
+
+func (ctrl *VoucherDashboardController) CreateVoucher(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqVoucher request.ReqVoucher
+	if err := c.BodyParser(&reqVoucher); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqVoucher, request.ReqVoucherErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.VoucherUseCase.CreateVoucher(ctx, &reqVoucher); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create voucher")
+	}
+
+	return response.SetResponseOK(c, "success create voucher", nil)
+}
+
+func (ctrl *VoucherDashboardController) GetVoucherByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.VoucherUseCase.GetVoucherByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get voucher")
+	}
+
+	return response.SetResponseOK(c, "success get voucher", res)
+}
+
+func (ctrl *VoucherDashboardController) GetListVoucher(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.VoucherUseCase.GetListVoucher(ctx, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list voucher")
+	}
+
+	return response.SetResponseOK(c, "success get list voucher", res)
+}
+
+func (ctrl *VoucherDashboardController) UpdateVoucherByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqUpdate := request.ReqVoucherUpdate{}
+	if err := c.BodyParser(&reqUpdate); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqUpdate.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqUpdate, request.ReqVoucherUpdateErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.VoucherUseCase.UpdateVoucherByID(ctx, &reqUpdate)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed update voucher")
+	}
+
+	return response.SetResponseOK(c, "success update voucher", res)
+}
+
+func (ctrl *VoucherDashboardController) DeleteVoucherByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	reqData := request.AbstractRequest{}
+	if err := c.BodyParser(&reqData); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	err = ctrl.VoucherUseCase.DeleteVoucherByID(ctx, id, reqData)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed delete voucher")
+	}
+
+	return response.SetResponseOK(c, "success delete voucher", nil)
+}
+
+func (ctrl *VoucherDashboardController) GetListVoucherCode(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.VoucherUseCase.GetListVoucherCode(ctx, id, utils.GetFiltersAndPagination(c))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list voucher code")
+	}
+
+	return response.SetResponseOK(c, "success get list voucher code", res)
+}
+
+func (ctrl *VoucherDashboardController) CreateVoucherCode(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	var reqCode request.ReqVoucherCode
+	if err := c.BodyParser(&reqCode); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqCode.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqCode, request.ReqVoucherCodeErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	if err := ctrl.VoucherUseCase.CreateVoucherCode(ctx, &reqCode); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create voucher code")
+	}
+
+	return response.SetResponseOK(c, "success create voucher code", nil)
+}
+
+// GenerateVoucherCode generate kode unik sekali pakai secara massal
+func (ctrl *VoucherDashboardController) GenerateVoucherCode(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	var reqGenerate request.ReqVoucherGenerate
+	if err := c.BodyParser(&reqGenerate); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	reqGenerate.ID = id
+
+	ok, errMsg := utils.ValidateRequest(reqGenerate, request.ReqVoucherGenerateErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.VoucherUseCase.GenerateVoucherCode(ctx, &reqGenerate)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed generate voucher code")
+	}
+
+	return response.SetResponseOK(c, "success generate voucher code", res)
+}
+
+// CheckVoucher simulasi pemakaian voucher untuk keranjang pelanggan tertentu (customer_id kosong = guest)
+func (ctrl *VoucherDashboardController) CheckVoucher(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqCheck request.ReqVoucherCheck
+	if err := c.BodyParser(&reqCheck); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqCheck, request.ReqVoucherCheckErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.VoucherUseCase.CheckVoucher(ctx, &reqCheck)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed check voucher")
+	}
+
+	return response.SetResponseOK(c, "success check voucher", res)
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/media"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PaymentController struct {
+	PaymentUseCase usecase.PaymentUseCase
+}
+
+func NewPaymentController(paymentUC usecase.PaymentUseCase) *PaymentController {
+	return &PaymentController{PaymentUseCase: paymentUC}
+}
+
+func (ctrl *PaymentController) CreatePayment(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqPayment request.ReqPayment
+	if err := c.BodyParser(&reqPayment); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqPayment, request.ReqPaymentErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PaymentUseCase.CreatePayment(ctx, &reqPayment)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed create payment")
+	}
+
+	return response.SetResponseOK(c, "success create payment", res)
+}
+
+func (ctrl *PaymentController) GetPaymentByID(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PaymentUseCase.GetPaymentByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get payment")
+	}
+
+	return response.SetResponseOK(c, "success get payment", res)
+}
+
+func (ctrl *PaymentController) UploadPaymentProof(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		logger.Error(ctx, "Failed to read form file", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	if fileHeader.Size > media.MaxUploadSize {
+		err := fmt.Errorf("ukuran file maksimal %d MB", media.MaxUploadSize>>20)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error(ctx, "Failed to open form file", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+	defer file.Close()
+
+	reqProof := request.ReqPaymentProof{ID: id}
+	reqProof.Data, err = io.ReadAll(io.LimitReader(file, media.MaxUploadSize+1))
+	if err != nil {
+		logger.Error(ctx, "Failed to read form file", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PaymentUseCase.UploadPaymentProof(ctx, &reqProof)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed upload payment proof")
+	}
+
+	return response.SetResponseOK(c, "success upload payment proof", res)
+}
+
+// HandleWebhook notifikasi provider tanpa auth, keaslian dicek dari signature di usecase
+func (ctrl *PaymentController) HandleWebhook(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	header := http.Header{}
+	for key, values := range c.GetReqHeaders() {
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+
+	if err := ctrl.PaymentUseCase.HandleWebhook(ctx, c.Params("provider"), header, c.Body()); err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed handle payment notification")
+	}
+
+	return response.SetResponseOK(c, "success handle payment notification", nil)
+}
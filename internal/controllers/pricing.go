@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PricingController struct {
+	PricingUseCase usecase.PricingUseCase
+}
+
+func NewPricingController(pricingUC usecase.PricingUseCase) *PricingController {
+	return &PricingController{PricingUseCase: pricingUC}
+}
+
+// QuotePrice harga efektif untuk pelanggan yang sedang login (price list + tier qty)
+func (ctrl *PricingController) QuotePrice(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqQuote request.ReqPriceQuote
+	if err := c.BodyParser(&reqQuote); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	ok, errMsg := utils.ValidateRequest(reqQuote, request.ReqPriceQuoteErrorMessage)
+	if !ok {
+		err := fmt.Errorf("%s", errMsg)
+		logger.Error(ctx, "error validate request ", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.PricingUseCase.QuotePriceCustomer(ctx, &reqQuote)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed quote price")
+	}
+
+	return response.SetResponseOK(c, "success quote price", res)
+}
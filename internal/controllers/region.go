@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RegionController struct {
+	RegionUseCase usecase.RegionUseCase
+}
+
+func NewRegionController(regionUC usecase.RegionUseCase) *RegionController {
+	return &RegionController{RegionUseCase: regionUC}
+}
+
+func (ctrl *RegionController) GetListProvince(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.RegionUseCase.GetListProvince(ctx)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list province")
+	}
+
+	return response.SetResponseOK(c, "success get list province", res)
+}
+
+func (ctrl *RegionController) GetListRegency(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.RegionUseCase.GetListRegency(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list regency")
+	}
+
+	return response.SetResponseOK(c, "success get list regency", res)
+}
+
+func (ctrl *RegionController) GetListDistrict(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.RegionUseCase.GetListDistrict(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list district")
+	}
+
+	return response.SetResponseOK(c, "success get list district", res)
+}
+
+func (ctrl *RegionController) GetListVillage(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+	id, err := utils.ReadRequestParamID(c)
+	if err != nil {
+		logger.Error(ctx, "Failed get param id", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.RegionUseCase.GetListVillage(ctx, id)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get list village")
+	}
+
+	return response.SetResponseOK(c, "success get list village", res)
+}
+
+func (ctrl *RegionController) SearchRegion(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.RegionUseCase.SearchRegion(ctx, c.Query("q"))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed search region")
+	}
+
+	return response.SetResponseOK(c, "success search region", res)
+}
+
+func (ctrl *RegionController) GetRegionByPostalCode(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	res, err := ctrl.RegionUseCase.GetRegionByPostalCode(ctx, c.Params("code"))
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get region by postal code")
+	}
+
+	return response.SetResponseOK(c, "success get region by postal code", res)
+}
@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ShippingController struct {
+	ShippingUseCase usecase.ShippingUseCase
+}
+
+func NewShippingController(shippingUC usecase.ShippingUseCase) *ShippingController {
+	return &ShippingController{ShippingUseCase: shippingUC}
+}
+
+func (ctrl *ShippingController) QuoteCart(c *fiber.Ctx) error {
+	ctx := utils.GetContext(c)
+
+	var reqQuote request.ReqShippingQuote
+	if err := c.BodyParser(&reqQuote); err != nil {
+		logger.Error(ctx, "Failed to parse request body", err)
+		return response.SetResponseBadRequest(c, "Invalid request", err)
+	}
+
+	res, err := ctrl.ShippingUseCase.QuoteCart(ctx, &reqQuote)
+	if err != nil {
+		return errorutils.HandleUsecaseError(c, err, "Failed get shipping rates")
+	}
+
+	return response.SetResponseOK(c, "success get shipping rates", res)
+}
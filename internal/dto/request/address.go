@@ -0,0 +1,105 @@
+package request
+
+import (
+	"errors"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/utils"
+	"strings"
+)
+
+// MaxAddresses batas jumlah alamat dalam satu buku alamat
+const MaxAddresses = 20
+
+type ReqAddress struct {
+	ID            int64  `json:"-"`
+	CustomerID    int64  `json:"-"` // dashboard: alamat milik customer ini
+	Label         string `json:"label" validate:"max=50"`
+	RecipientName string `json:"recipient_name" validate:"required,max=100"`
+	PhoneNumber   string `json:"phone_number" validate:"required"`
+	AddressLine1  string `json:"address_line1" validate:"required,max=255"`
+	AddressLine2  string `json:"address_line2" validate:"max=255"`
+	ProvinceID    int64  `json:"province_id" validate:"required"`
+	RegencyID     int64  `json:"regency_id" validate:"required"`
+	DistrictID    int64  `json:"district_id" validate:"required"`
+	VillageID     int64  `json:"village_id"`                     // opsional
+	PostalCode    string `json:"postal_code"`                    // opsional bila village_id diisi
+	City          string `json:"city" validate:"max=100"`        // nama kabupaten / kota, wajib bila wilayah belum ada di data wilayah
+	Subdistrict   string `json:"subdistrict" validate:"max=100"` // nama kecamatan, wajib bila wilayah belum ada di data wilayah
+	Village       string `json:"village" validate:"max=100"`     // nama kelurahan (opsional)
+	Country       string `json:"country" validate:"max=100"`
+	AddressType   string `json:"address_type"` // shipping (default), billing, store
+	IsDefault     bool   `json:"is_default"`
+	AbstractRequest
+}
+
+var ReqAddressErrorMessage = map[string]string{
+	"Label":         "label maksimal 50 karakter",
+	"RecipientName": "recipient_name required (maksimal 100 karakter)",
+	"PhoneNumber":   "phone_number required",
+	"AddressLine1":  "address_line1 required (maksimal 255 karakter)",
+	"AddressLine2":  "address_line2 maksimal 255 karakter",
+	"ProvinceID":    "province_id required",
+	"RegencyID":     "regency_id required",
+	"DistrictID":    "district_id required",
+	"City":          "city maksimal 100 karakter",
+	"Subdistrict":   "subdistrict maksimal 100 karakter",
+	"Village":       "village maksimal 100 karakter",
+	"Country":       "country maksimal 100 karakter",
+}
+
+func (r *ReqAddress) ValidateRequestCreate() error {
+	r.Label = strings.TrimSpace(r.Label)
+	r.RecipientName = strings.TrimSpace(r.RecipientName)
+	r.PhoneNumber = strings.TrimSpace(r.PhoneNumber)
+	r.AddressLine1 = strings.TrimSpace(r.AddressLine1)
+	r.AddressLine2 = strings.TrimSpace(r.AddressLine2)
+	r.PostalCode = strings.TrimSpace(r.PostalCode)
+	r.City = strings.TrimSpace(r.City)
+	r.Subdistrict = strings.TrimSpace(r.Subdistrict)
+	r.Village = strings.TrimSpace(r.Village)
+	r.Country = strings.TrimSpace(r.Country)
+
+	if err := utils.ValidatePhone(r.PhoneNumber); err != nil {
+		return err
+	}
+	if r.PostalCode == "" && r.VillageID == 0 {
+		return errors.New("postal_code wajib diisi bila village_id kosong")
+	}
+	if r.PostalCode != "" {
+		if err := utils.ValidatePostalCode(r.PostalCode); err != nil {
+			return err
+		}
+	}
+
+	if r.AddressType == "" {
+		r.AddressType = models.AddressTypeShipping
+	}
+	if !models.IsValidAddressType(r.AddressType) {
+		return errors.New("address_type harus shipping, billing atau store")
+	}
+	if r.Country == "" {
+		r.Country = "Indonesia"
+	}
+	return nil
+}
+
+func (r *ReqAddress) ValidateRequestUpdate() error {
+	if err := r.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+	return r.ValidateRequestCreate()
+}
+
+// Region id wilayah untuk divalidasi ke data wilayah
+func (r *ReqAddress) Region() models.RegionQuery {
+	return models.RegionQuery{
+		ProvinceID:   r.ProvinceID,
+		RegencyID:    r.RegencyID,
+		DistrictID:   r.DistrictID,
+		VillageID:    r.VillageID,
+		PostalCode:   r.PostalCode,
+		RegencyName:  r.City,
+		DistrictName: r.Subdistrict,
+		VillageName:  r.Village,
+	}
+}
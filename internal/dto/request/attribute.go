@@ -0,0 +1,88 @@
+package request
+
+import (
+	"errors"
+	"pleasurelove/internal/models"
+	"regexp"
+	"strings"
+)
+
+// kode atribut dipakai sebagai key filter (attr.<code>) sehingga dibatasi huruf kecil, angka dan underscore
+var attributeCodeRegex = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+type ReqAttribute struct {
+	CategoryID    int64    `json:"category_id" validate:"required"`
+	Code          string   `json:"code" validate:"required"`
+	Name          string   `json:"name" validate:"required"`
+	DataType      string   `json:"data_type" validate:"required,oneof=text number boolean enum"`
+	AllowedValues []string `json:"allowed_values"` // wajib untuk enum
+	Unit          string   `json:"unit"`           // contoh: cm, mAh
+	IsRequired    bool     `json:"is_required"`
+	IsFilterable  *bool    `json:"is_filterable"` // default true
+	SortOrder     int      `json:"sort_order"`
+}
+
+var ReqAttributeErrorMessage = map[string]string{
+	"CategoryID": "category_id required",
+	"Code":       "code required",
+	"Name":       "name required",
+	"DataType":   "data_type required (text, number, boolean, enum)",
+}
+
+func (r *ReqAttribute) ValidateRequestCreate() error {
+	r.Code = strings.ToLower(strings.TrimSpace(r.Code))
+	if !attributeCodeRegex.MatchString(r.Code) {
+		return errors.New("code atribut hanya huruf kecil, angka dan underscore, maksimal 50 karakter")
+	}
+
+	r.Name = strings.TrimSpace(r.Name)
+	r.Unit = strings.TrimSpace(r.Unit)
+
+	if r.IsFilterable == nil {
+		filterable := true
+		r.IsFilterable = &filterable
+	}
+
+	if r.DataType != models.AttributeTypeEnum {
+		if len(r.AllowedValues) > 0 {
+			return errors.New("allowed_values hanya untuk atribut enum")
+		}
+		r.AllowedValues = []string{}
+		return nil
+	}
+
+	values := make([]string, 0, len(r.AllowedValues))
+	seen := make(map[string]bool, len(r.AllowedValues))
+	for _, v := range r.AllowedValues {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if seen[strings.ToLower(v)] {
+			return errors.New("allowed_values tidak boleh duplikat")
+		}
+		seen[strings.ToLower(v)] = true
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return errors.New("atribut enum minimal memiliki satu allowed_values")
+	}
+	r.AllowedValues = values
+
+	return nil
+}
+
+type ReqAttributeUpdate struct {
+	ID int64 `json:"id" validate:"required"`
+	ReqAttribute
+	AbstractRequest
+}
+
+var ReqAttributeUpdateErrorMessage = map[string]string{
+	"ID":            "id required",
+	"CategoryID":    "category_id required",
+	"Code":          "code required",
+	"Name":          "name required",
+	"DataType":      "data_type required (text, number, boolean, enum)",
+	"UpdateddAtStr": "updated_at required",
+}
@@ -28,6 +28,7 @@ var ReqLoginErrorMessage = map[string]string{
 
 type ReqToken struct {
 	TemporaryToken string `json:"temporary_token" validate:"required"`
+	GuestToken     string `json:"guest_token"` // opsional, keranjang guest digabung ke user
 }
 
 func (r *ReqToken) ValidateRequest(ctx context.Context) error {
@@ -0,0 +1,118 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"pleasurelove/internal/utils"
+	"strings"
+)
+
+// MaxBulkItems batas jumlah data per request bulk
+const MaxBulkItems = 500
+
+// ReqBulkItem id beserta updated_at terakhir yang dimiliki klien (optimistic lock per item)
+type ReqBulkItem struct {
+	ID int64 `json:"id"`
+	AbstractRequest
+}
+
+type ReqBulkDelete struct {
+	Items []ReqBulkItem `json:"items"`
+}
+
+func (r *ReqBulkDelete) ValidateRequest() error {
+	return validateBulkItems(r.Items)
+}
+
+// validateBulkItems updated_at tidak divalidasi di sini supaya format yang salah hanya menggagalkan item tersebut
+func validateBulkItems(items []ReqBulkItem) error {
+	if len(items) == 0 {
+		return errors.New("items tidak boleh kosong")
+	}
+	if len(items) > MaxBulkItems {
+		return fmt.Errorf("items maksimal %d data", MaxBulkItems)
+	}
+
+	seen := make(map[int64]bool, len(items))
+	for _, item := range items {
+		if item.ID <= 0 {
+			return errors.New("id item tidak valid")
+		}
+		if seen[item.ID] {
+			return fmt.Errorf("id %d duplikat", item.ID)
+		}
+		seen[item.ID] = true
+	}
+	return nil
+}
+
+// ReqProductBulkPatch hanya field yang diisi yang diubah
+type ReqProductBulkPatch struct {
+	IsActive          *bool    `json:"is_active"`
+	Discount          *float64 `json:"discount"`
+	Brand             *string  `json:"brand"`
+	AddCategoryIDs    []int64  `json:"add_category_ids"`
+	RemoveCategoryIDs []int64  `json:"remove_category_ids"`
+}
+
+type ReqProductBulkUpdate struct {
+	Items []ReqBulkItem       `json:"items"`
+	Patch ReqProductBulkPatch `json:"patch"`
+}
+
+func (r *ReqProductBulkUpdate) ValidateRequest() error {
+	if err := validateBulkItems(r.Items); err != nil {
+		return err
+	}
+
+	p := &r.Patch
+	if p.IsActive == nil && p.Discount == nil && p.Brand == nil && len(p.AddCategoryIDs) == 0 && len(p.RemoveCategoryIDs) == 0 {
+		return errors.New("patch tidak boleh kosong")
+	}
+
+	if p.Discount != nil {
+		if *p.Discount < 0 || *p.Discount > 100 {
+			return errors.New("diskon harus antara 0 - 100 persen")
+		}
+		discount := utils.RoundTo2Digits(*p.Discount)
+		p.Discount = &discount
+	}
+
+	if p.Brand != nil {
+		brand := strings.TrimSpace(*p.Brand)
+		p.Brand = &brand
+	}
+
+	remove := make(map[int64]bool, len(p.RemoveCategoryIDs))
+	for _, id := range p.RemoveCategoryIDs {
+		remove[id] = true
+	}
+	for _, id := range p.AddCategoryIDs {
+		if remove[id] {
+			return fmt.Errorf("kategori %d tidak boleh ditambah dan dihapus sekaligus", id)
+		}
+	}
+
+	return nil
+}
+
+// ReqCategoryBulkMove memindahkan beberapa kategori ke parent yang sama, parent_id kosong = pindah ke root
+type ReqCategoryBulkMove struct {
+	Items    []ReqBulkItem `json:"items"`
+	ParentID *int64        `json:"parent_id"`
+}
+
+func (r *ReqCategoryBulkMove) ValidateRequest() error {
+	if err := validateBulkItems(r.Items); err != nil {
+		return err
+	}
+
+	if r.ParentID != nil {
+		for _, item := range r.Items {
+			if item.ID == *r.ParentID {
+				return errors.New("kategori tidak boleh menjadi parent dirinya sendiri")
+			}
+		}
+	}
+	return nil
+}
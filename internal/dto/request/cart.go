@@ -0,0 +1,35 @@
+package request
+
+import "errors"
+
+// MaxCartItems batas jumlah baris (produk / varian berbeda) dalam satu keranjang
+const MaxCartItems = 100
+
+type ReqCartItem struct {
+	ProductID int64 `json:"product_id" validate:"required"`
+	VarianID  int64 `json:"varian_id"` // 0 = produk tanpa varian
+	Quantity  int   `json:"quantity"`
+}
+
+var ReqCartItemErrorMessage = map[string]string{
+	"ProductID": "product_id required",
+}
+
+// ValidateRequestAdd quantity kosong dianggap 1
+func (r *ReqCartItem) ValidateRequestAdd() error {
+	if r.Quantity == 0 {
+		r.Quantity = 1
+	}
+	if r.Quantity < 1 {
+		return errors.New("quantity minimal 1")
+	}
+	return nil
+}
+
+// ValidateRequestUpdate quantity 0 berarti item dihapus dari keranjang
+func (r *ReqCartItem) ValidateRequestUpdate() error {
+	if r.Quantity < 0 {
+		return errors.New("quantity tidak boleh negatif")
+	}
+	return nil
+}
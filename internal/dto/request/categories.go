@@ -1,11 +1,18 @@
 package request
 
-import "pleasurelove/internal/utils"
+import (
+	"errors"
+	"pleasurelove/internal/utils"
+)
 
 type ReqCategory struct {
-	Name string `json:"name" validate:"required"`
-	Code string `json:"code" validate:"required"`
-	Slug string `json:"slug"`
+	Name      string `json:"name" validate:"required"`
+	Code      string `json:"code" validate:"required"`
+	Slug      string `json:"slug"`
+	ParentID  *int64 `json:"parent_id"`
+	SortOrder int    `json:"sort_order"`
+
+	TaxClassID *int64 `json:"tax_class_id"` // berlaku juga untuk sub kategori yang tidak punya kelas pajak
 }
 
 var ReqCategoryErrorMessage = map[string]string{
@@ -28,6 +35,8 @@ type ReqCategoryUpdate struct {
 	Name string `json:"name" validate:"required"`
 	Code string `json:"code" validate:"required"`
 	Slug string `json:"slug"`
+
+	TaxClassID *int64 `json:"tax_class_id"`
 	AbstractRequest
 }
 
@@ -51,3 +60,31 @@ func (r *ReqCategoryUpdate) ValidateRequestUpdate() error {
 	r.Slug = utils.GenerateSlug(r.Name)
 	return nil
 }
+
+type ReqCategoryMove struct {
+	ID        int64  `json:"id" validate:"required"`
+	ParentID  *int64 `json:"parent_id"` // nil = pindah ke root
+	SortOrder int    `json:"sort_order"`
+	AbstractRequest
+}
+
+var ReqCategoryMoveErrorMessage = map[string]string{
+	"ID":            "id required",
+	"UpdateddAtStr": "updated_at required",
+}
+
+func (r *ReqCategoryMove) ValidateRequestMove() error {
+	if err := r.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+
+	if r.ParentID != nil && *r.ParentID == r.ID {
+		return errors.New("kategori tidak boleh menjadi parent dirinya sendiri")
+	}
+
+	if r.SortOrder < 0 {
+		return errors.New("sort_order tidak boleh negatif")
+	}
+
+	return nil
+}
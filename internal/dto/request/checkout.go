@@ -0,0 +1,135 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/utils"
+	"strings"
+)
+
+// MaxIdempotencyKeyLength panjang maksimal header Idempotency-Key
+const MaxIdempotencyKeyLength = 64
+
+// ReqCheckout checkout keranjang user login / guest. Customer wajib diisi bila pemesan
+// belum punya data customer (checkout pertama kali). Alamat kirim dipilih dari buku alamat
+// (address_id) atau diisi langsung (shipping_address). Kurir dan layanan dari hasil cek ongkir,
+// kosong = layanan termurah.
+type ReqCheckout struct {
+	IdempotencyKey  string              `json:"-"` // dari header Idempotency-Key
+	Customer        *ReqCheckoutContact `json:"customer"`
+	AddressID       int64               `json:"address_id"`
+	ShippingAddress *ReqOrderAddress    `json:"shipping_address"`
+	ShippingCourier string              `json:"shipping_courier"`
+	ShippingService string              `json:"shipping_service"`
+	VoucherCode     string              `json:"voucher_code"`
+	Notes           string              `json:"notes"`
+}
+
+type ReqCheckoutContact struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+type ReqOrderAddress struct {
+	RecipientName string `json:"recipient_name" validate:"required"`
+	PhoneNumber   string `json:"phone_number" validate:"required"`
+	AddressLine1  string `json:"address_line1" validate:"required"`
+	AddressLine2  string `json:"address_line2"`
+	ProvinceID    int64  `json:"province_id" validate:"required"`
+	RegencyID     int64  `json:"regency_id" validate:"required"`
+	DistrictID    int64  `json:"district_id" validate:"required"`
+	VillageID     int64  `json:"village_id"`  // opsional
+	PostalCode    string `json:"postal_code"` // opsional bila village_id diisi
+	City          string `json:"city"`        // nama kabupaten / kota, wajib bila wilayah belum ada di data wilayah
+	Subdistrict   string `json:"subdistrict"` // nama kecamatan, wajib bila wilayah belum ada di data wilayah
+	Village       string `json:"village"`     // nama kelurahan (opsional)
+	Country       string `json:"country"`
+}
+
+var ReqCheckoutErrorMessage = map[string]string{
+	"RecipientName": "shipping_address.recipient_name required",
+	"PhoneNumber":   "shipping_address.phone_number required",
+	"AddressLine1":  "shipping_address.address_line1 required",
+	"ProvinceID":    "shipping_address.province_id required",
+	"RegencyID":     "shipping_address.regency_id required",
+	"DistrictID":    "shipping_address.district_id required",
+}
+
+func (r *ReqCheckout) ValidateRequest() error {
+	r.IdempotencyKey = strings.TrimSpace(r.IdempotencyKey)
+	if r.IdempotencyKey == "" {
+		return errors.New("header Idempotency-Key wajib diisi")
+	}
+	if len(r.IdempotencyKey) > MaxIdempotencyKeyLength {
+		return fmt.Errorf("header Idempotency-Key maksimal %d karakter", MaxIdempotencyKeyLength)
+	}
+
+	if r.Customer != nil {
+		r.Customer.Name = strings.TrimSpace(r.Customer.Name)
+		r.Customer.Email = strings.TrimSpace(r.Customer.Email)
+		r.Customer.Phone = strings.TrimSpace(r.Customer.Phone)
+		if r.Customer.Name == "" {
+			return errors.New("customer.name wajib diisi")
+		}
+		if err := utils.ValidateEmail(r.Customer.Email); err != nil {
+			return err
+		}
+		if err := utils.ValidatePhone(r.Customer.Phone); err != nil {
+			return err
+		}
+	}
+
+	if (r.AddressID == 0) == (r.ShippingAddress == nil) {
+		return errors.New("isi salah satu dari address_id atau shipping_address")
+	}
+	if r.ShippingAddress != nil {
+		if err := utils.ValidatePhone(r.ShippingAddress.PhoneNumber); err != nil {
+			return err
+		}
+		r.ShippingAddress.PostalCode = strings.TrimSpace(r.ShippingAddress.PostalCode)
+		if r.ShippingAddress.PostalCode == "" && r.ShippingAddress.VillageID == 0 {
+			return errors.New("shipping_address.postal_code wajib diisi bila village_id kosong")
+		}
+		if r.ShippingAddress.PostalCode != "" {
+			if err := utils.ValidatePostalCode(r.ShippingAddress.PostalCode); err != nil {
+				return err
+			}
+		}
+		if r.ShippingAddress.Country == "" {
+			r.ShippingAddress.Country = "Indonesia"
+		}
+	}
+
+	r.ShippingCourier = strings.ToLower(strings.TrimSpace(r.ShippingCourier))
+	r.ShippingService = strings.TrimSpace(r.ShippingService)
+	if (r.ShippingCourier == "") != (r.ShippingService == "") {
+		return errors.New("shipping_courier dan shipping_service harus diisi bersamaan")
+	}
+
+	if strings.TrimSpace(r.VoucherCode) != "" {
+		code, err := NormalizeVoucherCode(r.VoucherCode)
+		if err != nil {
+			return err
+		}
+		r.VoucherCode = code
+	}
+
+	r.Notes = strings.TrimSpace(r.Notes)
+	return nil
+}
+
+// Region id wilayah alamat kirim untuk divalidasi ke data wilayah
+func (r *ReqOrderAddress) Region() models.RegionQuery {
+	return models.RegionQuery{
+		ProvinceID:   r.ProvinceID,
+		RegencyID:    r.RegencyID,
+		DistrictID:   r.DistrictID,
+		VillageID:    r.VillageID,
+		PostalCode:   r.PostalCode,
+		RegencyName:  strings.TrimSpace(r.City),
+		DistrictName: strings.TrimSpace(r.Subdistrict),
+		VillageName:  strings.TrimSpace(r.Village),
+	}
+}
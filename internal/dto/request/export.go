@@ -0,0 +1,31 @@
+package request
+
+import (
+	"errors"
+	"pleasurelove/pkg/sheet"
+	"strings"
+)
+
+// ReqExport format=csv|xlsx, fields=id,name,... (opsional, default semua kolom list)
+type ReqExport struct {
+	Format string `query:"format"`
+	Fields string `query:"fields"`
+}
+
+func (r *ReqExport) ValidateRequest() error {
+	r.Format = strings.ToLower(strings.TrimSpace(r.Format))
+	if r.Format == "" {
+		r.Format = sheet.FormatCSV
+	}
+	if r.Format != sheet.FormatCSV && r.Format != sheet.FormatXLSX {
+		return errors.New("format export hanya csv atau xlsx")
+	}
+	return nil
+}
+
+func (r *ReqExport) FieldList() []string {
+	if strings.TrimSpace(r.Fields) == "" {
+		return nil
+	}
+	return strings.Split(r.Fields, ",")
+}
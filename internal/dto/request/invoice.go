@@ -0,0 +1,23 @@
+package request
+
+import "pleasurelove/pkg/invoice"
+
+// ReqInvoiceDocument unduh / cetak ulang dokumen, format kosong = pdf
+type ReqInvoiceDocument struct {
+	ID     int64  `json:"id" validate:"required"`
+	Format string `json:"format"`
+}
+
+var ReqInvoiceDocumentErrorMessage = map[string]string{
+	"ID": "id required",
+}
+
+func (r *ReqInvoiceDocument) ValidateRequest() error {
+	if r.Format == "" {
+		r.Format = string(invoice.FormatPDF)
+	}
+	if !invoice.Format(r.Format).Valid() {
+		return invoice.ErrUnknownFormat
+	}
+	return nil
+}
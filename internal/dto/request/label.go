@@ -0,0 +1,54 @@
+package request
+
+import (
+	"fmt"
+	"pleasurelove/pkg/label"
+)
+
+const MaxLabelPerRequest = 2000
+
+type ReqLabel struct {
+	Format    string         `json:"format" validate:"required,oneof=png pdf zpl"`
+	Symbology string         `json:"symbology" validate:"required,oneof=code128 ean13 qr"`
+	Items     []ReqLabelItem `json:"items" validate:"required,min=1,dive"`
+}
+
+type ReqLabelItem struct {
+	ProductID int64 `json:"product_id" validate:"required"`
+	VarianID  int64 `json:"varian_id"`
+	Quantity  int   `json:"quantity" validate:"gte=1"`
+}
+
+var ReqLabelErrorMessage = map[string]string{
+	"Format":    "format harus png, pdf atau zpl",
+	"Symbology": "symbology harus code128, ean13 atau qr",
+	"Items":     "items required",
+	"ProductID": "product_id required",
+	"Quantity":  "quantity minimal 1",
+}
+
+func (r *ReqLabel) ValidateRequest() error {
+	if !label.Format(r.Format).Valid() {
+		return label.ErrUnknownFormat
+	}
+
+	if !label.Symbology(r.Symbology).Valid() {
+		return label.ErrUnknownSymbology
+	}
+
+	total := 0
+	for _, item := range r.Items {
+		total += item.Quantity
+	}
+
+	if total > MaxLabelPerRequest {
+		return fmt.Errorf("jumlah label maksimal %d per permintaan", MaxLabelPerRequest)
+	}
+
+	// png satu gambar tanpa halaman, jumlah besar memakai pdf / zpl
+	if label.Format(r.Format) == label.FormatPNG && total > label.MaxPNGLabels {
+		return label.ErrTooManyPNGLabels
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"pleasurelove/internal/models"
+	"strings"
+)
+
+// MaxOrderItems batas jumlah baris produk dalam satu order
+const MaxOrderItems = 100
+
+// ReqOrder order manual dari dashboard (mis. pesanan lewat telepon / chat)
+type ReqOrder struct {
+	CustomerID int64          `json:"customer_id" validate:"required"`
+	Items      []ReqOrderItem `json:"items" validate:"required,min=1,dive"`
+	Notes      string         `json:"notes"`
+}
+
+type ReqOrderItem struct {
+	ProductID int64 `json:"product_id" validate:"required"`
+	VarianID  int64 `json:"varian_id"` // 0 = produk tanpa varian
+	Quantity  int   `json:"quantity"`
+}
+
+var ReqOrderErrorMessage = map[string]string{
+	"CustomerID": "customer_id required",
+	"Items":      "items required",
+	"ProductID":  "items.product_id required",
+}
+
+func (r *ReqOrder) ValidateRequestCreate() error {
+	if len(r.Items) > MaxOrderItems {
+		return fmt.Errorf("maksimal %d item per order", MaxOrderItems)
+	}
+
+	seen := make(map[[2]int64]bool, len(r.Items))
+	for i := range r.Items {
+		if r.Items[i].Quantity == 0 {
+			r.Items[i].Quantity = 1
+		}
+		if r.Items[i].Quantity < 1 {
+			return fmt.Errorf("items[%d]: quantity minimal 1", i)
+		}
+
+		key := [2]int64{r.Items[i].ProductID, r.Items[i].VarianID}
+		if seen[key] {
+			return fmt.Errorf("items[%d]: produk / varian tidak boleh duplikat", i)
+		}
+		seen[key] = true
+	}
+
+	r.Notes = strings.TrimSpace(r.Notes)
+	return nil
+}
+
+type ReqOrderStatus struct {
+	ID     int64  `json:"id" validate:"required"`
+	Status string `json:"status" validate:"required"`
+	Note   string `json:"note"`
+	AbstractRequest
+}
+
+var ReqOrderStatusErrorMessage = map[string]string{
+	"ID":     "id required",
+	"Status": "status required",
+}
+
+func (r *ReqOrderStatus) ValidateRequest() error {
+	if err := r.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+	if !models.IsValidOrderStatus(r.Status) {
+		return errors.New("status order tidak valid")
+	}
+
+	r.Note = strings.TrimSpace(r.Note)
+	return nil
+}
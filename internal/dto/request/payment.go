@@ -0,0 +1,92 @@
+package request
+
+import (
+	"errors"
+	"pleasurelove/internal/utils"
+	"strings"
+)
+
+// ReqPayment bayar order pending_payment milik pemesan. Channel = kode bank untuk
+// virtual_account (bca, bni, ...) atau nama e-wallet (gopay, shopeepay), kosong untuk qris / bank_transfer.
+type ReqPayment struct {
+	OrderID int64  `json:"order_id" validate:"required"`
+	Method  string `json:"method" validate:"required"`
+	Channel string `json:"channel"`
+}
+
+var ReqPaymentErrorMessage = map[string]string{
+	"OrderID": "order_id required",
+	"Method":  "method required",
+}
+
+func (r *ReqPayment) ValidateRequest() error {
+	r.Method = strings.ToLower(strings.TrimSpace(r.Method))
+	r.Channel = strings.ToLower(strings.TrimSpace(r.Channel))
+	if r.Method == "" {
+		return errors.New("method wajib diisi")
+	}
+	return nil
+}
+
+// ReqPaymentProof bukti transfer manual, file dikirim di field multipart "file"
+type ReqPaymentProof struct {
+	ID   int64  `form:"-"`
+	Data []byte `form:"-"`
+}
+
+func (r *ReqPaymentProof) ValidateRequest() error {
+	if len(r.Data) == 0 {
+		return errors.New("file bukti transfer wajib diisi")
+	}
+	return nil
+}
+
+// ReqPaymentConfirm keputusan staf atas transfer manual, catatan wajib bila ditolak
+type ReqPaymentConfirm struct {
+	ID       int64  `json:"-"`
+	Approved bool   `json:"approved"`
+	Note     string `json:"note" validate:"max=500"`
+	AbstractRequest
+}
+
+var ReqPaymentConfirmErrorMessage = map[string]string{
+	"Note": "note maksimal 500 karakter",
+}
+
+func (r *ReqPaymentConfirm) ValidateRequest() error {
+	if err := r.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+	r.Note = strings.TrimSpace(r.Note)
+	if !r.Approved && r.Note == "" {
+		return errors.New("note wajib diisi bila pembayaran ditolak")
+	}
+	return nil
+}
+
+// ReqPaymentRefund amount kosong = seluruh sisa dana yang belum dikembalikan
+type ReqPaymentRefund struct {
+	ID     int64   `json:"-"`
+	Amount float64 `json:"amount"`
+	Reason string  `json:"reason" validate:"required,max=255"`
+	AbstractRequest
+}
+
+var ReqPaymentRefundErrorMessage = map[string]string{
+	"Reason": "reason required, maksimal 255 karakter",
+}
+
+func (r *ReqPaymentRefund) ValidateRequest() error {
+	if err := r.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+	if r.Amount < 0 {
+		return errors.New("amount tidak boleh negatif")
+	}
+	r.Amount = utils.RoundTo2Digits(r.Amount)
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Reason == "" {
+		return errors.New("reason wajib diisi")
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"pleasurelove/internal/utils"
+	"time"
+)
+
+type ReqPriceList struct {
+	Code        string                   `json:"code" validate:"required"`
+	Name        string                   `json:"name" validate:"required"`
+	Description string                   `json:"description"`
+	Priority    int                      `json:"priority"`
+	IsDefault   bool                     `json:"is_default"`
+	IsActive    bool                     `json:"is_active"`
+	ValidFrom   *time.Time               `json:"valid_from"`
+	ValidTo     *time.Time               `json:"valid_to"`
+	Items       []ReqPriceListItem       `json:"items" validate:"dive"`
+	Assignments []ReqPriceListAssignment `json:"assignments" validate:"dive"`
+}
+
+type ReqPriceListItem struct {
+	ProductID int64      `json:"product_id" validate:"required"`
+	VarianID  *int64     `json:"varian_id"` // kosong = berlaku untuk semua varian
+	MinQty    int        `json:"min_qty"`
+	Price     float64    `json:"price"`
+	ValidFrom *time.Time `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to"`
+}
+
+type ReqPriceListAssignment struct {
+	CustomerID      *int64 `json:"customer_id"`
+	CustomerGroupID *int64 `json:"customer_group_id"`
+}
+
+var ReqPriceListErrorMessage = map[string]string{
+	"Code":      "code required",
+	"Name":      "name required",
+	"ProductID": "items.product_id required",
+}
+
+func (r *ReqPriceList) ValidateRequestCreate() error {
+	err := utils.ValidateCode(r.Code)
+	if err != nil {
+		return err
+	}
+
+	if err := validateValidity(r.ValidFrom, r.ValidTo); err != nil {
+		return err
+	}
+
+	for i := range r.Items {
+		item := &r.Items[i]
+		if item.MinQty == 0 {
+			item.MinQty = 1
+		}
+
+		if item.MinQty < 1 {
+			return fmt.Errorf("items[%d]: min_qty minimal 1", i)
+		}
+
+		if item.Price < 0 || item.Price > 9999999999.99 {
+			return fmt.Errorf("items[%d]: harga harus antara 0 - 9999999999.99", i)
+		}
+		item.Price = utils.RoundTo2Digits(item.Price)
+
+		if err := validateValidity(item.ValidFrom, item.ValidTo); err != nil {
+			return fmt.Errorf("items[%d]: %w", i, err)
+		}
+	}
+
+	for i, a := range r.Assignments {
+		if (a.CustomerID == nil) == (a.CustomerGroupID == nil) {
+			return fmt.Errorf("assignments[%d]: isi salah satu customer_id atau customer_group_id", i)
+		}
+	}
+
+	if r.IsDefault && len(r.Assignments) > 0 {
+		return errors.New("price list default berlaku untuk semua pelanggan, tidak perlu assignments")
+	}
+
+	return nil
+}
+
+type ReqPriceListUpdate struct {
+	ID int64 `json:"id" validate:"required"`
+	ReqPriceList
+	AbstractRequest
+}
+
+var ReqPriceListUpdateErrorMessage = map[string]string{
+	"ID":            "id required",
+	"Code":          "code required",
+	"Name":          "name required",
+	"ProductID":     "items.product_id required",
+	"UpdateddAtStr": "updated_at required",
+}
+
+type ReqCustomerGroup struct {
+	Code        string `json:"code" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+}
+
+var ReqCustomerGroupErrorMessage = map[string]string{
+	"Code": "code required",
+	"Name": "name required",
+}
+
+func (r *ReqCustomerGroup) ValidateRequestCreate() error {
+	return utils.ValidateCode(r.Code)
+}
+
+type ReqCustomerGroupUpdate struct {
+	ID int64 `json:"id" validate:"required"`
+	ReqCustomerGroup
+	AbstractRequest
+}
+
+var ReqCustomerGroupUpdateErrorMessage = map[string]string{
+	"ID":            "id required",
+	"Code":          "code required",
+	"Name":          "name required",
+	"UpdateddAtStr": "updated_at required",
+}
+
+type ReqCustomerGroupMember struct {
+	ID          int64   `json:"id"`
+	CustomerIDs []int64 `json:"customer_ids" validate:"required,min=1"`
+}
+
+var ReqCustomerGroupMemberErrorMessage = map[string]string{
+	"CustomerIDs": "customer_ids required",
+}
+
+type ReqPriceQuote struct {
+	CustomerID int64               `json:"customer_id"` // hanya dipakai dari dashboard
+	At         *time.Time          `json:"at"`          // hanya dipakai dari dashboard, kosong = sekarang
+	Items      []ReqPriceQuoteItem `json:"items" validate:"required,min=1,dive"`
+}
+
+type ReqPriceQuoteItem struct {
+	ProductID int64 `json:"product_id" validate:"required"`
+	VarianID  int64 `json:"varian_id"`
+	Quantity  int   `json:"quantity"`
+}
+
+var ReqPriceQuoteErrorMessage = map[string]string{
+	"Items":     "items required",
+	"ProductID": "items.product_id required",
+}
+
+func (r *ReqPriceQuote) ValidateRequest() error {
+	if len(r.Items) > 500 {
+		return errors.New("maksimal 500 item per permintaan harga")
+	}
+
+	for i := range r.Items {
+		if r.Items[i].Quantity == 0 {
+			r.Items[i].Quantity = 1
+		}
+		if r.Items[i].Quantity < 1 {
+			return fmt.Errorf("items[%d]: quantity minimal 1", i)
+		}
+	}
+	return nil
+}
+
+func validateValidity(from, to *time.Time) error {
+	if from != nil && to != nil && !to.After(*from) {
+		return errors.New("valid_to harus setelah valid_from")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"pleasurelove/internal/utils"
+	"time"
+)
+
+type ReqPriceSchedule struct {
+	ProductID   int64     `json:"product_id"`
+	VarianID    *int64    `json:"varian_id"` // kosong = harga produk
+	Price       float64   `json:"price"`
+	CostPrice   float64   `json:"cost_price"`
+	Discount    float64   `json:"discount"`
+	EffectiveAt time.Time `json:"effective_at" validate:"required"`
+	Note        string    `json:"note"`
+}
+
+var ReqPriceScheduleErrorMessage = map[string]string{
+	"EffectiveAt": "effective_at required",
+}
+
+func (r *ReqPriceSchedule) ValidateRequestCreate() error {
+	if !r.EffectiveAt.After(time.Now()) {
+		return errors.New("effective_at harus di masa depan")
+	}
+
+	if r.Price < 0 || r.Price > 9999999999.99 {
+		return fmt.Errorf("harga jual harus antara 0 - 9999999999.99")
+	}
+
+	if r.CostPrice < 0 || r.CostPrice > 9999999999.99 {
+		return fmt.Errorf("harga modal harus antara 0 - 9999999999.99")
+	}
+
+	if r.Discount < 0 || r.Discount > 100 {
+		return fmt.Errorf("diskon harus antara 0 - 100 persen")
+	}
+
+	r.Price = utils.RoundTo2Digits(r.Price)
+	r.CostPrice = utils.RoundTo2Digits(r.CostPrice)
+	r.Discount = utils.RoundTo2Digits(r.Discount)
+
+	return nil
+}
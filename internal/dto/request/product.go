@@ -2,6 +2,7 @@ package request
 
 import (
 	"fmt"
+	"pleasurelove/internal/models"
 	"pleasurelove/internal/utils"
 )
 
@@ -9,6 +10,7 @@ type ReqProduct struct {
 	ID          int64   `json:"id"`
 	Name        string  `json:"name"`
 	Code        string  `json:"code" validate:"required"`
+	Slug        string  `json:"slug"`
 	Barcode     string  `json:"barcode"`
 	Description string  `json:"description"`
 	Brand       string  `json:"brand"`      // asumsi brand berupa nama brand
@@ -19,6 +21,33 @@ type ReqProduct struct {
 	IsActive    bool    `json:"is_active"`
 	HasVarian   bool    `json:"has_varian"`
 	CategoryID  []int64 `json:"category_id"`
+
+	ProductType   string          `json:"product_type"`   // single (default) / bundle
+	BundlePricing string          `json:"bundle_pricing"` // khusus bundle: fixed (default) / sum
+	Stock         int             `json:"stock"`          // stok awal produk single tanpa varian
+	BundleItems   []ReqBundleItem `json:"bundle_items"`   // wajib untuk produk bundle
+
+	WeightGram int     `json:"weight_gram"` // berat per unit (gram) untuk ongkir
+	LengthCm   float64 `json:"length_cm"`
+	WidthCm    float64 `json:"width_cm"`
+	HeightCm   float64 `json:"height_cm"`
+
+	TaxClassID *int64 `json:"tax_class_id"` // kosong = mengikuti kategori lalu kelas pajak default
+
+	Attributes []ReqProductAttribute `json:"attributes"` // nilai atribut sesuai kategori produk
+}
+
+type ReqBundleItem struct {
+	ProductID int64 `json:"product_id"`
+	VarianID  int64 `json:"varian_id"` // wajib bila produk komponen memiliki varian
+	Quantity  int   `json:"quantity"`
+}
+
+// ReqProductAttribute value mengikuti tipe atribut: string, angka atau boolean
+type ReqProductAttribute struct {
+	AttributeID int64       `json:"attribute_id"`
+	VarianID    int64       `json:"varian_id"` // 0 = nilai produk
+	Value       interface{} `json:"value"`
 }
 
 var ReqProductErrorMessage = map[string]string{
@@ -47,6 +76,94 @@ func (r *ReqProduct) ValidateRequestCreate() error {
 	r.CostPrice = utils.RoundTo2Digits(r.CostPrice)
 	r.Discount = utils.RoundTo2Digits(r.Discount)
 
+	if err := ValidateDimension(r.WeightGram, r.LengthCm, r.WidthCm, r.HeightCm); err != nil {
+		return err
+	}
+	r.LengthCm = utils.RoundTo2Digits(r.LengthCm)
+	r.WidthCm = utils.RoundTo2Digits(r.WidthCm)
+	r.HeightCm = utils.RoundTo2Digits(r.HeightCm)
+
+	if err := r.validateBundle(); err != nil {
+		return err
+	}
+
+	// slug dipakai untuk url storefront, default dari nama + kode (sama dengan migrasi slug) agar
+	// produk bernama sama tetap unik
+	if r.Slug == "" {
+		r.Slug = r.Name + "-" + r.Code
+	}
+	r.Slug = utils.GenerateSlug(r.Slug)
+	if r.Slug == "" {
+		r.Slug = utils.GenerateSlug(r.Code)
+	}
+
+	return nil
+}
+
+// ValidateDimension berat maksimal 1 ton, dimensi maksimal 10 meter per sisi
+func ValidateDimension(weightGram int, lengthCm, widthCm, heightCm float64) error {
+	if weightGram < 0 || weightGram > 1000000 {
+		return fmt.Errorf("berat harus antara 0 - 1000000 gram")
+	}
+	for _, v := range []float64{lengthCm, widthCm, heightCm} {
+		if v < 0 || v > 1000 {
+			return fmt.Errorf("panjang, lebar dan tinggi harus antara 0 - 1000 cm")
+		}
+	}
+	return nil
+}
+
+func (r *ReqProduct) validateBundle() error {
+	if r.ProductType == "" {
+		r.ProductType = models.ProductTypeSingle
+	}
+
+	if r.Stock < 0 {
+		return fmt.Errorf("stok tidak boleh negatif")
+	}
+
+	switch r.ProductType {
+	case models.ProductTypeSingle:
+		if len(r.BundleItems) > 0 {
+			return fmt.Errorf("bundle_items hanya untuk produk bundle")
+		}
+		r.BundlePricing = models.BundlePricingFixed
+		return nil
+	case models.ProductTypeBundle:
+	default:
+		return fmt.Errorf("product_type harus single atau bundle")
+	}
+
+	if r.BundlePricing == "" {
+		r.BundlePricing = models.BundlePricingFixed
+	}
+	if r.BundlePricing != models.BundlePricingFixed && r.BundlePricing != models.BundlePricingSum {
+		return fmt.Errorf("bundle_pricing harus fixed atau sum")
+	}
+
+	if r.HasVarian {
+		return fmt.Errorf("produk bundle tidak boleh memiliki varian")
+	}
+	if r.Stock != 0 {
+		return fmt.Errorf("stok produk bundle mengikuti stok komponen")
+	}
+	if len(r.BundleItems) == 0 {
+		return fmt.Errorf("produk bundle minimal memiliki satu komponen")
+	}
+
+	seen := make(map[[2]int64]bool, len(r.BundleItems))
+	for _, item := range r.BundleItems {
+		if item.ProductID == 0 || item.Quantity < 1 {
+			return fmt.Errorf("komponen bundle wajib memiliki product_id dan quantity minimal 1")
+		}
+
+		key := [2]int64{item.ProductID, item.VarianID}
+		if seen[key] {
+			return fmt.Errorf("komponen bundle tidak boleh duplikat")
+		}
+		seen[key] = true
+	}
+
 	return nil
 }
 
@@ -69,8 +186,8 @@ type ReqProductUpdate struct {
 }
 
 var ReqProductUpdateErrorMessage = map[string]string{
-	"ID":         "id required",
-	"Code":       "code required",
+	"ID":            "id required",
+	"Code":          "code required",
 	"UpdateddAtStr": "updated_at required",
 }
 
@@ -98,3 +215,14 @@ var ReqProductUpdateErrorMessage = map[string]string{
 
 // 	return nil
 // }
+
+// ReqStockAdjust penyesuaian stok manual (stock opname, barang masuk / rusak)
+type ReqStockAdjust struct {
+	ProductID int64 `json:"product_id"`
+	VarianID  int64 `json:"varian_id"`
+	Quantity  int   `json:"quantity" validate:"required"` // positif = tambah, negatif = kurang
+}
+
+var ReqStockAdjustErrorMessage = map[string]string{
+	"Quantity": "quantity required",
+}
@@ -0,0 +1,17 @@
+package request
+
+import "errors"
+
+// ReqProductImport file csv / xlsx dikirim di field "file" (multipart/form-data)
+type ReqProductImport struct {
+	FileName string `form:"-"`
+	Data     []byte `form:"-"`
+	DryRun   bool   `form:"dry_run"`
+}
+
+func (r *ReqProductImport) ValidateRequest() error {
+	if len(r.Data) == 0 {
+		return errors.New("file import wajib diisi")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package request
+
+import (
+	"errors"
+	"strings"
+)
+
+// ReqProductMediaUpload field form multipart, file dikirim di field "file"
+type ReqProductMediaUpload struct {
+	ProductID int64  `form:"-"`
+	AltText   string `form:"alt_text" validate:"max=255"`
+	SortOrder int    `form:"sort_order"`
+	IsPrimary bool   `form:"is_primary"`
+	Data      []byte `form:"-"`
+}
+
+var ReqProductMediaUploadErrorMessage = map[string]string{
+	"AltText": "alt_text maksimal 255 karakter",
+}
+
+func (r *ReqProductMediaUpload) ValidateRequestCreate() error {
+	r.AltText = strings.TrimSpace(r.AltText)
+	if len(r.Data) == 0 {
+		return errors.New("file gambar wajib diisi")
+	}
+	if r.SortOrder < 0 {
+		return errors.New("sort_order tidak boleh negatif")
+	}
+	return nil
+}
+
+type ReqProductMediaUpdate struct {
+	ID        int64  `json:"-"`
+	AltText   string `json:"alt_text" validate:"max=255"`
+	SortOrder int    `json:"sort_order"`
+	IsPrimary bool   `json:"is_primary"`
+	AbstractRequest
+}
+
+var ReqProductMediaUpdateErrorMessage = map[string]string{
+	"AltText": "alt_text maksimal 255 karakter",
+}
+
+func (r *ReqProductMediaUpdate) ValidateRequestUpdate() error {
+	r.AltText = strings.TrimSpace(r.AltText)
+	if r.SortOrder < 0 {
+		return errors.New("sort_order tidak boleh negatif")
+	}
+	return nil
+}
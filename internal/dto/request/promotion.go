@@ -0,0 +1,71 @@
+package request
+
+import (
+	"errors"
+	"pleasurelove/internal/utils"
+	"pleasurelove/pkg/promotion"
+	"time"
+)
+
+type ReqPromotion struct {
+	Code             string                `json:"code" validate:"required"`
+	Name             string                `json:"name" validate:"required"`
+	Description      string                `json:"description"`
+	Priority         int                   `json:"priority"`
+	Stackable        bool                  `json:"stackable"`
+	IsActive         bool                  `json:"is_active"`
+	StartsAt         *time.Time            `json:"starts_at"`
+	EndsAt           *time.Time            `json:"ends_at"`
+	UsageLimit       int                   `json:"usage_limit"`        // 0 = tanpa batas
+	PerCustomerLimit int                   `json:"per_customer_limit"` // 0 = tanpa batas
+	Conditions       []promotion.Condition `json:"conditions"`
+	Actions          []promotion.Action    `json:"actions" validate:"required,min=1"`
+}
+
+var ReqPromotionErrorMessage = map[string]string{
+	"Code":    "code required",
+	"Name":    "name required",
+	"Actions": "actions required",
+}
+
+func (r *ReqPromotion) ValidateRequestCreate() error {
+	if err := utils.ValidateCode(r.Code); err != nil {
+		return err
+	}
+
+	if r.UsageLimit < 0 || r.PerCustomerLimit < 0 {
+		return errors.New("usage_limit dan per_customer_limit tidak boleh negatif")
+	}
+
+	return r.ToRule().Validate()
+}
+
+// ToRule rule evaluator dari request, dipakai untuk validasi sebelum disimpan
+func (r *ReqPromotion) ToRule() promotion.Rule {
+	return promotion.Rule{
+		Code:             r.Code,
+		Name:             r.Name,
+		Priority:         r.Priority,
+		Stackable:        r.Stackable,
+		StartsAt:         r.StartsAt,
+		EndsAt:           r.EndsAt,
+		Conditions:       r.Conditions,
+		Actions:          r.Actions,
+		UsageLimit:       r.UsageLimit,
+		PerCustomerLimit: r.PerCustomerLimit,
+	}
+}
+
+type ReqPromotionUpdate struct {
+	ID int64 `json:"id" validate:"required"`
+	ReqPromotion
+	AbstractRequest
+}
+
+var ReqPromotionUpdateErrorMessage = map[string]string{
+	"ID":            "id required",
+	"Code":          "code required",
+	"Name":          "name required",
+	"Actions":       "actions required",
+	"UpdateddAtStr": "updated_at required",
+}
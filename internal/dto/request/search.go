@@ -0,0 +1,35 @@
+package request
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+type ReqSearch struct {
+	Query string `query:"q"`
+	Page  int    `query:"page"`
+	Limit int    `query:"limit"`
+}
+
+func (r *ReqSearch) ValidateRequest() error {
+	r.Query = strings.TrimSpace(r.Query)
+
+	if utf8.RuneCountInString(r.Query) < 2 {
+		return errors.New("kata kunci pencarian minimal 2 karakter")
+	}
+
+	if utf8.RuneCountInString(r.Query) > 100 {
+		return errors.New("kata kunci pencarian maksimal 100 karakter")
+	}
+
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Limit < 1 || r.Limit > 100 {
+		r.Limit = 10
+	}
+
+	return nil
+}
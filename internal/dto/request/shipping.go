@@ -0,0 +1,67 @@
+package request
+
+import (
+	"errors"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/utils"
+	"strings"
+)
+
+// ReqShippingQuote cek ongkir isi keranjang ke alamat di buku alamat (address_id) atau ke wilayah tujuan
+type ReqShippingQuote struct {
+	AddressID  int64  `json:"address_id"`
+	ProvinceID int64  `json:"province_id"`
+	RegencyID  int64  `json:"regency_id"`
+	DistrictID int64  `json:"district_id"`
+	VillageID  int64  `json:"village_id"`
+	PostalCode string `json:"postal_code"`
+}
+
+func (r *ReqShippingQuote) ValidateRequest() error {
+	if r.AddressID != 0 {
+		return nil
+	}
+	if r.ProvinceID == 0 || r.RegencyID == 0 || r.DistrictID == 0 {
+		return errors.New("isi address_id atau province_id, regency_id dan district_id")
+	}
+
+	r.PostalCode = strings.TrimSpace(r.PostalCode)
+	if r.PostalCode == "" && r.VillageID == 0 {
+		return errors.New("postal_code wajib diisi bila village_id kosong")
+	}
+	if r.PostalCode != "" {
+		return utils.ValidatePostalCode(r.PostalCode)
+	}
+	return nil
+}
+
+func (r *ReqShippingQuote) Region() models.RegionQuery {
+	return models.RegionQuery{
+		ProvinceID: r.ProvinceID,
+		RegencyID:  r.RegencyID,
+		DistrictID: r.DistrictID,
+		VillageID:  r.VillageID,
+		PostalCode: r.PostalCode,
+	}
+}
+
+// ReqShipment buat pengiriman (resi) untuk order berstatus processing.
+// Kurir dan layanan kosong = sesuai pilihan pelanggan saat checkout.
+type ReqShipment struct {
+	OrderID int64  `json:"-"`
+	Courier string `json:"courier"`
+	Service string `json:"service"`
+	AbstractRequest
+}
+
+func (r *ReqShipment) ValidateRequest() error {
+	if err := r.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+	r.Courier = strings.ToLower(strings.TrimSpace(r.Courier))
+	r.Service = strings.TrimSpace(r.Service)
+	if (r.Courier == "") != (r.Service == "") {
+		return errors.New("courier dan service harus diisi bersamaan")
+	}
+	return nil
+}
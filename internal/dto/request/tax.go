@@ -0,0 +1,67 @@
+package request
+
+import (
+	"fmt"
+	"pleasurelove/internal/utils"
+	"time"
+)
+
+type ReqTaxClass struct {
+	Code        string       `json:"code" validate:"required"`
+	Name        string       `json:"name" validate:"required"`
+	Description string       `json:"description"`
+	IsDefault   bool         `json:"is_default"`
+	Rates       []ReqTaxRate `json:"rates" validate:"required,min=1,dive"`
+}
+
+// ReqTaxRate tarif berlaku mulai effective_from sampai ada tarif dengan effective_from berikutnya
+type ReqTaxRate struct {
+	Name          string    `json:"name" validate:"required"`
+	Rate          float64   `json:"rate"`
+	EffectiveFrom time.Time `json:"effective_from" validate:"required"`
+}
+
+var ReqTaxClassErrorMessage = map[string]string{
+	"Code":          "code required",
+	"Name":          "name required",
+	"Rates":         "rates minimal 1",
+	"EffectiveFrom": "rates.effective_from required",
+}
+
+func (r *ReqTaxClass) ValidateRequestCreate() error {
+	err := utils.ValidateCode(r.Code)
+	if err != nil {
+		return err
+	}
+
+	seen := map[time.Time]bool{}
+	for i := range r.Rates {
+		rate := &r.Rates[i]
+		if rate.Rate < 0 || rate.Rate > 100 {
+			return fmt.Errorf("rates[%d]: tarif harus antara 0 - 100", i)
+		}
+		rate.Rate = utils.RoundTo2Digits(rate.Rate)
+
+		if seen[rate.EffectiveFrom.UTC()] {
+			return fmt.Errorf("rates[%d]: effective_from sudah dipakai tarif lain", i)
+		}
+		seen[rate.EffectiveFrom.UTC()] = true
+	}
+
+	return nil
+}
+
+type ReqTaxClassUpdate struct {
+	ID int64 `json:"id" validate:"required"`
+	ReqTaxClass
+	AbstractRequest
+}
+
+var ReqTaxClassUpdateErrorMessage = map[string]string{
+	"ID":            "id required",
+	"Code":          "code required",
+	"Name":          "name required",
+	"Rates":         "rates minimal 1",
+	"EffectiveFrom": "rates.effective_from required",
+	"UpdateddAtStr": "updated_at required",
+}
@@ -0,0 +1,153 @@
+package request
+
+import (
+	"errors"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/utils"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	voucherCodeRegex   = regexp.MustCompile(`^[A-Z0-9-]{4,32}$`)
+	voucherPrefixRegex = regexp.MustCompile(`^[A-Z0-9-]{0,10}$`)
+)
+
+type ReqVoucher struct {
+	Code             string     `json:"code"` // opsional, kode bersama untuk voucher ini
+	Name             string     `json:"name" validate:"required"`
+	Description      string     `json:"description"`
+	DiscountType     string     `json:"discount_type" validate:"required,oneof=percent fixed"`
+	Value            float64    `json:"value" validate:"required"`
+	MaxDiscount      float64    `json:"max_discount"`
+	MinSpend         float64    `json:"min_spend"`
+	ProductIDs       []int64    `json:"product_ids"`
+	CategoryIDs      []int64    `json:"category_ids"`
+	IsActive         bool       `json:"is_active"`
+	StartsAt         *time.Time `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at"`
+	UsageLimit       int        `json:"usage_limit"`        // 0 = tanpa batas
+	PerCustomerLimit int        `json:"per_customer_limit"` // 0 = tanpa batas
+}
+
+var ReqVoucherErrorMessage = map[string]string{
+	"Name":         "name required",
+	"DiscountType": "discount_type required (percent, fixed)",
+	"Value":        "value required",
+}
+
+func (r *ReqVoucher) ValidateRequestCreate() error {
+	if r.Code != "" {
+		code, err := NormalizeVoucherCode(r.Code)
+		if err != nil {
+			return err
+		}
+		r.Code = code
+	}
+
+	if r.DiscountType == models.VoucherTypePercent && (r.Value <= 0 || r.Value > 100) {
+		return errors.New("value voucher persen harus di antara 0 dan 100")
+	}
+	if r.Value <= 0 || r.Value > 9999999999.99 {
+		return errors.New("value voucher harus antara 0 - 9999999999.99")
+	}
+	if r.MaxDiscount < 0 || r.MinSpend < 0 {
+		return errors.New("max_discount dan min_spend tidak boleh negatif")
+	}
+	if r.UsageLimit < 0 || r.PerCustomerLimit < 0 {
+		return errors.New("usage_limit dan per_customer_limit tidak boleh negatif")
+	}
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return errors.New("ends_at harus setelah starts_at")
+	}
+
+	r.Value = utils.RoundTo2Digits(r.Value)
+	r.MaxDiscount = utils.RoundTo2Digits(r.MaxDiscount)
+	r.MinSpend = utils.RoundTo2Digits(r.MinSpend)
+	return nil
+}
+
+type ReqVoucherUpdate struct {
+	ID int64 `json:"id" validate:"required"`
+	ReqVoucher
+	AbstractRequest
+}
+
+var ReqVoucherUpdateErrorMessage = map[string]string{
+	"ID":            "id required",
+	"Name":          "name required",
+	"DiscountType":  "discount_type required (percent, fixed)",
+	"Value":         "value required",
+	"UpdateddAtStr": "updated_at required",
+}
+
+type ReqVoucherCode struct {
+	ID             int64  `json:"id"`
+	Code           string `json:"code" validate:"required"`
+	MaxRedemptions int    `json:"max_redemptions"` // 0 = mengikuti batas voucher
+}
+
+var ReqVoucherCodeErrorMessage = map[string]string{
+	"Code": "code required",
+}
+
+func (r *ReqVoucherCode) ValidateRequestCreate() error {
+	code, err := NormalizeVoucherCode(r.Code)
+	if err != nil {
+		return err
+	}
+	r.Code = code
+
+	if r.MaxRedemptions < 0 {
+		return errors.New("max_redemptions tidak boleh negatif")
+	}
+	return nil
+}
+
+// ReqVoucherGenerate generate kode unik sekali pakai
+type ReqVoucherGenerate struct {
+	ID       int64  `json:"id"`
+	Quantity int    `json:"quantity" validate:"required,min=1,max=10000"`
+	Prefix   string `json:"prefix"`
+	Length   int    `json:"length"` // panjang bagian acak, default 8
+}
+
+var ReqVoucherGenerateErrorMessage = map[string]string{
+	"Quantity": "quantity required (1 - 10000)",
+}
+
+func (r *ReqVoucherGenerate) ValidateRequest() error {
+	r.Prefix = strings.ToUpper(strings.TrimSpace(r.Prefix))
+	if !voucherPrefixRegex.MatchString(r.Prefix) {
+		return errors.New("prefix hanya huruf, angka dan tanda hubung, maksimal 10 karakter")
+	}
+
+	if r.Length == 0 {
+		r.Length = 8
+	}
+	if r.Length < 6 || r.Length > 16 {
+		return errors.New("length harus antara 6 - 16")
+	}
+	return nil
+}
+
+type ReqVoucherCheck struct {
+	Code string `json:"code" validate:"required"`
+	ReqPriceQuote
+}
+
+var ReqVoucherCheckErrorMessage = map[string]string{
+	"Code":      "code required",
+	"Items":     "items required",
+	"ProductID": "items.product_id required",
+}
+
+// NormalizeVoucherCode kode voucher tidak case sensitive, disimpan uppercase
+func NormalizeVoucherCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !voucherCodeRegex.MatchString(code) {
+		return "", errors.New("format kode voucher tidak valid (huruf, angka dan tanda hubung, 4-32 karakter)")
+	}
+	return code, nil
+}
@@ -0,0 +1,74 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"time"
+)
+
+type AddressResponse struct {
+	ID            int64     `json:"id"`
+	UserID        int64     `json:"user_id"`
+	CustomerID    int64     `json:"customer_id"`
+	Label         string    `json:"label"`
+	RecipientName string    `json:"recipient_name"`
+	PhoneNumber   string    `json:"phone_number"`
+	AddressLine1  string    `json:"address_line1"`
+	AddressLine2  string    `json:"address_line2"`
+	ProvinceID    int64     `json:"province_id"`
+	RegencyID     int64     `json:"regency_id"`
+	DistrictID    int64     `json:"district_id"`
+	VillageID     int64     `json:"village_id"`
+	Village       string    `json:"village"`
+	Subdistrict   string    `json:"subdistrict"`
+	City          string    `json:"city"`
+	Province      string    `json:"province"`
+	PostalCode    string    `json:"postal_code"`
+	Country       string    `json:"country"`
+	AddressType   string    `json:"address_type"`
+	IsDefault     bool      `json:"is_default"`
+	CreatedAt     time.Time `json:"created_at"`
+	CreatedBy     int64     `json:"created_by"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	UpdatedBy     int64     `json:"updated_by"`
+}
+
+func SetAddressResponse(a models.Address) AddressResponse {
+	owner := a.Owner()
+	res := AddressResponse{
+		ID:            a.ID,
+		UserID:        owner.UserID,
+		CustomerID:    owner.CustomerID,
+		Label:         a.Label,
+		RecipientName: a.RecipientName,
+		PhoneNumber:   a.PhoneNumber,
+		AddressLine1:  a.AddressLine1,
+		AddressLine2:  a.AddressLine2,
+		ProvinceID:    a.ProvinceID,
+		RegencyID:     a.RegencyID,
+		DistrictID:    a.DistrictID,
+		Village:       a.Village,
+		Subdistrict:   a.Subdistrict,
+		City:          a.City,
+		Province:      a.Province,
+		PostalCode:    a.PostalCode,
+		Country:       a.Country,
+		AddressType:   a.AddressType,
+		IsDefault:     a.IsDefault,
+		CreatedAt:     a.CreatedAt,
+		CreatedBy:     a.CreatedBy,
+		UpdatedAt:     a.UpdatedAt,
+		UpdatedBy:     a.UpdatedBy,
+	}
+	if a.VillageID != nil {
+		res.VillageID = *a.VillageID
+	}
+	return res
+}
+
+func SetResponseListAddress(addresses []models.Address) []AddressResponse {
+	responses := make([]AddressResponse, 0, len(addresses))
+	for _, a := range addresses {
+		responses = append(responses, SetAddressResponse(a))
+	}
+	return responses
+}
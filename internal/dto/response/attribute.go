@@ -0,0 +1,82 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"time"
+)
+
+type AttributeResponse struct {
+	ID            int64     `json:"id"`
+	CategoryID    int64     `json:"category_id"`
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	DataType      string    `json:"data_type"`
+	AllowedValues []string  `json:"allowed_values"`
+	Unit          string    `json:"unit"`
+	IsRequired    bool      `json:"is_required"`
+	IsFilterable  bool      `json:"is_filterable"`
+	SortOrder     int       `json:"sort_order"`
+	CreatedAt     time.Time `json:"created_at"`
+	CreatedBy     int64     `json:"created_by"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	UpdatedBy     int64     `json:"updated_by"`
+}
+
+func SetAttributeResponse(a models.AttributeDefinition) AttributeResponse {
+	res := AttributeResponse{
+		ID:            a.ID,
+		CategoryID:    a.CategoryID,
+		Code:          a.Code,
+		Name:          a.Name,
+		DataType:      a.DataType,
+		AllowedValues: a.AllowedValues,
+		Unit:          a.Unit,
+		IsRequired:    a.IsRequired,
+		IsFilterable:  a.IsFilterable,
+		SortOrder:     a.SortOrder,
+		CreatedAt:     a.CreatedAt,
+		CreatedBy:     a.CreatedBy,
+		UpdatedAt:     a.UpdatedAt,
+		UpdatedBy:     a.UpdatedBy,
+	}
+	if res.AllowedValues == nil {
+		res.AllowedValues = []string{}
+	}
+	return res
+}
+
+func SetResponseListAttribute(attributes []models.AttributeDefinition) []AttributeResponse {
+	responses := make([]AttributeResponse, 0, len(attributes))
+	for _, a := range attributes {
+		responses = append(responses, SetAttributeResponse(a))
+	}
+	return responses
+}
+
+type ProductAttributeResponse struct {
+	AttributeID int64    `json:"attribute_id"`
+	VarianID    *int64   `json:"varian_id"`
+	Code        string   `json:"code"`
+	Name        string   `json:"name"`
+	DataType    string   `json:"data_type"`
+	Unit        string   `json:"unit"`
+	Value       string   `json:"value"`
+	ValueNumber *float64 `json:"value_number,omitempty"`
+}
+
+func SetResponseListProductAttribute(values []models.ProductAttributeValueDetail) []ProductAttributeResponse {
+	responses := make([]ProductAttributeResponse, 0, len(values))
+	for _, v := range values {
+		responses = append(responses, ProductAttributeResponse{
+			AttributeID: v.AttributeID,
+			VarianID:    v.ProductVarianID,
+			Code:        v.Code,
+			Name:        v.Name,
+			DataType:    v.DataType,
+			Unit:        v.Unit,
+			Value:       v.ValueText,
+			ValueNumber: v.ValueNumber,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+type BulkItemResult struct {
+	ID        int64      `json:"id"`
+	Success   bool       `json:"success"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"` // updated_at baru untuk item yang berhasil diubah
+	Message   string     `json:"message,omitempty"`
+}
+
+// BulkResponse hasil per item sesuai urutan request
+type BulkResponse struct {
+	Total   int              `json:"total"`
+	Success int              `json:"success"`
+	Failed  int              `json:"failed"`
+	Results []BulkItemResult `json:"results"`
+}
+
+func NewBulkResponse(total int) BulkResponse {
+	return BulkResponse{Total: total, Results: make([]BulkItemResult, 0, total)}
+}
+
+func (r *BulkResponse) AddSuccess(id int64, updatedAt *time.Time) {
+	r.Success++
+	r.Results = append(r.Results, BulkItemResult{ID: id, Success: true, UpdatedAt: updatedAt})
+}
+
+func (r *BulkResponse) AddFailed(id int64, message string) {
+	r.Failed++
+	r.Results = append(r.Results, BulkItemResult{ID: id, Message: message})
+}
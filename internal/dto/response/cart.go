@@ -0,0 +1,29 @@
+package response
+
+import "time"
+
+type CartItemResponse struct {
+	ProductID   int64   `json:"product_id"`
+	VarianID    int64   `json:"varian_id"`
+	ProductName string  `json:"product_name"`
+	ProductSlug string  `json:"product_slug"`
+	VarianName  string  `json:"varian_name,omitempty"`
+	Quantity    int     `json:"quantity"`
+	Stock       int     `json:"stock"`
+	BasePrice   float64 `json:"base_price"`
+	UnitPrice   float64 `json:"unit_price"`
+	PriceSource string  `json:"price_source,omitempty"`
+	LineTotal   float64 `json:"line_total"`
+	IsAvailable bool    `json:"is_available"`
+	Message     string  `json:"message,omitempty"` // alasan item tidak bisa dibeli (tidak aktif, stok kurang, dll)
+}
+
+// CartResponse harga selalu dihitung ulang dari harga produk saat ini, item yang tidak
+// tersedia tetap ditampilkan tetapi tidak dihitung ke subtotal
+type CartResponse struct {
+	Items         []CartItemResponse `json:"items"`
+	TotalQuantity int                `json:"total_quantity"`
+	Subtotal      float64            `json:"subtotal"`
+	ExpiresAt     *time.Time         `json:"expires_at"`
+	GuestToken    string             `json:"guest_token,omitempty"` // hanya diisi saat guest token baru dibuat
+}
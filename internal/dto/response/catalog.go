@@ -0,0 +1,142 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/utils"
+)
+
+// Response publik storefront, jangan tambahkan field internal (cost_price, created_by, dll)
+
+type PublicProductResponse struct {
+	Name       string  `json:"name"`
+	Slug       string  `json:"slug"`
+	Code       string  `json:"code"`
+	Brand      string  `json:"brand"`
+	Unit       string  `json:"unit"`
+	Price      float64 `json:"price"`
+	Discount   float64 `json:"discount"`
+	FinalPrice float64 `json:"final_price"`
+	HasVarian  bool    `json:"has_varian"`
+	ImageURL   string  `json:"image_url"` // thumbnail gambar utama, kosong bila belum ada
+}
+
+func SetPublicProductResponse(product models.Product) PublicProductResponse {
+	return PublicProductResponse{
+		Name:       product.Name,
+		Slug:       product.Slug,
+		Code:       product.Code,
+		Brand:      product.Brand,
+		Unit:       product.Unit,
+		Price:      utils.RoundTo2Digits(product.Price),
+		Discount:   utils.RoundTo2Digits(product.Discount),
+		FinalPrice: utils.PriceAfterDiscount(product.Price, product.Discount),
+		HasVarian:  product.HasVarian,
+	}
+}
+
+func SetResponseListPublicProduct(products []models.Product) []PublicProductResponse {
+	responses := make([]PublicProductResponse, 0, len(products))
+	for _, product := range products {
+		responses = append(responses, SetPublicProductResponse(product))
+	}
+	return responses
+}
+
+type PublicProductDetailResponse struct {
+	PublicProductResponse
+	Barcode     string                       `json:"barcode"`
+	Description string                       `json:"description"`
+	Categories  []PublicCategoryResponse     `json:"categories"`
+	Breadcrumbs [][]PublicCategoryResponse   `json:"breadcrumbs"`
+	Attributes  []ProductAttributeResponse   `json:"attributes"`
+	Media       []PublicProductMediaResponse `json:"media"`
+}
+
+func SetPublicProductDetailResponse(product models.Product) PublicProductDetailResponse {
+	categories := []PublicCategoryResponse{}
+	if product.ProductCategory != nil {
+		for _, pc := range *product.ProductCategory {
+			if pc.Category != nil {
+				categories = append(categories, SetPublicCategoryResponse(*pc.Category))
+			}
+		}
+	}
+
+	return PublicProductDetailResponse{
+		PublicProductResponse: SetPublicProductResponse(product),
+		Barcode:               product.Barcode,
+		Description:           product.Description,
+		Categories:            categories,
+		Attributes:            []ProductAttributeResponse{},
+		Media:                 []PublicProductMediaResponse{},
+	}
+}
+
+type PublicCategoryResponse struct {
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Depth int    `json:"depth"`
+}
+
+func SetPublicCategoryResponse(category models.Category) PublicCategoryResponse {
+	return PublicCategoryResponse{
+		Name:  category.Name,
+		Slug:  category.Slug,
+		Depth: category.Depth,
+	}
+}
+
+type PublicCategoryTreeResponse struct {
+	Name     string                       `json:"name"`
+	Slug     string                       `json:"slug"`
+	Children []PublicCategoryTreeResponse `json:"children"`
+}
+
+func SetPublicCategoryTreeResponse(tree []CategoryTreeResponse) []PublicCategoryTreeResponse {
+	res := make([]PublicCategoryTreeResponse, 0, len(tree))
+	for _, node := range tree {
+		res = append(res, PublicCategoryTreeResponse{
+			Name:     node.Name,
+			Slug:     node.Slug,
+			Children: SetPublicCategoryTreeResponse(node.Children),
+		})
+	}
+	return res
+}
+
+type PublicCategoryDetailResponse struct {
+	PublicCategoryResponse
+	Breadcrumbs []PublicCategoryResponse     `json:"breadcrumbs"`
+	Children    []PublicCategoryTreeResponse `json:"children"`
+}
+
+// SetPublicBreadcrumbResponse membuang id kategori dari breadcrumb untuk response publik
+func SetPublicBreadcrumbResponse(breadcrumb []CategoryBreadcrumbResponse) []PublicCategoryResponse {
+	res := make([]PublicCategoryResponse, 0, len(breadcrumb))
+	for i, b := range breadcrumb {
+		res = append(res, PublicCategoryResponse{
+			Name:  b.Name,
+			Slug:  b.Slug,
+			Depth: i,
+		})
+	}
+	return res
+}
+
+type PublicProductSearchResponse struct {
+	PublicProductResponse
+	NameHighlight string `json:"name_highlight"`
+	Snippet       string `json:"snippet"`
+}
+
+func SetResponseListPublicProductSearch(results []models.ProductSearchResult) []PublicProductSearchResponse {
+	responses := make([]PublicProductSearchResponse, 0, len(results))
+	for _, r := range results {
+		responses = append(responses, PublicProductSearchResponse{
+			PublicProductResponse: SetPublicProductResponse(r.Product),
+			NameHighlight:         r.NameHighlight,
+			Snippet:               r.Snippet,
+		})
+	}
+	return responses
+}
@@ -6,26 +6,36 @@ import (
 )
 
 type CategoryResponse struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Code      string    `json:"code"`
-	Slug      string    `json:"slug"`
-	CreatedAt time.Time `json:"created_at"`
-	CreatedBy int64     `json:"created_by"`
-	UpdatedAt time.Time `json:"updated_at"`
-	UpdatedBy int64     `json:"updated_by"`
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Code       string    `json:"code"`
+	Slug       string    `json:"slug"`
+	ParentID   *int64    `json:"parent_id"`
+	Depth      int       `json:"depth"`
+	Path       string    `json:"path"`
+	SortOrder  int       `json:"sort_order"`
+	TaxClassID *int64    `json:"tax_class_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	CreatedBy  int64     `json:"created_by"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	UpdatedBy  int64     `json:"updated_by"`
 }
 
 func SetCategoryResponse(category models.Category) CategoryResponse {
 	return CategoryResponse{
-		ID:        category.ID,
-		Name:      category.Name,
-		Code:      category.Code,
-		Slug:      category.Slug,
-		CreatedAt: category.CreatedAt,
-		CreatedBy: category.CreatedBy,
-		UpdatedAt: category.UpdatedAt,
-		UpdatedBy: category.UpdatedBy,
+		ID:         category.ID,
+		Name:       category.Name,
+		Code:       category.Code,
+		Slug:       category.Slug,
+		ParentID:   category.ParentID,
+		Depth:      category.Depth,
+		Path:       category.Path,
+		SortOrder:  category.SortOrder,
+		TaxClassID: category.TaxClassID,
+		CreatedAt:  category.CreatedAt,
+		CreatedBy:  category.CreatedBy,
+		UpdatedAt:  category.UpdatedAt,
+		UpdatedBy:  category.UpdatedBy,
 	}
 }
 
@@ -36,3 +46,76 @@ func SetResponseListCategory(category []models.Category) []CategoryResponse {
 	}
 	return categoryResponse
 }
+
+type CategoryTreeResponse struct {
+	ID        int64                  `json:"id"`
+	Name      string                 `json:"name"`
+	Code      string                 `json:"code"`
+	Slug      string                 `json:"slug"`
+	ParentID  *int64                 `json:"parent_id"`
+	Depth     int                    `json:"depth"`
+	SortOrder int                    `json:"sort_order"`
+	Children  []CategoryTreeResponse `json:"children"`
+}
+
+// SetCategoryTreeResponse menyusun list kategori (terurut depth) menjadi nested tree.
+// Kategori yang parent-nya tidak ada di list dianggap sebagai root.
+func SetCategoryTreeResponse(categories []models.Category) []CategoryTreeResponse {
+	children := make(map[int64][]models.Category)
+	exists := make(map[int64]bool, len(categories))
+	for _, c := range categories {
+		exists[c.ID] = true
+	}
+
+	var roots []models.Category
+	for _, c := range categories {
+		if c.ParentID == nil || !exists[*c.ParentID] {
+			roots = append(roots, c)
+			continue
+		}
+		children[*c.ParentID] = append(children[*c.ParentID], c)
+	}
+
+	var build func(nodes []models.Category) []CategoryTreeResponse
+	build = func(nodes []models.Category) []CategoryTreeResponse {
+		res := make([]CategoryTreeResponse, 0, len(nodes))
+		for _, n := range nodes {
+			res = append(res, CategoryTreeResponse{
+				ID:        n.ID,
+				Name:      n.Name,
+				Code:      n.Code,
+				Slug:      n.Slug,
+				ParentID:  n.ParentID,
+				Depth:     n.Depth,
+				SortOrder: n.SortOrder,
+				Children:  build(children[n.ID]),
+			})
+		}
+		return res
+	}
+
+	return build(roots)
+}
+
+type CategoryBreadcrumbResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// SetCategoryBreadcrumbResponse menyusun breadcrumb dari root sampai kategori berdasarkan path
+func SetCategoryBreadcrumbResponse(category models.Category, ancestors map[int64]models.Category) []CategoryBreadcrumbResponse {
+	var res []CategoryBreadcrumbResponse
+	for _, id := range category.AncestorIDs() {
+		c, ok := ancestors[id]
+		if !ok {
+			continue
+		}
+		res = append(res, CategoryBreadcrumbResponse{
+			ID:   c.ID,
+			Name: c.Name,
+			Slug: c.Slug,
+		})
+	}
+	return res
+}
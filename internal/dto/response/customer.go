@@ -12,7 +12,7 @@ type CustomerResponse struct {
 	Phone      string    `json:"phone"`
 	UserID     int64     `json:"user_id"`
 	IsGuest    bool      `json:"is_guest"`
-	GuestToken string    `json:"guest_token"`
+	GuestToken string    `json:"guest_token" export:"-"`
 	CreatedAt  time.Time `json:"created_at"`
 	CreatedBy  int64     `json:"created_by"`
 	UpdatedAt  time.Time `json:"updated_at"`
@@ -0,0 +1,91 @@
+package response
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ExportColumn kolom export diambil dari field response (nama = tag json), sehingga yang bisa
+// diexport sama dengan yang tampil di list. Field bertag export:"-" (data sensitif) dan field
+// bertipe object / array tidak ikut diexport
+type ExportColumn struct {
+	Name  string
+	index []int
+}
+
+// GetExportColumns kolom yang tersedia untuk T, bila fields diisi hanya kolom tersebut (sesuai urutan fields)
+func GetExportColumns[T any](fields []string) ([]ExportColumn, error) {
+	var available []ExportColumn
+	byName := map[string]ExportColumn{}
+
+	for _, f := range reflect.VisibleFields(reflect.TypeOf((*T)(nil)).Elem()) {
+		if f.Anonymous || !f.IsExported() || f.Tag.Get("export") == "-" || !isExportableType(f.Type) {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		col := ExportColumn{Name: name, index: f.Index}
+		available = append(available, col)
+		byName[name] = col
+	}
+
+	if len(fields) == 0 {
+		return available, nil
+	}
+
+	columns := make([]ExportColumn, 0, len(fields))
+	for _, name := range fields {
+		col, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("kolom %s tidak tersedia", name)
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+func isExportableType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Interface, reflect.Func, reflect.Chan:
+		return false
+	}
+	return true
+}
+
+func ExportHeader(columns []ExportColumn) []interface{} {
+	header := make([]interface{}, len(columns))
+	for i, col := range columns {
+		header[i] = col.Name
+	}
+	return header
+}
+
+// ExportRow nilai kolom dari satu item, pointer nil menjadi sel kosong
+func ExportRow[T any](columns []ExportColumn, item T) []interface{} {
+	v := reflect.ValueOf(item)
+	row := make([]interface{}, len(columns))
+	for i, col := range columns {
+		field := v.FieldByIndex(col.index)
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		row[i] = field.Interface()
+	}
+	return row
+}
@@ -0,0 +1,46 @@
+package response
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"pleasurelove/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type FileResponse struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// SetResponseFile mengirim file sebagai attachment
+func SetResponseFile(c *fiber.Ctx, file FileResponse) error {
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	return c.Status(fiber.StatusOK).Send(file.Content)
+}
+
+// FileStreamResponse file yang isinya ditulis langsung ke response (tanpa ditampung di memori)
+type FileStreamResponse struct {
+	FileName    string
+	ContentType string
+	Write       func(ctx context.Context, w io.Writer) error
+}
+
+// SetResponseFileStream header dikirim lebih dulu, sehingga error saat streaming hanya bisa
+// dicatat di log (file yang diterima klien terpotong)
+func SetResponseFileStream(c *fiber.Ctx, ctx context.Context, file FileStreamResponse) error {
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	c.Status(fiber.StatusOK)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := file.Write(ctx, w); err != nil {
+			logger.Error(ctx, "Failed to stream file "+file.FileName, err)
+		}
+		w.Flush()
+	})
+	return nil
+}
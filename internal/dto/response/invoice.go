@@ -0,0 +1,81 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"time"
+)
+
+type InvoiceResponse struct {
+	ID              int64      `json:"id"`
+	DocumentType    string     `json:"document_type"`
+	Number          string     `json:"number"`
+	BranchID        int64      `json:"branch_id"`
+	OrderID         int64      `json:"order_id"`
+	OrderNumber     string     `json:"order_number,omitempty"`
+	InvoiceID       *int64     `json:"invoice_id"`
+	InvoiceNumber   string     `json:"invoice_number,omitempty"`
+	PaymentID       *int64     `json:"payment_id"`
+	Reason          string     `json:"reason"`
+	TaxMode         string     `json:"tax_mode"`
+	CustomerName    string     `json:"customer_name"`
+	CustomerPhone   string     `json:"customer_phone"`
+	CustomerEmail   string     `json:"customer_email"`
+	CustomerAddress string     `json:"customer_address"`
+	Subtotal        float64    `json:"subtotal"`
+	DiscountTotal   float64    `json:"discount_total"`
+	ShippingTotal   float64    `json:"shipping_total"`
+	TaxTotal        float64    `json:"tax_total"`
+	GrandTotal      float64    `json:"grand_total"`
+	IssuedAt        time.Time  `json:"issued_at"`
+	PrintCount      int        `json:"print_count"`
+	LastPrintedAt   *time.Time `json:"last_printed_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	CreatedBy       int64      `json:"created_by"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	UpdatedBy       int64      `json:"updated_by"`
+}
+
+func SetInvoiceResponse(invoice models.Invoice) InvoiceResponse {
+	res := InvoiceResponse{
+		ID:              invoice.ID,
+		DocumentType:    invoice.DocumentType,
+		Number:          invoice.Number,
+		BranchID:        invoice.BranchID,
+		OrderID:         invoice.OrderID,
+		InvoiceID:       invoice.InvoiceID,
+		PaymentID:       invoice.PaymentID,
+		Reason:          invoice.Reason,
+		TaxMode:         invoice.TaxMode,
+		CustomerName:    invoice.CustomerName,
+		CustomerPhone:   invoice.CustomerPhone,
+		CustomerEmail:   invoice.CustomerEmail,
+		CustomerAddress: invoice.CustomerAddress,
+		Subtotal:        invoice.Subtotal,
+		DiscountTotal:   invoice.DiscountTotal,
+		ShippingTotal:   invoice.ShippingTotal,
+		TaxTotal:        invoice.TaxTotal,
+		GrandTotal:      invoice.GrandTotal,
+		IssuedAt:        invoice.IssuedAt,
+		PrintCount:      invoice.PrintCount,
+		LastPrintedAt:   invoice.LastPrintedAt,
+		CreatedAt:       invoice.CreatedAt,
+		CreatedBy:       invoice.CreatedBy,
+		UpdatedAt:       invoice.UpdatedAt,
+		UpdatedBy:       invoice.UpdatedBy,
+	}
+	if invoice.Order != nil {
+		res.OrderNumber = invoice.Order.OrderNumber
+	}
+	if invoice.Invoice != nil {
+		res.InvoiceNumber = invoice.Invoice.Number
+	}
+	return res
+}
+
+func SetResponseListInvoice(invoices []models.Invoice) []InvoiceResponse {
+	responses := make([]InvoiceResponse, 0, len(invoices))
+	for _, inv := range invoices {
+		responses = append(responses, SetInvoiceResponse(inv))
+	}
+	return responses
+}
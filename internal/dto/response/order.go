@@ -0,0 +1,77 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"time"
+)
+
+type OrderResponse struct {
+	ID              int64                       `json:"id"`
+	OrderNumber     string                      `json:"order_number"`
+	CustomerID      int64                       `json:"customer_id"`
+	CustomerName    string                      `json:"customer_name"`
+	CustomerEmail   string                      `json:"customer_email"`
+	CustomerPhone   string                      `json:"customer_phone"`
+	Status          string                      `json:"status"`
+	NextStatuses    []string                    `json:"next_statuses"`
+	Subtotal        float64                     `json:"subtotal"`
+	DiscountTotal   float64                     `json:"discount_total"`
+	ShippingTotal   float64                     `json:"shipping_total"`
+	TaxMode         string                      `json:"tax_mode"`
+	TaxTotal        float64                     `json:"tax_total"`
+	GrandTotal      float64                     `json:"grand_total"`
+	Notes           string                      `json:"notes"`
+	VoucherCode     string                      `json:"voucher_code"`
+	ShippingCourier string                      `json:"shipping_courier"`
+	ShippingService string                      `json:"shipping_service"`
+	ShippingAddress *models.OrderAddress        `json:"shipping_address"`
+	Items           []models.OrderItem          `json:"items,omitempty"`
+	Taxes           []models.OrderTax           `json:"taxes,omitempty"`
+	History         []models.OrderStatusHistory `json:"history,omitempty"`
+	CreatedAt       time.Time                   `json:"created_at"`
+	CreatedBy       int64                       `json:"created_by"`
+	UpdatedAt       time.Time                   `json:"updated_at"`
+	UpdatedBy       int64                       `json:"updated_by"`
+}
+
+func SetOrderResponse(o models.Order) OrderResponse {
+	res := OrderResponse{
+		ID:              o.ID,
+		OrderNumber:     o.OrderNumber,
+		CustomerID:      o.CustomerID,
+		Status:          o.Status,
+		NextStatuses:    models.NextOrderStatuses(o.Status),
+		Subtotal:        o.Subtotal,
+		DiscountTotal:   o.DiscountTotal,
+		ShippingTotal:   o.ShippingTotal,
+		TaxMode:         o.TaxMode,
+		TaxTotal:        o.TaxTotal,
+		GrandTotal:      o.GrandTotal,
+		Notes:           o.Notes,
+		VoucherCode:     o.VoucherCode,
+		ShippingCourier: o.ShippingCourier,
+		ShippingService: o.ShippingService,
+		ShippingAddress: o.ShippingAddress,
+		Items:           o.Items,
+		Taxes:           o.Taxes,
+		History:         o.History,
+		CreatedAt:       o.CreatedAt,
+		CreatedBy:       o.CreatedBy,
+		UpdatedAt:       o.UpdatedAt,
+		UpdatedBy:       o.UpdatedBy,
+	}
+	if o.Customer != nil {
+		res.CustomerName = o.Customer.Name
+		res.CustomerEmail = o.Customer.Email
+		res.CustomerPhone = o.Customer.Phone
+	}
+	return res
+}
+
+func SetResponseListOrder(orders []models.Order) []OrderResponse {
+	responses := make([]OrderResponse, 0, len(orders))
+	for _, o := range orders {
+		responses = append(responses, SetOrderResponse(o))
+	}
+	return responses
+}
@@ -0,0 +1,78 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"time"
+)
+
+type PaymentResponse struct {
+	ID                int64      `json:"id"`
+	OrderID           int64      `json:"order_id"`
+	Provider          string     `json:"provider"`
+	Method            string     `json:"method"`
+	Channel           string     `json:"channel"`
+	Reference         string     `json:"reference"`
+	TransactionID     string     `json:"transaction_id"`
+	Amount            float64    `json:"amount"`
+	RefundedAmount    float64    `json:"refunded_amount"`
+	Status            string     `json:"status"`
+	VANumber          string     `json:"va_number"`
+	QRString          string     `json:"qr_string"`
+	RedirectURL       string     `json:"redirect_url"`
+	BankName          string     `json:"bank_name"`
+	BankAccountNumber string     `json:"bank_account_number"`
+	BankAccountName   string     `json:"bank_account_name"`
+	ProofURL          string     `json:"proof_url"`
+	ProofUploadedAt   *time.Time `json:"proof_uploaded_at"`
+	ConfirmedBy       int64      `json:"confirmed_by"`
+	ConfirmNote       string     `json:"confirm_note"`
+	ExpiresAt         *time.Time `json:"expires_at"`
+	PaidAt            *time.Time `json:"paid_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	CreatedBy         int64      `json:"created_by"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	UpdatedBy         int64      `json:"updated_by"`
+}
+
+// SetPaymentResponse urlFn mengubah key bukti transfer di storage menjadi URL
+func SetPaymentResponse(p models.Payment, urlFn func(key string) string) PaymentResponse {
+	res := PaymentResponse{
+		ID:                p.ID,
+		OrderID:           p.OrderID,
+		Provider:          p.Provider,
+		Method:            p.Method,
+		Channel:           p.Channel,
+		Reference:         p.Reference,
+		TransactionID:     p.TransactionID,
+		Amount:            p.Amount,
+		RefundedAmount:    p.RefundedAmount,
+		Status:            p.Status,
+		VANumber:          p.VANumber,
+		QRString:          p.QRString,
+		RedirectURL:       p.RedirectURL,
+		BankName:          p.BankName,
+		BankAccountNumber: p.BankAccountNumber,
+		BankAccountName:   p.BankAccountName,
+		ProofUploadedAt:   p.ProofUploadedAt,
+		ConfirmedBy:       p.ConfirmedBy,
+		ConfirmNote:       p.ConfirmNote,
+		ExpiresAt:         p.ExpiresAt,
+		PaidAt:            p.PaidAt,
+		CreatedAt:         p.CreatedAt,
+		CreatedBy:         p.CreatedBy,
+		UpdatedAt:         p.UpdatedAt,
+		UpdatedBy:         p.UpdatedBy,
+	}
+	if p.ProofKey != "" {
+		res.ProofURL = urlFn(p.ProofKey)
+	}
+	return res
+}
+
+func SetResponseListPayment(payments []models.Payment, urlFn func(key string) string) []PaymentResponse {
+	res := make([]PaymentResponse, 0, len(payments))
+	for _, p := range payments {
+		res = append(res, SetPaymentResponse(p, urlFn))
+	}
+	return res
+}
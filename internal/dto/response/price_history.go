@@ -0,0 +1,92 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"time"
+)
+
+type PriceScheduleResponse struct {
+	ID          int64      `json:"id"`
+	ProductID   int64      `json:"product_id"`
+	VarianID    *int64     `json:"varian_id"`
+	Price       float64    `json:"price"`
+	CostPrice   float64    `json:"cost_price"`
+	Discount    float64    `json:"discount"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	Status      string     `json:"status"`
+	AppliedAt   *time.Time `json:"applied_at"`
+	Note        string     `json:"note"`
+	Error       string     `json:"error"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CreatedBy   int64      `json:"created_by"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	UpdatedBy   int64      `json:"updated_by"`
+}
+
+func SetPriceScheduleResponse(schedule models.PriceSchedule) PriceScheduleResponse {
+	return PriceScheduleResponse{
+		ID:          schedule.ID,
+		ProductID:   schedule.ProductID,
+		VarianID:    schedule.ProductVarianID,
+		Price:       schedule.Price,
+		CostPrice:   schedule.CostPrice,
+		Discount:    schedule.Discount,
+		EffectiveAt: schedule.EffectiveAt,
+		Status:      schedule.Status,
+		AppliedAt:   schedule.AppliedAt,
+		Note:        schedule.Note,
+		Error:       schedule.ErrorMessage,
+		CreatedAt:   schedule.CreatedAt,
+		CreatedBy:   schedule.CreatedBy,
+		UpdatedAt:   schedule.UpdatedAt,
+		UpdatedBy:   schedule.UpdatedBy,
+	}
+}
+
+func SetResponseListPriceSchedule(schedules []models.PriceSchedule) []PriceScheduleResponse {
+	responses := make([]PriceScheduleResponse, 0, len(schedules))
+	for _, s := range schedules {
+		responses = append(responses, SetPriceScheduleResponse(s))
+	}
+	return responses
+}
+
+type PriceHistoryResponse struct {
+	ID            int64     `json:"id"`
+	ProductID     int64     `json:"product_id"`
+	VarianID      *int64    `json:"varian_id"`
+	OldPrice      *float64  `json:"old_price"`
+	NewPrice      float64   `json:"new_price"`
+	OldCostPrice  *float64  `json:"old_cost_price"`
+	NewCostPrice  float64   `json:"new_cost_price"`
+	OldDiscount   *float64  `json:"old_discount"`
+	NewDiscount   float64   `json:"new_discount"`
+	Source        string    `json:"source"`
+	ScheduleID    *int64    `json:"schedule_id"`
+	ChangedAt     time.Time `json:"changed_at"`
+	ChangedBy     int64     `json:"changed_by"`
+	ChangedByName string    `json:"changed_by_name"`
+}
+
+func SetResponseListPriceHistory(timeline []models.PriceHistoryTimeline) []PriceHistoryResponse {
+	responses := make([]PriceHistoryResponse, 0, len(timeline))
+	for _, h := range timeline {
+		responses = append(responses, PriceHistoryResponse{
+			ID:            h.ID,
+			ProductID:     h.ProductID,
+			VarianID:      h.ProductVarianID,
+			OldPrice:      h.OldPrice,
+			NewPrice:      h.NewPrice,
+			OldCostPrice:  h.OldCostPrice,
+			NewCostPrice:  h.NewCostPrice,
+			OldDiscount:   h.OldDiscount,
+			NewDiscount:   h.NewDiscount,
+			Source:        h.Source,
+			ScheduleID:    h.ScheduleID,
+			ChangedAt:     h.ChangedAt,
+			ChangedBy:     h.ChangedBy,
+			ChangedByName: h.ChangedByName,
+		})
+	}
+	return responses
+}
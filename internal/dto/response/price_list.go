@@ -0,0 +1,147 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"time"
+)
+
+type PriceListResponse struct {
+	ID          int64      `json:"id"`
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Priority    int        `json:"priority"`
+	IsDefault   bool       `json:"is_default"`
+	IsActive    bool       `json:"is_active"`
+	ValidFrom   *time.Time `json:"valid_from"`
+	ValidTo     *time.Time `json:"valid_to"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CreatedBy   int64      `json:"created_by"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	UpdatedBy   int64      `json:"updated_by"`
+}
+
+func SetPriceListResponse(priceList models.PriceList) PriceListResponse {
+	return PriceListResponse{
+		ID:          priceList.ID,
+		Code:        priceList.Code,
+		Name:        priceList.Name,
+		Description: priceList.Description,
+		Priority:    priceList.Priority,
+		IsDefault:   priceList.IsDefault,
+		IsActive:    priceList.IsActive,
+		ValidFrom:   priceList.ValidFrom,
+		ValidTo:     priceList.ValidTo,
+		CreatedAt:   priceList.CreatedAt,
+		CreatedBy:   priceList.CreatedBy,
+		UpdatedAt:   priceList.UpdatedAt,
+		UpdatedBy:   priceList.UpdatedBy,
+	}
+}
+
+func SetResponseListPriceList(priceLists []models.PriceList) []PriceListResponse {
+	responses := make([]PriceListResponse, 0, len(priceLists))
+	for _, pl := range priceLists {
+		responses = append(responses, SetPriceListResponse(pl))
+	}
+	return responses
+}
+
+type PriceListItemResponse struct {
+	ID        int64      `json:"id"`
+	ProductID int64      `json:"product_id"`
+	VarianID  *int64     `json:"varian_id"`
+	MinQty    int        `json:"min_qty"`
+	Price     float64    `json:"price"`
+	ValidFrom *time.Time `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to"`
+}
+
+type PriceListAssignmentResponse struct {
+	ID              int64  `json:"id"`
+	CustomerID      *int64 `json:"customer_id"`
+	CustomerGroupID *int64 `json:"customer_group_id"`
+}
+
+type PriceListDetailResponse struct {
+	PriceListResponse
+	Items       []PriceListItemResponse       `json:"items"`
+	Assignments []PriceListAssignmentResponse `json:"assignments"`
+}
+
+func SetPriceListDetailResponse(priceList models.PriceList) PriceListDetailResponse {
+	res := PriceListDetailResponse{
+		PriceListResponse: SetPriceListResponse(priceList),
+		Items:             []PriceListItemResponse{},
+		Assignments:       []PriceListAssignmentResponse{},
+	}
+
+	if priceList.Items != nil {
+		for _, item := range *priceList.Items {
+			res.Items = append(res.Items, PriceListItemResponse{
+				ID:        item.ID,
+				ProductID: item.ProductID,
+				VarianID:  item.ProductVarianID,
+				MinQty:    item.MinQty,
+				Price:     item.Price,
+				ValidFrom: item.ValidFrom,
+				ValidTo:   item.ValidTo,
+			})
+		}
+	}
+
+	if priceList.Assignments != nil {
+		for _, a := range *priceList.Assignments {
+			res.Assignments = append(res.Assignments, PriceListAssignmentResponse{
+				ID:              a.ID,
+				CustomerID:      a.CustomerID,
+				CustomerGroupID: a.CustomerGroupID,
+			})
+		}
+	}
+
+	return res
+}
+
+type CustomerGroupResponse struct {
+	ID          int64     `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   int64     `json:"created_by"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	UpdatedBy   int64     `json:"updated_by"`
+}
+
+func SetCustomerGroupResponse(group models.CustomerGroup) CustomerGroupResponse {
+	return CustomerGroupResponse{
+		ID:          group.ID,
+		Code:        group.Code,
+		Name:        group.Name,
+		Description: group.Description,
+		CreatedAt:   group.CreatedAt,
+		CreatedBy:   group.CreatedBy,
+		UpdatedAt:   group.UpdatedAt,
+		UpdatedBy:   group.UpdatedBy,
+	}
+}
+
+func SetResponseListCustomerGroup(groups []models.CustomerGroup) []CustomerGroupResponse {
+	responses := make([]CustomerGroupResponse, 0, len(groups))
+	for _, g := range groups {
+		responses = append(responses, SetCustomerGroupResponse(g))
+	}
+	return responses
+}
+
+type PriceQuoteItemResponse struct {
+	models.ResolvedPrice
+	LineTotal float64 `json:"line_total"`
+}
+
+type PriceQuoteResponse struct {
+	At    time.Time                `json:"at"`
+	Items []PriceQuoteItemResponse `json:"items"`
+	Total float64                  `json:"total"`
+}
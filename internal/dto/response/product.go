@@ -7,32 +7,40 @@ import (
 )
 
 type ProductResponse struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Code      string    `json:"code"`
-	Price     float64   `json:"price"`
-	CostPrice float64   `json:"cost_price"`
-	Discount  float64   `json:"discount"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	CreatedBy int64     `json:"created_by"`
-	UpdatedAt time.Time `json:"updated_at"`
-	UpdatedBy int64     `json:"updated_by"`
+	ID             int64     `json:"id"`
+	Name           string    `json:"name"`
+	Code           string    `json:"code"`
+	Slug           string    `json:"slug"`
+	Price          float64   `json:"price"`
+	CostPrice      float64   `json:"cost_price"`
+	Discount       float64   `json:"discount"`
+	IsActive       bool      `json:"is_active"`
+	ProductType    string    `json:"product_type"`
+	Stock          int       `json:"stock"`
+	AvailableStock int       `json:"available_stock"` // bundle: dihitung dari stok komponen
+	CreatedAt      time.Time `json:"created_at"`
+	CreatedBy      int64     `json:"created_by"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	UpdatedBy      int64     `json:"updated_by"`
 }
 
 func SetProductResponse(product models.Product) ProductResponse {
 	return ProductResponse{
-		ID:        product.ID,
-		Name:      product.Name,
-		Code:      product.Code,
-		Price:     utils.RoundTo2Digits(product.Price),
-		CostPrice: utils.RoundTo2Digits(product.CostPrice),
-		Discount:  utils.RoundTo2Digits(product.Discount),
-		IsActive:  product.IsActive,
-		CreatedAt: product.CreatedAt,
-		CreatedBy: product.CreatedBy,
-		UpdatedAt: product.UpdatedAt,
-		UpdatedBy: product.UpdatedBy,
+		ID:             product.ID,
+		Name:           product.Name,
+		Code:           product.Code,
+		Slug:           product.Slug,
+		Price:          utils.RoundTo2Digits(product.Price),
+		CostPrice:      utils.RoundTo2Digits(product.CostPrice),
+		Discount:       utils.RoundTo2Digits(product.Discount),
+		IsActive:       product.IsActive,
+		ProductType:    product.ProductType,
+		Stock:          product.Stock,
+		AvailableStock: product.Stock,
+		CreatedAt:      product.CreatedAt,
+		CreatedBy:      product.CreatedBy,
+		UpdatedAt:      product.UpdatedAt,
+		UpdatedBy:      product.UpdatedBy,
 	}
 }
 
@@ -45,23 +53,37 @@ func SetResponseListProduct(products []models.Product) []ProductResponse {
 }
 
 type DetailProductResponse struct {
-	ID              int64                     `json:"id"`
-	Name            string                    `json:"name"`
-	Code            string                    `json:"code"`
-	Barcode         string                    `json:"barcode"`
-	Description     string                    `json:"description"`
-	Brand           string                    `json:"brand"`
-	Unit            string                    `json:"unit"`
-	Price           float64                   `json:"price"`
-	CostPrice       float64                   `json:"cost_price"`
-	Discount        float64                   `json:"discount"`
-	IsActive        bool                      `json:"is_active"`
-	HasVarian       bool                      `json:"has_varian"`
-	CreatedAt       time.Time                 `json:"created_at"`
-	CreatedBy       int64                     `json:"created_by"`
-	UpdatedAt       time.Time                 `json:"updated_at"`
-	UpdatedBy       int64                     `json:"updated_by"`
-	ProductCategory []ProductCategoryResponse `json:"product_category"`
+	ID              int64                          `json:"id"`
+	Name            string                         `json:"name"`
+	Code            string                         `json:"code"`
+	Slug            string                         `json:"slug"`
+	Barcode         string                         `json:"barcode"`
+	Description     string                         `json:"description"`
+	Brand           string                         `json:"brand"`
+	Unit            string                         `json:"unit"`
+	Price           float64                        `json:"price"`
+	CostPrice       float64                        `json:"cost_price"`
+	Discount        float64                        `json:"discount"`
+	IsActive        bool                           `json:"is_active"`
+	HasVarian       bool                           `json:"has_varian"`
+	ProductType     string                         `json:"product_type"`
+	BundlePricing   string                         `json:"bundle_pricing"`
+	Stock           int                            `json:"stock"`
+	AvailableStock  int                            `json:"available_stock"`
+	WeightGram      int                            `json:"weight_gram"`
+	LengthCm        float64                        `json:"length_cm"`
+	WidthCm         float64                        `json:"width_cm"`
+	HeightCm        float64                        `json:"height_cm"`
+	TaxClassID      *int64                         `json:"tax_class_id"`
+	BundleItems     []ProductBundleItemResponse    `json:"bundle_items"`
+	Attributes      []ProductAttributeResponse     `json:"attributes"`
+	Media           []ProductMediaResponse         `json:"media"`
+	CreatedAt       time.Time                      `json:"created_at"`
+	CreatedBy       int64                          `json:"created_by"`
+	UpdatedAt       time.Time                      `json:"updated_at"`
+	UpdatedBy       int64                          `json:"updated_by"`
+	ProductCategory []ProductCategoryResponse      `json:"product_category"`
+	Breadcrumbs     [][]CategoryBreadcrumbResponse `json:"breadcrumbs"`
 }
 
 func SetDetailProductResponse(product models.Product) DetailProductResponse {
@@ -74,6 +96,7 @@ func SetDetailProductResponse(product models.Product) DetailProductResponse {
 		ID:              product.ID,
 		Name:            product.Name,
 		Code:            product.Code,
+		Slug:            product.Slug,
 		Barcode:         product.Barcode,
 		Description:     product.Description,
 		Brand:           product.Brand,
@@ -83,6 +106,18 @@ func SetDetailProductResponse(product models.Product) DetailProductResponse {
 		Discount:        utils.RoundTo2Digits(product.Discount),
 		IsActive:        product.IsActive,
 		HasVarian:       product.HasVarian,
+		ProductType:     product.ProductType,
+		BundlePricing:   product.BundlePricing,
+		Stock:           product.Stock,
+		AvailableStock:  product.Stock,
+		WeightGram:      product.WeightGram,
+		LengthCm:        product.LengthCm,
+		WidthCm:         product.WidthCm,
+		HeightCm:        product.HeightCm,
+		TaxClassID:      product.TaxClassID,
+		BundleItems:     []ProductBundleItemResponse{},
+		Attributes:      []ProductAttributeResponse{},
+		Media:           []ProductMediaResponse{},
 		CreatedAt:       product.CreatedAt,
 		CreatedBy:       product.CreatedBy,
 		UpdatedAt:       product.UpdatedAt,
@@ -90,3 +125,43 @@ func SetDetailProductResponse(product models.Product) DetailProductResponse {
 		ProductCategory: productcategory,
 	}
 }
+
+type ProductBundleItemResponse struct {
+	ID                 int64  `json:"id"`
+	ComponentProductID int64  `json:"component_product_id"`
+	ComponentVarianID  *int64 `json:"component_varian_id"`
+	Quantity           int    `json:"quantity"`
+}
+
+func SetResponseListProductBundleItem(items []models.ProductBundleItem) []ProductBundleItemResponse {
+	responses := make([]ProductBundleItemResponse, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, ProductBundleItemResponse{
+			ID:                 item.ID,
+			ComponentProductID: item.ComponentProductID,
+			ComponentVarianID:  item.ComponentVarianID,
+			Quantity:           item.Quantity,
+		})
+	}
+	return responses
+}
+
+type ProductSearchResponse struct {
+	ProductResponse
+	Rank          float64 `json:"rank"`
+	NameHighlight string  `json:"name_highlight"`
+	Snippet       string  `json:"snippet"`
+}
+
+func SetResponseListProductSearch(results []models.ProductSearchResult) []ProductSearchResponse {
+	responses := make([]ProductSearchResponse, 0, len(results))
+	for _, r := range results {
+		responses = append(responses, ProductSearchResponse{
+			ProductResponse: SetProductResponse(r.Product),
+			Rank:            r.Rank,
+			NameHighlight:   r.NameHighlight,
+			Snippet:         r.Snippet,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,25 @@
+package response
+
+import "pleasurelove/internal/models"
+
+// ProductListFacetResponse list produk beserta facet (opsi filter + jumlah) dari hasil filter saat ini
+type ProductListFacetResponse[T any] struct {
+	ListResponse[T]
+	Facets ProductFacetResponse `json:"facets"`
+}
+
+type ProductFacetResponse struct {
+	Brands     []models.FacetBucket    `json:"brands"`
+	Categories []models.FacetBucket    `json:"categories"`
+	Prices     []models.PriceBucket    `json:"prices"`
+	Attributes []models.AttributeFacet `json:"attributes"`
+}
+
+func SetProductFacetResponse(facets models.ProductFacets) ProductFacetResponse {
+	return ProductFacetResponse{
+		Brands:     facets.Brands,
+		Categories: facets.Categories,
+		Prices:     facets.Prices,
+		Attributes: facets.Attributes,
+	}
+}
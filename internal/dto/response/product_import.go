@@ -0,0 +1,58 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"time"
+)
+
+type ProductImportResponse struct {
+	ID            string                     `json:"id"`
+	FileName      string                     `json:"file_name"`
+	Status        string                     `json:"status"`
+	DryRun        bool                       `json:"dry_run"`
+	TotalRows     int                        `json:"total_rows"`
+	ProcessedRows int                        `json:"processed_rows"`
+	Progress      float64                    `json:"progress"` // persen baris yang sudah diproses
+	Created       int                        `json:"created"`
+	Updated       int                        `json:"updated"`
+	Failed        int                        `json:"failed"`
+	Errors        []ProductImportRowResponse `json:"errors"`
+	CreatedBy     int64                      `json:"created_by"`
+	StartedAt     time.Time                  `json:"started_at"`
+	FinishedAt    *time.Time                 `json:"finished_at"`
+}
+
+type ProductImportRowResponse struct {
+	Row     int    `json:"row"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func SetProductImportResponse(job models.ProductImportJob) ProductImportResponse {
+	errs := make([]ProductImportRowResponse, 0, len(job.Errors))
+	for _, e := range job.Errors {
+		errs = append(errs, ProductImportRowResponse{Row: e.Row, Code: e.Code, Message: e.Message})
+	}
+
+	progress := 100.0
+	if job.TotalRows > 0 {
+		progress = float64(job.ProcessedRows*10000/job.TotalRows) / 100
+	}
+
+	return ProductImportResponse{
+		ID:            job.ID,
+		FileName:      job.FileName,
+		Status:        job.Status,
+		DryRun:        job.DryRun,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		Progress:      progress,
+		Created:       job.Created,
+		Updated:       job.Updated,
+		Failed:        job.Failed,
+		Errors:        errs,
+		CreatedBy:     job.CreatedBy,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+	}
+}
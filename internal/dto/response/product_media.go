@@ -0,0 +1,83 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"time"
+)
+
+type ProductMediaResponse struct {
+	ID          int64             `json:"id"`
+	ProductID   int64             `json:"product_id"`
+	URL         string            `json:"url"`
+	Thumbnails  map[string]string `json:"thumbnails"`
+	ContentType string            `json:"content_type"`
+	SizeBytes   int64             `json:"size_bytes"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	AltText     string            `json:"alt_text"`
+	SortOrder   int               `json:"sort_order"`
+	IsPrimary   bool              `json:"is_primary"`
+	CreatedAt   time.Time         `json:"created_at"`
+	CreatedBy   int64             `json:"created_by"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	UpdatedBy   int64             `json:"updated_by"`
+}
+
+// SetProductMediaResponse urlFn mengubah key storage menjadi URL publik
+func SetProductMediaResponse(m models.ProductMedia, urlFn func(key string) string) ProductMediaResponse {
+	thumbnails := make(map[string]string, len(m.Thumbnails))
+	for size, key := range m.Thumbnails {
+		thumbnails[size] = urlFn(key)
+	}
+
+	return ProductMediaResponse{
+		ID:          m.ID,
+		ProductID:   m.ProductID,
+		URL:         urlFn(m.StorageKey),
+		Thumbnails:  thumbnails,
+		ContentType: m.ContentType,
+		SizeBytes:   m.SizeBytes,
+		Width:       m.Width,
+		Height:      m.Height,
+		AltText:     m.AltText,
+		SortOrder:   m.SortOrder,
+		IsPrimary:   m.IsPrimary,
+		CreatedAt:   m.CreatedAt,
+		CreatedBy:   m.CreatedBy,
+		UpdatedAt:   m.UpdatedAt,
+		UpdatedBy:   m.UpdatedBy,
+	}
+}
+
+func SetResponseListProductMedia(media []models.ProductMedia, urlFn func(key string) string) []ProductMediaResponse {
+	res := make([]ProductMediaResponse, 0, len(media))
+	for _, m := range media {
+		res = append(res, SetProductMediaResponse(m, urlFn))
+	}
+	return res
+}
+
+type PublicProductMediaResponse struct {
+	URL        string            `json:"url"`
+	Thumbnails map[string]string `json:"thumbnails"`
+	Width      int               `json:"width"`
+	Height     int               `json:"height"`
+	AltText    string            `json:"alt_text"`
+	IsPrimary  bool              `json:"is_primary"`
+}
+
+func SetResponseListPublicProductMedia(media []models.ProductMedia, urlFn func(key string) string) []PublicProductMediaResponse {
+	res := make([]PublicProductMediaResponse, 0, len(media))
+	for _, m := range media {
+		full := SetProductMediaResponse(m, urlFn)
+		res = append(res, PublicProductMediaResponse{
+			URL:        full.URL,
+			Thumbnails: full.Thumbnails,
+			Width:      full.Width,
+			Height:     full.Height,
+			AltText:    full.AltText,
+			IsPrimary:  full.IsPrimary,
+		})
+	}
+	return res
+}
@@ -0,0 +1,73 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"pleasurelove/pkg/promotion"
+	"time"
+)
+
+type PromotionResponse struct {
+	ID               int64                 `json:"id"`
+	Code             string                `json:"code"`
+	Name             string                `json:"name"`
+	Description      string                `json:"description"`
+	Priority         int                   `json:"priority"`
+	Stackable        bool                  `json:"stackable"`
+	IsActive         bool                  `json:"is_active"`
+	StartsAt         *time.Time            `json:"starts_at"`
+	EndsAt           *time.Time            `json:"ends_at"`
+	UsageLimit       int                   `json:"usage_limit"`
+	PerCustomerLimit int                   `json:"per_customer_limit"`
+	UsageCount       int                   `json:"usage_count"`
+	Conditions       []promotion.Condition `json:"conditions"`
+	Actions          []promotion.Action    `json:"actions"`
+	CreatedAt        time.Time             `json:"created_at"`
+	CreatedBy        int64                 `json:"created_by"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+	UpdatedBy        int64                 `json:"updated_by"`
+}
+
+func SetPromotionResponse(p models.Promotion) PromotionResponse {
+	res := PromotionResponse{
+		ID:               p.ID,
+		Code:             p.Code,
+		Name:             p.Name,
+		Description:      p.Description,
+		Priority:         p.Priority,
+		Stackable:        p.Stackable,
+		IsActive:         p.IsActive,
+		StartsAt:         p.StartsAt,
+		EndsAt:           p.EndsAt,
+		UsageLimit:       p.UsageLimit,
+		PerCustomerLimit: p.PerCustomerLimit,
+		UsageCount:       p.UsageCount,
+		Conditions:       p.Conditions,
+		Actions:          p.Actions,
+		CreatedAt:        p.CreatedAt,
+		CreatedBy:        p.CreatedBy,
+		UpdatedAt:        p.UpdatedAt,
+		UpdatedBy:        p.UpdatedBy,
+	}
+	if res.Conditions == nil {
+		res.Conditions = []promotion.Condition{}
+	}
+	if res.Actions == nil {
+		res.Actions = []promotion.Action{}
+	}
+	return res
+}
+
+func SetResponseListPromotion(promotions []models.Promotion) []PromotionResponse {
+	responses := make([]PromotionResponse, 0, len(promotions))
+	for _, p := range promotions {
+		responses = append(responses, SetPromotionResponse(p))
+	}
+	return responses
+}
+
+// PromotionEvaluateResponse harga efektif per item beserta promosi yang diterapkan / dilewati
+type PromotionEvaluateResponse struct {
+	At    time.Time                `json:"at"`
+	Items []PriceQuoteItemResponse `json:"items"`
+	promotion.Result
+}
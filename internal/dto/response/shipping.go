@@ -0,0 +1,16 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"pleasurelove/pkg/shipping"
+)
+
+type ShippingQuoteResponse struct {
+	WeightGram int             `json:"weight_gram"`
+	Rates      []shipping.Rate `json:"rates"`
+}
+
+type ShipmentTrackingResponse struct {
+	Shipment models.Shipment   `json:"shipment"`
+	Tracking shipping.Tracking `json:"tracking"`
+}
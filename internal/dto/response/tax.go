@@ -0,0 +1,58 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"time"
+)
+
+type TaxClassResponse struct {
+	ID          int64             `json:"id"`
+	Code        string            `json:"code"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	IsDefault   bool              `json:"is_default"`
+	Rates       []TaxRateResponse `json:"rates"`
+	CreatedAt   time.Time         `json:"created_at"`
+	CreatedBy   int64             `json:"created_by"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	UpdatedBy   int64             `json:"updated_by"`
+}
+
+type TaxRateResponse struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	Rate          float64   `json:"rate"`
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+func SetTaxClassResponse(taxClass models.TaxClass) TaxClassResponse {
+	res := TaxClassResponse{
+		ID:          taxClass.ID,
+		Code:        taxClass.Code,
+		Name:        taxClass.Name,
+		Description: taxClass.Description,
+		IsDefault:   taxClass.IsDefault,
+		Rates:       make([]TaxRateResponse, 0, len(taxClass.Rates)),
+		CreatedAt:   taxClass.CreatedAt,
+		CreatedBy:   taxClass.CreatedBy,
+		UpdatedAt:   taxClass.UpdatedAt,
+		UpdatedBy:   taxClass.UpdatedBy,
+	}
+	for _, r := range taxClass.Rates {
+		res.Rates = append(res.Rates, TaxRateResponse{
+			ID:            r.ID,
+			Name:          r.Name,
+			Rate:          r.Rate,
+			EffectiveFrom: r.EffectiveFrom,
+		})
+	}
+	return res
+}
+
+func SetResponseListTaxClass(taxClasses []models.TaxClass) []TaxClassResponse {
+	responses := make([]TaxClassResponse, 0, len(taxClasses))
+	for _, tc := range taxClasses {
+		responses = append(responses, SetTaxClassResponse(tc))
+	}
+	return responses
+}
@@ -0,0 +1,108 @@
+package response
+
+import (
+	"pleasurelove/internal/models"
+	"time"
+)
+
+type VoucherResponse struct {
+	ID               int64      `json:"id"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description"`
+	DiscountType     string     `json:"discount_type"`
+	Value            float64    `json:"value"`
+	MaxDiscount      float64    `json:"max_discount"`
+	MinSpend         float64    `json:"min_spend"`
+	ProductIDs       []int64    `json:"product_ids"`
+	CategoryIDs      []int64    `json:"category_ids"`
+	IsActive         bool       `json:"is_active"`
+	StartsAt         *time.Time `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at"`
+	UsageLimit       int        `json:"usage_limit"`
+	PerCustomerLimit int        `json:"per_customer_limit"`
+	UsageCount       int        `json:"usage_count"`
+	CreatedAt        time.Time  `json:"created_at"`
+	CreatedBy        int64      `json:"created_by"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	UpdatedBy        int64      `json:"updated_by"`
+}
+
+func SetVoucherResponse(v models.Voucher) VoucherResponse {
+	res := VoucherResponse{
+		ID:               v.ID,
+		Name:             v.Name,
+		Description:      v.Description,
+		DiscountType:     v.DiscountType,
+		Value:            v.Value,
+		MaxDiscount:      v.MaxDiscount,
+		MinSpend:         v.MinSpend,
+		ProductIDs:       v.ProductIDs,
+		CategoryIDs:      v.CategoryIDs,
+		IsActive:         v.IsActive,
+		StartsAt:         v.StartsAt,
+		EndsAt:           v.EndsAt,
+		UsageLimit:       v.UsageLimit,
+		PerCustomerLimit: v.PerCustomerLimit,
+		UsageCount:       v.UsageCount,
+		CreatedAt:        v.CreatedAt,
+		CreatedBy:        v.CreatedBy,
+		UpdatedAt:        v.UpdatedAt,
+		UpdatedBy:        v.UpdatedBy,
+	}
+	if res.ProductIDs == nil {
+		res.ProductIDs = []int64{}
+	}
+	if res.CategoryIDs == nil {
+		res.CategoryIDs = []int64{}
+	}
+	return res
+}
+
+func SetResponseListVoucher(vouchers []models.Voucher) []VoucherResponse {
+	responses := make([]VoucherResponse, 0, len(vouchers))
+	for _, v := range vouchers {
+		responses = append(responses, SetVoucherResponse(v))
+	}
+	return responses
+}
+
+type VoucherCodeResponse struct {
+	ID             int64     `json:"id"`
+	VoucherID      int64     `json:"voucher_id"`
+	Code           string    `json:"code"`
+	MaxRedemptions int       `json:"max_redemptions"`
+	RedeemedCount  int       `json:"redeemed_count"`
+	CreatedAt      time.Time `json:"created_at"`
+	CreatedBy      int64     `json:"created_by"`
+}
+
+func SetResponseListVoucherCode(codes []models.VoucherCode) []VoucherCodeResponse {
+	responses := make([]VoucherCodeResponse, 0, len(codes))
+	for _, c := range codes {
+		responses = append(responses, VoucherCodeResponse{
+			ID:             c.ID,
+			VoucherID:      c.VoucherID,
+			Code:           c.Code,
+			MaxRedemptions: c.MaxRedemptions,
+			RedeemedCount:  c.RedeemedCount,
+			CreatedAt:      c.CreatedAt,
+			CreatedBy:      c.CreatedBy,
+		})
+	}
+	return responses
+}
+
+type VoucherGenerateResponse struct {
+	VoucherID int64    `json:"voucher_id"`
+	Generated int      `json:"generated"`
+	Codes     []string `json:"codes"`
+}
+
+// VoucherCheckResponse hasil simulasi voucher: promosi otomatis + voucher terhadap keranjang
+type VoucherCheckResponse struct {
+	Code            string  `json:"code"`
+	VoucherID       int64   `json:"voucher_id"`
+	VoucherName     string  `json:"voucher_name"`
+	VoucherDiscount float64 `json:"voucher_discount"`
+	PromotionEvaluateResponse
+}
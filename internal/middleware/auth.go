@@ -256,6 +256,47 @@ func AuthMiddleware() fiber.Handler {
 	}
 }
 
+// AuthOrGuestMiddleware untuk route yang bisa diakses user login maupun guest (cart).
+// Authorization divalidasi seperti AuthMiddleware, tanpa Authorization dipakai guest token
+// dari header X-Guest-Token. Tanpa keduanya request tetap diteruskan (pengunjung baru).
+func AuthOrGuestMiddleware() fiber.Handler {
+	auth := AuthMiddleware()
+	return func(c *fiber.Ctx) error {
+		if c.Get("Authorization") != "" {
+			return auth(c)
+		}
+
+		guestToken := c.Get(constanta.HeaderGuestToken)
+		if guestToken == "" {
+			return c.Next()
+		}
+
+		claims, err := utils.ParseGuestToken(guestToken)
+		if err != nil {
+			return response.SetResponseUnauthorized(c, errorutils.ErrMessageInvalidOrExpiredToken, err.Error())
+		}
+
+		// guest token tidak berlaku lagi setelah guest login
+		revoked, err := utils.IsGuestTokenRevoked(c.Context(), claims.GuestID)
+		if err != nil {
+			return response.SetResponseInternalServerError(c, "Failed to validate token", err)
+		}
+		if revoked {
+			return response.SetResponseUnauthorized(c, errorutils.ErrMessageInvalidOrExpiredToken, "")
+		}
+
+		c.Locals(constanta.AuthGuestID, claims.GuestID)
+		c.Locals(constanta.AuthGuestToken, guestToken)
+		CopyLocalsToContext(c,
+			constanta.Tx,
+			constanta.AuthGuestID,
+			constanta.AuthGuestToken,
+		)
+
+		return c.Next()
+	}
+}
+
 func GenerateTemporaryToken(user models.UserLogin) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id":   user.ID,
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PublicCacheMiddleware menambahkan header Cache-Control untuk response publik yang sukses
+// agar bisa di-cache oleh browser maupun CDN
+func PublicCacheMiddleware(maxAgeSeconds int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+		if err == nil && c.Method() == fiber.MethodGet && c.Response().StatusCode() == fiber.StatusOK {
+			c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", maxAgeSeconds, maxAgeSeconds))
+			c.Set(fiber.HeaderVary, fiber.HeaderAcceptEncoding)
+		}
+		return err
+	}
+}
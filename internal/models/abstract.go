@@ -1,5 +1,9 @@
 package models
 
+import "strings"
+
+// GetListStruct Filters disimpan per parameter query (field atau field__operator, mis. price__gte)
+// sehingga satu field bisa punya beberapa kondisi sekaligus, contoh rentang price__gte & price__lte
 type GetListStruct struct {
 	Filters map[string][2]interface{}
 	Page    int
@@ -7,3 +11,9 @@ type GetListStruct struct {
 	OrderBy string
 	SortBy  string
 }
+
+// FilterField nama field dari key filter, contoh: price__gte -> price
+func FilterField(key string) string {
+	field, _, _ := strings.Cut(key, "__")
+	return field
+}
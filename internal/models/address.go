@@ -0,0 +1,111 @@
+package models
+
+import "time"
+
+const (
+	AddressTypeShipping = "shipping"
+	AddressTypeBilling  = "billing"
+	AddressTypeStore    = "store"
+)
+
+func IsValidAddressType(addressType string) bool {
+	switch addressType {
+	case AddressTypeShipping, AddressTypeBilling, AddressTypeStore:
+		return true
+	}
+	return false
+}
+
+type Address struct {
+	ID            int64     `gorm:"primaryKey" json:"id"`
+	UserID        *int64    `json:"user_id"`
+	CustomerID    *int64    `json:"customer_id"`
+	Label         string    `json:"label"`
+	RecipientName string    `json:"recipient_name"`
+	PhoneNumber   string    `json:"phone_number"`
+	AddressLine1  string    `gorm:"column:address_line1" json:"address_line1"`
+	AddressLine2  string    `gorm:"column:address_line2" json:"address_line2"`
+	ProvinceID    int64     `json:"province_id"`
+	RegencyID     int64     `json:"regency_id"`
+	DistrictID    int64     `json:"district_id"`
+	VillageID     *int64    `json:"village_id"` // opsional
+	Village       string    `json:"village"`
+	Subdistrict   string    `json:"subdistrict"` // nama kecamatan
+	City          string    `json:"city"`        // nama kabupaten / kota
+	Province      string    `json:"province"`
+	PostalCode    string    `json:"postal_code"`
+	Country       string    `json:"country"`
+	IsDefault     bool      `json:"is_default"`
+	AddressType   string    `json:"address_type"`
+	CreatedBy     int64     `json:"created_by"`
+	UpdatedBy     int64     `json:"updated_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (Address) TableName() string {
+	return "addresses"
+}
+
+// Owner pemilik alamat, alamat user login diutamakan
+func (a Address) Owner() AddressOwner {
+	var owner AddressOwner
+	if a.UserID != nil {
+		owner.UserID = *a.UserID
+	}
+	if a.CustomerID != nil {
+		owner.CustomerID = *a.CustomerID
+	}
+	return owner
+}
+
+// SetRegion id dan nama wilayah (beserta kode pos) dari data wilayah yang sudah divalidasi
+func (a *Address) SetRegion(path RegionPath) {
+	a.ProvinceID = path.ProvinceID
+	a.RegencyID = path.RegencyID
+	a.DistrictID = path.DistrictID
+	a.VillageID = nil
+	if path.VillageID != 0 {
+		villageID := path.VillageID
+		a.VillageID = &villageID
+	}
+	a.Province = path.ProvinceName
+	a.City = path.RegencyName
+	a.Subdistrict = path.DistrictName
+	a.Village = path.VillageName
+	a.PostalCode = path.PostalCode
+}
+
+// ToOrderAddress snapshot alamat untuk order
+func (a Address) ToOrderAddress() OrderAddress {
+	address := OrderAddress{
+		RecipientName: a.RecipientName,
+		PhoneNumber:   a.PhoneNumber,
+		AddressLine1:  a.AddressLine1,
+		AddressLine2:  a.AddressLine2,
+		ProvinceID:    a.ProvinceID,
+		RegencyID:     a.RegencyID,
+		DistrictID:    a.DistrictID,
+		Village:       a.Village,
+		Subdistrict:   a.Subdistrict,
+		City:          a.City,
+		Province:      a.Province,
+		PostalCode:    a.PostalCode,
+		Country:       a.Country,
+	}
+	if a.VillageID != nil {
+		address.VillageID = *a.VillageID
+	}
+	return address
+}
+
+// AddressOwner buku alamat milik user login (UserID) atau customer guest (CustomerID tanpa user).
+// CustomerID pada user login hanya dicatat ke alamat baru supaya terlihat di data customer.
+type AddressOwner struct {
+	UserID     int64
+	CustomerID int64
+}
+
+func (o AddressOwner) IsEmpty() bool {
+	return o.UserID == 0 && o.CustomerID == 0
+}
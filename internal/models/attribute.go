@@ -0,0 +1,131 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	AttributeTypeText    = "text"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeEnum    = "enum"
+
+	// prefix key filter atribut pada list produk, contoh: attr.material=silicone, attr.length__gte=15
+	AttributeFilterPrefix = "attr."
+
+	attributeTextMaxLength = 500
+)
+
+type AttributeDefinition struct {
+	ID            int64          `gorm:"primaryKey" json:"id"`
+	CategoryID    int64          `json:"category_id"`
+	Code          string         `json:"code"`
+	Name          string         `json:"name"`
+	DataType      string         `json:"data_type"`
+	AllowedValues pq.StringArray `gorm:"type:text[]" json:"allowed_values"`
+	Unit          string         `json:"unit"`
+	IsRequired    bool           `json:"is_required"`
+	IsFilterable  bool           `json:"is_filterable"`
+	SortOrder     int            `json:"sort_order"`
+	CreatedAt     time.Time      `json:"created_at"`
+	CreatedBy     int64          `json:"created_by"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	UpdatedBy     int64          `json:"updated_by"`
+}
+
+func (AttributeDefinition) TableName() string {
+	return "attribute_definition"
+}
+
+// NormalizeValue validasi nilai sesuai tipe atribut dan mengembalikan bentuk kanonik
+// (value_text) beserta nilai angka untuk tipe number
+func (a AttributeDefinition) NormalizeValue(value interface{}) (string, *float64, error) {
+	switch a.DataType {
+	case AttributeTypeNumber:
+		var number float64
+		switch v := value.(type) {
+		case float64:
+			number = v
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return "", nil, fmt.Errorf("atribut %s harus berupa angka", a.Code)
+			}
+			number = parsed
+		default:
+			return "", nil, fmt.Errorf("atribut %s harus berupa angka", a.Code)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), &number, nil
+
+	case AttributeTypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return strconv.FormatBool(v), nil, nil
+		case string:
+			parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err == nil {
+				return strconv.FormatBool(parsed), nil, nil
+			}
+		}
+		return "", nil, fmt.Errorf("atribut %s harus berupa true / false", a.Code)
+
+	case AttributeTypeEnum:
+		text, ok := value.(string)
+		if ok {
+			for _, allowed := range a.AllowedValues {
+				if strings.EqualFold(allowed, strings.TrimSpace(text)) {
+					return allowed, nil, nil
+				}
+			}
+		}
+		return "", nil, fmt.Errorf("atribut %s harus salah satu dari: %s", a.Code, strings.Join(a.AllowedValues, ", "))
+
+	default:
+		text, ok := value.(string)
+		text = strings.TrimSpace(text)
+		if !ok || text == "" {
+			return "", nil, fmt.Errorf("atribut %s harus berupa teks", a.Code)
+		}
+		if len(text) > attributeTextMaxLength {
+			return "", nil, fmt.Errorf("atribut %s maksimal %d karakter", a.Code, attributeTextMaxLength)
+		}
+		return text, nil, nil
+	}
+}
+
+type ProductAttributeValue struct {
+	ID              int64     `gorm:"primaryKey" json:"id"`
+	ProductID       int64     `json:"product_id"`
+	ProductVarianID *int64    `json:"product_varian_id"`
+	AttributeID     int64     `json:"attribute_id"`
+	ValueText       string    `json:"value_text"`
+	ValueNumber     *float64  `json:"value_number"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int64     `json:"created_by"`
+}
+
+func (ProductAttributeValue) TableName() string {
+	return "product_attribute_value"
+}
+
+// ProductAttributeValueDetail nilai atribut beserta definisinya (untuk detail produk)
+type ProductAttributeValueDetail struct {
+	ProductAttributeValue
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	DataType string `json:"data_type"`
+	Unit     string `json:"unit"`
+}
+
+// AttributeFacet opsi nilai satu atribut yang bisa difilter
+type AttributeFacet struct {
+	Code    string        `json:"code"`
+	Name    string        `json:"name"`
+	Unit    string        `json:"unit"`
+	Buckets []FacetBucket `json:"buckets"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// Cart keranjang belanja milik user login (UserID) atau guest (GuestID), tidak pernah keduanya
+type Cart struct {
+	ID        int64      `gorm:"primaryKey" json:"id"`
+	UserID    *int64     `json:"user_id"`
+	GuestID   *string    `json:"guest_id"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Items     []CartItem `gorm:"foreignKey:CartID" json:"items"`
+}
+
+func (Cart) TableName() string {
+	return "cart"
+}
+
+type CartItem struct {
+	ID        int64     `gorm:"primaryKey" json:"id"`
+	CartID    int64     `json:"cart_id"`
+	ProductID int64     `json:"product_id"`
+	VarianID  int64     `gorm:"column:product_varian_id" json:"varian_id"` // 0 = tanpa varian
+	Quantity  int       `json:"quantity"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (CartItem) TableName() string {
+	return "cart_item"
+}
+
+// CartOwner pemilik keranjang, diambil dari context (user login atau guest token)
+type CartOwner struct {
+	UserID  int64
+	GuestID string
+}
+
+func (o CartOwner) IsEmpty() bool {
+	return o.UserID == 0 && o.GuestID == ""
+}
+
+// Key suffix key Redis keranjang
+func (o CartOwner) Key() string {
+	if o.UserID != 0 {
+		return "user:" + strconv.FormatInt(o.UserID, 10)
+	}
+	return "guest:" + o.GuestID
+}
+
+// FindItem index item produk / varian di keranjang, -1 bila belum ada
+func (c Cart) FindItem(productID, varianID int64) int {
+	for i, item := range c.Items {
+		if item.ProductID == productID && item.VarianID == varianID {
+			return i
+		}
+	}
+	return -1
+}
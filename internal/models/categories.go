@@ -1,18 +1,53 @@
 package models
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 type Category struct {
-	ID        int64     `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name"`
-	Code      string    `json:"code"`
-	Slug      string    `json:"slug"`
-	CreatedAt time.Time `json:"created_at"`
-	CreatedBy int64     `json:"created_by"`
-	UpdatedAt time.Time `json:"updated_at"`
-	UpdatedBy int64     `json:"updated_by"`
+	ID         int64     `json:"id" gorm:"primaryKey"`
+	Name       string    `json:"name"`
+	Code       string    `json:"code"`
+	Slug       string    `json:"slug"`
+	ParentID   *int64    `json:"parent_id"`
+	Depth      int       `json:"depth"`
+	Path       string    `json:"path"` // materialized path, contoh: /1/5/12/
+	SortOrder  int       `json:"sort_order"`
+	TaxClassID *int64    `json:"tax_class_id"` // berlaku juga untuk sub kategori yang tidak punya kelas pajak
+	CreatedAt  time.Time `json:"created_at"`
+	CreatedBy  int64     `json:"created_by"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	UpdatedBy  int64     `json:"updated_by"`
 }
 
 func (Category) TableName() string {
 	return "categories"
 }
+
+// BuildCategoryPath membentuk path kategori dari path parent
+func BuildCategoryPath(parentPath string, id int64) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+	return parentPath + strconv.FormatInt(id, 10) + "/"
+}
+
+// AncestorIDs mengembalikan id dari root sampai kategori itu sendiri
+func (c Category) AncestorIDs() []int64 {
+	var ids []int64
+	for _, part := range strings.Split(strings.Trim(c.Path, "/"), "/") {
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// IsDescendantOf true jika kategori berada di dalam subtree (termasuk dirinya sendiri)
+func (c Category) IsDescendantOf(ancestor Category) bool {
+	return ancestor.Path != "" && strings.HasPrefix(c.Path, ancestor.Path)
+}
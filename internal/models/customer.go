@@ -3,17 +3,18 @@ package models
 import "time"
 
 type Customer struct {
-	ID         int64     `json:"id"`
-	Name       string    `json:"name"`
-	Email      string    `json:"email"`
-	Phone      string    `json:"phone"`
-	UserID     int64     `json:"user_id"`
-	IsGuest    bool      `json:"is_guest"`
-	GuestToken string    `json:"guest_token"`
-	CreatedAt  time.Time `json:"created_at"`
-	CreatedBy  int       `json:"created_by"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	UpdatedBy  int       `json:"updated_by"`
+	ID              int64     `json:"id"`
+	Name            string    `json:"name"`
+	Email           string    `json:"email"`
+	Phone           string    `json:"phone"`
+	UserID          int64     `json:"user_id"`
+	IsGuest         bool      `json:"is_guest"`
+	GuestToken      string    `json:"guest_token"`
+	CustomerGroupID *int64    `json:"customer_group_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
 }
 
 func (Customer) TableName() string {
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// Invoice dokumen invoice (satu per order) atau nota kredit atas refund (DocumentType credit_note,
+// InvoiceID = invoice yang dikreditkan). Data pelanggan dan nominal disalin saat dokumen terbit.
+type Invoice struct {
+	ID              int64      `gorm:"primaryKey" json:"id"`
+	DocumentType    string     `json:"document_type"`
+	Number          string     `json:"number"`
+	BranchID        int64      `json:"branch_id"`
+	Year            int        `json:"year"`
+	Sequence        int64      `json:"sequence"`
+	OrderID         int64      `json:"order_id"`
+	InvoiceID       *int64     `json:"invoice_id"`
+	PaymentID       *int64     `json:"payment_id"`
+	Reason          string     `json:"reason"`
+	TaxMode         string     `json:"tax_mode"`
+	CustomerName    string     `json:"customer_name"`
+	CustomerPhone   string     `json:"customer_phone"`
+	CustomerEmail   string     `json:"customer_email"`
+	CustomerAddress string     `json:"customer_address"`
+	Subtotal        float64    `json:"subtotal"`
+	DiscountTotal   float64    `json:"discount_total"`
+	ShippingTotal   float64    `json:"shipping_total"`
+	TaxTotal        float64    `json:"tax_total"`
+	GrandTotal      float64    `json:"grand_total"`
+	IssuedAt        time.Time  `json:"issued_at"`
+	PrintCount      int        `json:"print_count"` // jumlah cetak, cetakan setelah yang pertama ditandai salinan
+	LastPrintedAt   *time.Time `json:"last_printed_at"`
+	CreatedBy       int64      `json:"created_by"`
+	UpdatedBy       int64      `json:"updated_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	Order   *Order   `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+	Invoice *Invoice `gorm:"foreignKey:InvoiceID" json:"invoice,omitempty"`
+}
+
+func (Invoice) TableName() string {
+	return "invoice"
+}
+
+// InvoiceSequence nomor urut terakhir per jenis dokumen, cabang dan tahun
+type InvoiceSequence struct {
+	DocumentType string `gorm:"primaryKey" json:"document_type"`
+	BranchID     int64  `gorm:"primaryKey" json:"branch_id"`
+	Year         int    `gorm:"primaryKey" json:"year"`
+	LastNumber   int64  `json:"last_number"`
+}
+
+func (InvoiceSequence) TableName() string {
+	return "invoice_sequence"
+}
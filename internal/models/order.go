@@ -0,0 +1,209 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+const (
+	OrderStatusPendingPayment = "pending_payment"
+	OrderStatusPaid           = "paid"
+	OrderStatusProcessing     = "processing"
+	OrderStatusShipped        = "shipped"
+	OrderStatusDelivered      = "delivered"
+	OrderStatusCompleted      = "completed"
+	OrderStatusCancelled      = "cancelled"
+	OrderStatusRefunded       = "refunded"
+)
+
+const (
+	OrderActorUser     = "user"     // user dashboard
+	OrderActorCustomer = "customer" // pelanggan dari storefront
+	OrderActorSystem   = "system"   // proses otomatis (payment callback, scheduler)
+)
+
+// orderTransitions status tujuan yang diperbolehkan dari tiap status.
+// Order yang belum dibayar dibatalkan (cancelled), yang sudah dibayar dikembalikan dananya (refunded).
+var orderTransitions = map[string][]string{
+	OrderStatusPendingPayment: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:           {OrderStatusProcessing, OrderStatusRefunded},
+	OrderStatusProcessing:     {OrderStatusShipped, OrderStatusRefunded},
+	OrderStatusShipped:        {OrderStatusDelivered},
+	OrderStatusDelivered:      {OrderStatusCompleted, OrderStatusRefunded},
+}
+
+func IsValidOrderStatus(status string) bool {
+	switch status {
+	case OrderStatusPendingPayment, OrderStatusPaid, OrderStatusProcessing, OrderStatusShipped,
+		OrderStatusDelivered, OrderStatusCompleted, OrderStatusCancelled, OrderStatusRefunded:
+		return true
+	}
+	return false
+}
+
+func CanTransitionOrder(from, to string) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOrderStatuses status yang bisa dipilih dari status saat ini (kosong = status akhir)
+func NextOrderStatuses(from string) []string {
+	next := orderTransitions[from]
+	if next == nil {
+		return []string{}
+	}
+	return next
+}
+
+// IsStockRestoredOnTransition stok dikembalikan bila order batal atau di-refund sebelum barang dikirim
+func IsStockRestoredOnTransition(from, to string) bool {
+	switch to {
+	case OrderStatusCancelled:
+		return true
+	case OrderStatusRefunded:
+		return from == OrderStatusPaid || from == OrderStatusProcessing
+	}
+	return false
+}
+
+type Order struct {
+	ID              int64                `gorm:"primaryKey" json:"id"`
+	OrderNumber     string               `json:"order_number"`
+	CustomerID      int64                `json:"customer_id"`
+	Status          string               `json:"status"`
+	Subtotal        float64              `json:"subtotal"`
+	DiscountTotal   float64              `json:"discount_total"`
+	ShippingTotal   float64              `json:"shipping_total"`
+	TaxTotal        float64              `json:"tax_total"`
+	GrandTotal      float64              `json:"grand_total"`
+	Notes           string               `json:"notes"`
+	VoucherCode     string               `json:"voucher_code"`
+	ShippingCourier string               `json:"shipping_courier"`
+	ShippingService string               `json:"shipping_service"`
+	TaxMode         string               `json:"tax_mode"` // exclusive / inclusive, harga item sudah termasuk pajak bila inclusive
+	IdempotencyKey  *string              `json:"-"`        // key dari client saat checkout, unik per pelanggan (nil = order dashboard)
+	ShippingAddress *OrderAddress        `gorm:"type:jsonb" json:"shipping_address"`
+	CreatedBy       int64                `json:"created_by"`
+	UpdatedBy       int64                `json:"updated_by"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+	Customer        *Customer            `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Items           []OrderItem          `gorm:"foreignKey:OrderID" json:"items,omitempty"`
+	History         []OrderStatusHistory `gorm:"foreignKey:OrderID" json:"history,omitempty"`
+	Taxes           []OrderTax           `gorm:"foreignKey:OrderID" json:"taxes,omitempty"`
+}
+
+func (Order) TableName() string {
+	return "orders"
+}
+
+// StockItems jumlah stok yang dipakai order, untuk ConsumeStock / RestoreStock
+func (o Order) StockItems() []StockItem {
+	items := make([]StockItem, 0, len(o.Items))
+	for _, item := range o.Items {
+		items = append(items, StockItem{
+			ProductID: item.ProductID,
+			VarianID:  item.VarianID,
+			Quantity:  item.Quantity,
+		})
+	}
+	return items
+}
+
+// OrderItem snapshot produk saat order dibuat. Price & Discount = harga jual dan diskon default
+// produk / varian, UnitPrice = harga efektif (price list / tier), DiscountAmount = potongan
+// promosi / voucher untuk baris ini. TaxableAmount = DPP baris setelah diskon
+type OrderItem struct {
+	ID             int64   `gorm:"primaryKey" json:"id"`
+	OrderID        int64   `json:"order_id"`
+	ProductID      int64   `json:"product_id"`
+	VarianID       int64   `gorm:"column:product_varian_id" json:"varian_id"`
+	ProductName    string  `json:"product_name"`
+	ProductCode    string  `json:"product_code"`
+	VarianName     string  `json:"varian_name"`
+	VarianCode     string  `json:"varian_code"`
+	Quantity       int     `json:"quantity"`
+	Price          float64 `json:"price"`
+	Discount       float64 `json:"discount"`
+	UnitPrice      float64 `json:"unit_price"`
+	CostPrice      float64 `json:"cost_price"`
+	DiscountAmount float64 `json:"discount_amount"`
+	LineTotal      float64 `json:"line_total"`
+	PriceSource    string  `json:"price_source"`
+	WeightGram     int     `json:"weight_gram"`
+	LengthCm       float64 `json:"length_cm"`
+	WidthCm        float64 `json:"width_cm"`
+	HeightCm       float64 `json:"height_cm"`
+	TaxClassCode   string  `json:"tax_class_code"`
+	TaxRate        float64 `json:"tax_rate"`
+	TaxableAmount  float64 `json:"taxable_amount"`
+	TaxAmount      float64 `json:"tax_amount"`
+}
+
+// OrderTax rekap pajak order per kelas dan tarif, untuk ringkasan order dan invoice
+type OrderTax struct {
+	ID            int64   `gorm:"primaryKey" json:"id"`
+	OrderID       int64   `json:"order_id"`
+	TaxClassID    int64   `json:"tax_class_id"`
+	TaxClassCode  string  `json:"tax_class_code"`
+	TaxClassName  string  `json:"tax_class_name"`
+	RateName      string  `json:"rate_name"`
+	Rate          float64 `json:"rate"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	TaxAmount     float64 `json:"tax_amount"`
+}
+
+func (OrderTax) TableName() string {
+	return "order_tax"
+}
+
+// OrderAddress snapshot alamat kirim, tidak ikut berubah bila alamat pelanggan diubah
+type OrderAddress struct {
+	RecipientName string `json:"recipient_name"`
+	PhoneNumber   string `json:"phone_number"`
+	AddressLine1  string `json:"address_line1"`
+	AddressLine2  string `json:"address_line2"`
+	ProvinceID    int64  `json:"province_id"`
+	RegencyID     int64  `json:"regency_id"`
+	DistrictID    int64  `json:"district_id"`
+	VillageID     int64  `json:"village_id"`
+	Village       string `json:"village"`
+	Subdistrict   string `json:"subdistrict"`
+	City          string `json:"city"`
+	Province      string `json:"province"`
+	PostalCode    string `json:"postal_code"`
+	Country       string `json:"country"`
+}
+
+func (a OrderAddress) Value() (driver.Value, error) {
+	b, err := json.Marshal(a)
+	return string(b), err
+}
+
+func (a *OrderAddress) Scan(value interface{}) error {
+	return scanJSON(value, a)
+}
+
+func (OrderItem) TableName() string {
+	return "order_item"
+}
+
+type OrderStatusHistory struct {
+	ID         int64     `gorm:"primaryKey" json:"id"`
+	OrderID    int64     `json:"order_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Note       string    `json:"note"`
+	ActorType  string    `json:"actor_type"`
+	ActorID    int64     `json:"actor_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (OrderStatusHistory) TableName() string {
+	return "order_status_history"
+}
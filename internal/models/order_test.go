@@ -0,0 +1,84 @@
+package models
+
+import "testing"
+
+var allOrderStatuses = []string{
+	OrderStatusPendingPayment, OrderStatusPaid, OrderStatusProcessing, OrderStatusShipped,
+	OrderStatusDelivered, OrderStatusCompleted, OrderStatusCancelled, OrderStatusRefunded,
+}
+
+func TestOrderTransitions(t *testing.T) {
+	// seluruh transisi yang diizinkan beserta apakah stok dikembalikan
+	allowed := []struct {
+		from, to     string
+		restoreStock bool
+	}{
+		{OrderStatusPendingPayment, OrderStatusPaid, false},
+		{OrderStatusPendingPayment, OrderStatusCancelled, true},
+		{OrderStatusPaid, OrderStatusProcessing, false},
+		{OrderStatusPaid, OrderStatusRefunded, true},
+		{OrderStatusProcessing, OrderStatusShipped, false},
+		{OrderStatusProcessing, OrderStatusRefunded, true},
+		{OrderStatusShipped, OrderStatusDelivered, false},
+		{OrderStatusDelivered, OrderStatusCompleted, false},
+		{OrderStatusDelivered, OrderStatusRefunded, false}, // barang sudah di pelanggan, stok tidak kembali otomatis
+	}
+
+	isAllowed := make(map[[2]string]bool)
+	for _, tt := range allowed {
+		isAllowed[[2]string{tt.from, tt.to}] = true
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			if !CanTransitionOrder(tt.from, tt.to) {
+				t.Errorf("CanTransitionOrder(%s, %s) = false, want true", tt.from, tt.to)
+			}
+			if got := IsStockRestoredOnTransition(tt.from, tt.to); got != tt.restoreStock {
+				t.Errorf("IsStockRestoredOnTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.restoreStock)
+			}
+		})
+	}
+
+	// pasangan status lain harus ditolak
+	for _, from := range allOrderStatuses {
+		for _, to := range allOrderStatuses {
+			if !isAllowed[[2]string{from, to}] && CanTransitionOrder(from, to) {
+				t.Errorf("CanTransitionOrder(%s, %s) = true, want false", from, to)
+			}
+		}
+	}
+}
+
+func TestOrderTransitionsForbidden(t *testing.T) {
+	tests := []struct{ from, to string }{
+		{OrderStatusCompleted, OrderStatusPendingPayment},
+		{OrderStatusCancelled, OrderStatusPaid},
+		{OrderStatusRefunded, OrderStatusProcessing},
+		{OrderStatusPendingPayment, OrderStatusShipped},
+		{OrderStatusPendingPayment, OrderStatusRefunded},
+		{OrderStatusPaid, OrderStatusCancelled},
+		{OrderStatusShipped, OrderStatusRefunded},
+		{OrderStatusShipped, OrderStatusProcessing},
+		{OrderStatusPaid, OrderStatusPaid},
+		{"unknown", OrderStatusPaid},
+	}
+	for _, tt := range tests {
+		if CanTransitionOrder(tt.from, tt.to) {
+			t.Errorf("CanTransitionOrder(%s, %s) = true, want false", tt.from, tt.to)
+		}
+	}
+}
+
+func TestNextOrderStatusesFinal(t *testing.T) {
+	for _, status := range []string{OrderStatusCompleted, OrderStatusCancelled, OrderStatusRefunded} {
+		if next := NextOrderStatuses(status); next == nil || len(next) != 0 {
+			t.Errorf("NextOrderStatuses(%s) = %v, want kosong", status, next)
+		}
+	}
+	for _, status := range allOrderStatuses {
+		if !IsValidOrderStatus(status) {
+			t.Errorf("IsValidOrderStatus(%s) = false", status)
+		}
+	}
+	if IsValidOrderStatus("unknown") {
+		t.Error("IsValidOrderStatus(unknown) = true")
+	}
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+const (
+	PaymentStatusPending  = "pending"
+	PaymentStatusPaid     = "paid"
+	PaymentStatusFailed   = "failed"
+	PaymentStatusExpired  = "expired"
+	PaymentStatusRefunded = "refunded"
+)
+
+// Payment satu percobaan bayar order lewat provider pembayaran (gateway / transfer manual).
+// Reference = id transaksi di provider, unik per percobaan.
+type Payment struct {
+	ID                int64      `gorm:"primaryKey" json:"id"`
+	OrderID           int64      `json:"order_id"`
+	Provider          string     `json:"provider"`
+	Method            string     `json:"method"`
+	Channel           string     `json:"channel"`
+	Reference         string     `json:"reference"`
+	TransactionID     string     `json:"transaction_id"`
+	Amount            float64    `json:"amount"`
+	RefundedAmount    float64    `json:"refunded_amount"`
+	Status            string     `json:"status"`
+	VANumber          string     `gorm:"column:va_number" json:"va_number"`
+	QRString          string     `gorm:"column:qr_string" json:"qr_string"`
+	RedirectURL       string     `gorm:"column:redirect_url" json:"redirect_url"`
+	BankName          string     `json:"bank_name"`
+	BankAccountNumber string     `json:"bank_account_number"`
+	BankAccountName   string     `json:"bank_account_name"`
+	ProofKey          string     `json:"-"` // key bukti transfer di storage
+	ProofUploadedAt   *time.Time `json:"proof_uploaded_at"`
+	ConfirmedBy       int64      `json:"confirmed_by"`
+	ConfirmNote       string     `json:"confirm_note"`
+	ExpiresAt         *time.Time `json:"expires_at"`
+	PaidAt            *time.Time `json:"paid_at"`
+	CreatedBy         int64      `json:"created_by"`
+	UpdatedBy         int64      `json:"updated_by"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+func (Payment) TableName() string {
+	return "payment"
+}
+
+// IsExpired pembayaran pending yang sudah lewat batas waktu bayar
+func (p Payment) IsExpired(now time.Time) bool {
+	return p.Status == PaymentStatusPending && p.ExpiresAt != nil && now.After(*p.ExpiresAt)
+}
+
+// PaymentWebhookEvent notifikasi webhook yang sudah diproses (idempotensi)
+type PaymentWebhookEvent struct {
+	ID        int64     `gorm:"primaryKey" json:"id"`
+	Provider  string    `json:"provider"`
+	EventKey  string    `json:"event_key"`
+	PaymentID *int64    `json:"payment_id"`
+	Status    string    `json:"status"`
+	Payload   string    `gorm:"type:jsonb" json:"payload"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (PaymentWebhookEvent) TableName() string {
+	return "payment_webhook_event"
+}
@@ -0,0 +1,94 @@
+package models
+
+import "time"
+
+const (
+	PriceScheduleStatusPending   = "pending"
+	PriceScheduleStatusApplied   = "applied"
+	PriceScheduleStatusCancelled = "cancelled"
+	PriceScheduleStatusFailed    = "failed"
+)
+
+const (
+	PriceChangeSourceCreate   = "create"
+	PriceChangeSourceManual   = "manual"
+	PriceChangeSourceSchedule = "schedule"
+	PriceChangeSourceImport   = "import"
+)
+
+type PriceSchedule struct {
+	ID              int64      `gorm:"primaryKey" json:"id"`
+	ProductID       int64      `json:"product_id"`
+	ProductVarianID *int64     `json:"product_varian_id"`
+	Price           float64    `json:"price"`
+	CostPrice       float64    `json:"cost_price"`
+	Discount        float64    `json:"discount"`
+	EffectiveAt     time.Time  `json:"effective_at"`
+	Status          string     `json:"status"`
+	AppliedAt       *time.Time `json:"applied_at"`
+	Note            string     `json:"note"`
+	ErrorMessage    string     `json:"error_message"` // alasan gagal diterapkan scheduler (status failed)
+	CreatedBy       int64      `json:"created_by"`
+	UpdatedBy       int64      `json:"updated_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func (PriceSchedule) TableName() string {
+	return "price_change_schedule"
+}
+
+type PriceHistory struct {
+	ID              int64     `gorm:"primaryKey" json:"id"`
+	ProductID       int64     `json:"product_id"`
+	ProductVarianID *int64    `json:"product_varian_id"`
+	OldPrice        *float64  `json:"old_price"`
+	NewPrice        float64   `json:"new_price"`
+	OldCostPrice    *float64  `json:"old_cost_price"`
+	NewCostPrice    float64   `json:"new_cost_price"`
+	OldDiscount     *float64  `json:"old_discount"`
+	NewDiscount     float64   `json:"new_discount"`
+	Source          string    `json:"source"`
+	ScheduleID      *int64    `json:"schedule_id"`
+	ChangedAt       time.Time `json:"changed_at"`
+	ChangedBy       int64     `json:"changed_by"`
+}
+
+func (PriceHistory) TableName() string {
+	return "price_history"
+}
+
+// PriceHistoryTimeline riwayat harga beserta nama user yang mengubah
+type PriceHistoryTimeline struct {
+	PriceHistory
+	ChangedByName string `json:"changed_by_name"`
+}
+
+// PriceValue harga jual, modal dan diskon yang dicatat dalam riwayat
+type PriceValue struct {
+	Price     float64
+	CostPrice float64
+	Discount  float64
+}
+
+// NewPriceHistory membuat baris riwayat; old nil berarti harga awal
+func NewPriceHistory(productID int64, varianID *int64, old *PriceValue, updated PriceValue, source string, changedBy int64) PriceHistory {
+	history := PriceHistory{
+		ProductID:       productID,
+		ProductVarianID: varianID,
+		NewPrice:        updated.Price,
+		NewCostPrice:    updated.CostPrice,
+		NewDiscount:     updated.Discount,
+		Source:          source,
+		ChangedAt:       time.Now(),
+		ChangedBy:       changedBy,
+	}
+
+	if old != nil {
+		history.OldPrice = &old.Price
+		history.OldCostPrice = &old.CostPrice
+		history.OldDiscount = &old.Discount
+	}
+
+	return history
+}
@@ -0,0 +1,82 @@
+package models
+
+import "time"
+
+type CustomerGroup struct {
+	ID          int64     `gorm:"primaryKey" json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedBy   int64     `json:"created_by"`
+	UpdatedBy   int64     `json:"updated_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (CustomerGroup) TableName() string {
+	return "customer_group"
+}
+
+type PriceList struct {
+	ID          int64      `gorm:"primaryKey" json:"id"`
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Priority    int        `json:"priority"`
+	IsDefault   bool       `json:"is_default"`
+	IsActive    bool       `json:"is_active"`
+	ValidFrom   *time.Time `json:"valid_from"`
+	ValidTo     *time.Time `json:"valid_to"`
+	CreatedBy   int64      `json:"created_by"`
+	UpdatedBy   int64      `json:"updated_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Items       *[]PriceListItem       `json:"items" gorm:"foreignKey:PriceListID"`
+	Assignments *[]PriceListAssignment `json:"assignments" gorm:"foreignKey:PriceListID"`
+}
+
+func (PriceList) TableName() string {
+	return "price_list"
+}
+
+type PriceListAssignment struct {
+	ID              int64     `gorm:"primaryKey" json:"id"`
+	PriceListID     int64     `json:"price_list_id"`
+	CustomerID      *int64    `json:"customer_id"`
+	CustomerGroupID *int64    `json:"customer_group_id"`
+	CreatedBy       int64     `json:"created_by"`
+	UpdatedBy       int64     `json:"updated_by"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func (PriceListAssignment) TableName() string {
+	return "price_list_assignment"
+}
+
+type PriceListItem struct {
+	ID              int64      `gorm:"primaryKey" json:"id"`
+	PriceListID     int64      `json:"price_list_id"`
+	ProductID       int64      `json:"product_id"`
+	ProductVarianID *int64     `json:"product_varian_id"`
+	MinQty          int        `json:"min_qty"`
+	Price           float64    `json:"price"`
+	ValidFrom       *time.Time `json:"valid_from"`
+	ValidTo         *time.Time `json:"valid_to"`
+	CreatedBy       int64      `json:"created_by"`
+	UpdatedBy       int64      `json:"updated_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func (PriceListItem) TableName() string {
+	return "price_list_item"
+}
+
+// PriceListItemCandidate item price list yang berlaku untuk pelanggan, beserta info price list-nya
+type PriceListItemCandidate struct {
+	PriceListItem
+	PriceListCode     string `json:"price_list_code"`
+	PriceListPriority int    `json:"price_list_priority"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	PriceSourceBase      = "base"
+	PriceSourcePriceList = "price_list"
+)
+
+// PriceQuery input untuk resolve harga satuan efektif
+type PriceQuery struct {
+	ProductID int64
+	VarianID  int64 // 0 = tanpa varian
+	Quantity  int
+}
+
+// PricingContext pelanggan & waktu yang dipakai saat resolve harga
+type PricingContext struct {
+	CustomerID      int64  // 0 = guest / tanpa pelanggan
+	CustomerGroupID *int64 // diisi otomatis dari data pelanggan
+	At              time.Time
+}
+
+type ResolvedPrice struct {
+	ProductID     int64   `json:"product_id"`
+	VarianID      int64   `json:"varian_id"`
+	Quantity      int     `json:"quantity"`
+	BasePrice     float64 `json:"base_price"` // harga jual setelah diskon default produk / varian
+	UnitPrice     float64 `json:"unit_price"`
+	Source        string  `json:"source"`
+	PriceListID   int64   `json:"price_list_id,omitempty"`
+	PriceListCode string  `json:"price_list_code,omitempty"`
+	MinQty        int     `json:"min_qty,omitempty"`
+}
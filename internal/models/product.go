@@ -6,6 +6,7 @@ type Product struct {
 	ID          int64  `gorm:"primaryKey" json:"id"`
 	Name        string `json:"name"`
 	Code        string `json:"code"`
+	Slug        string `json:"slug"`
 	Barcode     string `json:"barcode"`
 	Description string `json:"description"`
 
@@ -18,6 +19,18 @@ type Product struct {
 	IsActive  bool `json:"is_active"`
 	HasVarian bool `json:"has_varian"`
 
+	ProductType   string `json:"product_type"`   // single / bundle
+	BundlePricing string `json:"bundle_pricing"` // khusus bundle: fixed / sum
+	Stock         int    `json:"stock"`          // produk bundle selalu 0, stok mengikuti komponen
+
+	// berat dan dimensi per unit untuk ongkir
+	WeightGram int     `json:"weight_gram"`
+	LengthCm   float64 `json:"length_cm"`
+	WidthCm    float64 `json:"width_cm"`
+	HeightCm   float64 `json:"height_cm"`
+
+	TaxClassID *int64 `json:"tax_class_id"` // nil = mengikuti kategori / kelas pajak default
+
 	CreatedBy       int64              `json:"created_by"`
 	UpdatedBy       int64              `json:"updated_by"`
 	CreatedAt       time.Time          `json:"created_at"`
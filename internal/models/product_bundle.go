@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	ProductTypeSingle = "single"
+	ProductTypeBundle = "bundle"
+
+	BundlePricingFixed = "fixed" // harga bundle = harga jual produk bundle
+	BundlePricingSum   = "sum"   // harga bundle = total harga komponen dikurangi diskon produk bundle
+)
+
+type ProductBundleItem struct {
+	ID                 int64     `gorm:"primaryKey" json:"id"`
+	BundleProductID    int64     `json:"bundle_product_id"`
+	ComponentProductID int64     `json:"component_product_id"`
+	ComponentVarianID  *int64    `json:"component_varian_id"`
+	Quantity           int       `json:"quantity"` // jumlah komponen per satu bundle
+	CreatedBy          int64     `json:"created_by"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+func (ProductBundleItem) TableName() string {
+	return "product_bundle_item"
+}
+
+// StockItem jumlah stok yang dipakai / dikembalikan untuk satu produk atau varian
+type StockItem struct {
+	ProductID int64
+	VarianID  int64 // 0 = tanpa varian
+	Quantity  int
+}
@@ -19,3 +19,9 @@ type ProductCategory struct {
 func (ProductCategory) TableName() string {
 	return "product_categories"
 }
+
+// ProductCategoryPath path kategori produk, berisi id leluhur sampai kategori itu sendiri (/1/5/12/)
+type ProductCategoryPath struct {
+	ProductID int64
+	Path      string
+}
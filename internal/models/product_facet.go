@@ -0,0 +1,22 @@
+package models
+
+// FacetBucket satu opsi filter beserta jumlah produk yang cocok
+type FacetBucket struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// PriceBucket satu rentang harga [Min, Max) pada histogram harga
+type PriceBucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int64   `json:"count"`
+}
+
+type ProductFacets struct {
+	Brands     []FacetBucket    `json:"brands"`
+	Categories []FacetBucket    `json:"categories"`
+	Prices     []PriceBucket    `json:"prices"`
+	Attributes []AttributeFacet `json:"attributes"`
+}
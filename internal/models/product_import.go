@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	ProductImportStatusRunning = "running"
+	ProductImportStatusDone    = "done"
+	ProductImportStatusFailed  = "failed"
+)
+
+// ProductImportJob progress import produk, disimpan di Redis selama proses berjalan
+// dan beberapa saat setelah selesai
+type ProductImportJob struct {
+	ID            string                  `json:"id"`
+	FileName      string                  `json:"file_name"`
+	Status        string                  `json:"status"`
+	DryRun        bool                    `json:"dry_run"`
+	TotalRows     int                     `json:"total_rows"`
+	ProcessedRows int                     `json:"processed_rows"`
+	Created       int                     `json:"created"`
+	Updated       int                     `json:"updated"`
+	Failed        int                     `json:"failed"`
+	Errors        []ProductImportRowError `json:"errors"`
+	CreatedBy     int64                   `json:"created_by"`
+	StartedAt     time.Time               `json:"started_at"`
+	FinishedAt    *time.Time              `json:"finished_at"`
+}
+
+// ProductImportRowError Row = nomor baris di file (header = baris 1)
+type ProductImportRowError struct {
+	Row     int    `json:"row"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+// MediaThumbnails key storage thumbnail per nama ukuran (small, medium, large)
+type MediaThumbnails map[string]string
+
+type ProductMedia struct {
+	ID          int64           `gorm:"primaryKey" json:"id"`
+	ProductID   int64           `json:"product_id"`
+	StorageKey  string          `json:"storage_key"`
+	ContentType string          `json:"content_type"`
+	SizeBytes   int64           `json:"size_bytes"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	Thumbnails  MediaThumbnails `gorm:"type:jsonb" json:"thumbnails"`
+	AltText     string          `json:"alt_text"`
+	SortOrder   int             `json:"sort_order"`
+	IsPrimary   bool            `json:"is_primary"`
+
+	CreatedBy int64     `json:"created_by"`
+	UpdatedBy int64     `json:"updated_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (ProductMedia) TableName() string {
+	return "product_media"
+}
+
+// StorageKeys file asli + semua thumbnail, dipakai saat membersihkan storage
+func (m ProductMedia) StorageKeys() []string {
+	keys := []string{m.StorageKey}
+	for _, k := range m.Thumbnails {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (t MediaThumbnails) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(t)
+	return string(b), err
+}
+
+func (t *MediaThumbnails) Scan(value interface{}) error {
+	return scanJSON(value, t)
+}
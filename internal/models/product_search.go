@@ -0,0 +1,17 @@
+package models
+
+type SearchProductStruct struct {
+	Query      string
+	ActiveOnly bool
+	Page       int
+	Limit      int
+}
+
+// ProductSearchResult hasil full-text search beserta skor dan potongan teks yang di-highlight.
+// NameHighlight dan Snippet berupa HTML ter-escape dengan kata yang cocok dibungkus <mark>
+type ProductSearchResult struct {
+	Product
+	Rank          float64 `json:"rank" gorm:"column:rank"`
+	NameHighlight string  `json:"name_highlight" gorm:"column:name_highlight"`
+	Snippet       string  `json:"snippet" gorm:"column:snippet"`
+}
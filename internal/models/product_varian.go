@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type ProductVarian struct {
+	ID        int64   `gorm:"primaryKey" json:"id"`
+	ProductID int64   `json:"product_id"`
+	Name      string  `json:"name"`
+	Code      string  `json:"code"`
+	Barcode   string  `json:"barcode"`
+	Price     float64 `json:"price"`
+	CostPrice float64 `json:"cost_price"`
+	Discount  float64 `json:"discount"`
+	IsActive  bool    `json:"is_active"`
+	Stock     int     `json:"stock"`
+
+	// 0 = mengikuti berat / dimensi produk
+	WeightGram int     `json:"weight_gram"`
+	LengthCm   float64 `json:"length_cm"`
+	WidthCm    float64 `json:"width_cm"`
+	HeightCm   float64 `json:"height_cm"`
+
+	CreatedBy int64     `json:"created_by"`
+	UpdatedBy int64     `json:"updated_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (ProductVarian) TableName() string {
+	return "product_varian"
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"pleasurelove/pkg/promotion"
+	"time"
+)
+
+type PromotionConditions []promotion.Condition
+
+type PromotionActions []promotion.Action
+
+type Promotion struct {
+	ID               int64               `gorm:"primaryKey" json:"id"`
+	Code             string              `json:"code"`
+	Name             string              `json:"name"`
+	Description      string              `json:"description"`
+	Priority         int                 `json:"priority"`
+	Stackable        bool                `json:"stackable"`
+	IsActive         bool                `json:"is_active"`
+	StartsAt         *time.Time          `json:"starts_at"`
+	EndsAt           *time.Time          `json:"ends_at"`
+	UsageLimit       int                 `json:"usage_limit"`
+	PerCustomerLimit int                 `json:"per_customer_limit"`
+	UsageCount       int                 `json:"usage_count"`
+	Conditions       PromotionConditions `gorm:"type:jsonb" json:"conditions"`
+	Actions          PromotionActions    `gorm:"type:jsonb" json:"actions"`
+	CreatedBy        int64               `json:"created_by"`
+	UpdatedBy        int64               `json:"updated_by"`
+	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at"`
+}
+
+func (Promotion) TableName() string {
+	return "promotion"
+}
+
+// ToRule mengubah promosi menjadi rule evaluator, customerUsage = pemakaian oleh pelanggan keranjang
+func (p Promotion) ToRule(customerUsage int) promotion.Rule {
+	return promotion.Rule{
+		ID:               p.ID,
+		Source:           promotion.SourcePromotion,
+		Code:             p.Code,
+		Name:             p.Name,
+		Priority:         p.Priority,
+		Stackable:        p.Stackable,
+		StartsAt:         p.StartsAt,
+		EndsAt:           p.EndsAt,
+		Conditions:       p.Conditions,
+		Actions:          p.Actions,
+		UsageLimit:       p.UsageLimit,
+		UsageCount:       p.UsageCount,
+		PerCustomerLimit: p.PerCustomerLimit,
+		CustomerUsage:    customerUsage,
+	}
+}
+
+type PromotionUsage struct {
+	ID             int64     `gorm:"primaryKey" json:"id"`
+	PromotionID    int64     `json:"promotion_id"`
+	CustomerID     *int64    `json:"customer_id"`
+	Reference      string    `json:"reference"`
+	DiscountAmount float64   `json:"discount_amount"`
+	UsedAt         time.Time `json:"used_at"`
+}
+
+func (PromotionUsage) TableName() string {
+	return "promotion_usage"
+}
+
+func (c PromotionConditions) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(c)
+	return string(b), err
+}
+
+func (c *PromotionConditions) Scan(value interface{}) error {
+	return scanJSON(value, c)
+}
+
+func (a PromotionActions) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(a)
+	return string(b), err
+}
+
+func (a *PromotionActions) Scan(value interface{}) error {
+	return scanJSON(value, a)
+}
+
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("tipe %T tidak bisa di-scan sebagai JSON", value)
+	}
+}
@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// Wilayah administratif, id = kode Kemendagri tanpa titik
+type Province struct {
+	ID   int64  `gorm:"primaryKey;autoIncrement:false" json:"id"`
+	Name string `json:"name"`
+}
+
+func (Province) TableName() string {
+	return "region_province"
+}
+
+type Regency struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement:false" json:"id"`
+	ProvinceID int64  `json:"province_id"`
+	Name       string `json:"name"`
+}
+
+func (Regency) TableName() string {
+	return "region_regency"
+}
+
+type District struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement:false" json:"id"`
+	RegencyID int64  `json:"regency_id"`
+	Name      string `json:"name"`
+}
+
+func (District) TableName() string {
+	return "region_district"
+}
+
+type Village struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement:false" json:"id"`
+	DistrictID int64  `json:"district_id"`
+	Name       string `json:"name"`
+	PostalCode string `json:"postal_code"`
+}
+
+func (Village) TableName() string {
+	return "region_village"
+}
+
+type RegionDataset struct {
+	ID       int16     `gorm:"primaryKey;autoIncrement:false"`
+	Checksum string    `gorm:"column:checksum"`
+	SeededAt time.Time `gorm:"column:seeded_at"`
+}
+
+func (RegionDataset) TableName() string {
+	return "region_dataset"
+}
+
+// RegionPath satu wilayah lengkap sampai provinsi, hasil lookup / autocomplete.
+// VillageID 0 bila wilayah berhenti di kecamatan.
+type RegionPath struct {
+	ProvinceID   int64  `json:"province_id"`
+	ProvinceName string `json:"province_name"`
+	RegencyID    int64  `json:"regency_id"`
+	RegencyName  string `json:"regency_name"`
+	DistrictID   int64  `json:"district_id"`
+	DistrictName string `json:"district_name"`
+	VillageID    int64  `json:"village_id"`
+	VillageName  string `json:"village_name"`
+	PostalCode   string `json:"postal_code"`
+}
+
+// RegionQuery id wilayah dari request alamat, VillageID opsional. Nama wilayah (teks bebas) dipakai
+// bila wilayah belum ada di data wilayah yang di-seed.
+type RegionQuery struct {
+	ProvinceID   int64
+	RegencyID    int64
+	DistrictID   int64
+	VillageID    int64
+	PostalCode   string
+	RegencyName  string
+	DistrictName string
+	VillageName  string
+}
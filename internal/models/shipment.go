@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	ShipmentStatusCreated   = "created"
+	ShipmentStatusInTransit = "in_transit"
+	ShipmentStatusDelivered = "delivered"
+)
+
+// Shipment pengiriman order lewat provider ongkir (flat / agregator), satu pengiriman per order
+type Shipment struct {
+	ID             int64      `gorm:"primaryKey" json:"id"`
+	OrderID        int64      `json:"order_id"`
+	Provider       string     `json:"provider"`
+	Courier        string     `json:"courier"`
+	Service        string     `json:"service"`
+	TrackingNumber string     `json:"tracking_number"`
+	Cost           float64    `json:"cost"`
+	WeightGram     int        `json:"weight_gram"`
+	Status         string     `json:"status"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedBy      int64      `json:"created_by"`
+	UpdatedBy      int64      `json:"updated_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (Shipment) TableName() string {
+	return "shipment"
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"pleasurelove/pkg/tax"
+	"time"
+)
+
+// TaxClass kelas pajak produk / kategori, tarifnya berubah sesuai tanggal berlaku
+type TaxClass struct {
+	ID          int64     `gorm:"primaryKey" json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsDefault   bool      `json:"is_default"` // dipakai produk yang tidak punya kelas pajak dari produk / kategori
+	CreatedBy   int64     `json:"created_by"`
+	UpdatedBy   int64     `json:"updated_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	Rates []TaxRate `json:"rates" gorm:"foreignKey:TaxClassID"`
+}
+
+func (TaxClass) TableName() string {
+	return "tax_class"
+}
+
+// ToClass kelas pajak untuk kalkulator pajak
+func (c TaxClass) ToClass() tax.Class {
+	class := tax.Class{ID: c.ID, Code: c.Code, Name: c.Name}
+	for _, r := range c.Rates {
+		class.Rates = append(class.Rates, tax.Rate{
+			Name:          r.Name,
+			Percent:       r.Rate,
+			EffectiveFrom: r.EffectiveFrom,
+		})
+	}
+	return class
+}
+
+type TaxRate struct {
+	ID            int64     `gorm:"primaryKey" json:"id"`
+	TaxClassID    int64     `json:"tax_class_id"`
+	Name          string    `json:"name"`
+	Rate          float64   `json:"rate"` // persen
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedBy     int64     `json:"created_by"`
+	UpdatedBy     int64     `json:"updated_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (TaxRate) TableName() string {
+	return "tax_rate"
+}
+
+// ProductTaxClass kelas pajak efektif produk (dari produk atau kategori terdekat), nil = kelas default
+type ProductTaxClass struct {
+	ProductID  int64
+	TaxClassID *int64
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"math"
+	"pleasurelove/pkg/promotion"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	VoucherTypePercent = "percent"
+	VoucherTypeFixed   = "fixed"
+)
+
+type Voucher struct {
+	ID               int64         `gorm:"primaryKey" json:"id"`
+	Name             string        `json:"name"`
+	Description      string        `json:"description"`
+	DiscountType     string        `json:"discount_type"`
+	Value            float64       `json:"value"`
+	MaxDiscount      float64       `json:"max_discount"`
+	MinSpend         float64       `json:"min_spend"`
+	ProductIDs       pq.Int64Array `gorm:"type:bigint[]" json:"product_ids"`
+	CategoryIDs      pq.Int64Array `gorm:"type:bigint[]" json:"category_ids"`
+	IsActive         bool          `json:"is_active"`
+	StartsAt         *time.Time    `json:"starts_at"`
+	EndsAt           *time.Time    `json:"ends_at"`
+	UsageLimit       int           `json:"usage_limit"`
+	PerCustomerLimit int           `json:"per_customer_limit"`
+	UsageCount       int           `json:"usage_count"`
+	CreatedBy        int64         `json:"created_by"`
+	UpdatedBy        int64         `json:"updated_by"`
+	CreatedAt        time.Time     `json:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+}
+
+func (Voucher) TableName() string {
+	return "voucher"
+}
+
+// ToRule mengubah voucher menjadi rule evaluator promosi. Voucher selalu dievaluasi
+// paling akhir (setelah promosi otomatis) dan dihitung dari harga setelah promosi.
+func (v Voucher) ToRule(code string, customerUsage int) promotion.Rule {
+	action := promotion.Action{
+		MaxDiscount: v.MaxDiscount,
+		Target: promotion.Target{
+			ProductIDs:  v.ProductIDs,
+			CategoryIDs: v.CategoryIDs,
+		},
+	}
+	if v.DiscountType == VoucherTypePercent {
+		action.Type = promotion.ActionPercentOff
+		action.Percent = v.Value
+	} else {
+		action.Type = promotion.ActionFixedOff
+		action.Amount = v.Value
+	}
+
+	rule := promotion.Rule{
+		ID:               v.ID,
+		Source:           promotion.SourceVoucher,
+		Code:             code,
+		Name:             v.Name,
+		Priority:         math.MinInt32,
+		Stackable:        true,
+		StartsAt:         v.StartsAt,
+		EndsAt:           v.EndsAt,
+		Actions:          []promotion.Action{action},
+		UsageLimit:       v.UsageLimit,
+		UsageCount:       v.UsageCount,
+		PerCustomerLimit: v.PerCustomerLimit,
+		CustomerUsage:    customerUsage,
+	}
+	if v.MinSpend > 0 {
+		rule.Conditions = []promotion.Condition{{Type: promotion.ConditionMinSubtotal, Value: v.MinSpend}}
+	}
+	return rule
+}
+
+type VoucherCode struct {
+	ID             int64     `gorm:"primaryKey" json:"id"`
+	VoucherID      int64     `json:"voucher_id"`
+	Code           string    `json:"code"`
+	MaxRedemptions int       `json:"max_redemptions"`
+	RedeemedCount  int       `json:"redeemed_count"`
+	CreatedBy      int64     `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	Voucher *Voucher `json:"voucher,omitempty" gorm:"foreignKey:VoucherID"`
+}
+
+func (VoucherCode) TableName() string {
+	return "voucher_code"
+}
+
+type VoucherRedemption struct {
+	ID             int64     `gorm:"primaryKey" json:"id"`
+	VoucherID      int64     `json:"voucher_id"`
+	VoucherCodeID  int64     `json:"voucher_code_id"`
+	CustomerID     *int64    `json:"customer_id"`
+	CustomerSeq    *int      `json:"customer_seq"`
+	Reference      string    `json:"reference"`
+	DiscountAmount float64   `json:"discount_amount"`
+	RedeemedAt     time.Time `json:"redeemed_at"`
+}
+
+func (VoucherRedemption) TableName() string {
+	return "voucher_redemption"
+}
@@ -56,6 +56,7 @@ func (a *AbstractRepo) applyFilters(filters map[string][2]interface{}) func(*gor
 			operator := val[0].(string)
 			value := val[1]
 
+			key = models.FilterField(key)
 			alias, ok := a.FilterAlias[key]
 			if ok {
 				key = alias
@@ -96,6 +97,35 @@ func (a *AbstractRepo) applyFiltersAndPaginationAndOrder(params *models.GetListS
 	}
 }
 
+// ExportBatchSize jumlah baris per query saat export
+const ExportBatchSize = 500
+
+// findInBatches menjalankan query list (filter + urutan sama dengan list) per halaman tanpa batas
+// jumlah data, fn dipanggil per batch sehingga memori yang dipakai tetap. Urutan ditambah id supaya
+// halaman stabil saat nilai kolom order sama
+func findInBatches[T any](a *AbstractRepo, db *gorm.DB, listStruct *models.GetListStruct, fn func([]T) error) error {
+	db = db.Scopes(a.orderBy(listStruct.OrderBy, listStruct.SortBy)).Session(&gorm.Session{})
+	if listStruct.OrderBy != "id" {
+		db = db.Order("id ASC").Session(&gorm.Session{})
+	}
+
+	for page := 1; ; page++ {
+		var batch []T
+		if err := db.Scopes(a.paginate(page, ExportBatchSize)).Find(&batch).Error; err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < ExportBatchSize {
+			return nil
+		}
+	}
+}
+
 func (a *AbstractRepo) filterByRole(role string, userID int64) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		if role == "admin" {
@@ -108,7 +138,7 @@ func (a *AbstractRepo) filterByRole(role string, userID int64) func(*gorm.DB) *g
 func (a *AbstractRepo) applyJoins(params *models.GetListStruct) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		for k := range params.Filters {
-			joinStr, ok := a.Joins[k]
+			joinStr, ok := a.Joins[models.FilterField(k)]
 			if ok {
 				// Jika ada join yang sesuai dengan filter, kita tambahkan join tersebut
 				db = db.Joins(joinStr)
@@ -0,0 +1,152 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AddressRepository interface {
+	Create(ctx context.Context, address *models.Address) error
+	GetAddressByID(ctx context.Context, id int64) (models.Address, error)
+	GetAddressByOwner(ctx context.Context, owner models.AddressOwner, id int64) (models.Address, error)
+	GetListAddressByOwner(ctx context.Context, owner models.AddressOwner) ([]models.Address, error)
+	UpdateAddressByID(ctx context.Context, id int64, updatedAt time.Time, address models.Address) (models.Address, error)
+	DeleteAddressByID(ctx context.Context, id int64, updatedAt time.Time) error
+	UnsetDefaultAddress(ctx context.Context, owner models.AddressOwner, addressType string) error
+	SetFirstAddressAsDefault(ctx context.Context, owner models.AddressOwner, addressType string) error
+}
+
+type addressRepository struct {
+	AbstractRepo
+}
+
+var (
+	AddressConstraintErrorMessages = map[string]string{
+		"idx_addresses_default_user":     "Hanya boleh ada satu alamat utama per jenis alamat",
+		"idx_addresses_default_customer": "Hanya boleh ada satu alamat utama per jenis alamat",
+		"chk_address_type":               "Jenis alamat tidak valid",
+	}
+)
+
+func NewAddressRepository(db *gorm.DB) AddressRepository {
+	return &addressRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			ConstraintError: AddressConstraintErrorMessages,
+		},
+	}
+}
+
+// addressOwnerScope alamat user login dicari dari user_id, alamat guest dari customer_id tanpa user
+func addressOwnerScope(owner models.AddressOwner) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if owner.UserID != 0 {
+			return db.Where("user_id = ?", owner.UserID)
+		}
+		return db.Where("customer_id = ? AND user_id IS NULL", owner.CustomerID)
+	}
+}
+
+func (r *addressRepository) Create(ctx context.Context, address *models.Address) error {
+	return r.getDB(ctx).WithContext(ctx).Create(address).Error
+}
+
+func (r *addressRepository) GetAddressByID(ctx context.Context, id int64) (models.Address, error) {
+	var address models.Address
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&address).Error
+	if err != nil {
+		return models.Address{}, err
+	}
+	return address, nil
+}
+
+// GetAddressByOwner gorm.ErrRecordNotFound bila alamat bukan milik owner
+func (r *addressRepository) GetAddressByOwner(ctx context.Context, owner models.AddressOwner, id int64) (models.Address, error) {
+	var address models.Address
+	err := r.db.WithContext(ctx).
+		Scopes(addressOwnerScope(owner)).
+		Where("id = ?", id).
+		First(&address).Error
+	if err != nil {
+		return models.Address{}, err
+	}
+	return address, nil
+}
+
+// GetListAddressByOwner urutan tampil: jenis alamat, alamat utama, lalu urutan dibuat
+func (r *addressRepository) GetListAddressByOwner(ctx context.Context, owner models.AddressOwner) ([]models.Address, error) {
+	var addresses []models.Address
+	err := r.getDB(ctx).WithContext(ctx).
+		Scopes(addressOwnerScope(owner)).
+		Order("address_type ASC, is_default DESC, id ASC").
+		Find(&addresses).Error
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+func (r *addressRepository) UpdateAddressByID(ctx context.Context, id int64, updatedAt time.Time, address models.Address) (models.Address, error) {
+	res := r.getDB(ctx).WithContext(ctx).
+		Model(&address).
+		Select("label", "recipient_name", "phone_number", "address_line1", "address_line2",
+			"province_id", "regency_id", "district_id", "village_id", "village", "subdistrict",
+			"city", "province", "postal_code", "country", "is_default", "address_type", "updated_at", "updated_by").
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Updates(&address)
+	if res.Error != nil {
+		return models.Address{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.Address{}, gorm.ErrRecordNotFound
+	}
+	return address, nil
+}
+
+func (r *addressRepository) DeleteAddressByID(ctx context.Context, id int64, updatedAt time.Time) error {
+	res := r.getDB(ctx).WithContext(ctx).
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Delete(&models.Address{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *addressRepository) UnsetDefaultAddress(ctx context.Context, owner models.AddressOwner, addressType string) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.Address{}).
+		Scopes(addressOwnerScope(owner)).
+		Where("address_type = ? AND is_default", addressType).
+		Update("is_default", false).Error
+}
+
+// SetFirstAddressAsDefault menjadikan alamat pertama sebagai alamat utama bila jenis alamat tersebut belum punya
+func (r *addressRepository) SetFirstAddressAsDefault(ctx context.Context, owner models.AddressOwner, addressType string) error {
+	db := r.getDB(ctx).WithContext(ctx)
+
+	var count int64
+	err := db.Model(&models.Address{}).
+		Scopes(addressOwnerScope(owner)).
+		Where("address_type = ? AND is_default", addressType).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return err
+	}
+
+	first := db.Model(&models.Address{}).
+		Select("id").
+		Scopes(addressOwnerScope(owner)).
+		Where("address_type = ?", addressType).
+		Order("id ASC").
+		Limit(1)
+	return db.Model(&models.Address{}).
+		Where("id = (?)", first).
+		Update("is_default", true).Error
+}
@@ -0,0 +1,156 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AttributeRepository interface {
+	Create(ctx context.Context, attribute *models.AttributeDefinition) error
+	GetAttributeByID(ctx context.Context, id int64) (models.AttributeDefinition, error)
+	GetListAttribute(ctx context.Context, listStruct *models.GetListStruct) ([]models.AttributeDefinition, int64, error)
+	UpdateAttributeByID(ctx context.Context, id int64, updatedAt time.Time, attribute models.AttributeDefinition) (models.AttributeDefinition, error)
+	DeleteAttributeByID(ctx context.Context, id int64, updatedAt time.Time) error
+	GetAttributeByCategoryIDs(ctx context.Context, categoryIDs []int64) ([]models.AttributeDefinition, error)
+	CreateValueBulk(ctx context.Context, values []models.ProductAttributeValue) error
+	DeleteValueByProductID(ctx context.Context, productID int64) error
+	GetValueByProductID(ctx context.Context, productID int64) ([]models.ProductAttributeValueDetail, error)
+}
+
+type attributeRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterAttribute = map[string]string{
+		"category_id":   "category_id",
+		"code":          "code",
+		"name":          "name",
+		"data_type":     "data_type",
+		"is_filterable": "is_filterable",
+	}
+	JoinsAttribute                   = map[string]string{}
+	AttributeConstraintErrorMessages = map[string]string{
+		"unique_attribute_definition_code":   "Kode atribut sudah digunakan pada kategori ini",
+		"idx_product_attribute_value_unique": "Nilai atribut tidak boleh duplikat",
+	}
+)
+
+func NewAttributeRepository(db *gorm.DB) AttributeRepository {
+	return &attributeRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterAttribute,
+			Joins:           JoinsAttribute,
+			ConstraintError: AttributeConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *attributeRepository) Create(ctx context.Context, attribute *models.AttributeDefinition) error {
+	return r.getDB(ctx).WithContext(ctx).Create(attribute).Error
+}
+
+func (r *attributeRepository) GetAttributeByID(ctx context.Context, id int64) (models.AttributeDefinition, error) {
+	var attribute models.AttributeDefinition
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&attribute).Error
+	if err != nil {
+		return models.AttributeDefinition{}, err
+	}
+	return attribute, nil
+}
+
+func (r *attributeRepository) GetListAttribute(ctx context.Context, listStruct *models.GetListStruct) ([]models.AttributeDefinition, int64, error) {
+	var attributes []models.AttributeDefinition
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.AttributeDefinition{}).
+		Scopes(r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.AttributeDefinition{}).
+		Scopes(r.applyFiltersAndPaginationAndOrder(listStruct)).
+		Find(&attributes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return attributes, total, nil
+}
+
+func (r *attributeRepository) UpdateAttributeByID(ctx context.Context, id int64, updatedAt time.Time, attribute models.AttributeDefinition) (models.AttributeDefinition, error) {
+	db := r.getDB(ctx)
+
+	res := db.WithContext(ctx).
+		Model(&attribute).
+		Select("code", "name", "allowed_values", "unit", "is_required", "is_filterable", "sort_order", "updated_at", "updated_by").
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Updates(&attribute)
+	if res.Error != nil {
+		return models.AttributeDefinition{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.AttributeDefinition{}, gorm.ErrRecordNotFound
+	}
+	return attribute, nil
+}
+
+func (r *attributeRepository) DeleteAttributeByID(ctx context.Context, id int64, updatedAt time.Time) error {
+	db := r.getDB(ctx)
+
+	err := db.WithContext(ctx).
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Delete(&models.AttributeDefinition{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *attributeRepository) GetAttributeByCategoryIDs(ctx context.Context, categoryIDs []int64) ([]models.AttributeDefinition, error) {
+	var attributes []models.AttributeDefinition
+	err := r.db.WithContext(ctx).
+		Where("category_id IN ?", categoryIDs).
+		Order("sort_order ASC, id ASC").
+		Find(&attributes).Error
+	if err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+func (r *attributeRepository) CreateValueBulk(ctx context.Context, values []models.ProductAttributeValue) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return r.getDB(ctx).WithContext(ctx).Create(&values).Error
+}
+
+func (r *attributeRepository) DeleteValueByProductID(ctx context.Context, productID int64) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Where("product_id = ?", productID).
+		Delete(&models.ProductAttributeValue{}).Error
+}
+
+func (r *attributeRepository) GetValueByProductID(ctx context.Context, productID int64) ([]models.ProductAttributeValueDetail, error) {
+	var values []models.ProductAttributeValueDetail
+	err := r.db.WithContext(ctx).
+		Table("product_attribute_value pav").
+		Select("pav.*, ad.code, ad.name, ad.data_type, COALESCE(ad.unit, '') AS unit").
+		Joins("JOIN attribute_definition ad ON ad.id = pav.attribute_id").
+		Where("pav.product_id = ?", productID).
+		Order("ad.sort_order ASC, ad.id ASC, pav.product_varian_id ASC NULLS FIRST").
+		Scan(&values).Error
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,149 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CartRepository interface {
+	GetCartByOwner(ctx context.Context, owner models.CartOwner) (models.Cart, error)
+	LockCartByOwner(ctx context.Context, owner models.CartOwner, expiresAt time.Time) (models.Cart, error)
+	ReplaceCartItems(ctx context.Context, cartID int64, items []models.CartItem, expiresAt time.Time) error
+	DeleteCartByOwner(ctx context.Context, owner models.CartOwner) (models.Cart, error)
+}
+
+type cartRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterCart                  = map[string]string{}
+	JoinsCart                   = map[string]string{}
+	CartConstraintErrorMessages = map[string]string{
+		"unique_cart_item": "produk sudah ada di keranjang",
+	}
+)
+
+func NewCartRepository(db *gorm.DB) CartRepository {
+	return &cartRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterCart,
+			Joins:           JoinsCart,
+			ConstraintError: CartConstraintErrorMessages,
+		},
+	}
+}
+
+func cartOwnerScope(owner models.CartOwner) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if owner.UserID != 0 {
+			return db.Where("user_id = ?", owner.UserID)
+		}
+		return db.Where("guest_id = ?", owner.GuestID)
+	}
+}
+
+func (r *cartRepository) GetCartByOwner(ctx context.Context, owner models.CartOwner) (models.Cart, error) {
+	var cart models.Cart
+	err := r.db.WithContext(ctx).
+		Scopes(cartOwnerScope(owner)).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		First(&cart).Error
+	if err != nil {
+		return models.Cart{}, err
+	}
+	return cart, nil
+}
+
+// LockCartByOwner membuat keranjang bila belum ada lalu mengunci barisnya (ON CONFLICT DO UPDATE
+// ikut mengunci baris yang sudah ada) sampai transaksi selesai, supaya perubahan keranjang
+// yang sama dari beberapa request diproses berurutan. Wajib dipanggil di dalam transaksi.
+func (r *cartRepository) LockCartByOwner(ctx context.Context, owner models.CartOwner, expiresAt time.Time) (models.Cart, error) {
+	cart := models.Cart{ExpiresAt: expiresAt}
+	conflict := "guest_id"
+	if owner.UserID != 0 {
+		cart.UserID = &owner.UserID
+		conflict = "user_id"
+	} else {
+		cart.GuestID = &owner.GuestID
+	}
+
+	err := r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: conflict}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"updated_at": time.Now()}),
+		}).
+		Create(&cart).Error
+	if err != nil {
+		return models.Cart{}, err
+	}
+
+	var locked models.Cart
+	err = r.getDB(ctx).WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Where("id = ?", cart.ID).
+		First(&locked).Error
+	if err != nil {
+		return models.Cart{}, err
+	}
+	return locked, nil
+}
+
+// ReplaceCartItems isi keranjang diganti seluruhnya dengan items (snapshot terakhir)
+func (r *cartRepository) ReplaceCartItems(ctx context.Context, cartID int64, items []models.CartItem, expiresAt time.Time) error {
+	db := r.getDB(ctx).WithContext(ctx)
+
+	err := db.Where("cart_id = ?", cartID).Delete(&models.CartItem{}).Error
+	if err != nil {
+		return err
+	}
+
+	if len(items) > 0 {
+		for i := range items {
+			items[i].ID = 0
+			items[i].CartID = cartID
+		}
+		if err := db.Create(&items).Error; err != nil {
+			return err
+		}
+	}
+
+	return db.Model(&models.Cart{}).
+		Where("id = ?", cartID).
+		Updates(map[string]interface{}{
+			"expires_at": expiresAt,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// DeleteCartByOwner menghapus keranjang (item ikut terhapus) dan mengembalikan isinya,
+// gorm.ErrRecordNotFound bila keranjang tidak ada
+func (r *cartRepository) DeleteCartByOwner(ctx context.Context, owner models.CartOwner) (models.Cart, error) {
+	db := r.getDB(ctx).WithContext(ctx)
+
+	var cart models.Cart
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scopes(cartOwnerScope(owner)).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		First(&cart).Error
+	if err != nil {
+		return models.Cart{}, err
+	}
+
+	err = db.Where("id = ?", cart.ID).Delete(&models.Cart{}).Error
+	if err != nil {
+		return models.Cart{}, err
+	}
+	return cart, nil
+}
@@ -6,16 +6,27 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CategoryRepository interface {
 	Create(ctx context.Context, category *models.Category) error
 	GetCategoryByID(ctx context.Context, id int64) (models.Category, error)
+	GetCategoryByIDForUpdate(ctx context.Context, id int64) (models.Category, error)
 	GetListCategory(ctx context.Context, listStruct *models.GetListStruct) ([]models.Category, int64, error)
+	ExportListCategory(ctx context.Context, listStruct *models.GetListStruct, fn func([]models.Category) error) error
 	UpdateCategoryByID(ctx context.Context, id int64, updatedAt time.Time, category models.Category) (models.Category, error)
 	DeleteCategoryByID(ctx context.Context, id int64, updatedAt time.Time) error
 	GetCategoryByNameOrCode(ctx context.Context, name string, code string) (models.Category, error)
 	GetCategoryByListIDs(ctx context.Context, ids []int64) ([]models.Category, error)
+	GetCategoryByCodes(ctx context.Context, codes []string) ([]models.Category, error)
+	GetCategoryTree(ctx context.Context, rootPath string) ([]models.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (models.Category, error)
+	GetDescendantIDs(ctx context.Context, ids []int64) ([]int64, error)
+	CountChildrenByID(ctx context.Context, id int64) (int64, error)
+	UpdateCategoryPath(ctx context.Context, id int64, path string, depth int) error
+	MoveCategoryByID(ctx context.Context, id int64, updatedAt time.Time, category models.Category) (models.Category, error)
+	UpdateSubtreePath(ctx context.Context, oldPath string, newPath string, depthDelta int) error
 }
 
 type categoryRepository struct {
@@ -24,12 +35,15 @@ type categoryRepository struct {
 
 var (
 	FilterCategory = map[string]string{
-		"name": "name",
+		"name":      "name",
+		"parent_id": "parent_id",
+		"depth":     "depth",
 	}
 	JoinsCategory           = map[string]string{}
 	ConstraintErrorMessages = map[string]string{
-		"idx_categories_code": "Kode kategori sudah digunakan",
-		"idx_categories_slug": "Nama kategori sudah digunakan",
+		"idx_categories_code":  "Kode kategori sudah digunakan",
+		"idx_categories_slug":  "Nama kategori sudah digunakan",
+		"fk_categories_parent": "Kategori masih memiliki sub kategori",
 	}
 )
 
@@ -57,6 +71,28 @@ func (r *categoryRepository) GetCategoryByID(ctx context.Context, id int64) (mod
 	return category, nil
 }
 
+// GetCategoryByIDForUpdate mengunci baris kategori sampai transaksi selesai
+func (r *categoryRepository) GetCategoryByIDForUpdate(ctx context.Context, id int64) (models.Category, error) {
+	var category models.Category
+	err := r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&category).Error
+	if err != nil {
+		return models.Category{}, err
+	}
+	return category, nil
+}
+
+func (r *categoryRepository) GetCategoryBySlug(ctx context.Context, slug string) (models.Category, error) {
+	var category models.Category
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&category).Error
+	if err != nil {
+		return models.Category{}, err
+	}
+	return category, nil
+}
+
 func (r *categoryRepository) GetListCategory(ctx context.Context, listStruct *models.GetListStruct) ([]models.Category, int64, error) {
 	var categories []models.Category
 	var total int64
@@ -83,12 +119,19 @@ func (r *categoryRepository) GetListCategory(ctx context.Context, listStruct *mo
 func (r *categoryRepository) UpdateCategoryByID(ctx context.Context, id int64, updatedAt time.Time, category models.Category) (models.Category, error) {
 	db := r.getDB(ctx)
 
-	err := db.WithContext(ctx).
+	// Select("*") supaya tax_class_id nil (kembali mengikuti kategori induk) tetap tersimpan dalam satu
+	// update yang dijaga updated_at. Parent, path dan urutan hanya berubah lewat MoveCategoryByID
+	res := db.WithContext(ctx).
 		Model(&category).
+		Select("*").
+		Omit("id", "parent_id", "depth", "path", "sort_order", "created_at", "created_by").
 		Where("id = ? AND updated_at = ?", id, updatedAt).
-		Updates(category).Error
-	if err != nil {
-		return models.Category{}, err
+		Updates(category)
+	if res.Error != nil {
+		return models.Category{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.Category{}, gorm.ErrRecordNotFound
 	}
 	return category, nil
 }
@@ -96,11 +139,14 @@ func (r *categoryRepository) UpdateCategoryByID(ctx context.Context, id int64, u
 func (r *categoryRepository) DeleteCategoryByID(ctx context.Context, id int64, updatedAt time.Time) error {
 	db := r.getDB(ctx)
 
-	err := db.WithContext(ctx).
+	res := db.WithContext(ctx).
 		Where("id = ? AND updated_at = ?", id, updatedAt).
-		Delete(&models.Category{}).Error
-	if err != nil {
-		return err
+		Delete(&models.Category{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
 	return nil
 }
@@ -124,3 +170,106 @@ func (r *categoryRepository) GetCategoryByListIDs(ctx context.Context, ids []int
 	}
 	return categories, nil
 }
+
+// GetCategoryTree mengambil seluruh kategori (atau subtree dari rootPath) terurut untuk disusun menjadi tree
+func (r *categoryRepository) GetCategoryTree(ctx context.Context, rootPath string) ([]models.Category, error) {
+	var categories []models.Category
+
+	db := r.db.WithContext(ctx)
+	if rootPath != "" {
+		db = db.Where("path LIKE ?", rootPath+"%")
+	}
+
+	err := db.Order("depth ASC, sort_order ASC, name ASC").Find(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// GetDescendantIDs mengambil id kategori beserta seluruh turunannya
+func (r *categoryRepository) GetDescendantIDs(ctx context.Context, ids []int64) ([]int64, error) {
+	var result []int64
+	err := r.db.WithContext(ctx).
+		Table("categories c").
+		Distinct("c.id").
+		Joins("JOIN categories p ON c.path LIKE p.path || '%'").
+		Where("p.id IN ?", ids).
+		Pluck("c.id", &result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *categoryRepository) CountChildrenByID(ctx context.Context, id int64) (int64, error) {
+	var total int64
+	err := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Category{}).
+		Where("parent_id = ?", id).
+		Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *categoryRepository) UpdateCategoryPath(ctx context.Context, id int64, path string, depth int) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.Category{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"path":  path,
+			"depth": depth,
+		}).Error
+}
+
+func (r *categoryRepository) MoveCategoryByID(ctx context.Context, id int64, updatedAt time.Time, category models.Category) (models.Category, error) {
+	db := r.getDB(ctx)
+
+	// pakai map agar parent_id nil (pindah ke root) tetap ikut ter-update
+	res := db.WithContext(ctx).
+		Model(&models.Category{}).
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Updates(map[string]interface{}{
+			"parent_id":  category.ParentID,
+			"path":       category.Path,
+			"depth":      category.Depth,
+			"sort_order": category.SortOrder,
+			"updated_at": category.UpdatedAt,
+			"updated_by": category.UpdatedBy,
+		})
+	if res.Error != nil {
+		return models.Category{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.Category{}, gorm.ErrRecordNotFound
+	}
+	return category, nil
+}
+
+// UpdateSubtreePath mengganti prefix path seluruh turunan setelah kategori dipindah
+func (r *categoryRepository) UpdateSubtreePath(ctx context.Context, oldPath string, newPath string, depthDelta int) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Exec("UPDATE categories SET path = ? || substring(path from ?), depth = depth + ? WHERE path LIKE ? AND path <> ?",
+			newPath, len(oldPath)+1, depthDelta, oldPath+"%", oldPath).Error
+}
+
+func (r *categoryRepository) GetCategoryByCodes(ctx context.Context, codes []string) ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.WithContext(ctx).
+		Where("code IN ?", codes).
+		Find(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *categoryRepository) ExportListCategory(ctx context.Context, listStruct *models.GetListStruct, fn func([]models.Category) error) error {
+	db := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Category{}).
+		Scopes(r.withCheckScope(ctx), r.applyFilters(listStruct.Filters))
+
+	return findInBatches(&r.AbstractRepo, db, listStruct, fn)
+}
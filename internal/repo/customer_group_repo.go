@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CustomerGroupRepository interface {
+	Create(ctx context.Context, group *models.CustomerGroup) error
+	GetCustomerGroupByID(ctx context.Context, id int64) (models.CustomerGroup, error)
+	GetListCustomerGroup(ctx context.Context, listStruct *models.GetListStruct) ([]models.CustomerGroup, int64, error)
+	UpdateCustomerGroupByID(ctx context.Context, id int64, updatedAt time.Time, group models.CustomerGroup) (models.CustomerGroup, error)
+	DeleteCustomerGroupByID(ctx context.Context, id int64, updatedAt time.Time) error
+	AddCustomerGroupMember(ctx context.Context, groupID int64, customerIDs []int64) (int64, error)
+	RemoveCustomerGroupMember(ctx context.Context, groupID int64, customerIDs []int64) (int64, error)
+}
+
+type customerGroupRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterCustomerGroup = map[string]string{
+		"code": "code",
+		"name": "name",
+	}
+	JoinsCustomerGroup                   = map[string]string{}
+	CustomerGroupConstraintErrorMessages = map[string]string{
+		"unique_customer_group_code": "Kode grup pelanggan sudah digunakan",
+	}
+)
+
+func NewCustomerGroupRepository(db *gorm.DB) CustomerGroupRepository {
+	return &customerGroupRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterCustomerGroup,
+			Joins:           JoinsCustomerGroup,
+			ConstraintError: CustomerGroupConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *customerGroupRepository) Create(ctx context.Context, group *models.CustomerGroup) error {
+	return r.getDB(ctx).WithContext(ctx).Create(group).Error
+}
+
+func (r *customerGroupRepository) GetCustomerGroupByID(ctx context.Context, id int64) (models.CustomerGroup, error) {
+	var group models.CustomerGroup
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&group).Error
+	if err != nil {
+		return models.CustomerGroup{}, err
+	}
+	return group, nil
+}
+
+func (r *customerGroupRepository) GetListCustomerGroup(ctx context.Context, listStruct *models.GetListStruct) ([]models.CustomerGroup, int64, error) {
+	var groups []models.CustomerGroup
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.CustomerGroup{}).
+		Scopes(r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.CustomerGroup{}).
+		Scopes(r.applyFiltersAndPaginationAndOrder(listStruct)).
+		Find(&groups).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return groups, total, nil
+}
+
+func (r *customerGroupRepository) UpdateCustomerGroupByID(ctx context.Context, id int64, updatedAt time.Time, group models.CustomerGroup) (models.CustomerGroup, error) {
+	db := r.getDB(ctx)
+
+	err := db.WithContext(ctx).
+		Model(&group).
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Updates(group).Error
+	if err != nil {
+		return models.CustomerGroup{}, err
+	}
+	return group, nil
+}
+
+func (r *customerGroupRepository) DeleteCustomerGroupByID(ctx context.Context, id int64, updatedAt time.Time) error {
+	db := r.getDB(ctx)
+
+	err := db.WithContext(ctx).
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Delete(&models.CustomerGroup{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddCustomerGroupMember memindahkan pelanggan ke grup (pelanggan hanya bisa berada di satu grup)
+func (r *customerGroupRepository) AddCustomerGroupMember(ctx context.Context, groupID int64, customerIDs []int64) (int64, error) {
+	res := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Customer{}).
+		Where("id IN ?", customerIDs).
+		Update("customer_group_id", groupID)
+	return res.RowsAffected, res.Error
+}
+
+func (r *customerGroupRepository) RemoveCustomerGroupMember(ctx context.Context, groupID int64, customerIDs []int64) (int64, error) {
+	res := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Customer{}).
+		Where("id IN ? AND customer_group_id = ?", customerIDs, groupID).
+		Update("customer_group_id", nil)
+	return res.RowsAffected, res.Error
+}
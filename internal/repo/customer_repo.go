@@ -2,20 +2,26 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"pleasurelove/internal/dto/request"
 	"pleasurelove/internal/models"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CustomerRepository interface {
 	Create(ctx context.Context, customer *models.Customer) error
 	GetCustomerByID(ctx context.Context, id int64) (models.Customer, error)
 	GetCustomerByUserID(ctx context.Context, userID int64) (models.Customer, error)
+	GetCustomerByGuestToken(ctx context.Context, guestToken string) (models.Customer, error)
 	GetListCustomer(ctx context.Context, listStruct *models.GetListStruct) ([]models.Customer, int64, error)
+	ExportListCustomer(ctx context.Context, listStruct *models.GetListStruct, fn func([]models.Customer) error) error
 	UpdateCustomerByID(ctx context.Context, reqData request.ReqCustomerUpdate, customer models.Customer) (models.Customer, error)
 	DeleteCustomerByID(ctx context.Context, id int64, updatedAt time.Time) error
+	UpdateCustomerContact(ctx context.Context, id int64, name, email, phone string) error
+	LinkGuestCustomerToUser(ctx context.Context, guestToken string, userID int64) error
 }
 
 type customerRepository struct {
@@ -69,6 +75,20 @@ func (r *customerRepository) GetCustomerByUserID(ctx context.Context, userID int
 	return customer, nil
 }
 
+// GetCustomerByGuestToken customer guest yang dibuat saat checkout dengan guest token yang sama
+func (r *customerRepository) GetCustomerByGuestToken(ctx context.Context, guestToken string) (models.Customer, error) {
+	var customer models.Customer
+
+	err := r.db.WithContext(ctx).
+		Where("guest_token = ? AND is_guest", guestToken).
+		First(&customer).Error
+	if err != nil {
+		return models.Customer{}, err
+	}
+
+	return customer, nil
+}
+
 func (r *customerRepository) GetListCustomer(ctx context.Context, listStruct *models.GetListStruct) ([]models.Customer, int64, error) {
 	var customers []models.Customer
 	var total int64
@@ -120,3 +140,111 @@ func (r *customerRepository) DeleteCustomerByID(ctx context.Context, id int64, u
 
 	return nil
 }
+
+func (r *customerRepository) ExportListCustomer(ctx context.Context, listStruct *models.GetListStruct, fn func([]models.Customer) error) error {
+	db := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Customer{}).
+		Scopes(r.withCheckScope(ctx), r.applyFilters(listStruct.Filters))
+
+	return findInBatches(&r.AbstractRepo, db, listStruct, fn)
+}
+
+// UpdateCustomerContact melengkapi kontak customer saat checkout (mis. customer guest dari buku alamat)
+func (r *customerRepository) UpdateCustomerContact(ctx context.Context, id int64, name, email, phone string) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.Customer{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"name":       name,
+			"email":      email,
+			"phone":      phone,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// LinkGuestCustomerToUser data customer guest (dibuat dengan guest token) dipindahkan ke user yang login.
+// User yang sudah punya customer: order, alamat dan pemakaian promo / voucher guest dipindah ke customer
+// tersebut lalu customer guest dihapus. Belum punya: customer guest dijadikan customer user.
+func (r *customerRepository) LinkGuestCustomerToUser(ctx context.Context, guestToken string, userID int64) error {
+	db := r.getDB(ctx).WithContext(ctx)
+
+	var guestIDs []int64
+	err := db.Model(&models.Customer{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("guest_token = ? AND is_guest", guestToken).
+		Order("id ASC").
+		Pluck("id", &guestIDs).Error
+	if err != nil {
+		return err
+	}
+	if len(guestIDs) == 0 {
+		return nil
+	}
+
+	// alamat guest ikut masuk buku alamat user, alamat utama user yang sudah ada tetap dipakai
+	err = db.Model(&models.Address{}).
+		Where("user_id IS NULL AND customer_id IN ?", guestIDs).
+		Updates(map[string]interface{}{
+			"user_id":    userID,
+			"is_default": false,
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	var customer models.Customer
+	err = db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND NOT is_guest", userID).
+		Order("id ASC").
+		First(&customer).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if customer.ID == 0 {
+		customer.ID = guestIDs[0]
+		err = db.Model(&models.Customer{}).
+			Where("id = ?", customer.ID).
+			Updates(map[string]interface{}{
+				"user_id":     userID,
+				"is_guest":    false,
+				"guest_token": "",
+				"updated_at":  time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+		guestIDs = guestIDs[1:]
+		if len(guestIDs) == 0 {
+			return nil
+		}
+	}
+
+	return r.mergeCustomers(db, customer.ID, guestIDs)
+}
+
+// mergeCustomers memindahkan data customer sumber ke customer tujuan lalu menghapus customer sumber
+func (r *customerRepository) mergeCustomers(db *gorm.DB, targetID int64, sourceIDs []int64) error {
+	for _, model := range []interface{}{&models.Order{}, &models.Address{}, &models.PromotionUsage{}} {
+		err := db.Model(model).
+			Where("customer_id IN ?", sourceIDs).
+			Update("customer_id", targetID).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	// urutan pemakaian voucher guest disambung setelah pemakaian customer tujuan agar tetap unik
+	err := db.Exec(`UPDATE voucher_redemption vr
+		SET customer_id = ?,
+			customer_seq = vr.customer_seq + COALESCE((
+				SELECT MAX(t.customer_seq) FROM voucher_redemption t
+				WHERE t.voucher_id = vr.voucher_id AND t.customer_id = ?), 0)
+		WHERE vr.customer_id IN ?`, targetID, targetID, sourceIDs).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Where("id IN ?", sourceIDs).Delete(&models.Customer{}).Error
+}
@@ -0,0 +1,56 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestExportListRespectsOwnScope(t *testing.T) {
+	const ownerID int64 = 42
+
+	tests := []struct {
+		name   string
+		export func(ctx context.Context, db *gorm.DB, listStruct *models.GetListStruct) error
+	}{
+		{"product", func(ctx context.Context, db *gorm.DB, listStruct *models.GetListStruct) error {
+			return NewProductRepository(db).ExportListProduct(ctx, listStruct, func([]models.Product) error { return nil })
+		}},
+		{"category", func(ctx context.Context, db *gorm.DB, listStruct *models.GetListStruct) error {
+			return NewCategoryRepository(db).ExportListCategory(ctx, listStruct, func([]models.Category) error { return nil })
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, queries := newRecordingDB(t)
+			listStruct := &models.GetListStruct{
+				Filters: map[string][2]interface{}{"name": {"LIKE", "%kaos%"}},
+				OrderBy: "id",
+				SortBy:  "ASC",
+			}
+
+			if err := tt.export(ownScopeContext(ownerID), db, listStruct); err != nil {
+				t.Fatalf("export: %v", err)
+			}
+			if len(*queries) == 0 {
+				t.Fatal("tidak ada query export")
+			}
+			q := (*queries)[0]
+			if !strings.Contains(q.sql, "created_by = $") || !hasVar(q, ownerID) {
+				t.Errorf("scope own harus membatasi export ke baris milik user %d:\n%s %v", ownerID, q.sql, q.vars)
+			}
+
+			// tanpa scope own seluruh baris ikut diexport
+			*queries = nil
+			if err := tt.export(context.Background(), db, listStruct); err != nil {
+				t.Fatalf("export: %v", err)
+			}
+			if q := (*queries)[0]; strings.Contains(q.sql, "created_by") {
+				t.Errorf("scope all tidak boleh memfilter created_by:\n%s", q.sql)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type InvoiceRepository interface {
+	Create(ctx context.Context, invoice *models.Invoice) error
+	GetInvoiceByID(ctx context.Context, id int64) (models.Invoice, error)
+	GetInvoiceByOrderID(ctx context.Context, orderID int64) (models.Invoice, error)
+	GetListInvoiceByOrderID(ctx context.Context, orderID int64) ([]models.Invoice, error)
+	GetListInvoice(ctx context.Context, listStruct *models.GetListStruct) ([]models.Invoice, int64, error)
+	NextSequence(ctx context.Context, documentType string, branchID int64, year int) (int64, error)
+	IncrementPrintCount(ctx context.Context, id int64, userID int64) (int, error)
+}
+
+type invoiceRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterInvoice = map[string]string{
+		"document_type": "document_type",
+		"number":        "number",
+		"order_id":      "order_id",
+		"invoice_id":    "invoice_id",
+		"branch_id":     "branch_id",
+		"year":          "year",
+		"issued_at":     "issued_at",
+	}
+	JoinsInvoice                   = map[string]string{}
+	InvoiceConstraintErrorMessages = map[string]string{
+		"unique_invoice_number":       "Nomor invoice sudah digunakan",
+		"unique_invoice_sequence":     "Nomor urut invoice sudah digunakan",
+		"idx_invoice_order":           "Order sudah memiliki invoice",
+		"chk_invoice_document_type":   "Jenis dokumen tidak valid",
+		"chk_invoice_credit_note_ref": "Nota kredit wajib merujuk invoice",
+		"invoice_order_id_fkey":       "Order tidak ditemukan",
+		"invoice_invoice_id_fkey":     "Invoice yang dikreditkan tidak ditemukan",
+		"invoice_payment_id_fkey":     "Pembayaran tidak ditemukan",
+	}
+)
+
+func NewInvoiceRepository(db *gorm.DB) InvoiceRepository {
+	return &invoiceRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterInvoice,
+			Joins:           JoinsInvoice,
+			ConstraintError: InvoiceConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *invoiceRepository) Create(ctx context.Context, invoice *models.Invoice) error {
+	return r.getDB(ctx).WithContext(ctx).Omit("Order", "Invoice").Create(invoice).Error
+}
+
+// GetInvoiceByID dokumen beserta order (item & rekap pajak) dan invoice yang dikreditkan
+func (r *invoiceRepository) GetInvoiceByID(ctx context.Context, id int64) (models.Invoice, error) {
+	var invoice models.Invoice
+	err := r.getDB(ctx).WithContext(ctx).
+		Preload("Order").
+		Preload("Order.Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Preload("Order.Taxes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Preload("Invoice").
+		Where("id = ?", id).
+		First(&invoice).Error
+	if err != nil {
+		return models.Invoice{}, err
+	}
+	return invoice, nil
+}
+
+// GetInvoiceByOrderID invoice order (bukan nota kredit), dibaca di dalam transaksi bila ada
+func (r *invoiceRepository) GetInvoiceByOrderID(ctx context.Context, orderID int64) (models.Invoice, error) {
+	var invoice models.Invoice
+	err := r.getDB(ctx).WithContext(ctx).
+		Where("order_id = ? AND document_type = ?", orderID, "invoice").
+		First(&invoice).Error
+	if err != nil {
+		return models.Invoice{}, err
+	}
+	return invoice, nil
+}
+
+// GetListInvoiceByOrderID invoice dan nota kredit order, urut terbit
+func (r *invoiceRepository) GetListInvoiceByOrderID(ctx context.Context, orderID int64) ([]models.Invoice, error) {
+	var invoices []models.Invoice
+	err := r.getDB(ctx).WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("issued_at ASC, id ASC").
+		Find(&invoices).Error
+	if err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+func (r *invoiceRepository) GetListInvoice(ctx context.Context, listStruct *models.GetListStruct) ([]models.Invoice, int64, error) {
+	var invoices []models.Invoice
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Invoice{}).
+		Scopes(r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.Invoice{}).
+		Scopes(r.applyFiltersAndPaginationAndOrder(listStruct)).
+		Find(&invoices).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return invoices, total, nil
+}
+
+// NextSequence nomor urut berikutnya. Wajib dipanggil di dalam transaksi yang juga menyimpan dokumen:
+// baris sequence terkunci sampai transaksi selesai sehingga penerbitan bersamaan berurutan, dan
+// rollback ikut mengembalikan nomor (tidak ada nomor yang loncat).
+func (r *invoiceRepository) NextSequence(ctx context.Context, documentType string, branchID int64, year int) (int64, error) {
+	var next int64
+	err := r.getDB(ctx).WithContext(ctx).
+		Raw(`INSERT INTO invoice_sequence (document_type, branch_id, year, last_number)
+			VALUES (?, ?, ?, 1)
+			ON CONFLICT (document_type, branch_id, year)
+			DO UPDATE SET last_number = invoice_sequence.last_number + 1
+			RETURNING last_number`, documentType, branchID, year).
+		Scan(&next).Error
+	if err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+// IncrementPrintCount mencatat satu kali cetak, mengembalikan jumlah cetak setelah ditambah
+func (r *invoiceRepository) IncrementPrintCount(ctx context.Context, id int64, userID int64) (int, error) {
+	var count int
+	now := time.Now()
+	err := r.getDB(ctx).WithContext(ctx).
+		Raw(`UPDATE invoice SET print_count = print_count + 1, last_printed_at = ?, updated_at = ?, updated_by = ?
+			WHERE id = ?
+			RETURNING print_count`, now, now, userID, id).
+		Scan(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return count, nil
+}
@@ -0,0 +1,135 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OrderRepository interface {
+	Create(ctx context.Context, order *models.Order) error
+	GetOrderByID(ctx context.Context, id int64) (models.Order, error)
+	GetOrderByIdempotencyKey(ctx context.Context, customerID int64, key string) (models.Order, error)
+	GetListOrder(ctx context.Context, listStruct *models.GetListStruct) ([]models.Order, int64, error)
+	UpdateOrderStatusByID(ctx context.Context, id int64, updatedAt time.Time, fromStatus, toStatus string, userID int64) (time.Time, error)
+	CreateStatusHistory(ctx context.Context, history *models.OrderStatusHistory) error
+}
+
+type orderRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterOrder = map[string]string{
+		"order_number": "order_number",
+		"status":       "status",
+		"customer_id":  "customer_id",
+		"created_at":   "created_at",
+	}
+	JoinsOrder                   = map[string]string{}
+	OrderConstraintErrorMessages = map[string]string{
+		"unique_order_number":          "Nomor order sudah digunakan",
+		"chk_order_status":             "Status order tidak valid",
+		"unique_order_idempotency_key": "Order dengan idempotency key ini sudah dibuat",
+	}
+)
+
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &orderRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterOrder,
+			Joins:           JoinsOrder,
+			ConstraintError: OrderConstraintErrorMessages,
+		},
+	}
+}
+
+// Create order beserta item dan riwayat status awal (association ikut dibuat)
+func (r *orderRepository) Create(ctx context.Context, order *models.Order) error {
+	return r.getDB(ctx).WithContext(ctx).Omit("Customer").Create(order).Error
+}
+
+func (r *orderRepository) GetOrderByID(ctx context.Context, id int64) (models.Order, error) {
+	var order models.Order
+	err := r.db.WithContext(ctx).
+		Scopes(r.withCheckScope(ctx)).
+		Preload("Customer").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Preload("History", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC, id ASC")
+		}).
+		Preload("Taxes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Where("id = ?", id).
+		First(&order).Error
+	if err != nil {
+		return models.Order{}, err
+	}
+	return order, nil
+}
+
+func (r *orderRepository) GetOrderByIdempotencyKey(ctx context.Context, customerID int64, key string) (models.Order, error) {
+	var order models.Order
+	err := r.getDB(ctx).WithContext(ctx).
+		Where("customer_id = ? AND idempotency_key = ?", customerID, key).
+		First(&order).Error
+	if err != nil {
+		return models.Order{}, err
+	}
+	return order, nil
+}
+
+func (r *orderRepository) GetListOrder(ctx context.Context, listStruct *models.GetListStruct) ([]models.Order, int64, error) {
+	var orders []models.Order
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Order{}).
+		Scopes(r.withCheckScope(ctx), r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.Order{}).
+		Preload("Customer").
+		Scopes(r.withCheckScope(ctx), r.applyFiltersAndPaginationAndOrder(listStruct)).
+		Find(&orders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orders, total, nil
+}
+
+// UpdateOrderStatusByID hanya berhasil bila status & updated_at masih sama dengan yang dibaca,
+// gorm.ErrRecordNotFound bila order sudah berubah
+func (r *orderRepository) UpdateOrderStatusByID(ctx context.Context, id int64, updatedAt time.Time, fromStatus, toStatus string, userID int64) (time.Time, error) {
+	now := time.Now()
+	res := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Order{}).
+		Where("id = ? AND status = ? AND updated_at = ?", id, fromStatus, updatedAt).
+		Updates(map[string]interface{}{
+			"status":     toStatus,
+			"updated_at": now,
+			"updated_by": userID,
+		})
+	if res.Error != nil {
+		return time.Time{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return time.Time{}, gorm.ErrRecordNotFound
+	}
+	return now, nil
+}
+
+func (r *orderRepository) CreateStatusHistory(ctx context.Context, history *models.OrderStatusHistory) error {
+	return r.getDB(ctx).WithContext(ctx).Create(history).Error
+}
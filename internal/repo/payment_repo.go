@@ -0,0 +1,213 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentRepository interface {
+	Create(ctx context.Context, payment *models.Payment) error
+	GetPaymentByID(ctx context.Context, id int64) (models.Payment, error)
+	GetPaymentByIDForUpdate(ctx context.Context, id int64) (models.Payment, error)
+	GetPaymentByReferenceForUpdate(ctx context.Context, provider, reference string) (models.Payment, error)
+	GetListPaymentByOrderID(ctx context.Context, orderID int64) ([]models.Payment, error)
+	CountPaymentByOrderID(ctx context.Context, orderID int64) (int64, error)
+	GetListPayment(ctx context.Context, listStruct *models.GetListStruct) ([]models.Payment, int64, error)
+	UpdatePaymentStatusByID(ctx context.Context, id int64, status string, paidAt *time.Time, userID int64) error
+	UpdatePaymentProofByID(ctx context.Context, id int64, proofKey string) error
+	UpdatePaymentConfirmByID(ctx context.Context, id int64, status, note string, userID int64) error
+	UpdatePaymentRefundByID(ctx context.Context, id int64, refundedAmount float64, status string, userID int64) error
+	CreateWebhookEvent(ctx context.Context, event *models.PaymentWebhookEvent) (bool, error)
+}
+
+type paymentRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterPayment = map[string]string{
+		"order_id":   "order_id",
+		"provider":   "provider",
+		"method":     "method",
+		"status":     "status",
+		"reference":  "reference",
+		"created_at": "created_at",
+	}
+	JoinsPayment                   = map[string]string{}
+	PaymentConstraintErrorMessages = map[string]string{
+		"unique_payment_reference":    "Reference pembayaran sudah digunakan",
+		"unique_payment_order_active": "Order masih memiliki pembayaran yang belum selesai",
+		"chk_payment_status":          "Status pembayaran tidak valid",
+		"chk_payment_refunded_amount": "Nominal refund melebihi nominal pembayaran",
+	}
+)
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &paymentRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterPayment,
+			Joins:           JoinsPayment,
+			ConstraintError: PaymentConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *paymentRepository) Create(ctx context.Context, payment *models.Payment) error {
+	return r.getDB(ctx).WithContext(ctx).Create(payment).Error
+}
+
+func (r *paymentRepository) GetPaymentByID(ctx context.Context, id int64) (models.Payment, error) {
+	var payment models.Payment
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&payment).Error
+	if err != nil {
+		return models.Payment{}, err
+	}
+	return payment, nil
+}
+
+// GetPaymentByIDForUpdate mengunci baris pembayaran sampai transaksi selesai
+func (r *paymentRepository) GetPaymentByIDForUpdate(ctx context.Context, id int64) (models.Payment, error) {
+	var payment models.Payment
+	err := r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&payment).Error
+	if err != nil {
+		return models.Payment{}, err
+	}
+	return payment, nil
+}
+
+// GetPaymentByReferenceForUpdate pembayaran dari notifikasi provider, baris dikunci supaya
+// notifikasi yang datang bersamaan diproses berurutan
+func (r *paymentRepository) GetPaymentByReferenceForUpdate(ctx context.Context, provider, reference string) (models.Payment, error) {
+	var payment models.Payment
+	err := r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("provider = ? AND reference = ?", provider, reference).
+		First(&payment).Error
+	if err != nil {
+		return models.Payment{}, err
+	}
+	return payment, nil
+}
+
+func (r *paymentRepository) GetListPaymentByOrderID(ctx context.Context, orderID int64) ([]models.Payment, error) {
+	var payments []models.Payment
+	err := r.getDB(ctx).WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("created_at DESC, id DESC").
+		Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (r *paymentRepository) CountPaymentByOrderID(ctx context.Context, orderID int64) (int64, error) {
+	var count int64
+	err := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Payment{}).
+		Where("order_id = ?", orderID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *paymentRepository) GetListPayment(ctx context.Context, listStruct *models.GetListStruct) ([]models.Payment, int64, error) {
+	var payments []models.Payment
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Payment{}).
+		Scopes(r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.Payment{}).
+		Scopes(r.applyFiltersAndPaginationAndOrder(listStruct)).
+		Find(&payments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return payments, total, nil
+}
+
+func (r *paymentRepository) UpdatePaymentStatusByID(ctx context.Context, id int64, status string, paidAt *time.Time, userID int64) error {
+	values := map[string]interface{}{
+		"status":     status,
+		"updated_at": time.Now(),
+		"updated_by": userID,
+	}
+	if paidAt != nil {
+		values["paid_at"] = paidAt
+	}
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.Payment{}).
+		Where("id = ?", id).
+		Updates(values).Error
+}
+
+func (r *paymentRepository) UpdatePaymentProofByID(ctx context.Context, id int64, proofKey string) error {
+	now := time.Now()
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.Payment{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"proof_key":         proofKey,
+			"proof_uploaded_at": now,
+			"updated_at":        now,
+		}).Error
+}
+
+// UpdatePaymentConfirmByID keputusan staf atas transfer manual (paid / failed)
+func (r *paymentRepository) UpdatePaymentConfirmByID(ctx context.Context, id int64, status, note string, userID int64) error {
+	now := time.Now()
+	values := map[string]interface{}{
+		"status":       status,
+		"confirmed_by": userID,
+		"confirm_note": note,
+		"updated_at":   now,
+		"updated_by":   userID,
+	}
+	if status == models.PaymentStatusPaid {
+		values["paid_at"] = now
+	}
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.Payment{}).
+		Where("id = ?", id).
+		Updates(values).Error
+}
+
+func (r *paymentRepository) UpdatePaymentRefundByID(ctx context.Context, id int64, refundedAmount float64, status string, userID int64) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.Payment{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"refunded_amount": refundedAmount,
+			"status":          status,
+			"updated_at":      time.Now(),
+			"updated_by":      userID,
+		}).Error
+}
+
+// CreateWebhookEvent false bila event yang sama sudah pernah dicatat (notifikasi dikirim ulang)
+func (r *paymentRepository) CreateWebhookEvent(ctx context.Context, event *models.PaymentWebhookEvent) (bool, error) {
+	res := r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(event)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
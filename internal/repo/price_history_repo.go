@@ -0,0 +1,175 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PriceHistoryRepository interface {
+	Create(ctx context.Context, history *models.PriceHistory) error
+	GetPriceTimeline(ctx context.Context, productID int64, listStruct *models.GetListStruct) ([]models.PriceHistoryTimeline, int64, error)
+	CreateSchedule(ctx context.Context, schedule *models.PriceSchedule) error
+	GetScheduleByID(ctx context.Context, id int64) (models.PriceSchedule, error)
+	GetListScheduleByProductID(ctx context.Context, productID int64, listStruct *models.GetListStruct) ([]models.PriceSchedule, int64, error)
+	CancelScheduleByID(ctx context.Context, id int64, updatedAt time.Time, userID int64) error
+	GetDueScheduleForUpdate(ctx context.Context, now time.Time) (models.PriceSchedule, error)
+	MarkScheduleApplied(ctx context.Context, id int64, appliedAt time.Time) error
+	MarkScheduleFailed(ctx context.Context, id int64, message string, failedAt time.Time) error
+}
+
+type priceHistoryRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterPriceHistory = map[string]string{
+		"product_varian_id": "product_varian_id",
+		"source":            "source",
+		"status":            "status",
+	}
+	JoinsPriceHistory                   = map[string]string{}
+	PriceHistoryConstraintErrorMessages = map[string]string{}
+)
+
+func NewPriceHistoryRepository(db *gorm.DB) PriceHistoryRepository {
+	return &priceHistoryRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterPriceHistory,
+			Joins:           JoinsPriceHistory,
+			ConstraintError: PriceHistoryConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *priceHistoryRepository) Create(ctx context.Context, history *models.PriceHistory) error {
+	return r.getDB(ctx).WithContext(ctx).Create(history).Error
+}
+
+func (r *priceHistoryRepository) GetPriceTimeline(ctx context.Context, productID int64, listStruct *models.GetListStruct) ([]models.PriceHistoryTimeline, int64, error) {
+	var timeline []models.PriceHistoryTimeline
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.PriceHistory{}).
+		Where("product_id = ?", productID).
+		Scopes(r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.PriceHistory{}).
+		Select("price_history.*, COALESCE(u.name, '') AS changed_by_name").
+		Joins("LEFT JOIN users u ON u.id = price_history.changed_by").
+		Where("price_history.product_id = ?", productID).
+		Scopes(r.applyFilters(listStruct.Filters), r.paginate(listStruct.Page, listStruct.Limit)).
+		Order("price_history.changed_at DESC, price_history.id DESC").
+		Scan(&timeline).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return timeline, total, nil
+}
+
+func (r *priceHistoryRepository) CreateSchedule(ctx context.Context, schedule *models.PriceSchedule) error {
+	return r.getDB(ctx).WithContext(ctx).Create(schedule).Error
+}
+
+func (r *priceHistoryRepository) GetScheduleByID(ctx context.Context, id int64) (models.PriceSchedule, error) {
+	var schedule models.PriceSchedule
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&schedule).Error
+	if err != nil {
+		return models.PriceSchedule{}, err
+	}
+	return schedule, nil
+}
+
+func (r *priceHistoryRepository) GetListScheduleByProductID(ctx context.Context, productID int64, listStruct *models.GetListStruct) ([]models.PriceSchedule, int64, error) {
+	var schedules []models.PriceSchedule
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.PriceSchedule{}).
+		Where("product_id = ?", productID).
+		Scopes(r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.PriceSchedule{}).
+		Where("product_id = ?", productID).
+		Scopes(r.applyFilters(listStruct.Filters), r.paginate(listStruct.Page, listStruct.Limit)).
+		Order("effective_at DESC, id DESC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return schedules, total, nil
+}
+
+// CancelScheduleByID hanya membatalkan jadwal yang masih pending
+func (r *priceHistoryRepository) CancelScheduleByID(ctx context.Context, id int64, updatedAt time.Time, userID int64) error {
+	res := r.getDB(ctx).WithContext(ctx).
+		Model(&models.PriceSchedule{}).
+		Where("id = ? AND updated_at = ? AND status = ?", id, updatedAt, models.PriceScheduleStatusPending).
+		Updates(map[string]interface{}{
+			"status":     models.PriceScheduleStatusCancelled,
+			"updated_at": time.Now(),
+			"updated_by": userID,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetDueScheduleForUpdate mengunci satu jadwal yang sudah jatuh tempo,
+// SKIP LOCKED agar beberapa instance scheduler tidak menerapkan jadwal yang sama
+func (r *priceHistoryRepository) GetDueScheduleForUpdate(ctx context.Context, now time.Time) (models.PriceSchedule, error) {
+	var schedule models.PriceSchedule
+	err := r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND effective_at <= ?", models.PriceScheduleStatusPending, now).
+		Order("effective_at ASC, id ASC").
+		First(&schedule).Error
+	if err != nil {
+		return models.PriceSchedule{}, err
+	}
+	return schedule, nil
+}
+
+func (r *priceHistoryRepository) MarkScheduleApplied(ctx context.Context, id int64, appliedAt time.Time) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.PriceSchedule{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     models.PriceScheduleStatusApplied,
+			"applied_at": appliedAt,
+			"updated_at": appliedAt,
+		}).Error
+}
+
+// MarkScheduleFailed jadwal yang gagal diterapkan tidak diambil lagi oleh scheduler
+func (r *priceHistoryRepository) MarkScheduleFailed(ctx context.Context, id int64, message string, failedAt time.Time) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.PriceSchedule{}).
+		Where("id = ? AND status = ?", id, models.PriceScheduleStatusPending).
+		Updates(map[string]interface{}{
+			"status":        models.PriceScheduleStatusFailed,
+			"error_message": message,
+			"updated_at":    failedAt,
+		}).Error
+}
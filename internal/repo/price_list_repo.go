@@ -0,0 +1,175 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PriceListRepository interface {
+	Create(ctx context.Context, priceList *models.PriceList) error
+	GetPriceListByID(ctx context.Context, id int64) (models.PriceList, error)
+	GetListPriceList(ctx context.Context, listStruct *models.GetListStruct) ([]models.PriceList, int64, error)
+	UpdatePriceListByID(ctx context.Context, id int64, updatedAt time.Time, priceList models.PriceList) (models.PriceList, error)
+	DeletePriceListByID(ctx context.Context, id int64, updatedAt time.Time) error
+	CreateItemBulk(ctx context.Context, items []models.PriceListItem) error
+	DeleteItemByPriceListID(ctx context.Context, priceListID int64) error
+	CreateAssignmentBulk(ctx context.Context, assignments []models.PriceListAssignment) error
+	DeleteAssignmentByPriceListID(ctx context.Context, priceListID int64) error
+	GetApplicableItems(ctx context.Context, pricingCtx models.PricingContext, productIDs []int64) ([]models.PriceListItemCandidate, error)
+}
+
+type priceListRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterPriceList = map[string]string{
+		"code":       "code",
+		"name":       "name",
+		"is_default": "is_default",
+		"is_active":  "is_active",
+	}
+	JoinsPriceList                   = map[string]string{}
+	PriceListConstraintErrorMessages = map[string]string{
+		"unique_price_list_code":             "Kode price list sudah digunakan",
+		"idx_price_list_item_tier":           "Tier harga produk dengan minimal qty yang sama sudah ada",
+		"idx_price_list_assignment_customer": "Pelanggan sudah di-assign ke price list ini",
+		"idx_price_list_assignment_group":    "Grup pelanggan sudah di-assign ke price list ini",
+	}
+)
+
+func NewPriceListRepository(db *gorm.DB) PriceListRepository {
+	return &priceListRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterPriceList,
+			Joins:           JoinsPriceList,
+			ConstraintError: PriceListConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *priceListRepository) Create(ctx context.Context, priceList *models.PriceList) error {
+	return r.getDB(ctx).WithContext(ctx).Omit("Items", "Assignments").Create(priceList).Error
+}
+
+func (r *priceListRepository) GetPriceListByID(ctx context.Context, id int64) (models.PriceList, error) {
+	var priceList models.PriceList
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("product_id ASC, product_varian_id ASC NULLS FIRST, min_qty ASC")
+		}).
+		Preload("Assignments").
+		Where("id = ?", id).
+		First(&priceList).Error
+	if err != nil {
+		return models.PriceList{}, err
+	}
+	return priceList, nil
+}
+
+func (r *priceListRepository) GetListPriceList(ctx context.Context, listStruct *models.GetListStruct) ([]models.PriceList, int64, error) {
+	var priceLists []models.PriceList
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.PriceList{}).
+		Scopes(r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.PriceList{}).
+		Scopes(r.applyFiltersAndPaginationAndOrder(listStruct)).
+		Find(&priceLists).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return priceLists, total, nil
+}
+
+func (r *priceListRepository) UpdatePriceListByID(ctx context.Context, id int64, updatedAt time.Time, priceList models.PriceList) (models.PriceList, error) {
+	db := r.getDB(ctx)
+
+	// pakai Select agar nilai false / nil (is_default, valid_to, ...) ikut tersimpan
+	res := db.WithContext(ctx).
+		Model(&priceList).
+		Select("code", "name", "description", "priority", "is_default", "is_active", "valid_from", "valid_to", "updated_at", "updated_by").
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Updates(&priceList)
+	if res.Error != nil {
+		return models.PriceList{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.PriceList{}, gorm.ErrRecordNotFound
+	}
+	return priceList, nil
+}
+
+func (r *priceListRepository) DeletePriceListByID(ctx context.Context, id int64, updatedAt time.Time) error {
+	db := r.getDB(ctx)
+
+	err := db.WithContext(ctx).
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Delete(&models.PriceList{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *priceListRepository) CreateItemBulk(ctx context.Context, items []models.PriceListItem) error {
+	return r.getDB(ctx).WithContext(ctx).Create(items).Error
+}
+
+func (r *priceListRepository) DeleteItemByPriceListID(ctx context.Context, priceListID int64) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Where("price_list_id = ?", priceListID).
+		Delete(&models.PriceListItem{}).Error
+}
+
+func (r *priceListRepository) CreateAssignmentBulk(ctx context.Context, assignments []models.PriceListAssignment) error {
+	return r.getDB(ctx).WithContext(ctx).Create(assignments).Error
+}
+
+func (r *priceListRepository) DeleteAssignmentByPriceListID(ctx context.Context, priceListID int64) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Where("price_list_id = ?", priceListID).
+		Delete(&models.PriceListAssignment{}).Error
+}
+
+// GetApplicableItems mengambil semua item price list aktif pada waktu At yang berlaku
+// untuk pelanggan (default, assign langsung, atau lewat grup) untuk produk-produk yang diminta
+func (r *priceListRepository) GetApplicableItems(ctx context.Context, pricingCtx models.PricingContext, productIDs []int64) ([]models.PriceListItemCandidate, error) {
+	assigned := r.db.Table("price_list_assignment pla").Select("pla.price_list_id")
+	switch {
+	case pricingCtx.CustomerID != 0 && pricingCtx.CustomerGroupID != nil:
+		assigned = assigned.Where("(pla.customer_id = ? OR pla.customer_group_id = ?)", pricingCtx.CustomerID, *pricingCtx.CustomerGroupID)
+	case pricingCtx.CustomerID != 0:
+		assigned = assigned.Where("pla.customer_id = ?", pricingCtx.CustomerID)
+	default:
+		assigned = assigned.Where("1 = 0")
+	}
+
+	var candidates []models.PriceListItemCandidate
+	err := r.getDB(ctx).WithContext(ctx).
+		Table("price_list_item pli").
+		Select("pli.*, pl.code AS price_list_code, pl.priority AS price_list_priority").
+		Joins("JOIN price_list pl ON pl.id = pli.price_list_id").
+		Where("pli.product_id IN ?", productIDs).
+		Where("pl.is_active = ?", true).
+		Where("(pl.is_default = ? OR pl.id IN (?))", true, assigned).
+		Where("(pl.valid_from IS NULL OR pl.valid_from <= @at) AND (pl.valid_to IS NULL OR pl.valid_to > @at)", map[string]interface{}{"at": pricingCtx.At}).
+		Where("(pli.valid_from IS NULL OR pli.valid_from <= @at) AND (pli.valid_to IS NULL OR pli.valid_to > @at)", map[string]interface{}{"at": pricingCtx.At}).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
@@ -2,19 +2,36 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"math"
 	"pleasurelove/internal/models"
+	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository interface {
 	Create(ctx context.Context, product *models.Product) error
 	GetProductByID(ctx context.Context, id int64) (models.Product, error)
 	GetListProduct(ctx context.Context, listStruct *models.GetListStruct) ([]models.Product, int64, error)
+	ExportListProduct(ctx context.Context, listStruct *models.GetListStruct, fn func([]models.Product) error) error
 	UpdateProductByID(ctx context.Context, id int64, updatedAt time.Time, product models.Product) (models.Product, error)
+	PatchProductByID(ctx context.Context, id int64, updatedAt time.Time, values map[string]interface{}) error
 	DeleteProductByID(ctx context.Context, id int64, updatedAt time.Time) error
 	GetProductByCode(ctx context.Context, code string) (models.Product, error)
+	GetProductByCodes(ctx context.Context, codes []string) ([]models.Product, error)
+	GetProductBySlugs(ctx context.Context, slugs []string) ([]models.Product, error)
+	GetProductByListIDs(ctx context.Context, ids []int64) ([]models.Product, error)
+	GetActiveProductBySlug(ctx context.Context, slug string) (models.Product, error)
+	SearchProduct(ctx context.Context, params models.SearchProductStruct) ([]models.ProductSearchResult, int64, error)
+	GetProductFacets(ctx context.Context, listStruct *models.GetListStruct) (models.ProductFacets, error)
+	GetProductByIDForUpdate(ctx context.Context, id int64) (models.Product, error)
+	UpdateProductPriceByID(ctx context.Context, id int64, price models.PriceValue, userID int64) error
+	UpdateStockByID(ctx context.Context, id int64, delta int) (bool, error)
 }
 
 type productRepository struct {
@@ -23,12 +40,20 @@ type productRepository struct {
 
 var (
 	FilterProduct = map[string]string{
-		"name": "name",
-		"code": "code",
+		"name":        "name",
+		"code":        "code",
+		"category_id": "category_id", // ditangani khusus, termasuk sub kategori
+		"brand":       "brand",
+		"price":       "price",
+		"is_active":   "is_active",
 	}
 	JoinsProduct                   = map[string]string{}
 	ProductConstraintErrorMessages = map[string]string{
 		"unique_product_code": "Kode produk sudah digunakan",
+		"idx_product_slug":    "Slug produk sudah digunakan",
+		"chk_product_stock":   "Stok produk tidak mencukupi",
+
+		"fk_product_bundle_item_component": "Produk masih digunakan sebagai komponen bundle",
 	}
 )
 
@@ -65,9 +90,11 @@ func (r *productRepository) GetListProduct(ctx context.Context, listStruct *mode
 	var products []models.Product
 	var total int64
 
+	listStruct, categoryScope := r.splitCategoryFilter(listStruct)
+
 	err := r.db.WithContext(ctx).
 		Model(&models.Product{}).
-		Scopes(r.applyFilters(listStruct.Filters)).
+		Scopes(categoryScope, r.applyFilters(listStruct.Filters)).
 		Count(&total).Error
 	if err != nil {
 		return nil, 0, err
@@ -75,7 +102,7 @@ func (r *productRepository) GetListProduct(ctx context.Context, listStruct *mode
 
 	err = r.db.WithContext(ctx).
 		Model(&models.Product{}).
-		Scopes(r.applyFiltersAndPaginationAndOrder(listStruct)).
+		Scopes(categoryScope, r.applyFiltersAndPaginationAndOrder(listStruct)).
 		Find(&products).Error
 	if err != nil {
 		return nil, 0, err
@@ -87,28 +114,93 @@ func (r *productRepository) GetListProduct(ctx context.Context, listStruct *mode
 func (r *productRepository) UpdateProductByID(ctx context.Context, id int64, updatedAt time.Time, product models.Product) (models.Product, error) {
 	db := r.getDB(ctx)
 
-	err := db.WithContext(ctx).
+	// Select("*") supaya harga / diskon 0, is_active false dan tax_class_id nil (kembali mengikuti
+	// kategori) tetap tersimpan dalam satu update yang dijaga updated_at. Stok hanya berubah lewat
+	// UpdateStockByID, tipe produk tidak bisa diubah
+	res := db.WithContext(ctx).
 		Model(&product).
+		Select("*").
+		Omit("id", "stock", "product_type", "created_at", "created_by", "ProductCategory").
 		Where("id = ? AND updated_at = ?", id, updatedAt).
-		Updates(product).Error
-	if err != nil {
-		return models.Product{}, err
+		Updates(product)
+	if res.Error != nil {
+		return models.Product{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.Product{}, gorm.ErrRecordNotFound
 	}
 	return product, nil
 }
 
+// PatchProductByID mengubah sebagian kolom (map supaya nilai false / 0 tetap ikut ter-update)
+func (r *productRepository) PatchProductByID(ctx context.Context, id int64, updatedAt time.Time, values map[string]interface{}) error {
+	res := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Product{}).
+		Scopes(r.withCheckScope(ctx)).
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Updates(values)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 func (r *productRepository) DeleteProductByID(ctx context.Context, id int64, updatedAt time.Time) error {
 	db := r.getDB(ctx)
 
-	err := db.WithContext(ctx).
+	res := db.WithContext(ctx).
 		Where("id = ? AND updated_at = ?", id, updatedAt).
-		Delete(&models.Product{}).Error
-	if err != nil {
-		return err
+		Delete(&models.Product{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
 	return nil
 }
 
+// GetProductByIDForUpdate mengunci baris produk sampai transaksi selesai (dipakai saat ubah harga)
+func (r *productRepository) GetProductByIDForUpdate(ctx context.Context, id int64) (models.Product, error) {
+	var product models.Product
+	err := r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&product).Error
+	if err != nil {
+		return models.Product{}, err
+	}
+	return product, nil
+}
+
+func (r *productRepository) UpdateProductPriceByID(ctx context.Context, id int64, price models.PriceValue, userID int64) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"price":      price.Price,
+			"cost_price": price.CostPrice,
+			"discount":   price.Discount,
+			"updated_at": time.Now(),
+			"updated_by": userID,
+		}).Error
+}
+
+// UpdateStockByID menambah / mengurangi stok secara atomik, false bila stok tidak mencukupi
+func (r *productRepository) UpdateStockByID(ctx context.Context, id int64, delta int) (bool, error) {
+	res := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Product{}).
+		Where("id = ? AND product_type = ? AND stock + ? >= 0", id, models.ProductTypeSingle, delta).
+		UpdateColumn("stock", gorm.Expr("stock + ?", delta))
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
 func (r *productRepository) GetProductByCode(ctx context.Context, code string) (models.Product, error) {
 	var product models.Product
 	err := r.db.WithContext(ctx).
@@ -119,3 +211,452 @@ func (r *productRepository) GetProductByCode(ctx context.Context, code string) (
 	}
 	return product, nil
 }
+
+func (r *productRepository) GetProductByListIDs(ctx context.Context, ids []int64) ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.WithContext(ctx).
+		Scopes(r.withCheckScope(ctx)).
+		Where("id IN ?", ids).
+		Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// splitCategoryFilter memisahkan filter category_id dan atribut (attr.<code>) dari filter biasa
+// karena filter kategori harus mencakup seluruh sub kategori (berdasarkan path) dan
+// filter atribut dicocokkan ke tabel nilai atribut produk / varian
+func (r *productRepository) splitCategoryFilter(listStruct *models.GetListStruct) (*models.GetListStruct, func(*gorm.DB) *gorm.DB) {
+	filters := make(map[string][2]interface{}, len(listStruct.Filters))
+	categoryFilters := make(map[string][2]interface{})
+	attrFilters := make(map[string][2]interface{})
+	for k, v := range listStruct.Filters {
+		field := models.FilterField(k)
+		switch {
+		case field == "category_id":
+			categoryFilters[k] = v
+		case strings.HasPrefix(field, models.AttributeFilterPrefix):
+			attrFilters[k] = v
+		default:
+			filters[k] = v
+		}
+	}
+
+	params := *listStruct
+	params.Filters = filters
+
+	return &params, func(db *gorm.DB) *gorm.DB {
+		for _, v := range categoryFilters {
+			subQuery := r.db.Table("product_categories pc").
+				Select("pc.product_id").
+				Joins("JOIN categories c ON c.id = pc.categories_id").
+				Joins("JOIN categories p ON c.path LIKE p.path || '%'").
+				Where("p.id IN ?", filterValueToInt64s(v[1]))
+
+			db = db.Where("product.id IN (?)", subQuery)
+		}
+
+		for k, v := range attrFilters {
+			code := strings.TrimPrefix(models.FilterField(k), models.AttributeFilterPrefix)
+			db = db.Where("product.id IN (?)", r.attributeFilterQuery(code, v[0].(string), v[1]))
+		}
+		return db
+	}
+}
+
+// attributeFilterQuery sub query produk yang (produk atau salah satu variannya) memiliki nilai atribut sesuai filter.
+// =, != dan IN dicocokkan ke value_text, <, >, <=, >= ke value_number, LIKE ke value_text tanpa case
+func (r *productRepository) attributeFilterQuery(code, operator string, value interface{}) *gorm.DB {
+	query := r.db.Table("product_attribute_value pav").
+		Select("pav.product_id").
+		Joins("JOIN attribute_definition ad ON ad.id = pav.attribute_id").
+		Where("ad.code = ?", code)
+
+	switch operator {
+	case "IN":
+		return query.Where("pav.value_text IN ?", value)
+	case "<", ">", "<=", ">=":
+		return query.Where("pav.value_number "+operator+" ?", value)
+	case "LIKE":
+		return query.Where("pav.value_text ILIKE ?", value)
+	case "!=":
+		return query.Where("pav.value_text <> ?", filterValueToText(value))
+	default:
+		return query.Where("pav.value_text = ?", filterValueToText(value))
+	}
+}
+
+// filterValueToText menyamakan nilai filter hasil parsing query (int, float, bool) dengan bentuk kanonik value_text
+func filterValueToText(value interface{}) string {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func filterValueToInt64s(value interface{}) []int64 {
+	var ids []int64
+	switch v := value.(type) {
+	case int:
+		ids = append(ids, int64(v))
+	case int64:
+		ids = append(ids, v)
+	case []string:
+		for _, s := range v {
+			id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err == nil {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func (r *productRepository) GetActiveProductBySlug(ctx context.Context, slug string) (models.Product, error) {
+	var product models.Product
+	err := r.db.WithContext(ctx).
+		Preload("ProductCategory").
+		Preload("ProductCategory.Category").
+		Where("slug = ? AND is_active = ?", slug, true).
+		First(&product).Error
+	if err != nil {
+		return models.Product{}, err
+	}
+	return product, nil
+}
+
+const (
+	searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	searchSnippetOptions  = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
+)
+
+// searchEscapeHTML meng-escape teks sumber sebelum ts_headline supaya hasilnya aman dirender sebagai HTML,
+// satu-satunya markup di hasil adalah tag <mark> dari ts_headline
+func searchEscapeHTML(expr string) string {
+	return "replace(replace(replace(replace(replace(" + expr +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+}
+
+// searchMatchScope kondisi pencarian: full-text (stemming id + en) atau trigram untuk toleransi typo
+func (r *productRepository) searchMatchScope(params models.SearchProductStruct) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("(product.search_vector @@ (websearch_to_tsquery('indonesian', @q) || websearch_to_tsquery('english', @q)) OR product.name % @q OR @q <% product.name)",
+			sql.Named("q", params.Query))
+
+		if params.ActiveOnly {
+			db = db.Where("product.is_active = ?", true)
+		}
+		return db
+	}
+}
+
+func (r *productRepository) SearchProduct(ctx context.Context, params models.SearchProductStruct) ([]models.ProductSearchResult, int64, error) {
+	var (
+		results []models.ProductSearchResult
+		total   int64
+	)
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Product{}).
+		Scopes(r.withCheckScope(ctx), r.searchMatchScope(params)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// rank gabungan: skor full-text + kemiripan trigram nama
+	err = r.db.WithContext(ctx).
+		Model(&models.Product{}).
+		Select(`product.*,
+			ts_rank_cd(product.search_vector, websearch_to_tsquery('indonesian', @q) || websearch_to_tsquery('english', @q)) + word_similarity(@q, product.name) AS rank,
+			ts_headline('indonesian', `+searchEscapeHTML("product.name")+`, websearch_to_tsquery('indonesian', @q) || websearch_to_tsquery('english', @q), @headline) AS name_highlight,
+			ts_headline('indonesian', `+searchEscapeHTML("coalesce(product.description, '')")+`, websearch_to_tsquery('indonesian', @q) || websearch_to_tsquery('english', @q), @snippet) AS snippet`,
+			sql.Named("q", params.Query),
+			sql.Named("headline", searchHeadlineOptions),
+			sql.Named("snippet", searchSnippetOptions)).
+		Scopes(r.withCheckScope(ctx), r.searchMatchScope(params), r.paginate(params.Page, params.Limit)).
+		Order("rank DESC, product.id DESC").
+		Find(&results).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+const (
+	facetMaxBuckets      = 20
+	facetPriceBucketSize = 5
+)
+
+// facetScope menerapkan semua filter aktif kecuali filter milik facet itu sendiri,
+// sehingga opsi lain pada facet yang sama tetap terlihat beserta jumlahnya
+func (r *productRepository) facetScope(listStruct *models.GetListStruct, exclude string) func(db *gorm.DB) *gorm.DB {
+	filters := make(map[string][2]interface{}, len(listStruct.Filters))
+	for k, v := range listStruct.Filters {
+		if models.FilterField(k) != exclude {
+			filters[k] = v
+		}
+	}
+
+	params := *listStruct
+	params.Filters = filters
+
+	return func(db *gorm.DB) *gorm.DB {
+		filtered, categoryScope := r.splitCategoryFilter(&params)
+		return db.Scopes(categoryScope, r.applyFilters(filtered.Filters))
+	}
+}
+
+func (r *productRepository) GetProductFacets(ctx context.Context, listStruct *models.GetListStruct) (models.ProductFacets, error) {
+	var (
+		facets models.ProductFacets
+		err    error
+	)
+
+	facets.Brands, err = r.getBrandFacet(ctx, listStruct)
+	if err != nil {
+		return models.ProductFacets{}, err
+	}
+
+	facets.Categories, err = r.getCategoryFacet(ctx, listStruct)
+	if err != nil {
+		return models.ProductFacets{}, err
+	}
+
+	facets.Prices, err = r.getPriceFacet(ctx, listStruct)
+	if err != nil {
+		return models.ProductFacets{}, err
+	}
+
+	facets.Attributes, err = r.getAttributeFacet(ctx, listStruct)
+	if err != nil {
+		return models.ProductFacets{}, err
+	}
+
+	return facets, nil
+}
+
+func (r *productRepository) getBrandFacet(ctx context.Context, listStruct *models.GetListStruct) ([]models.FacetBucket, error) {
+	buckets := []models.FacetBucket{}
+	err := r.db.WithContext(ctx).
+		Model(&models.Product{}).
+		Scopes(r.facetScope(listStruct, "brand")).
+		Select("brand AS value, brand AS label, COUNT(*) AS count").
+		Where("brand <> ''").
+		Group("brand").
+		Order("count DESC, brand ASC").
+		Limit(facetMaxBuckets).
+		Scan(&buckets).Error
+	if err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+func (r *productRepository) getCategoryFacet(ctx context.Context, listStruct *models.GetListStruct) ([]models.FacetBucket, error) {
+	// filter produk dijalankan sebagai sub query agar kolom filter (name, ...) tidak ambigu dengan categories
+	subQuery := r.db.Model(&models.Product{}).
+		Select("product.id").
+		Scopes(r.facetScope(listStruct, "category_id"))
+
+	buckets := []models.FacetBucket{}
+	err := r.db.WithContext(ctx).
+		Table("product_categories pc").
+		Select("c.id::text AS value, c.name AS label, COUNT(DISTINCT pc.product_id) AS count").
+		Joins("JOIN categories c ON c.id = pc.categories_id").
+		Where("pc.product_id IN (?)", subQuery).
+		Group("c.id, c.name").
+		Order("count DESC, c.name ASC").
+		Limit(facetMaxBuckets).
+		Scan(&buckets).Error
+	if err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+type attributeFacetRow struct {
+	Code  string
+	Name  string
+	Unit  string
+	Value string
+	Count int64
+}
+
+// getAttributeFacet nilai atribut yang bisa difilter beserta jumlah produk. Atribut yang sedang
+// difilter dihitung ulang tanpa filternya sendiri agar opsi lain tetap terlihat
+func (r *productRepository) getAttributeFacet(ctx context.Context, listStruct *models.GetListStruct) ([]models.AttributeFacet, error) {
+	rows, err := r.getAttributeFacetRows(ctx, listStruct, "")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for key := range listStruct.Filters {
+		field := models.FilterField(key)
+		if !strings.HasPrefix(field, models.AttributeFilterPrefix) || seen[field] {
+			continue
+		}
+		seen[field] = true
+
+		code := strings.TrimPrefix(field, models.AttributeFilterPrefix)
+		codeRows, err := r.getAttributeFacetRows(ctx, listStruct, code)
+		if err != nil {
+			return nil, err
+		}
+
+		filtered := rows[:0]
+		for _, row := range rows {
+			if row.Code != code {
+				filtered = append(filtered, row)
+			}
+		}
+		rows = append(filtered, codeRows...)
+	}
+
+	facets := []models.AttributeFacet{}
+	index := make(map[string]int)
+	for _, row := range rows {
+		i, ok := index[row.Code]
+		if !ok {
+			i = len(facets)
+			index[row.Code] = i
+			facets = append(facets, models.AttributeFacet{Code: row.Code, Name: row.Name, Unit: row.Unit, Buckets: []models.FacetBucket{}})
+		}
+		if len(facets[i].Buckets) < facetMaxBuckets {
+			facets[i].Buckets = append(facets[i].Buckets, models.FacetBucket{Value: row.Value, Label: row.Value, Count: row.Count})
+		}
+	}
+	return facets, nil
+}
+
+// getAttributeFacetRows code kosong = semua atribut dengan semua filter aktif,
+// code terisi = hanya atribut itu dengan filter atribut itu sendiri diabaikan
+func (r *productRepository) getAttributeFacetRows(ctx context.Context, listStruct *models.GetListStruct, code string) ([]attributeFacetRow, error) {
+	exclude := ""
+	if code != "" {
+		exclude = models.AttributeFilterPrefix + code
+	}
+
+	subQuery := r.db.Model(&models.Product{}).
+		Select("product.id").
+		Scopes(r.facetScope(listStruct, exclude))
+
+	query := r.db.WithContext(ctx).
+		Table("product_attribute_value pav").
+		Select("ad.code, MAX(ad.name) AS name, MAX(COALESCE(ad.unit, '')) AS unit, pav.value_text AS value, COUNT(DISTINCT pav.product_id) AS count").
+		Joins("JOIN attribute_definition ad ON ad.id = pav.attribute_id").
+		Where("ad.is_filterable = ? AND pav.product_id IN (?)", true, subQuery)
+	if code != "" {
+		query = query.Where("ad.code = ?", code)
+	}
+
+	var rows []attributeFacetRow
+	err := query.
+		Group("ad.code, pav.value_text").
+		Order("ad.code ASC, count DESC, pav.value_text ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *productRepository) getPriceFacet(ctx context.Context, listStruct *models.GetListStruct) ([]models.PriceBucket, error) {
+	var bounds struct {
+		Min float64
+		Max float64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.Product{}).
+		Scopes(r.facetScope(listStruct, "price")).
+		Select("COALESCE(MIN(price), 0) AS min, COALESCE(MAX(price), 0) AS max").
+		Scan(&bounds).Error
+	if err != nil {
+		return nil, err
+	}
+
+	step := priceFacetStep(bounds.Min, bounds.Max)
+
+	var rows []struct {
+		Bucket int64
+		Count  int64
+	}
+	err = r.db.WithContext(ctx).
+		Model(&models.Product{}).
+		Scopes(r.facetScope(listStruct, "price")).
+		Select("FLOOR(price / ?)::bigint AS bucket, COUNT(*) AS count", step).
+		Group("bucket").
+		Order("bucket ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]models.PriceBucket, 0, len(rows))
+	for _, row := range rows {
+		buckets = append(buckets, models.PriceBucket{
+			Min:   float64(row.Bucket) * step,
+			Max:   float64(row.Bucket+1) * step,
+			Count: row.Count,
+		})
+	}
+	return buckets, nil
+}
+
+// priceFacetStep membulatkan lebar rentang harga ke angka 1, 2, 5 x 10^n
+// supaya batas histogram mudah dibaca (mis. 0 - 50.000, 50.000 - 100.000)
+func priceFacetStep(min, max float64) float64 {
+	raw := (max - min) / facetPriceBucketSize
+	if raw <= 0 {
+		raw = math.Max(max, 1)
+	}
+
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 5} {
+		if raw <= m*magnitude {
+			return m * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+func (r *productRepository) GetProductByCodes(ctx context.Context, codes []string) ([]models.Product, error) {
+	var products []models.Product
+	err := r.getDB(ctx).WithContext(ctx).
+		Where("code IN ?", codes).
+		Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *productRepository) GetProductBySlugs(ctx context.Context, slugs []string) ([]models.Product, error) {
+	var products []models.Product
+	err := r.getDB(ctx).WithContext(ctx).
+		Where("slug IN ?", slugs).
+		Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *productRepository) ExportListProduct(ctx context.Context, listStruct *models.GetListStruct, fn func([]models.Product) error) error {
+	listStruct, categoryScope := r.splitCategoryFilter(listStruct)
+
+	db := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Product{}).
+		Scopes(r.withCheckScope(ctx), categoryScope, r.applyFilters(listStruct.Filters))
+
+	return findInBatches(&r.AbstractRepo, db, listStruct, fn)
+}
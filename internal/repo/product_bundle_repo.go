@@ -0,0 +1,68 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ProductBundleRepository interface {
+	CreateBulk(ctx context.Context, items []models.ProductBundleItem) error
+	GetBundleItemByBundleProductIDs(ctx context.Context, bundleProductIDs []int64) ([]models.ProductBundleItem, error)
+	DeleteBundleItemByBundleProductID(ctx context.Context, bundleProductID int64) error
+}
+
+type productBundleRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterProductBundle = map[string]string{
+		"bundle_product_id":    "bundle_product_id",
+		"component_product_id": "component_product_id",
+	}
+	JoinsProductBundle                   = map[string]string{}
+	ProductBundleConstraintErrorMessages = map[string]string{
+		"idx_product_bundle_item_component": "Komponen bundle tidak boleh duplikat",
+		"fk_product_bundle_item_component":  "Produk masih digunakan sebagai komponen bundle",
+		"fk_product_bundle_item_varian":     "Varian masih digunakan sebagai komponen bundle",
+		"chk_product_bundle_item_self":      "Bundle tidak boleh berisi dirinya sendiri",
+	}
+)
+
+func NewProductBundleRepository(db *gorm.DB) ProductBundleRepository {
+	return &productBundleRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterProductBundle,
+			Joins:           JoinsProductBundle,
+			ConstraintError: ProductBundleConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *productBundleRepository) CreateBulk(ctx context.Context, items []models.ProductBundleItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return r.getDB(ctx).WithContext(ctx).Create(&items).Error
+}
+
+func (r *productBundleRepository) GetBundleItemByBundleProductIDs(ctx context.Context, bundleProductIDs []int64) ([]models.ProductBundleItem, error) {
+	var items []models.ProductBundleItem
+	err := r.getDB(ctx).WithContext(ctx).
+		Where("bundle_product_id IN ?", bundleProductIDs).
+		Order("id ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *productBundleRepository) DeleteBundleItemByBundleProductID(ctx context.Context, bundleProductID int64) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Where("bundle_product_id = ?", bundleProductID).
+		Delete(&models.ProductBundleItem{}).Error
+}
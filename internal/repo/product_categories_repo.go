@@ -5,12 +5,16 @@ import (
 	"pleasurelove/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductCategoryRepository interface {
 	CreateBulk(ctx context.Context, pc []models.ProductCategory) error
 	GetProductCategoryByProductID(ctx context.Context, productID int64) ([]models.ProductCategory, error)
+	CreateBulkIgnoreExisting(ctx context.Context, pc []models.ProductCategory) error
 	DeleteProductCategoryByProductID(ctx context.Context, productID int64) error
+	DeleteProductCategoryByCategoryIDs(ctx context.Context, productID int64, categoryIDs []int64) error
+	GetCategoryPathByProductIDs(ctx context.Context, productIDs []int64) ([]models.ProductCategoryPath, error)
 }
 
 type productCategoryRepository struct {
@@ -43,6 +47,11 @@ func (r *productCategoryRepository) CreateBulk(ctx context.Context, pc []models.
 	return r.getDB(ctx).WithContext(ctx).Create(pc).Error
 }
 
+// CreateBulkIgnoreExisting kategori yang sudah terpasang di produk dilewati
+func (r *productCategoryRepository) CreateBulkIgnoreExisting(ctx context.Context, pc []models.ProductCategory) error {
+	return r.getDB(ctx).WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(pc).Error
+}
+
 func (r *productCategoryRepository) GetProductCategoryByProductID(ctx context.Context, productID int64) ([]models.ProductCategory, error) {
 	var pcs []models.ProductCategory
 	err := r.db.WithContext(ctx).
@@ -59,3 +68,23 @@ func (r *productCategoryRepository) DeleteProductCategoryByProductID(ctx context
 		Where("product_id = ?", productID).
 		Delete(&models.ProductCategory{}).Error
 }
+
+func (r *productCategoryRepository) DeleteProductCategoryByCategoryIDs(ctx context.Context, productID int64, categoryIDs []int64) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Where("product_id = ? AND categories_id IN ?", productID, categoryIDs).
+		Delete(&models.ProductCategory{}).Error
+}
+
+func (r *productCategoryRepository) GetCategoryPathByProductIDs(ctx context.Context, productIDs []int64) ([]models.ProductCategoryPath, error) {
+	var paths []models.ProductCategoryPath
+	err := r.db.WithContext(ctx).
+		Table("product_categories pc").
+		Select("pc.product_id, c.path").
+		Joins("JOIN categories c ON c.id = pc.categories_id").
+		Where("pc.product_id IN ?", productIDs).
+		Scan(&paths).Error
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
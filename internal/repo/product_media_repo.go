@@ -0,0 +1,132 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ProductMediaRepository interface {
+	Create(ctx context.Context, media *models.ProductMedia) error
+	GetMediaByID(ctx context.Context, id int64) (models.ProductMedia, error)
+	GetMediaByProductIDs(ctx context.Context, productIDs []int64) ([]models.ProductMedia, error)
+	GetPrimaryMediaByProductIDs(ctx context.Context, productIDs []int64) ([]models.ProductMedia, error)
+	UpdateMediaByID(ctx context.Context, id int64, updatedAt time.Time, media models.ProductMedia) (models.ProductMedia, error)
+	DeleteMediaByID(ctx context.Context, id int64, updatedAt time.Time) error
+	UnsetPrimaryByProductID(ctx context.Context, productID int64) error
+	SetFirstMediaAsPrimary(ctx context.Context, productID int64) error
+}
+
+type productMediaRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterProductMedia = map[string]string{
+		"product_id": "product_id",
+		"is_primary": "is_primary",
+	}
+	JoinsProductMedia                   = map[string]string{}
+	ProductMediaConstraintErrorMessages = map[string]string{
+		"idx_product_media_primary":        "Produk hanya boleh memiliki satu gambar utama",
+		"unique_product_media_storage_key": "File gambar sudah terdaftar",
+	}
+)
+
+func NewProductMediaRepository(db *gorm.DB) ProductMediaRepository {
+	return &productMediaRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterProductMedia,
+			Joins:           JoinsProductMedia,
+			ConstraintError: ProductMediaConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *productMediaRepository) Create(ctx context.Context, media *models.ProductMedia) error {
+	return r.getDB(ctx).WithContext(ctx).Create(media).Error
+}
+
+func (r *productMediaRepository) GetMediaByID(ctx context.Context, id int64) (models.ProductMedia, error) {
+	var media models.ProductMedia
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&media).Error
+	if err != nil {
+		return models.ProductMedia{}, err
+	}
+	return media, nil
+}
+
+// GetMediaByProductIDs urutan tampil: gambar utama, sort_order, lalu urutan upload
+func (r *productMediaRepository) GetMediaByProductIDs(ctx context.Context, productIDs []int64) ([]models.ProductMedia, error) {
+	var media []models.ProductMedia
+	err := r.getDB(ctx).WithContext(ctx).
+		Where("product_id IN ?", productIDs).
+		Order("product_id ASC, is_primary DESC, sort_order ASC, id ASC").
+		Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (r *productMediaRepository) GetPrimaryMediaByProductIDs(ctx context.Context, productIDs []int64) ([]models.ProductMedia, error) {
+	var media []models.ProductMedia
+	err := r.db.WithContext(ctx).
+		Where("product_id IN ? AND is_primary", productIDs).
+		Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (r *productMediaRepository) UpdateMediaByID(ctx context.Context, id int64, updatedAt time.Time, media models.ProductMedia) (models.ProductMedia, error) {
+	db := r.getDB(ctx)
+
+	res := db.WithContext(ctx).
+		Model(&media).
+		Select("alt_text", "sort_order", "is_primary", "updated_at", "updated_by").
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Updates(&media)
+	if res.Error != nil {
+		return models.ProductMedia{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.ProductMedia{}, gorm.ErrRecordNotFound
+	}
+	return media, nil
+}
+
+func (r *productMediaRepository) DeleteMediaByID(ctx context.Context, id int64, updatedAt time.Time) error {
+	res := r.getDB(ctx).WithContext(ctx).
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Delete(&models.ProductMedia{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *productMediaRepository) UnsetPrimaryByProductID(ctx context.Context, productID int64) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.ProductMedia{}).
+		Where("product_id = ? AND is_primary", productID).
+		Update("is_primary", false).Error
+}
+
+// SetFirstMediaAsPrimary menjadikan gambar pertama sebagai gambar utama bila produk belum punya
+func (r *productMediaRepository) SetFirstMediaAsPrimary(ctx context.Context, productID int64) error {
+	return r.getDB(ctx).WithContext(ctx).Exec(`
+		UPDATE product_media SET is_primary = TRUE
+		WHERE id = (
+			SELECT id FROM product_media WHERE product_id = ? ORDER BY sort_order ASC, id ASC LIMIT 1
+		) AND NOT EXISTS (
+			SELECT 1 FROM product_media WHERE product_id = ? AND is_primary
+		)`, productID, productID).Error
+}
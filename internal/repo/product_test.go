@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestGetListProductPriceRange(t *testing.T) {
+	db, queries := newRecordingDB(t)
+	r := NewProductRepository(db)
+	listStruct := &models.GetListStruct{
+		Filters: map[string][2]interface{}{
+			"price__gte": {">=", 50000},
+			"price__lte": {"<=", 150000},
+			"brand":      {"=", "Kaos"},
+		},
+		Page:    1,
+		Limit:   10,
+		OrderBy: "id",
+		SortBy:  "ASC",
+	}
+
+	if _, _, err := r.GetListProduct(context.Background(), listStruct); err != nil {
+		t.Fatalf("GetListProduct: %v", err)
+	}
+	for _, q := range *queries {
+		if !strings.Contains(q.sql, "price >= $") || !strings.Contains(q.sql, "price <= $") {
+			t.Errorf("list harus memakai kedua batas harga:\n%s", q.sql)
+		}
+		if !hasVar(q, 50000) || !hasVar(q, 150000) {
+			t.Errorf("argumen batas harga tidak lengkap: %v", q.vars)
+		}
+	}
+
+	*queries = nil
+	if _, err := r.GetProductFacets(context.Background(), listStruct); err != nil {
+		t.Fatalf("GetProductFacets: %v", err)
+	}
+
+	var priceQueries int
+	for _, q := range *queries {
+		if !strings.Contains(q.sql, "MIN(price)") && !strings.Contains(q.sql, "FLOOR(price") {
+			// facet lain tetap memakai rentang harga
+			if !strings.Contains(q.sql, "price >= $") || !strings.Contains(q.sql, "price <= $") {
+				t.Errorf("facet selain harga harus memakai rentang harga:\n%s", q.sql)
+			}
+			continue
+		}
+
+		// histogram harga mengabaikan filter harganya sendiri, filter lain tetap berlaku
+		priceQueries++
+		if strings.Contains(q.sql, "price >=") || strings.Contains(q.sql, "price <=") {
+			t.Errorf("histogram harga tidak boleh memakai filter harga:\n%s", q.sql)
+		}
+		if !strings.Contains(q.sql, "brand = $") {
+			t.Errorf("histogram harga harus tetap memakai filter brand:\n%s", q.sql)
+		}
+	}
+	if priceQueries != 2 {
+		t.Errorf("query histogram harga = %d, want 2", priceQueries)
+	}
+}
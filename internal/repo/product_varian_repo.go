@@ -0,0 +1,167 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductVarianRepository interface {
+	Create(ctx context.Context, varian *models.ProductVarian) error
+	GetProductVarianByID(ctx context.Context, id int64) (models.ProductVarian, error)
+	GetProductVarianByListIDs(ctx context.Context, ids []int64) ([]models.ProductVarian, error)
+	GetProductVarianByProductID(ctx context.Context, productID int64) ([]models.ProductVarian, error)
+	GetProductVarianByCodes(ctx context.Context, codes []string) ([]models.ProductVarian, error)
+	UpdateProductVarianByID(ctx context.Context, varian models.ProductVarian) error
+	GetProductVarianByIDForUpdate(ctx context.Context, id int64) (models.ProductVarian, error)
+	UpdateProductVarianPriceByID(ctx context.Context, id int64, price models.PriceValue, userID int64) error
+	UpdateStockByID(ctx context.Context, id int64, delta int) (bool, error)
+}
+
+type productVarianRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterProductVarian = map[string]string{
+		"name":       "name",
+		"code":       "code",
+		"product_id": "product_id",
+	}
+	JoinsProductVarian                   = map[string]string{}
+	ProductVarianConstraintErrorMessages = map[string]string{
+		"unique_product_varian_code": "Kode varian sudah digunakan",
+		"product_varian_code_key":    "Kode varian sudah digunakan",
+		"product_varian_barcode_key": "Barcode varian sudah digunakan",
+	}
+)
+
+func NewProductVarianRepository(db *gorm.DB) ProductVarianRepository {
+	return &productVarianRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterProductVarian,
+			Joins:           JoinsProductVarian,
+			ConstraintError: ProductVarianConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *productVarianRepository) GetProductVarianByID(ctx context.Context, id int64) (models.ProductVarian, error) {
+	var varian models.ProductVarian
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&varian).Error
+	if err != nil {
+		return models.ProductVarian{}, err
+	}
+	return varian, nil
+}
+
+func (r *productVarianRepository) GetProductVarianByListIDs(ctx context.Context, ids []int64) ([]models.ProductVarian, error) {
+	var varians []models.ProductVarian
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&varians).Error
+	if err != nil {
+		return nil, err
+	}
+	return varians, nil
+}
+
+func (r *productVarianRepository) GetProductVarianByProductID(ctx context.Context, productID int64) ([]models.ProductVarian, error) {
+	var varians []models.ProductVarian
+	err := r.db.WithContext(ctx).
+		Where("product_id = ?", productID).
+		Find(&varians).Error
+	if err != nil {
+		return nil, err
+	}
+	return varians, nil
+}
+
+func (r *productVarianRepository) GetProductVarianByIDForUpdate(ctx context.Context, id int64) (models.ProductVarian, error) {
+	var varian models.ProductVarian
+	err := r.getDB(ctx).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&varian).Error
+	if err != nil {
+		return models.ProductVarian{}, err
+	}
+	return varian, nil
+}
+
+func (r *productVarianRepository) UpdateProductVarianPriceByID(ctx context.Context, id int64, price models.PriceValue, userID int64) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.ProductVarian{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"price":      price.Price,
+			"cost_price": price.CostPrice,
+			"discount":   price.Discount,
+			"updated_at": time.Now(),
+			"updated_by": userID,
+		}).Error
+}
+
+// UpdateStockByID menambah / mengurangi stok varian secara atomik, false bila stok tidak mencukupi
+func (r *productVarianRepository) UpdateStockByID(ctx context.Context, id int64, delta int) (bool, error) {
+	res := r.getDB(ctx).WithContext(ctx).
+		Model(&models.ProductVarian{}).
+		Where("id = ? AND stock + ? >= 0", id, delta).
+		UpdateColumn("stock", gorm.Expr("stock + ?", delta))
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// Create barcode kosong disimpan NULL karena kolom barcode unik
+func (r *productVarianRepository) Create(ctx context.Context, varian *models.ProductVarian) error {
+	db := r.getDB(ctx).WithContext(ctx)
+	if varian.Barcode == "" {
+		db = db.Omit("barcode")
+	}
+	return db.Create(varian).Error
+}
+
+func (r *productVarianRepository) GetProductVarianByCodes(ctx context.Context, codes []string) ([]models.ProductVarian, error) {
+	var varians []models.ProductVarian
+	err := r.getDB(ctx).WithContext(ctx).
+		Where("code IN ?", codes).
+		Find(&varians).Error
+	if err != nil {
+		return nil, err
+	}
+	return varians, nil
+}
+
+// UpdateProductVarianByID stok tidak ikut diubah, stok hanya berubah lewat UpdateStockByID
+func (r *productVarianRepository) UpdateProductVarianByID(ctx context.Context, varian models.ProductVarian) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.ProductVarian{}).
+		Where("id = ?", varian.ID).
+		Updates(map[string]interface{}{
+			"name":        varian.Name,
+			"barcode":     nullableString(varian.Barcode),
+			"price":       varian.Price,
+			"cost_price":  varian.CostPrice,
+			"discount":    varian.Discount,
+			"is_active":   varian.IsActive,
+			"weight_gram": varian.WeightGram,
+			"length_cm":   varian.LengthCm,
+			"width_cm":    varian.WidthCm,
+			"height_cm":   varian.HeightCm,
+			"updated_at":  varian.UpdatedAt,
+			"updated_by":  varian.UpdatedBy,
+		}).Error
+}
+
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
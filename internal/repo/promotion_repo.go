@@ -0,0 +1,169 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *models.Promotion) error
+	GetPromotionByID(ctx context.Context, id int64) (models.Promotion, error)
+	GetListPromotion(ctx context.Context, listStruct *models.GetListStruct) ([]models.Promotion, int64, error)
+	UpdatePromotionByID(ctx context.Context, id int64, updatedAt time.Time, promotion models.Promotion) (models.Promotion, error)
+	DeletePromotionByID(ctx context.Context, id int64, updatedAt time.Time) error
+	GetActivePromotions(ctx context.Context, at time.Time) ([]models.Promotion, error)
+	CountUsageByCustomerID(ctx context.Context, customerID int64, promotionIDs []int64) (map[int64]int, error)
+	IncrementUsageCount(ctx context.Context, id int64) (bool, error)
+	CreateUsage(ctx context.Context, usage *models.PromotionUsage) error
+}
+
+type promotionRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterPromotion = map[string]string{
+		"code":      "code",
+		"name":      "name",
+		"stackable": "stackable",
+		"is_active": "is_active",
+	}
+	JoinsPromotion                   = map[string]string{}
+	PromotionConstraintErrorMessages = map[string]string{
+		"unique_promotion_code": "Kode promosi sudah digunakan",
+	}
+)
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &promotionRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterPromotion,
+			Joins:           JoinsPromotion,
+			ConstraintError: PromotionConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion *models.Promotion) error {
+	return r.getDB(ctx).WithContext(ctx).Omit("usage_count").Create(promotion).Error
+}
+
+func (r *promotionRepository) GetPromotionByID(ctx context.Context, id int64) (models.Promotion, error) {
+	var promotion models.Promotion
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&promotion).Error
+	if err != nil {
+		return models.Promotion{}, err
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepository) GetListPromotion(ctx context.Context, listStruct *models.GetListStruct) ([]models.Promotion, int64, error) {
+	var promotions []models.Promotion
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Promotion{}).
+		Scopes(r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.Promotion{}).
+		Scopes(r.applyFiltersAndPaginationAndOrder(listStruct)).
+		Find(&promotions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return promotions, total, nil
+}
+
+func (r *promotionRepository) UpdatePromotionByID(ctx context.Context, id int64, updatedAt time.Time, promotion models.Promotion) (models.Promotion, error) {
+	db := r.getDB(ctx)
+
+	// usage_count tidak ikut diubah, hanya bertambah lewat IncrementUsageCount
+	res := db.WithContext(ctx).
+		Model(&promotion).
+		Select("code", "name", "description", "priority", "stackable", "is_active", "starts_at", "ends_at",
+			"usage_limit", "per_customer_limit", "conditions", "actions", "updated_at", "updated_by").
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Updates(&promotion)
+	if res.Error != nil {
+		return models.Promotion{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.Promotion{}, gorm.ErrRecordNotFound
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepository) DeletePromotionByID(ctx context.Context, id int64, updatedAt time.Time) error {
+	db := r.getDB(ctx)
+
+	err := db.WithContext(ctx).
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Delete(&models.Promotion{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetActivePromotions mengambil promosi aktif yang periodenya mencakup waktu at
+func (r *promotionRepository) GetActivePromotions(ctx context.Context, at time.Time) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Where("(starts_at IS NULL OR starts_at <= ?)", at).
+		Where("(ends_at IS NULL OR ends_at > ?)", at).
+		Order("priority DESC, id ASC").
+		Find(&promotions).Error
+	if err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (r *promotionRepository) CountUsageByCustomerID(ctx context.Context, customerID int64, promotionIDs []int64) (map[int64]int, error) {
+	var rows []struct {
+		PromotionID int64
+		Total       int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.PromotionUsage{}).
+		Select("promotion_id, COUNT(*) AS total").
+		Where("customer_id = ? AND promotion_id IN ?", customerID, promotionIDs).
+		Group("promotion_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		result[row.PromotionID] = row.Total
+	}
+	return result, nil
+}
+
+// IncrementUsageCount menambah pemakaian selama kuota belum habis, false bila kuota sudah habis
+func (r *promotionRepository) IncrementUsageCount(ctx context.Context, id int64) (bool, error) {
+	res := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Promotion{}).
+		Where("id = ? AND (usage_limit = 0 OR usage_count < usage_limit)", id).
+		UpdateColumn("usage_count", gorm.Expr("usage_count + 1"))
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *promotionRepository) CreateUsage(ctx context.Context, usage *models.PromotionUsage) error {
+	return r.getDB(ctx).WithContext(ctx).Create(usage).Error
+}
@@ -0,0 +1,177 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type RegionRepository interface {
+	GetListProvince(ctx context.Context) ([]models.Province, error)
+	GetListRegencyByProvinceID(ctx context.Context, provinceID int64) ([]models.Regency, error)
+	GetListDistrictByRegencyID(ctx context.Context, regencyID int64) ([]models.District, error)
+	GetListVillageByDistrictID(ctx context.Context, districtID int64) ([]models.Village, error)
+	GetDistrictPath(ctx context.Context, districtID int64) (models.RegionPath, error)
+	GetVillagePath(ctx context.Context, villageID int64) (models.RegionPath, error)
+	GetVillagePathByPostalCode(ctx context.Context, postalCode string) ([]models.RegionPath, error)
+	GetPostalCodesByDistrictID(ctx context.Context, districtID int64) ([]string, error)
+	SearchRegion(ctx context.Context, keyword string, limit int) ([]models.RegionPath, error)
+}
+
+type regionRepository struct {
+	AbstractRepo
+}
+
+func NewRegionRepository(db *gorm.DB) RegionRepository {
+	return &regionRepository{
+		AbstractRepo: AbstractRepo{
+			db: db,
+		},
+	}
+}
+
+const (
+	districtPathSelect = `region_province.id AS province_id, region_province.name AS province_name,
+		region_regency.id AS regency_id, region_regency.name AS regency_name,
+		region_district.id AS district_id, region_district.name AS district_name`
+	villagePathSelect = districtPathSelect + `,
+		region_village.id AS village_id, region_village.name AS village_name, region_village.postal_code`
+)
+
+func (r *regionRepository) districtPathQuery(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("region_district").
+		Select(districtPathSelect).
+		Joins("JOIN region_regency ON region_regency.id = region_district.regency_id").
+		Joins("JOIN region_province ON region_province.id = region_regency.province_id")
+}
+
+func (r *regionRepository) villagePathQuery(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("region_village").
+		Select(villagePathSelect).
+		Joins("JOIN region_district ON region_district.id = region_village.district_id").
+		Joins("JOIN region_regency ON region_regency.id = region_district.regency_id").
+		Joins("JOIN region_province ON region_province.id = region_regency.province_id")
+}
+
+func (r *regionRepository) GetListProvince(ctx context.Context) ([]models.Province, error) {
+	var provinces []models.Province
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&provinces).Error
+	if err != nil {
+		return nil, err
+	}
+	return provinces, nil
+}
+
+func (r *regionRepository) GetListRegencyByProvinceID(ctx context.Context, provinceID int64) ([]models.Regency, error) {
+	var regencies []models.Regency
+	err := r.db.WithContext(ctx).Where("province_id = ?", provinceID).Order("name ASC").Find(&regencies).Error
+	if err != nil {
+		return nil, err
+	}
+	return regencies, nil
+}
+
+func (r *regionRepository) GetListDistrictByRegencyID(ctx context.Context, regencyID int64) ([]models.District, error) {
+	var districts []models.District
+	err := r.db.WithContext(ctx).Where("regency_id = ?", regencyID).Order("name ASC").Find(&districts).Error
+	if err != nil {
+		return nil, err
+	}
+	return districts, nil
+}
+
+func (r *regionRepository) GetListVillageByDistrictID(ctx context.Context, districtID int64) ([]models.Village, error) {
+	var villages []models.Village
+	err := r.db.WithContext(ctx).Where("district_id = ?", districtID).Order("name ASC").Find(&villages).Error
+	if err != nil {
+		return nil, err
+	}
+	return villages, nil
+}
+
+func (r *regionRepository) GetDistrictPath(ctx context.Context, districtID int64) (models.RegionPath, error) {
+	var path models.RegionPath
+	res := r.districtPathQuery(ctx).Where("region_district.id = ?", districtID).Limit(1).Scan(&path)
+	if res.Error != nil {
+		return models.RegionPath{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.RegionPath{}, gorm.ErrRecordNotFound
+	}
+	return path, nil
+}
+
+func (r *regionRepository) GetVillagePath(ctx context.Context, villageID int64) (models.RegionPath, error) {
+	var path models.RegionPath
+	res := r.villagePathQuery(ctx).Where("region_village.id = ?", villageID).Limit(1).Scan(&path)
+	if res.Error != nil {
+		return models.RegionPath{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.RegionPath{}, gorm.ErrRecordNotFound
+	}
+	return path, nil
+}
+
+func (r *regionRepository) GetVillagePathByPostalCode(ctx context.Context, postalCode string) ([]models.RegionPath, error) {
+	var paths []models.RegionPath
+	err := r.villagePathQuery(ctx).
+		Where("region_village.postal_code = ?", postalCode).
+		Order("region_village.id ASC").
+		Scan(&paths).Error
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+func (r *regionRepository) GetPostalCodesByDistrictID(ctx context.Context, districtID int64) ([]string, error) {
+	var postalCodes []string
+	err := r.db.WithContext(ctx).
+		Model(&models.Village{}).
+		Distinct("postal_code").
+		Where("district_id = ? AND postal_code <> ''", districtID).
+		Pluck("postal_code", &postalCodes).Error
+	if err != nil {
+		return nil, err
+	}
+	return postalCodes, nil
+}
+
+// SearchRegion autocomplete kecamatan lalu kelurahan / desa yang namanya diawali keyword
+func (r *regionRepository) SearchRegion(ctx context.Context, keyword string, limit int) ([]models.RegionPath, error) {
+	prefix := escapeLike(strings.ToLower(keyword)) + "%"
+
+	var districts []models.RegionPath
+	err := r.districtPathQuery(ctx).
+		Where("lower(region_district.name) LIKE ?", prefix).
+		Order("region_district.name ASC, region_district.id ASC").
+		Limit(limit).
+		Scan(&districts).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(districts) >= limit {
+		return districts, nil
+	}
+
+	var villages []models.RegionPath
+	err = r.villagePathQuery(ctx).
+		Where("lower(region_village.name) LIKE ?", prefix).
+		Order("region_village.name ASC, region_village.id ASC").
+		Limit(limit - len(districts)).
+		Scan(&villages).Error
+	if err != nil {
+		return nil, err
+	}
+	return append(districts, villages...), nil
+}
+
+// escapeLike karakter wildcard dari input user dicari apa adanya (escape default Postgres = backslash)
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
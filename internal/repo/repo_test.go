@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"pleasurelove/internal/constanta"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type capturedQuery struct {
+	sql  string
+	vars []interface{}
+}
+
+// recordingDriver driver database/sql palsu: setiap query dicatat (SQL + argumen) dan selalu
+// mengembalikan hasil kosong, sehingga query repo bisa diperiksa tanpa database
+type recordingDriver struct {
+	queries []capturedQuery
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return recordingConn{d}, nil }
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c recordingConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c recordingConn) Close() error                        { return nil }
+func (c recordingConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c recordingConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q := capturedQuery{sql: query}
+	for _, arg := range args {
+		q.vars = append(q.vars, arg.Value)
+	}
+	c.d.queries = append(c.d.queries, q)
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+// newRecordingDB gorm postgres di atas recordingDriver
+func newRecordingDB(t *testing.T) (*gorm.DB, *[]capturedQuery) {
+	t.Helper()
+	d := &recordingDriver{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(recordingConnector{d})}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open recording db: %v", err)
+	}
+	return db, &d.queries
+}
+
+type recordingConnector struct{ d *recordingDriver }
+
+func (c recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return recordingConn{c.d}, nil
+}
+func (c recordingConnector) Driver() driver.Driver { return c.d }
+
+func ownScopeContext(userID int64) context.Context {
+	ctx := context.WithValue(context.Background(), constanta.Scope, constanta.ScopeOwn)
+	return context.WithValue(ctx, constanta.AuthUserID, userID)
+}
+
+// hasVar true bila argumen query memuat nilai v (int dikirim driver sebagai int64)
+func hasVar(q capturedQuery, v interface{}) bool {
+	for _, x := range q.vars {
+		if fmt.Sprint(x) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
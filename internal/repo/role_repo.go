@@ -12,6 +12,7 @@ type RoleRepository interface {
 	Create(ctx context.Context, role *models.Roles) error
 	GetRoleByID(ctx context.Context, id int64) (models.Roles, error)
 	GetListRole(ctx context.Context, listStruct *models.GetListStruct) ([]models.Roles, int64, error)
+	ExportListRole(ctx context.Context, listStruct *models.GetListStruct, fn func([]models.Roles) error) error
 	UpdateRoleByID(ctx context.Context, id int64, updatedAt time.Time, role models.Roles) (models.Roles, error)
 	DeleteRoleByID(ctx context.Context, id int64, updatedAt time.Time) error
 	GetRoleByCode(ctx context.Context, code string) (models.Roles, error)
@@ -109,3 +110,11 @@ func (r *roleRepository) GetRoleByCode(ctx context.Context, code string) (models
 	}
 	return role, nil
 }
+
+func (r *roleRepository) ExportListRole(ctx context.Context, listStruct *models.GetListStruct, fn func([]models.Roles) error) error {
+	db := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Roles{}).
+		Scopes(r.withCheckScope(ctx), r.applyFilters(listStruct.Filters))
+
+	return findInBatches(&r.AbstractRepo, db, listStruct, fn)
+}
@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ShipmentRepository interface {
+	Create(ctx context.Context, shipment *models.Shipment) error
+	GetShipmentByOrderID(ctx context.Context, orderID int64) (models.Shipment, error)
+	UpdateShipmentStatusByID(ctx context.Context, id int64, status string, deliveredAt *time.Time) error
+}
+
+type shipmentRepository struct {
+	AbstractRepo
+}
+
+var ShipmentConstraintErrorMessages = map[string]string{
+	"unique_shipment_order":           "Order sudah memiliki pengiriman",
+	"unique_shipment_tracking_number": "Nomor resi sudah digunakan",
+}
+
+func NewShipmentRepository(db *gorm.DB) ShipmentRepository {
+	return &shipmentRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			ConstraintError: ShipmentConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *shipmentRepository) Create(ctx context.Context, shipment *models.Shipment) error {
+	return r.getDB(ctx).WithContext(ctx).Create(shipment).Error
+}
+
+func (r *shipmentRepository) GetShipmentByOrderID(ctx context.Context, orderID int64) (models.Shipment, error) {
+	var shipment models.Shipment
+	err := r.getDB(ctx).WithContext(ctx).
+		Where("order_id = ?", orderID).
+		First(&shipment).Error
+	if err != nil {
+		return models.Shipment{}, err
+	}
+	return shipment, nil
+}
+
+func (r *shipmentRepository) UpdateShipmentStatusByID(ctx context.Context, id int64, status string, deliveredAt *time.Time) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.Shipment{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"delivered_at": deliveredAt,
+			"updated_at":   time.Now(),
+		}).Error
+}
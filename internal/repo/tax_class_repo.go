@@ -0,0 +1,215 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TaxClassRepository interface {
+	Create(ctx context.Context, taxClass *models.TaxClass) error
+	GetTaxClassByID(ctx context.Context, id int64) (models.TaxClass, error)
+	GetListTaxClass(ctx context.Context, listStruct *models.GetListStruct) ([]models.TaxClass, int64, error)
+	UpdateTaxClassByID(ctx context.Context, id int64, updatedAt time.Time, taxClass models.TaxClass) (models.TaxClass, error)
+	DeleteTaxClassByID(ctx context.Context, id int64, updatedAt time.Time) error
+	ClearDefaultTaxClass(ctx context.Context, exceptID int64) error
+	CountTaxClassUsage(ctx context.Context, id int64) (int64, error)
+	CreateRateBulk(ctx context.Context, rates []models.TaxRate) error
+	DeleteRateByTaxClassID(ctx context.Context, taxClassID int64) error
+	GetTaxClassByListIDs(ctx context.Context, ids []int64) ([]models.TaxClass, error)
+	GetDefaultTaxClass(ctx context.Context) (models.TaxClass, error)
+	GetProductTaxClass(ctx context.Context, productIDs []int64) ([]models.ProductTaxClass, error)
+}
+
+type taxClassRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterTaxClass = map[string]string{
+		"code":       "code",
+		"name":       "name",
+		"is_default": "is_default",
+	}
+	JoinsTaxClass                   = map[string]string{}
+	TaxClassConstraintErrorMessages = map[string]string{
+		"unique_tax_class_code":     "Kode kelas pajak sudah digunakan",
+		"idx_tax_class_default":     "Kelas pajak default sudah ada",
+		"unique_tax_rate_effective": "Tarif dengan tanggal berlaku yang sama sudah ada",
+	}
+)
+
+func NewTaxClassRepository(db *gorm.DB) TaxClassRepository {
+	return &taxClassRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterTaxClass,
+			Joins:           JoinsTaxClass,
+			ConstraintError: TaxClassConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *taxClassRepository) Create(ctx context.Context, taxClass *models.TaxClass) error {
+	return r.getDB(ctx).WithContext(ctx).Omit("Rates").Create(taxClass).Error
+}
+
+func (r *taxClassRepository) GetTaxClassByID(ctx context.Context, id int64) (models.TaxClass, error) {
+	var taxClass models.TaxClass
+	err := r.db.WithContext(ctx).
+		Preload("Rates", func(db *gorm.DB) *gorm.DB {
+			return db.Order("effective_from ASC")
+		}).
+		Where("id = ?", id).
+		First(&taxClass).Error
+	if err != nil {
+		return models.TaxClass{}, err
+	}
+	return taxClass, nil
+}
+
+func (r *taxClassRepository) GetListTaxClass(ctx context.Context, listStruct *models.GetListStruct) ([]models.TaxClass, int64, error) {
+	var taxClasses []models.TaxClass
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.TaxClass{}).
+		Scopes(r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.TaxClass{}).
+		Preload("Rates", func(db *gorm.DB) *gorm.DB {
+			return db.Order("effective_from ASC")
+		}).
+		Scopes(r.applyFiltersAndPaginationAndOrder(listStruct)).
+		Find(&taxClasses).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return taxClasses, total, nil
+}
+
+func (r *taxClassRepository) UpdateTaxClassByID(ctx context.Context, id int64, updatedAt time.Time, taxClass models.TaxClass) (models.TaxClass, error) {
+	db := r.getDB(ctx)
+
+	// pakai Select agar is_default false / description kosong ikut tersimpan
+	res := db.WithContext(ctx).
+		Model(&taxClass).
+		Select("code", "name", "description", "is_default", "updated_at", "updated_by").
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Updates(&taxClass)
+	if res.Error != nil {
+		return models.TaxClass{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.TaxClass{}, gorm.ErrRecordNotFound
+	}
+	return taxClass, nil
+}
+
+func (r *taxClassRepository) DeleteTaxClassByID(ctx context.Context, id int64, updatedAt time.Time) error {
+	res := r.getDB(ctx).WithContext(ctx).
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Delete(&models.TaxClass{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ClearDefaultTaxClass hanya satu kelas pajak default, dipanggil sebelum kelas lain dijadikan default
+func (r *taxClassRepository) ClearDefaultTaxClass(ctx context.Context, exceptID int64) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Model(&models.TaxClass{}).
+		Where("is_default AND id <> ?", exceptID).
+		Update("is_default", false).Error
+}
+
+// CountTaxClassUsage jumlah produk dan kategori yang memakai kelas pajak
+func (r *taxClassRepository) CountTaxClassUsage(ctx context.Context, id int64) (int64, error) {
+	var products, categories int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("tax_class_id = ?", id).
+		Count(&products).Error
+	if err != nil {
+		return 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.Category{}).
+		Where("tax_class_id = ?", id).
+		Count(&categories).Error
+	if err != nil {
+		return 0, err
+	}
+	return products + categories, nil
+}
+
+func (r *taxClassRepository) CreateRateBulk(ctx context.Context, rates []models.TaxRate) error {
+	return r.getDB(ctx).WithContext(ctx).Create(rates).Error
+}
+
+func (r *taxClassRepository) DeleteRateByTaxClassID(ctx context.Context, taxClassID int64) error {
+	return r.getDB(ctx).WithContext(ctx).
+		Where("tax_class_id = ?", taxClassID).
+		Delete(&models.TaxRate{}).Error
+}
+
+func (r *taxClassRepository) GetTaxClassByListIDs(ctx context.Context, ids []int64) ([]models.TaxClass, error) {
+	var taxClasses []models.TaxClass
+	err := r.db.WithContext(ctx).
+		Preload("Rates").
+		Where("id IN ?", ids).
+		Find(&taxClasses).Error
+	if err != nil {
+		return nil, err
+	}
+	return taxClasses, nil
+}
+
+func (r *taxClassRepository) GetDefaultTaxClass(ctx context.Context) (models.TaxClass, error) {
+	var taxClass models.TaxClass
+	err := r.db.WithContext(ctx).
+		Preload("Rates").
+		Where("is_default").
+		First(&taxClass).Error
+	if err != nil {
+		return models.TaxClass{}, err
+	}
+	return taxClass, nil
+}
+
+// GetProductTaxClass kelas pajak produk, bila kosong diambil dari kategori produk atau leluhurnya
+// yang terdalam (kategori dengan kedalaman sama: id terkecil)
+func (r *taxClassRepository) GetProductTaxClass(ctx context.Context, productIDs []int64) ([]models.ProductTaxClass, error) {
+	categoryClass := r.db.
+		Table("product_categories pc").
+		Select("c.tax_class_id").
+		Joins("JOIN categories leaf ON leaf.id = pc.categories_id").
+		Joins("JOIN categories c ON leaf.path LIKE c.path || '%'").
+		Where("pc.product_id = p.id AND c.tax_class_id IS NOT NULL").
+		Order("c.depth DESC, c.id ASC").
+		Limit(1)
+
+	var result []models.ProductTaxClass
+	err := r.db.WithContext(ctx).
+		Table("product p").
+		Select("p.id AS product_id, COALESCE(p.tax_class_id, (?)) AS tax_class_id", categoryClass).
+		Where("p.id IN ?", productIDs).
+		Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -13,7 +13,9 @@ type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	Login(ctx context.Context, emailOrUsername string, userID int64) (*models.User, error)
 	GetUserByID(ctx context.Context, id int64) (models.User, error)
+	GetUserBranchID(ctx context.Context, id int64) (int64, error)
 	GetListUser(ctx context.Context, listStruct *models.GetListStruct) ([]models.User, int64, error)
+	ExportListUser(ctx context.Context, listStruct *models.GetListStruct, fn func([]models.User) error) error
 	UpdateUserByID(ctx context.Context, reqData request.ReqUserUpdate, user models.User) (models.User, error)
 	DeleteUserByID(ctx context.Context, id int64, updatedAt time.Time) error
 }
@@ -77,6 +79,20 @@ func (r *userRepository) GetUserByID(ctx context.Context, id int64) (models.User
 	return user, nil
 }
 
+// GetUserBranchID cabang user tanpa pembatasan scope, 0 bila user tidak terikat cabang
+func (r *userRepository) GetUserBranchID(ctx context.Context, id int64) (int64, error) {
+	var branchID *int64
+	err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Select("branch_id").
+		Where("id = ?", id).
+		Scan(&branchID).Error
+	if err != nil || branchID == nil {
+		return 0, err
+	}
+	return *branchID, nil
+}
+
 func (r *userRepository) GetListUser(ctx context.Context, listStruct *models.GetListStruct) ([]models.User, int64, error) {
 	var users []models.User
 	var total int64
@@ -130,3 +146,11 @@ func (r *userRepository) DeleteUserByID(ctx context.Context, id int64, updatedAt
 
 	return nil
 }
+
+func (r *userRepository) ExportListUser(ctx context.Context, listStruct *models.GetListStruct, fn func([]models.User) error) error {
+	db := r.getDB(ctx).WithContext(ctx).
+		Model(&models.User{}).Preload("Roles").
+		Scopes(r.withCheckScope(ctx), r.applyFilters(listStruct.Filters))
+
+	return findInBatches(&r.AbstractRepo, db, listStruct, fn)
+}
@@ -0,0 +1,220 @@
+package repo
+
+import (
+	"context"
+	"pleasurelove/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+type VoucherRepository interface {
+	Create(ctx context.Context, voucher *models.Voucher) error
+	GetVoucherByID(ctx context.Context, id int64) (models.Voucher, error)
+	GetListVoucher(ctx context.Context, listStruct *models.GetListStruct) ([]models.Voucher, int64, error)
+	UpdateVoucherByID(ctx context.Context, id int64, updatedAt time.Time, voucher models.Voucher) (models.Voucher, error)
+	DeleteVoucherByID(ctx context.Context, id int64, updatedAt time.Time) error
+	CreateCode(ctx context.Context, code *models.VoucherCode) error
+	CreateCodeBulkIgnoreDuplicate(ctx context.Context, voucherID int64, codes []string, maxRedemptions int, userID int64) ([]string, error)
+	GetListVoucherCode(ctx context.Context, voucherID int64, listStruct *models.GetListStruct) ([]models.VoucherCode, int64, error)
+	GetVoucherCodeByCode(ctx context.Context, code string) (models.VoucherCode, error)
+	CountRedemptionByCustomerID(ctx context.Context, voucherID int64, customerID int64) (int, error)
+	IncrementUsageCount(ctx context.Context, id int64) (bool, error)
+	IncrementCodeRedeemedCount(ctx context.Context, codeID int64) (bool, error)
+	CreateRedemption(ctx context.Context, redemption *models.VoucherRedemption) error
+}
+
+type voucherRepository struct {
+	AbstractRepo
+}
+
+var (
+	FilterVoucher = map[string]string{
+		"name":          "name",
+		"discount_type": "discount_type",
+		"is_active":     "is_active",
+	}
+	JoinsVoucher                   = map[string]string{}
+	VoucherConstraintErrorMessages = map[string]string{
+		"unique_voucher_code":                 "Kode voucher sudah digunakan",
+		"chk_voucher_usage":                   "Kuota voucher sudah habis",
+		"chk_voucher_code_redemption":         "Kode voucher sudah digunakan",
+		"idx_voucher_redemption_customer_seq": "Batas pemakaian voucher per pelanggan sudah tercapai",
+	}
+)
+
+func NewVoucherRepository(db *gorm.DB) VoucherRepository {
+	return &voucherRepository{
+		AbstractRepo: AbstractRepo{
+			db:              db,
+			FilterAlias:     FilterVoucher,
+			Joins:           JoinsVoucher,
+			ConstraintError: VoucherConstraintErrorMessages,
+		},
+	}
+}
+
+func (r *voucherRepository) Create(ctx context.Context, voucher *models.Voucher) error {
+	return r.getDB(ctx).WithContext(ctx).Omit("usage_count").Create(voucher).Error
+}
+
+func (r *voucherRepository) GetVoucherByID(ctx context.Context, id int64) (models.Voucher, error) {
+	var voucher models.Voucher
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&voucher).Error
+	if err != nil {
+		return models.Voucher{}, err
+	}
+	return voucher, nil
+}
+
+func (r *voucherRepository) GetListVoucher(ctx context.Context, listStruct *models.GetListStruct) ([]models.Voucher, int64, error) {
+	var vouchers []models.Voucher
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Voucher{}).
+		Scopes(r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.Voucher{}).
+		Scopes(r.applyFiltersAndPaginationAndOrder(listStruct)).
+		Find(&vouchers).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return vouchers, total, nil
+}
+
+func (r *voucherRepository) UpdateVoucherByID(ctx context.Context, id int64, updatedAt time.Time, voucher models.Voucher) (models.Voucher, error) {
+	db := r.getDB(ctx)
+
+	// usage_count tidak ikut diubah, hanya bertambah lewat IncrementUsageCount
+	res := db.WithContext(ctx).
+		Model(&voucher).
+		Select("name", "description", "discount_type", "value", "max_discount", "min_spend", "product_ids", "category_ids",
+			"is_active", "starts_at", "ends_at", "usage_limit", "per_customer_limit", "updated_at", "updated_by").
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Updates(&voucher)
+	if res.Error != nil {
+		return models.Voucher{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return models.Voucher{}, gorm.ErrRecordNotFound
+	}
+	return voucher, nil
+}
+
+func (r *voucherRepository) DeleteVoucherByID(ctx context.Context, id int64, updatedAt time.Time) error {
+	db := r.getDB(ctx)
+
+	err := db.WithContext(ctx).
+		Where("id = ? AND updated_at = ?", id, updatedAt).
+		Delete(&models.Voucher{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *voucherRepository) CreateCode(ctx context.Context, code *models.VoucherCode) error {
+	return r.getDB(ctx).WithContext(ctx).Omit("Voucher", "redeemed_count").Create(code).Error
+}
+
+// CreateCodeBulkIgnoreDuplicate menyimpan kode yang belum ada, kode yang bentrok dilewati.
+// Mengembalikan kode yang berhasil disimpan.
+func (r *voucherRepository) CreateCodeBulkIgnoreDuplicate(ctx context.Context, voucherID int64, codes []string, maxRedemptions int, userID int64) ([]string, error) {
+	var inserted []string
+	err := r.getDB(ctx).WithContext(ctx).
+		Raw(`INSERT INTO voucher_code (voucher_id, code, max_redemptions, created_by)
+			SELECT ?, c, ?, ? FROM unnest(?::varchar[]) AS c
+			ON CONFLICT (code) DO NOTHING
+			RETURNING code`, voucherID, maxRedemptions, userID, pq.Array(codes)).
+		Scan(&inserted).Error
+	if err != nil {
+		return nil, err
+	}
+	return inserted, nil
+}
+
+func (r *voucherRepository) GetListVoucherCode(ctx context.Context, voucherID int64, listStruct *models.GetListStruct) ([]models.VoucherCode, int64, error) {
+	var codes []models.VoucherCode
+	var total int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.VoucherCode{}).
+		Where("voucher_id = ?", voucherID).
+		Scopes(r.applyFilters(listStruct.Filters)).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.VoucherCode{}).
+		Where("voucher_id = ?", voucherID).
+		Scopes(r.applyFiltersAndPaginationAndOrder(listStruct)).
+		Find(&codes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return codes, total, nil
+}
+
+func (r *voucherRepository) GetVoucherCodeByCode(ctx context.Context, code string) (models.VoucherCode, error) {
+	var voucherCode models.VoucherCode
+	err := r.db.WithContext(ctx).
+		Preload("Voucher").
+		Where("code = ?", code).
+		First(&voucherCode).Error
+	if err != nil {
+		return models.VoucherCode{}, err
+	}
+	return voucherCode, nil
+}
+
+func (r *voucherRepository) CountRedemptionByCustomerID(ctx context.Context, voucherID int64, customerID int64) (int, error) {
+	var total int64
+	err := r.getDB(ctx).WithContext(ctx).
+		Model(&models.VoucherRedemption{}).
+		Where("voucher_id = ? AND customer_id = ?", voucherID, customerID).
+		Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(total), nil
+}
+
+// IncrementUsageCount menambah pemakaian voucher selama kuota belum habis, false bila kuota sudah habis
+func (r *voucherRepository) IncrementUsageCount(ctx context.Context, id int64) (bool, error) {
+	res := r.getDB(ctx).WithContext(ctx).
+		Model(&models.Voucher{}).
+		Where("id = ? AND (usage_limit = 0 OR usage_count < usage_limit)", id).
+		UpdateColumn("usage_count", gorm.Expr("usage_count + 1"))
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// IncrementCodeRedeemedCount menambah pemakaian kode, false bila kode sekali pakai sudah terpakai
+func (r *voucherRepository) IncrementCodeRedeemedCount(ctx context.Context, codeID int64) (bool, error) {
+	res := r.getDB(ctx).WithContext(ctx).
+		Model(&models.VoucherCode{}).
+		Where("id = ? AND (max_redemptions = 0 OR redeemed_count < max_redemptions)", codeID).
+		UpdateColumn("redeemed_count", gorm.Expr("redeemed_count + 1"))
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *voucherRepository) CreateRedemption(ctx context.Context, redemption *models.VoucherRedemption) error {
+	return r.getDB(ctx).WithContext(ctx).Create(redemption).Error
+}
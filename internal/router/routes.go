@@ -3,6 +3,7 @@ package router
 import (
 	"pleasurelove/internal/controllers"
 	"pleasurelove/pkg/redis"
+	"pleasurelove/pkg/storage"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -14,6 +15,11 @@ func SetupRoutes(app *fiber.App, db *gorm.DB) {
 		Redis: redis.RDB,
 	}))
 
+	// file gambar storage lokal disajikan langsung, storage S3 memakai URL bucket / CDN
+	if local, ok := storage.Store.(*storage.LocalStorage); ok {
+		app.Static(storage.LocalURLPrefix, local.Dir, fiber.Static{MaxAge: 86400})
+	}
+
 	DashboardRoute(app, db)
 
 	WebRoute(app, db)
@@ -27,6 +33,18 @@ func DashboardRoute(app *fiber.App, db *gorm.DB) {
 	permissions := InitPermissionDashboard(db)
 	rolePermissions := InitRolePermissionsDashboard(db)
 	product := InitProductDashboard(db)
+	attribute := InitAttributeDashboard(db)
+	label := InitLabelDashboard(db)
+	priceList := InitPriceListDashboard(db)
+	customer := InitCustomerDashboard(db)
+	customerGroup := InitCustomerGroupDashboard(db)
+	promotion := InitPromotionDashboard(db)
+	voucher := InitVoucherDashboard(db)
+	order := InitOrderDashboard(db)
+	shipment := InitShipmentDashboard(db)
+	payment := InitPaymentDashboard(db)
+	taxClass := InitTaxDashboard(db)
+	invoice := InitInvoiceDashboard(db)
 
 	api := app.Group("/api/v1/dashboard")
 	// Public routes
@@ -40,15 +58,43 @@ func DashboardRoute(app *fiber.App, db *gorm.DB) {
 	UserRoutesDashboard(api, user)
 	CategoryRoutesdashboard(api, category)
 	ProductRoutesdashboard(api, product)
+	AttributeRoutesDashboard(api, attribute)
+	LabelRoutesDashboard(api, label)
+	PriceListRoutesDashboard(api, priceList)
+	CustomerRoutesDashboard(api, customer)
+	CustomerGroupRoutesDashboard(api, customerGroup)
+	PromotionRoutesDashboard(api, promotion)
+	VoucherRoutesDashboard(api, voucher)
+	OrderRoutesDashboard(api, order)
+	ShipmentRoutesDashboard(api, shipment)
+	PaymentRoutesDashboard(api, payment)
+	TaxRoutesDashboard(api, taxClass)
+	InvoiceRoutesDashboard(api, invoice)
 }
 
 func WebRoute(app *fiber.App, db *gorm.DB) {
 	auth := InitAuthWeb(db)
 	user := InitUser(db)
+	catalog := InitCatalog(db)
+	pricing := InitPricing(db)
+	cart := InitCart(db)
+	address := InitAddress(db)
+	checkout := InitCheckout(db)
+	region := InitRegion(db)
+	shipping := InitShipping(db)
+	payment := InitPayment(db)
 
 	api := app.Group("/api/v1")
 
 	AuthRoutesWeb(api, auth)
+	CatalogRoutesWeb(api, catalog)
+	RegionRoutesWeb(api, region)
 	// Protected routes
 	UserRoutesWeb(api, user)
+	PricingRoutesWeb(api, pricing)
+	CartRoutesWeb(api, cart)
+	AddressRoutesWeb(api, address)
+	ShippingRoutesWeb(api, shipping)
+	CheckoutRoutesWeb(api, checkout)
+	PaymentRoutesWeb(api, payment)
 }
@@ -22,6 +22,7 @@ func UserRoutesDashboard(api fiber.Router, handler *dashboard.UserDahboardContro
 	userDashboard := api.Group("/user")
 	userDashboard.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuUserActionCreate), handler.CreateUserDashboard)
 	userDashboard.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuUserActionRead), handler.GetListUser)
+	userDashboard.Get("/export", middleware.AuthMiddlewareDashboard(constanta.MenuUserActionRead), handler.ExportListUser)
 	userDashboard.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuUserActionRead), handler.GetUserByID)
 	userDashboard.Put("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuUserActionUpdate), handler.UpdateUserByID)
 	userDashboard.Delete("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuUserActionDelete), handler.DeleteUserByID)
@@ -32,8 +33,13 @@ func CategoryRoutesdashboard(api fiber.Router, handler *dashboard.CategoryDashbo
 	category := api.Group("/category")
 	category.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionCreate), handler.CreateCategory)
 	category.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionRead), handler.GetListCategory)
+	category.Get("/tree", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionRead), handler.GetCategoryTree)
+	category.Get("/export", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionRead), handler.ExportListCategory)
+	category.Put("/bulk", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionUpdate), handler.BulkMoveCategory)
+	category.Delete("/bulk", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionDelete), handler.BulkDeleteCategory)
 	category.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionRead), handler.GetCategoryByID)
 	category.Put("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionUpdate), handler.UpdateCategoryByID)
+	category.Put("/:id/move", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionUpdate), handler.MoveCategoryByID)
 	category.Delete("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionDelete), handler.DeleteCategoryByID)
 }
 
@@ -42,6 +48,7 @@ func RoleRoutesDashboard(api fiber.Router, handler *dashboard.RoleController) {
 	role := api.Group("/role")
 	role.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuRoleActionCreate), middleware.CheckAdminRoleMiddleware(), handler.CreateRole)
 	role.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuRoleActionRead), middleware.CheckAdminRoleMiddleware(), handler.GetListRole)
+	role.Get("/export", middleware.AuthMiddlewareDashboard(constanta.MenuRoleActionRead), middleware.CheckAdminRoleMiddleware(), handler.ExportListRole)
 	role.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuRoleActionRead), middleware.CheckAdminRoleMiddleware(), handler.GetRoleByID)
 	role.Put("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuRoleActionUpdate), middleware.CheckAdminRoleMiddleware(), handler.UpdateRoleByID)
 	role.Delete("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuRoleActionDelete), middleware.CheckAdminRoleMiddleware(), handler.DeleteRoleByID)
@@ -72,7 +79,154 @@ func ProductRoutesdashboard(api fiber.Router, handler *dashboard.ProductDashboar
 	category := api.Group("/product")
 	category.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionCreate), handler.CreateProduct)
 	category.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionRead), handler.GetListProduct)
+	category.Get("/search", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionRead), handler.SearchProduct)
+	category.Get("/export", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionRead), handler.ExportListProduct)
+	category.Put("/bulk", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionUpdate), handler.BulkUpdateProduct)
+	category.Delete("/bulk", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionDelete), handler.BulkDeleteProduct)
+	category.Post("/import", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionCreate), handler.ImportProduct)
+	category.Get("/import/:id", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionRead), handler.GetProductImportStatus)
 	category.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionRead), handler.GetProductByID)
 	category.Put("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionUpdate), handler.UpdateProductByID)
 	category.Delete("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionDelete), handler.DeleteProductByID)
+
+	category.Get("/:id/price-history", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionRead), handler.GetPriceTimeline)
+	category.Get("/:id/price-schedule", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionRead), handler.GetListPriceSchedule)
+	category.Post("/:id/price-schedule", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionUpdate), handler.CreatePriceSchedule)
+	category.Delete("/price-schedule/:id", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionUpdate), handler.CancelPriceSchedule)
+
+	category.Post("/:id/stock", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionUpdate), handler.AdjustStock)
+
+	category.Get("/:id/media", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionRead), handler.GetListMedia)
+	category.Post("/:id/media", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionUpdate), handler.UploadMedia)
+	category.Put("/media/:id", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionUpdate), handler.UpdateMediaByID)
+	category.Delete("/media/:id", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionUpdate), handler.DeleteMediaByID)
+}
+
+// AttributeRoutesDashboard definisi atribut melekat pada kategori sehingga memakai hak akses kategori
+func AttributeRoutesDashboard(api fiber.Router, handler *dashboard.AttributeDashboardController) {
+	// Protected routes
+	attribute := api.Group("/attribute")
+	attribute.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionCreate), handler.CreateAttribute)
+	attribute.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionRead), handler.GetListAttribute)
+	attribute.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionRead), handler.GetAttributeByID)
+	attribute.Put("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionUpdate), handler.UpdateAttributeByID)
+	attribute.Delete("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuCategoryActionDelete), handler.DeleteAttributeByID)
+}
+
+func LabelRoutesDashboard(api fiber.Router, handler *dashboard.LabelDashboardController) {
+	// Protected routes
+	label := api.Group("/label")
+	label.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuProductActionRead), handler.GenerateLabel)
+}
+
+func PriceListRoutesDashboard(api fiber.Router, handler *dashboard.PriceListDashboardController) {
+	// Protected routes
+	priceList := api.Group("/price-list")
+	priceList.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionCreate), handler.CreatePriceList)
+	priceList.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionRead), handler.GetListPriceList)
+	priceList.Post("/quote", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionRead), handler.QuotePrice)
+	priceList.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionRead), handler.GetPriceListByID)
+	priceList.Put("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionUpdate), handler.UpdatePriceListByID)
+	priceList.Delete("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionDelete), handler.DeletePriceListByID)
+}
+
+func CustomerRoutesDashboard(api fiber.Router, handler *dashboard.CustomerDashboardController) {
+	// Protected routes
+	customer := api.Group("/customer")
+	customer.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuCustomerActionRead), handler.GetListCustomer)
+	customer.Get("/export", middleware.AuthMiddlewareDashboard(constanta.MenuCustomerActionRead), handler.ExportListCustomer)
+
+	customer.Get("/:id/address", middleware.AuthMiddlewareDashboard(constanta.MenuCustomerActionRead), handler.GetListCustomerAddress)
+	customer.Post("/:id/address", middleware.AuthMiddlewareDashboard(constanta.MenuCustomerActionUpdate), handler.CreateCustomerAddress)
+	customer.Put("/address/:id", middleware.AuthMiddlewareDashboard(constanta.MenuCustomerActionUpdate), handler.UpdateCustomerAddressByID)
+}
+
+func CustomerGroupRoutesDashboard(api fiber.Router, handler *dashboard.CustomerGroupDashboardController) {
+	// Protected routes, grup pelanggan dikelola bersama price list
+	group := api.Group("/customer-group")
+	group.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionCreate), handler.CreateCustomerGroup)
+	group.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionRead), handler.GetListCustomerGroup)
+	group.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionRead), handler.GetCustomerGroupByID)
+	group.Put("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionUpdate), handler.UpdateCustomerGroupByID)
+	group.Delete("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionDelete), handler.DeleteCustomerGroupByID)
+	group.Post("/:id/member", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionUpdate), handler.AddCustomerGroupMember)
+	group.Delete("/:id/member", middleware.AuthMiddlewareDashboard(constanta.MenuPriceListActionUpdate), handler.RemoveCustomerGroupMember)
+}
+
+func PromotionRoutesDashboard(api fiber.Router, handler *dashboard.PromotionDashboardController) {
+	// Protected routes
+	promotion := api.Group("/promotion")
+	promotion.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuPromotionActionCreate), handler.CreatePromotion)
+	promotion.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuPromotionActionRead), handler.GetListPromotion)
+	promotion.Post("/evaluate", middleware.AuthMiddlewareDashboard(constanta.MenuPromotionActionRead), handler.EvaluatePromotion)
+	promotion.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuPromotionActionRead), handler.GetPromotionByID)
+	promotion.Put("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuPromotionActionUpdate), handler.UpdatePromotionByID)
+	promotion.Delete("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuPromotionActionDelete), handler.DeletePromotionByID)
+}
+
+func VoucherRoutesDashboard(api fiber.Router, handler *dashboard.VoucherDashboardController) {
+	// Protected routes
+	voucher := api.Group("/voucher")
+	voucher.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuVoucherActionCreate), handler.CreateVoucher)
+	voucher.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuVoucherActionRead), handler.GetListVoucher)
+	voucher.Post("/check", middleware.AuthMiddlewareDashboard(constanta.MenuVoucherActionRead), handler.CheckVoucher)
+	voucher.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuVoucherActionRead), handler.GetVoucherByID)
+	voucher.Put("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuVoucherActionUpdate), handler.UpdateVoucherByID)
+	voucher.Delete("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuVoucherActionDelete), handler.DeleteVoucherByID)
+	voucher.Get("/:id/code", middleware.AuthMiddlewareDashboard(constanta.MenuVoucherActionRead), handler.GetListVoucherCode)
+	voucher.Post("/:id/code", middleware.AuthMiddlewareDashboard(constanta.MenuVoucherActionCreate), handler.CreateVoucherCode)
+	voucher.Post("/:id/code/generate", middleware.AuthMiddlewareDashboard(constanta.MenuVoucherActionCreate), handler.GenerateVoucherCode)
+}
+
+func OrderRoutesDashboard(api fiber.Router, handler *dashboard.OrderDashboardController) {
+	// Protected routes, order tidak bisa dihapus, gunakan status cancelled / refunded
+	order := api.Group("/order")
+	order.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuOrderActionCreate), handler.CreateOrder)
+	order.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuOrderActionRead), handler.GetListOrder)
+	order.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuOrderActionRead), handler.GetOrderByID)
+	order.Put("/:id/status", middleware.AuthMiddlewareDashboard(constanta.MenuOrderActionUpdate), handler.UpdateOrderStatusByID)
+}
+
+func ShipmentRoutesDashboard(api fiber.Router, handler *dashboard.ShipmentDashboardController) {
+	// resi, tracking dan label pengiriman per order
+	order := api.Group("/order")
+
+	order.Post("/:id/shipment", middleware.AuthMiddlewareDashboard(constanta.MenuOrderActionUpdate), handler.CreateShipment)
+	order.Get("/:id/shipment/tracking", middleware.AuthMiddlewareDashboard(constanta.MenuOrderActionRead), handler.GetShipmentTracking)
+	order.Get("/:id/shipment/label", middleware.AuthMiddlewareDashboard(constanta.MenuOrderActionRead), handler.GetShipmentLabel)
+}
+
+func PaymentRoutesDashboard(api fiber.Router, handler *dashboard.PaymentDashboardController) {
+	// Protected routes, konfirmasi transfer manual dan refund
+	payment := api.Group("/payment")
+	payment.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuPaymentActionRead), handler.GetListPayment)
+	payment.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuPaymentActionRead), handler.GetPaymentByID)
+	payment.Put("/:id/confirm", middleware.AuthMiddlewareDashboard(constanta.MenuPaymentActionUpdate), handler.ConfirmPayment)
+	payment.Post("/:id/refund", middleware.AuthMiddlewareDashboard(constanta.MenuPaymentActionUpdate), handler.RefundPayment)
+
+	order := api.Group("/order")
+	order.Get("/:id/payment", middleware.AuthMiddlewareDashboard(constanta.MenuPaymentActionRead), handler.GetListPaymentByOrderID)
+}
+
+func TaxRoutesDashboard(api fiber.Router, handler *dashboard.TaxDashboardController) {
+	// Protected routes
+	taxClass := api.Group("/tax-class")
+	taxClass.Post("/", middleware.AuthMiddlewareDashboard(constanta.MenuTaxActionCreate), handler.CreateTaxClass)
+	taxClass.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuTaxActionRead), handler.GetListTaxClass)
+	taxClass.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuTaxActionRead), handler.GetTaxClassByID)
+	taxClass.Put("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuTaxActionUpdate), handler.UpdateTaxClassByID)
+	taxClass.Delete("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuTaxActionDelete), handler.DeleteTaxClassByID)
+}
+
+func InvoiceRoutesDashboard(api fiber.Router, handler *dashboard.InvoiceDashboardController) {
+	// Protected routes, invoice & nota kredit terbit otomatis dari pembayaran, tidak bisa diubah / dihapus
+	invoice := api.Group("/invoice")
+	invoice.Get("/", middleware.AuthMiddlewareDashboard(constanta.MenuInvoiceActionRead), handler.GetListInvoice)
+	invoice.Get("/:id", middleware.AuthMiddlewareDashboard(constanta.MenuInvoiceActionRead), handler.GetInvoiceByID)
+	invoice.Get("/:id/download", middleware.AuthMiddlewareDashboard(constanta.MenuInvoiceActionRead), handler.DownloadInvoice)
+	invoice.Post("/:id/print", middleware.AuthMiddlewareDashboard(constanta.MenuInvoiceActionUpdate), handler.PrintInvoice)
+
+	order := api.Group("/order")
+	order.Get("/:id/invoice", middleware.AuthMiddlewareDashboard(constanta.MenuInvoiceActionRead), handler.GetListInvoiceByOrderID)
+	order.Post("/:id/invoice", middleware.AuthMiddlewareDashboard(constanta.MenuInvoiceActionCreate), handler.IssueInvoice)
 }
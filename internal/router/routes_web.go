@@ -5,6 +5,7 @@ import (
 	"pleasurelove/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/etag"
 )
 
 func AuthRoutesWeb(api fiber.Router, handler *controllers.AuthController) {
@@ -24,3 +25,81 @@ func UserRoutesWeb(api fiber.Router, handler *controllers.UserController) {
 
 	userRoute.Get("/:id", handler.GetProfile)
 }
+
+func CatalogRoutesWeb(api fiber.Router, handler *controllers.CatalogController) {
+	// Public routes, boleh di-cache
+	catalog := api.Group("/catalog", etag.New(), middleware.PublicCacheMiddleware(60))
+
+	catalog.Get("/product", handler.GetListProduct)
+	catalog.Get("/search", handler.SearchProduct)
+	catalog.Get("/product/:slug", handler.GetProductBySlug)
+	catalog.Get("/category", handler.GetCategoryTree)
+	catalog.Get("/category/:slug", handler.GetCategoryBySlug)
+}
+
+func RegionRoutesWeb(api fiber.Router, handler *controllers.RegionController) {
+	// Public routes, data wilayah jarang berubah
+	region := api.Group("/region", etag.New(), middleware.PublicCacheMiddleware(3600))
+
+	region.Get("/province", handler.GetListProvince)
+	region.Get("/province/:id/regency", handler.GetListRegency)
+	region.Get("/regency/:id/district", handler.GetListDistrict)
+	region.Get("/district/:id/village", handler.GetListVillage)
+	region.Get("/search", handler.SearchRegion)
+	region.Get("/postal-code/:code", handler.GetRegionByPostalCode)
+}
+
+func PricingRoutesWeb(api fiber.Router, handler *controllers.PricingController) {
+	// Protected routes, harga khusus pelanggan tidak boleh di-cache publik
+	pricing := api.Group("/pricing", middleware.AuthMiddleware())
+
+	pricing.Post("/quote", handler.QuotePrice)
+}
+
+func CartRoutesWeb(api fiber.Router, handler *controllers.CartController) {
+	// user login (Authorization) atau guest (X-Guest-Token), tidak boleh di-cache
+	cart := api.Group("/cart", middleware.AuthOrGuestMiddleware())
+
+	cart.Get("/", handler.GetCart)
+	cart.Delete("/", handler.ClearCart)
+	cart.Post("/items", handler.AddCartItem)
+	cart.Put("/items", handler.UpdateCartItem)
+	cart.Delete("/items/:id", handler.RemoveCartItem)
+}
+
+func AddressRoutesWeb(api fiber.Router, handler *controllers.AddressController) {
+	// buku alamat user login atau guest (X-Guest-Token)
+	address := api.Group("/address", middleware.AuthOrGuestMiddleware())
+
+	address.Get("/", handler.GetListAddress)
+	address.Post("/", handler.CreateAddress)
+	address.Get("/:id", handler.GetAddressByID)
+	address.Put("/:id", handler.UpdateAddressByID)
+	address.Delete("/:id", handler.DeleteAddressByID)
+}
+
+func CheckoutRoutesWeb(api fiber.Router, handler *controllers.CheckoutController) {
+	// user login atau guest, request ulang dengan header Idempotency-Key yang sama tidak membuat order baru
+	checkout := api.Group("/checkout", middleware.AuthOrGuestMiddleware())
+
+	checkout.Post("/", handler.Checkout)
+}
+
+func ShippingRoutesWeb(api fiber.Router, handler *controllers.ShippingController) {
+	// cek ongkir isi keranjang user login atau guest
+	shipping := api.Group("/shipping", middleware.AuthOrGuestMiddleware())
+
+	shipping.Post("/quote", handler.QuoteCart)
+}
+
+func PaymentRoutesWeb(api fiber.Router, handler *controllers.PaymentController) {
+	// notifikasi provider tanpa auth (diverifikasi dari signature), didaftarkan sebelum group
+	// /payment supaya tidak melewati AuthOrGuestMiddleware
+	api.Post("/payment/webhook/:provider", handler.HandleWebhook)
+
+	payment := api.Group("/payment", middleware.AuthOrGuestMiddleware())
+
+	payment.Post("/", handler.CreatePayment)
+	payment.Get("/:id", handler.GetPaymentByID)
+	payment.Post("/:id/proof", handler.UploadPaymentProof)
+}
@@ -5,6 +5,11 @@ import (
 	"pleasurelove/internal/controllers/dashboard"
 	"pleasurelove/internal/repo"
 	"pleasurelove/internal/usecase"
+	"pleasurelove/pkg/invoice"
+	"pleasurelove/pkg/payment"
+	"pleasurelove/pkg/shipping"
+	"pleasurelove/pkg/storage"
+	"pleasurelove/pkg/tax"
 
 	"gorm.io/gorm"
 )
@@ -37,7 +42,8 @@ func InitUserDahboard(db *gorm.DB) *dashboard.UserDahboardController {
 
 func InitCategoryDashboard(db *gorm.DB) *dashboard.CategoryDashboardController {
 	categoryRepo := repo.NewCategoryRepository(db)
-	categoryUC := usecase.NewCategoryUseCase(db, categoryRepo)
+	taxClassRepo := repo.NewTaxClassRepository(db)
+	categoryUC := usecase.NewCategoryUseCase(db, categoryRepo, taxClassRepo)
 	categoryController := dashboard.NewCategoryController(categoryUC)
 
 	return categoryController
@@ -73,17 +79,318 @@ func InitProductDashboard(db *gorm.DB) *dashboard.ProductDashboardController {
 	categoryrepo := repo.NewCategoryRepository(db)
 	productCategoryrepo := repo.NewProductCategoryRepository(db)
 	productRepo := repo.NewProductRepository(db)
-	productUC := usecase.NewProductUseCase(db, productRepo, categoryrepo, productCategoryrepo)
-	productController := dashboard.NewProductController(productUC)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	priceHistoryRepo := repo.NewPriceHistoryRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	attributeRepo := repo.NewAttributeRepository(db)
+	productMediaRepo := repo.NewProductMediaRepository(db)
+	taxClassRepo := repo.NewTaxClassRepository(db)
+	inventoryUC := usecase.NewInventoryUseCase(db, productRepo, productVarianRepo, productBundleRepo)
+	productUC := usecase.NewProductUseCase(db, productRepo, categoryrepo, productCategoryrepo, priceHistoryRepo, productVarianRepo, productBundleRepo, attributeRepo, productMediaRepo, taxClassRepo, storage.Store, inventoryUC)
+	priceScheduleUC := usecase.NewPriceScheduleUseCase(db, priceHistoryRepo, productRepo, productVarianRepo)
+	productMediaUC := usecase.NewProductMediaUseCase(db, productRepo, productMediaRepo, storage.Store)
+	productController := dashboard.NewProductController(productUC, priceScheduleUC, inventoryUC, productMediaUC)
 
 	return productController
 }
 
+func InitAttributeDashboard(db *gorm.DB) *dashboard.AttributeDashboardController {
+	attributeRepo := repo.NewAttributeRepository(db)
+	categoryRepo := repo.NewCategoryRepository(db)
+	attributeUC := usecase.NewAttributeUseCase(db, attributeRepo, categoryRepo)
+	attributeController := dashboard.NewAttributeController(attributeUC)
+
+	return attributeController
+}
+
+func InitLabelDashboard(db *gorm.DB) *dashboard.LabelDashboardController {
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	labelUC := usecase.NewLabelUseCase(productRepo, productVarianRepo, pricingUC)
+	labelController := dashboard.NewLabelController(labelUC)
+
+	return labelController
+}
+
+func InitPriceListDashboard(db *gorm.DB) *dashboard.PriceListDashboardController {
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	customerGroupRepo := repo.NewCustomerGroupRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	priceListUC := usecase.NewPriceListUseCase(db, priceListRepo, productRepo, productVarianRepo, customerRepo, customerGroupRepo)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	priceListController := dashboard.NewPriceListController(priceListUC, pricingUC)
+
+	return priceListController
+}
+
+func InitCustomerGroupDashboard(db *gorm.DB) *dashboard.CustomerGroupDashboardController {
+	customerGroupRepo := repo.NewCustomerGroupRepository(db)
+	customerGroupUC := usecase.NewCustomerGroupUseCase(db, customerGroupRepo)
+	customerGroupController := dashboard.NewCustomerGroupController(customerGroupUC)
+
+	return customerGroupController
+}
+
+func InitCustomerDashboard(db *gorm.DB) *dashboard.CustomerDashboardController {
+	customerRepo := repo.NewCustomerRepository(db)
+	addressRepo := repo.NewAddressRepository(db)
+	regionRepo := repo.NewRegionRepository(db)
+	customerUC := usecase.NewCustomerUseCase(db, customerRepo)
+	regionUC := usecase.NewRegionUseCase(regionRepo)
+	addressUC := usecase.NewAddressUseCase(db, addressRepo, customerRepo, regionUC)
+	customerController := dashboard.NewCustomerController(customerUC, addressUC)
+
+	return customerController
+}
+
+func InitPromotionDashboard(db *gorm.DB) *dashboard.PromotionDashboardController {
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	categoryRepo := repo.NewCategoryRepository(db)
+	productCategoryRepo := repo.NewProductCategoryRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	promotionRepo := repo.NewPromotionRepository(db)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	promotionUC := usecase.NewPromotionUseCase(db, promotionRepo, productRepo, categoryRepo, productCategoryRepo, pricingUC)
+	promotionController := dashboard.NewPromotionController(promotionUC)
+
+	return promotionController
+}
+
+func InitVoucherDashboard(db *gorm.DB) *dashboard.VoucherDashboardController {
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	categoryRepo := repo.NewCategoryRepository(db)
+	productCategoryRepo := repo.NewProductCategoryRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	promotionRepo := repo.NewPromotionRepository(db)
+	voucherRepo := repo.NewVoucherRepository(db)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	promotionUC := usecase.NewPromotionUseCase(db, promotionRepo, productRepo, categoryRepo, productCategoryRepo, pricingUC)
+	voucherUC := usecase.NewVoucherUseCase(db, voucherRepo, productRepo, categoryRepo, pricingUC, promotionUC)
+	voucherController := dashboard.NewVoucherController(voucherUC)
+
+	return voucherController
+}
+
+func InitOrderDashboard(db *gorm.DB) *dashboard.OrderDashboardController {
+	orderRepo := repo.NewOrderRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	taxClassRepo := repo.NewTaxClassRepository(db)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	inventoryUC := usecase.NewInventoryUseCase(db, productRepo, productVarianRepo, productBundleRepo)
+	taxUC := usecase.NewTaxUseCase(db, taxClassRepo, tax.Default)
+	orderUC := usecase.NewOrderUseCase(db, orderRepo, customerRepo, productRepo, productVarianRepo, pricingUC, inventoryUC, taxUC)
+	orderController := dashboard.NewOrderController(orderUC)
+
+	return orderController
+}
+
+func InitTaxDashboard(db *gorm.DB) *dashboard.TaxDashboardController {
+	taxClassRepo := repo.NewTaxClassRepository(db)
+	taxUC := usecase.NewTaxUseCase(db, taxClassRepo, tax.Default)
+	taxController := dashboard.NewTaxController(taxUC)
+
+	return taxController
+}
+
+func InitInvoiceDashboard(db *gorm.DB) *dashboard.InvoiceDashboardController {
+	invoiceUC := newInvoiceUseCase(db)
+	invoiceController := dashboard.NewInvoiceController(invoiceUC)
+
+	return invoiceController
+}
+
+func InitShipmentDashboard(db *gorm.DB) *dashboard.ShipmentDashboardController {
+	shippingUC := newShippingUseCase(db)
+	shipmentController := dashboard.NewShipmentController(shippingUC)
+
+	return shipmentController
+}
+
+func InitPaymentDashboard(db *gorm.DB) *dashboard.PaymentDashboardController {
+	paymentUC := newPaymentUseCase(db)
+	paymentController := dashboard.NewPaymentController(paymentUC)
+
+	return paymentController
+}
+
 // Note: Web Init Route
 func InitAuthWeb(db *gorm.DB) *controllers.AuthController {
 	userRepo := repo.NewUserRepository(db)
 	authUC := usecase.NewAuthUseCase(db, userRepo)
-	authController := controllers.NewAuthController(authUC)
+	cartRepo := repo.NewCartRepository(db)
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	inventoryUC := usecase.NewInventoryUseCase(db, productRepo, productVarianRepo, productBundleRepo)
+	cartUC := usecase.NewCartUseCase(db, cartRepo, productRepo, productVarianRepo, customerRepo, pricingUC, inventoryUC)
+	authController := controllers.NewAuthController(authUC, cartUC)
 
 	return authController
 }
+
+func InitCatalog(db *gorm.DB) *controllers.CatalogController {
+	productRepo := repo.NewProductRepository(db)
+	categoryRepo := repo.NewCategoryRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	attributeRepo := repo.NewAttributeRepository(db)
+	productMediaRepo := repo.NewProductMediaRepository(db)
+	catalogUC := usecase.NewCatalogUseCase(productRepo, categoryRepo, attributeRepo, productMediaRepo, storage.Store, pricingUC)
+	catalogController := controllers.NewCatalogController(catalogUC)
+
+	return catalogController
+}
+
+func InitPricing(db *gorm.DB) *controllers.PricingController {
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	pricingController := controllers.NewPricingController(pricingUC)
+
+	return pricingController
+}
+
+func InitCart(db *gorm.DB) *controllers.CartController {
+	cartRepo := repo.NewCartRepository(db)
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	inventoryUC := usecase.NewInventoryUseCase(db, productRepo, productVarianRepo, productBundleRepo)
+	cartUC := usecase.NewCartUseCase(db, cartRepo, productRepo, productVarianRepo, customerRepo, pricingUC, inventoryUC)
+	cartController := controllers.NewCartController(cartUC)
+
+	return cartController
+}
+
+func InitAddress(db *gorm.DB) *controllers.AddressController {
+	addressRepo := repo.NewAddressRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	regionRepo := repo.NewRegionRepository(db)
+	regionUC := usecase.NewRegionUseCase(regionRepo)
+	addressUC := usecase.NewAddressUseCase(db, addressRepo, customerRepo, regionUC)
+	addressController := controllers.NewAddressController(addressUC)
+
+	return addressController
+}
+
+func InitRegion(db *gorm.DB) *controllers.RegionController {
+	regionRepo := repo.NewRegionRepository(db)
+	regionUC := usecase.NewRegionUseCase(regionRepo)
+	regionController := controllers.NewRegionController(regionUC)
+
+	return regionController
+}
+
+func InitShipping(db *gorm.DB) *controllers.ShippingController {
+	shippingUC := newShippingUseCase(db)
+	shippingController := controllers.NewShippingController(shippingUC)
+
+	return shippingController
+}
+
+func InitCheckout(db *gorm.DB) *controllers.CheckoutController {
+	cartRepo := repo.NewCartRepository(db)
+	orderRepo := repo.NewOrderRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	addressRepo := repo.NewAddressRepository(db)
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	categoryRepo := repo.NewCategoryRepository(db)
+	productCategoryRepo := repo.NewProductCategoryRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	promotionRepo := repo.NewPromotionRepository(db)
+	voucherRepo := repo.NewVoucherRepository(db)
+	regionRepo := repo.NewRegionRepository(db)
+	shipmentRepo := repo.NewShipmentRepository(db)
+	taxClassRepo := repo.NewTaxClassRepository(db)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	regionUC := usecase.NewRegionUseCase(regionRepo)
+	inventoryUC := usecase.NewInventoryUseCase(db, productRepo, productVarianRepo, productBundleRepo)
+	taxUC := usecase.NewTaxUseCase(db, taxClassRepo, tax.Default)
+	promotionUC := usecase.NewPromotionUseCase(db, promotionRepo, productRepo, categoryRepo, productCategoryRepo, pricingUC)
+	voucherUC := usecase.NewVoucherUseCase(db, voucherRepo, productRepo, categoryRepo, pricingUC, promotionUC)
+	checkoutUC := usecase.NewCheckoutUseCase(db, cartRepo, orderRepo, customerRepo, addressRepo, productRepo, productVarianRepo, pricingUC, promotionUC, voucherUC, inventoryUC, regionUC, shipmentRepo, shipping.Default, taxUC)
+	checkoutController := controllers.NewCheckoutController(checkoutUC)
+
+	return checkoutController
+}
+
+func newShippingUseCase(db *gorm.DB) usecase.ShippingUseCase {
+	cartRepo := repo.NewCartRepository(db)
+	orderRepo := repo.NewOrderRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	addressRepo := repo.NewAddressRepository(db)
+	shipmentRepo := repo.NewShipmentRepository(db)
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	regionRepo := repo.NewRegionRepository(db)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	regionUC := usecase.NewRegionUseCase(regionRepo)
+	inventoryUC := usecase.NewInventoryUseCase(db, productRepo, productVarianRepo, productBundleRepo)
+
+	return usecase.NewShippingUseCase(db, cartRepo, orderRepo, customerRepo, addressRepo, shipmentRepo, productRepo, productVarianRepo, pricingUC, inventoryUC, regionUC, shipping.Default)
+}
+
+func InitPayment(db *gorm.DB) *controllers.PaymentController {
+	paymentUC := newPaymentUseCase(db)
+	paymentController := controllers.NewPaymentController(paymentUC)
+
+	return paymentController
+}
+
+// newPaymentUseCase dipakai web (pembayaran pelanggan, webhook) dan dashboard (konfirmasi, refund)
+func newPaymentUseCase(db *gorm.DB) usecase.PaymentUseCase {
+	orderRepo := repo.NewOrderRepository(db)
+	customerRepo := repo.NewCustomerRepository(db)
+	paymentRepo := repo.NewPaymentRepository(db)
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	priceListRepo := repo.NewPriceListRepository(db)
+	productBundleRepo := repo.NewProductBundleRepository(db)
+	pricingUC := usecase.NewPricingUseCase(priceListRepo, productRepo, productVarianRepo, customerRepo, productBundleRepo)
+	inventoryUC := usecase.NewInventoryUseCase(db, productRepo, productVarianRepo, productBundleRepo)
+	invoiceUC := newInvoiceUseCase(db)
+
+	return usecase.NewPaymentUseCase(db, orderRepo, customerRepo, paymentRepo, productRepo, productVarianRepo, pricingUC, inventoryUC, invoiceUC, storage.Store, payment.Default)
+}
+
+// newInvoiceUseCase dipakai dashboard (unduh / cetak) dan pembayaran (terbit saat dibayar / refund)
+func newInvoiceUseCase(db *gorm.DB) usecase.InvoiceUseCase {
+	invoiceRepo := repo.NewInvoiceRepository(db)
+	orderRepo := repo.NewOrderRepository(db)
+	userRepo := repo.NewUserRepository(db)
+
+	return usecase.NewInvoiceUseCase(db, invoiceRepo, orderRepo, userRepo, invoice.Default)
+}
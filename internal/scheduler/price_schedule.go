@@ -0,0 +1,31 @@
+package scheduler
+
+import (
+	"context"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/usecase"
+	"pleasurelove/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PriceScheduleJob menerapkan perubahan harga terjadwal yang sudah jatuh tempo
+func PriceScheduleJob(db *gorm.DB) Job {
+	productRepo := repo.NewProductRepository(db)
+	productVarianRepo := repo.NewProductVarianRepository(db)
+	priceHistoryRepo := repo.NewPriceHistoryRepository(db)
+	priceScheduleUC := usecase.NewPriceScheduleUseCase(db, priceHistoryRepo, productRepo, productVarianRepo)
+
+	return Job{
+		Name:     "price_schedule",
+		Interval: intervalFromEnv("PRICE_SCHEDULE_INTERVAL", time.Minute),
+		Run: func(ctx context.Context) error {
+			applied, failed, err := priceScheduleUC.ApplyDueSchedules(ctx, time.Now())
+			if applied > 0 || failed > 0 {
+				logger.Info(ctx, "Price schedule applied", map[string]interface{}{"count": applied, "failed": failed})
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"pleasurelove/pkg/logger"
+	"time"
+)
+
+// Job pekerjaan background yang dijalankan berkala
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Start menjalankan setiap job di goroutine sendiri sampai ctx dibatalkan
+func Start(ctx context.Context, jobs ...Job) {
+	for _, job := range jobs {
+		go runJob(ctx, job)
+	}
+}
+
+func runJob(ctx context.Context, job Job) {
+	logger.Info(ctx, "Scheduler job started", map[string]interface{}{"job": job.Name, "interval": job.Interval.String()})
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			logger.Info(ctx, "Scheduler job stopped", map[string]interface{}{"job": job.Name})
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce menjaga agar panic di satu job tidak mematikan aplikasi
+func runOnce(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(ctx, "Scheduler job panic", fmt.Errorf("job %s: %v", job.Name, r))
+		}
+	}()
+
+	if err := job.Run(ctx); err != nil {
+		logger.Error(ctx, "Scheduler job failed", fmt.Errorf("job %s: %w", job.Name, err))
+	}
+}
+
+// intervalFromEnv membaca durasi dari env (contoh: 30s, 1m), default jika kosong / tidak valid
+func intervalFromEnv(key string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
+}
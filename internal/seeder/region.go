@@ -0,0 +1,230 @@
+package seeder
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"pleasurelove/internal/models"
+	"pleasurelove/pkg/logger"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Dataset wilayah bawaan (seluruh provinsi + contoh DKI Jakarta).
+// Dataset lengkap Kemendagri dengan format yang sama bisa dipakai lewat REGION_DATA_DIR. Selama
+// kabupaten / kecamatan suatu daerah belum di-seed, alamat memakai nama wilayah dari teks bebas.
+//
+//go:embed data/regions/*.csv
+var regionData embed.FS
+
+const (
+	regionDatasetID = 1
+	regionBatchSize = 1000
+)
+
+var regionFiles = []string{"provinces.csv", "regencies.csv", "districts.csv", "villages.csv"}
+
+type regionDataset struct {
+	provinces []models.Province
+	regencies []models.Regency
+	districts []models.District
+	villages  []models.Village
+}
+
+// SeedRegions isi tabel wilayah, dilewati bila checksum dataset tidak berubah
+func SeedRegions(ctx context.Context, db *gorm.DB) error {
+	if db == nil {
+		err := errors.New("database connection is nil")
+		logger.Error(ctx, "Database connection is nil", err)
+		return err
+	}
+
+	var source fs.FS
+	if dir := os.Getenv("REGION_DATA_DIR"); dir != "" {
+		source = os.DirFS(dir)
+	} else {
+		sub, err := fs.Sub(regionData, "data/regions")
+		if err != nil {
+			return err
+		}
+		source = sub
+	}
+
+	checksum, err := regionChecksum(source)
+	if err != nil {
+		logger.Error(ctx, "Failed to read region dataset", err)
+		return err
+	}
+
+	var current models.RegionDataset
+	err = db.WithContext(ctx).Where("id = ?", regionDatasetID).First(&current).Error
+	if err == nil && current.Checksum == checksum {
+		return nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error(ctx, "Failed to query region dataset", err)
+		return err
+	}
+
+	data, err := loadRegionDataset(source)
+	if err != nil {
+		logger.Error(ctx, "Failed to parse region dataset", err)
+		return err
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := upsertRegion(tx, data.provinces, "name"); err != nil {
+			return err
+		}
+		if err := upsertRegion(tx, data.regencies, "province_id", "name"); err != nil {
+			return err
+		}
+		if err := upsertRegion(tx, data.districts, "regency_id", "name"); err != nil {
+			return err
+		}
+		if err := upsertRegion(tx, data.villages, "district_id", "name", "postal_code"); err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"checksum", "seeded_at"}),
+		}).Create(&models.RegionDataset{ID: regionDatasetID, Checksum: checksum, SeededAt: time.Now()}).Error
+	})
+	if err != nil {
+		logger.Error(ctx, "Failed to seed region", err)
+		return err
+	}
+
+	logger.Info(ctx, "Region seeded", map[string]interface{}{
+		"provinces": len(data.provinces),
+		"regencies": len(data.regencies),
+		"districts": len(data.districts),
+		"villages":  len(data.villages),
+	})
+	return nil
+}
+
+func upsertRegion[T any](tx *gorm.DB, rows []T, columns ...string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).CreateInBatches(rows, regionBatchSize).Error
+}
+
+func regionChecksum(source fs.FS) (string, error) {
+	hash := sha256.New()
+	for _, name := range regionFiles {
+		file, err := source.Open(name)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(hash, file)
+		file.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func loadRegionDataset(source fs.FS) (regionDataset, error) {
+	var data regionDataset
+
+	err := readRegionCSV(source, "provinces.csv", 2, func(rec []string, id int64) error {
+		data.provinces = append(data.provinces, models.Province{ID: id, Name: rec[1]})
+		return nil
+	})
+	if err != nil {
+		return data, err
+	}
+
+	err = readRegionCSV(source, "regencies.csv", 3, func(rec []string, id int64) error {
+		parentID, err := parseRegionID(rec[1])
+		if err != nil {
+			return err
+		}
+		data.regencies = append(data.regencies, models.Regency{ID: id, ProvinceID: parentID, Name: rec[2]})
+		return nil
+	})
+	if err != nil {
+		return data, err
+	}
+
+	err = readRegionCSV(source, "districts.csv", 3, func(rec []string, id int64) error {
+		parentID, err := parseRegionID(rec[1])
+		if err != nil {
+			return err
+		}
+		data.districts = append(data.districts, models.District{ID: id, RegencyID: parentID, Name: rec[2]})
+		return nil
+	})
+	if err != nil {
+		return data, err
+	}
+
+	err = readRegionCSV(source, "villages.csv", 4, func(rec []string, id int64) error {
+		parentID, err := parseRegionID(rec[1])
+		if err != nil {
+			return err
+		}
+		data.villages = append(data.villages, models.Village{ID: id, DistrictID: parentID, Name: rec[2], PostalCode: rec[3]})
+		return nil
+	})
+	return data, err
+}
+
+// readRegionCSV baca file csv dengan header, kolom pertama selalu id (kode tanpa titik)
+func readRegionCSV(source fs.FS, name string, columns int, fn func(rec []string, id int64) error) error {
+	file, err := source.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = columns
+	reader.TrimLeadingSpace = true
+
+	if _, err := reader.Read(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		for i := range rec {
+			rec[i] = strings.TrimSpace(rec[i])
+		}
+		id, err := parseRegionID(rec[0])
+		if err != nil {
+			return fmt.Errorf("%s: invalid id %q", name, rec[0])
+		}
+		if err := fn(rec, id); err != nil {
+			line, _ := reader.FieldPos(0)
+			return fmt.Errorf("%s line %d: %w", name, line, err)
+		}
+	}
+}
+
+// parseRegionID terima kode dengan atau tanpa titik (11.01.01 / 110101)
+func parseRegionID(code string) (int64, error) {
+	return strconv.ParseInt(strings.ReplaceAll(code, ".", ""), 10, 64)
+}
@@ -0,0 +1,409 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AddressUseCase buku alamat pelanggan. Storefront memakai alamat milik user login atau customer
+// guest (dari guest token), dashboard memakai alamat pada data customer. Tiap jenis alamat
+// (shipping, billing, store) punya tepat satu alamat utama selama alamat jenis itu masih ada.
+type AddressUseCase interface {
+	GetListAddress(ctx context.Context) ([]response.AddressResponse, error)
+	GetAddressByID(ctx context.Context, id int64) (response.AddressResponse, error)
+	CreateAddress(ctx context.Context, req *request.ReqAddress) (response.AddressResponse, error)
+	UpdateAddressByID(ctx context.Context, req *request.ReqAddress) (response.AddressResponse, error)
+	DeleteAddressByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
+	GetListCustomerAddress(ctx context.Context, customerID int64) ([]response.AddressResponse, error)
+	CreateCustomerAddress(ctx context.Context, req *request.ReqAddress) (response.AddressResponse, error)
+	UpdateCustomerAddressByID(ctx context.Context, req *request.ReqAddress) (response.AddressResponse, error)
+}
+
+type addressUseCase struct {
+	db           *gorm.DB
+	addressRepo  repo.AddressRepository
+	customerRepo repo.CustomerRepository
+	regionUC     RegionUseCase
+}
+
+func NewAddressUseCase(db *gorm.DB, addressRepo repo.AddressRepository, customerRepo repo.CustomerRepository, regionUC RegionUseCase) AddressUseCase {
+	return &addressUseCase{
+		db:           db,
+		addressRepo:  addressRepo,
+		customerRepo: customerRepo,
+		regionUC:     regionUC,
+	}
+}
+
+func (uc *addressUseCase) GetListAddress(ctx context.Context) ([]response.AddressResponse, error) {
+	owner, err := uc.getOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if owner.IsEmpty() {
+		return []response.AddressResponse{}, nil
+	}
+
+	addresses, err := uc.addressRepo.GetListAddressByOwner(ctx, owner)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	return response.SetResponseListAddress(addresses), nil
+}
+
+func (uc *addressUseCase) GetAddressByID(ctx context.Context, id int64) (response.AddressResponse, error) {
+	owner, err := uc.getOwner(ctx)
+	if err != nil {
+		return response.AddressResponse{}, err
+	}
+	if owner.IsEmpty() {
+		return response.AddressResponse{}, errorutils.ErrDataNotFound
+	}
+
+	address, err := uc.addressRepo.GetAddressByOwner(ctx, owner, id)
+	if err != nil {
+		return response.AddressResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	return response.SetAddressResponse(address), nil
+}
+
+// CreateAddress guest yang belum punya data customer dibuatkan customer guest dari penerima alamat
+func (uc *addressUseCase) CreateAddress(ctx context.Context, req *request.ReqAddress) (response.AddressResponse, error) {
+	if err := req.ValidateRequestCreate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.AddressResponse{}, err
+	}
+
+	owner, err := uc.getOwner(ctx)
+	if err != nil {
+		return response.AddressResponse{}, err
+	}
+	userID, _ := utils.GetUserIDFromCtx(ctx)
+
+	var id int64
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		if owner.IsEmpty() {
+			customer := models.Customer{
+				Name:       req.RecipientName,
+				Phone:      req.PhoneNumber,
+				IsGuest:    true,
+				GuestToken: utils.GetGuestTokenFromCtx(ctx),
+			}
+			if err := uc.customerRepo.Create(ctx, &customer); err != nil {
+				return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.customerRepo))
+			}
+			owner.CustomerID = customer.ID
+		}
+
+		id, err = uc.createAddress(ctx, owner, req, userID)
+		return err
+	})
+	if err != nil {
+		return response.AddressResponse{}, err
+	}
+
+	return uc.getAddressResponse(ctx, id)
+}
+
+func (uc *addressUseCase) UpdateAddressByID(ctx context.Context, req *request.ReqAddress) (response.AddressResponse, error) {
+	if err := req.ValidateRequestUpdate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.AddressResponse{}, err
+	}
+
+	owner, err := uc.getOwner(ctx)
+	if err != nil {
+		return response.AddressResponse{}, err
+	}
+	if owner.IsEmpty() {
+		return response.AddressResponse{}, errorutils.ErrDataNotFound
+	}
+
+	addressDb, err := uc.addressRepo.GetAddressByOwner(ctx, owner, req.ID)
+	if err != nil {
+		return response.AddressResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	userID, _ := utils.GetUserIDFromCtx(ctx)
+	if err := uc.updateAddress(ctx, addressDb, req, userID); err != nil {
+		return response.AddressResponse{}, err
+	}
+
+	return uc.getAddressResponse(ctx, addressDb.ID)
+}
+
+// DeleteAddressByID bila alamat utama dihapus, alamat pertama dengan jenis yang sama menjadi alamat utama
+func (uc *addressUseCase) DeleteAddressByID(ctx context.Context, id int64, reqData request.AbstractRequest) error {
+	if err := reqData.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+
+	owner, err := uc.getOwner(ctx)
+	if err != nil {
+		return err
+	}
+	if owner.IsEmpty() {
+		return errorutils.ErrDataNotFound
+	}
+
+	addressDb, err := uc.addressRepo.GetAddressByOwner(ctx, owner, id)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(reqData.UpdatedAt, addressDb.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		err := uc.addressRepo.DeleteAddressByID(ctx, id, reqData.UpdatedAt)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.addressRepo))
+		}
+
+		if addressDb.IsDefault {
+			err := uc.addressRepo.SetFirstAddressAsDefault(ctx, addressDb.Owner(), addressDb.AddressType)
+			if err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (uc *addressUseCase) GetListCustomerAddress(ctx context.Context, customerID int64) ([]response.AddressResponse, error) {
+	owner, err := uc.getCustomerOwner(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := uc.addressRepo.GetListAddressByOwner(ctx, owner)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	return response.SetResponseListAddress(addresses), nil
+}
+
+func (uc *addressUseCase) CreateCustomerAddress(ctx context.Context, req *request.ReqAddress) (response.AddressResponse, error) {
+	if err := req.ValidateRequestCreate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.AddressResponse{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.AddressResponse{}, errorutils.ErrDataNotFound
+	}
+
+	owner, err := uc.getCustomerOwner(ctx, req.CustomerID)
+	if err != nil {
+		return response.AddressResponse{}, err
+	}
+
+	id, err := uc.createAddress(ctx, owner, req, userID)
+	if err != nil {
+		return response.AddressResponse{}, err
+	}
+
+	return uc.getAddressResponse(ctx, id)
+}
+
+func (uc *addressUseCase) UpdateCustomerAddressByID(ctx context.Context, req *request.ReqAddress) (response.AddressResponse, error) {
+	if err := req.ValidateRequestUpdate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.AddressResponse{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.AddressResponse{}, errorutils.ErrDataNotFound
+	}
+
+	addressDb, err := uc.addressRepo.GetAddressByID(ctx, req.ID)
+	if err != nil {
+		return response.AddressResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	if err := uc.updateAddress(ctx, addressDb, req, userID); err != nil {
+		return response.AddressResponse{}, err
+	}
+
+	return uc.getAddressResponse(ctx, addressDb.ID)
+}
+
+func (uc *addressUseCase) createAddress(ctx context.Context, owner models.AddressOwner, req *request.ReqAddress, userID int64) (int64, error) {
+	region, err := uc.regionUC.ResolveRegion(ctx, req.Region())
+	if err != nil {
+		return 0, err
+	}
+	if err := requireRegionNames(ctx, region); err != nil {
+		return 0, err
+	}
+
+	address := models.Address{
+		Label:         req.Label,
+		RecipientName: req.RecipientName,
+		PhoneNumber:   req.PhoneNumber,
+		AddressLine1:  req.AddressLine1,
+		AddressLine2:  req.AddressLine2,
+		Country:       req.Country,
+		AddressType:   req.AddressType,
+		IsDefault:     req.IsDefault,
+		CreatedBy:     userID,
+		UpdatedBy:     userID,
+	}
+	address.SetRegion(region)
+	if owner.UserID != 0 {
+		address.UserID = &owner.UserID
+	}
+	if owner.CustomerID != 0 {
+		address.CustomerID = &owner.CustomerID
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		addresses, err := uc.addressRepo.GetListAddressByOwner(ctx, owner)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if len(addresses) >= request.MaxAddresses {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageAddressFull, constanta.FieldAddress)
+		}
+
+		if address.IsDefault {
+			if err := uc.addressRepo.UnsetDefaultAddress(ctx, owner, address.AddressType); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+
+		if err := uc.addressRepo.Create(ctx, &address); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.addressRepo))
+		}
+
+		// alamat pertama dari jenisnya otomatis menjadi alamat utama
+		if err := uc.addressRepo.SetFirstAddressAsDefault(ctx, owner, address.AddressType); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return address.ID, nil
+}
+
+func (uc *addressUseCase) updateAddress(ctx context.Context, addressDb models.Address, req *request.ReqAddress, userID int64) error {
+	if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, addressDb.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+
+	typeChanged := addressDb.AddressType != req.AddressType
+	if addressDb.IsDefault && !req.IsDefault && !typeChanged {
+		return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageAddressDefault, constanta.FieldAddress)
+	}
+
+	region, err := uc.regionUC.ResolveRegion(ctx, req.Region())
+	if err != nil {
+		return err
+	}
+	if err := requireRegionNames(ctx, region); err != nil {
+		return err
+	}
+
+	address := addressDb
+	address.Label = req.Label
+	address.RecipientName = req.RecipientName
+	address.PhoneNumber = req.PhoneNumber
+	address.AddressLine1 = req.AddressLine1
+	address.AddressLine2 = req.AddressLine2
+	address.SetRegion(region)
+	address.Country = req.Country
+	address.AddressType = req.AddressType
+	address.IsDefault = req.IsDefault
+	address.UpdatedAt = time.Now()
+	address.UpdatedBy = userID
+
+	owner := addressDb.Owner()
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		if req.IsDefault && (!addressDb.IsDefault || typeChanged) {
+			if err := uc.addressRepo.UnsetDefaultAddress(ctx, owner, req.AddressType); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+
+		_, err := uc.addressRepo.UpdateAddressByID(ctx, addressDb.ID, req.UpdatedAt, address)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.addressRepo))
+		}
+
+		// alamat pindah jenis: kedua jenis tetap punya alamat utama
+		if typeChanged {
+			for _, addressType := range []string{addressDb.AddressType, req.AddressType} {
+				if err := uc.addressRepo.SetFirstAddressAsDefault(ctx, owner, addressType); err != nil {
+					return errorutils.HandleRepoError(ctx, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (uc *addressUseCase) getAddressResponse(ctx context.Context, id int64) (response.AddressResponse, error) {
+	address, err := uc.addressRepo.GetAddressByID(ctx, id)
+	if err != nil {
+		return response.AddressResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	return response.SetAddressResponse(address), nil
+}
+
+func (uc *addressUseCase) getCustomerOwner(ctx context.Context, customerID int64) (models.AddressOwner, error) {
+	customer, err := uc.customerRepo.GetCustomerByID(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.AddressOwner{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldCustomer)
+		}
+		return models.AddressOwner{}, errorutils.HandleRepoError(ctx, err)
+	}
+	return models.AddressOwner{UserID: customer.UserID, CustomerID: customer.ID}, nil
+}
+
+// getOwner user login (beserta customer-nya bila ada) atau customer guest dari guest token,
+// kosong bila guest belum punya data customer
+func (uc *addressUseCase) getOwner(ctx context.Context) (models.AddressOwner, error) {
+	var (
+		customer models.Customer
+		err      error
+	)
+	userID, _ := utils.GetUserIDFromCtx(ctx)
+	switch {
+	case userID != 0:
+		customer, err = uc.customerRepo.GetCustomerByUserID(ctx, userID)
+	case utils.GetGuestTokenFromCtx(ctx) != "":
+		customer, err = uc.customerRepo.GetCustomerByGuestToken(ctx, utils.GetGuestTokenFromCtx(ctx))
+	default:
+		return models.AddressOwner{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageUserNotLogin, constanta.FieldUserID)
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.AddressOwner{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	return models.AddressOwner{UserID: userID, CustomerID: customer.ID}, nil
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AttributeUseCase interface {
+	CreateAttribute(ctx context.Context, req *request.ReqAttribute) error
+	GetAttributeByID(ctx context.Context, id int64) (response.AttributeResponse, error)
+	GetListAttribute(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.AttributeResponse], error)
+	UpdateAttributeByID(ctx context.Context, req *request.ReqAttributeUpdate) (response.AttributeResponse, error)
+	DeleteAttributeByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
+}
+
+type attributeUseCase struct {
+	db            *gorm.DB
+	attributeRepo repo.AttributeRepository
+	categoryRepo  repo.CategoryRepository
+}
+
+func NewAttributeUseCase(db *gorm.DB, attributeRepo repo.AttributeRepository, categoryRepo repo.CategoryRepository) AttributeUseCase {
+	return &attributeUseCase{
+		db:            db,
+		attributeRepo: attributeRepo,
+		categoryRepo:  categoryRepo,
+	}
+}
+
+func (uc *attributeUseCase) CreateAttribute(ctx context.Context, req *request.ReqAttribute) error {
+	if err := req.ValidateRequestCreate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return err
+	}
+
+	if _, err := uc.categoryRepo.GetCategoryByID(ctx, req.CategoryID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldCategory)
+		}
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return errorutils.ErrDataNotFound
+	}
+
+	attribute := models.AttributeDefinition{
+		CategoryID:    req.CategoryID,
+		Code:          req.Code,
+		Name:          req.Name,
+		DataType:      req.DataType,
+		AllowedValues: req.AllowedValues,
+		Unit:          req.Unit,
+		IsRequired:    req.IsRequired,
+		IsFilterable:  *req.IsFilterable,
+		SortOrder:     req.SortOrder,
+		CreatedBy:     userID,
+		UpdatedBy:     userID,
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		err := uc.attributeRepo.Create(ctx, &attribute)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.attributeRepo))
+		}
+		return nil
+	})
+}
+
+func (uc *attributeUseCase) GetAttributeByID(ctx context.Context, id int64) (response.AttributeResponse, error) {
+	attribute, err := uc.attributeRepo.GetAttributeByID(ctx, id)
+	if err != nil {
+		return response.AttributeResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	return response.SetAttributeResponse(attribute), nil
+}
+
+func (uc *attributeUseCase) GetListAttribute(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.AttributeResponse], error) {
+	attributes, count, err := uc.attributeRepo.GetListAttribute(ctx, listStruct)
+	if err != nil {
+		return response.ListResponse[response.AttributeResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListAttribute(attributes), count, listStruct, repo.GetFilterAvailableFromRepo(uc.attributeRepo))
+	return listResponse, nil
+}
+
+// UpdateAttributeByID kategori dan tipe atribut tidak bisa diubah karena nilai produk yang sudah ada
+// disimpan sesuai tipe tersebut
+func (uc *attributeUseCase) UpdateAttributeByID(ctx context.Context, req *request.ReqAttributeUpdate) (response.AttributeResponse, error) {
+	if err := req.ValidateUpdatedAt(); err != nil {
+		return response.AttributeResponse{}, err
+	}
+
+	if err := req.ValidateRequestCreate(); err != nil {
+		return response.AttributeResponse{}, err
+	}
+
+	attributeDb, err := uc.attributeRepo.GetAttributeByID(ctx, req.ID)
+	if err != nil {
+		return response.AttributeResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, attributeDb.UpdatedAt) {
+		return response.AttributeResponse{}, errorutils.ErrDataDataUpdated
+	}
+
+	if req.CategoryID != attributeDb.CategoryID || req.DataType != attributeDb.DataType {
+		return response.AttributeResponse{}, errorutils.HandleCustomError(ctx, nil, "category_id dan data_type atribut tidak bisa diubah", constanta.FieldAttribute)
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.AttributeResponse{}, errorutils.ErrDataNotFound
+	}
+
+	attribute := models.AttributeDefinition{
+		ID:            req.ID,
+		CategoryID:    attributeDb.CategoryID,
+		Code:          req.Code,
+		Name:          req.Name,
+		DataType:      attributeDb.DataType,
+		AllowedValues: req.AllowedValues,
+		Unit:          req.Unit,
+		IsRequired:    req.IsRequired,
+		IsFilterable:  *req.IsFilterable,
+		SortOrder:     req.SortOrder,
+		CreatedAt:     attributeDb.CreatedAt,
+		CreatedBy:     attributeDb.CreatedBy,
+		UpdatedAt:     time.Now(),
+		UpdatedBy:     userID,
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		_, err := uc.attributeRepo.UpdateAttributeByID(ctx, req.ID, req.UpdatedAt, attribute)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.attributeRepo))
+		}
+		return nil
+	})
+	if err != nil {
+		return response.AttributeResponse{}, err
+	}
+
+	return response.SetAttributeResponse(attribute), nil
+}
+
+func (uc *attributeUseCase) DeleteAttributeByID(ctx context.Context, id int64, reqData request.AbstractRequest) error {
+	if err := reqData.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+
+	attribute, err := uc.attributeRepo.GetAttributeByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(reqData.UpdatedAt, attribute.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		// nilai atribut pada produk ikut terhapus (ON DELETE CASCADE)
+		err := uc.attributeRepo.DeleteAttributeByID(ctx, id, reqData.UpdatedAt)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+	})
+}
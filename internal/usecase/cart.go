@@ -0,0 +1,626 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/redis"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	cartTTL       = 30 * 24 * time.Hour
+	cartKeyPrefix = "cart:"
+	cartGuestSrc  = "cart"
+)
+
+// CartUseCase keranjang belanja user login / guest. Data dibaca dari Redis, setiap perubahan
+// disimpan ke Redis (TTL diperpanjang) dan Postgres sebagai cadangan bila key Redis hilang.
+// Harga tidak pernah disimpan, selalu dihitung ulang lewat PricingUseCase.
+type CartUseCase interface {
+	GetCart(ctx context.Context) (response.CartResponse, error)
+	AddCartItem(ctx context.Context, req *request.ReqCartItem) (response.CartResponse, error)
+	UpdateCartItem(ctx context.Context, req *request.ReqCartItem) (response.CartResponse, error)
+	RemoveCartItem(ctx context.Context, productID, varianID int64) (response.CartResponse, error)
+	ClearCart(ctx context.Context) error
+	MergeGuestCart(ctx context.Context, userID int64, guestToken string) error
+}
+
+type cartUseCase struct {
+	db                *gorm.DB
+	cartRepo          repo.CartRepository
+	productRepo       repo.ProductRepository
+	productVarianRepo repo.ProductVarianRepository
+	customerRepo      repo.CustomerRepository
+	pricingUC         PricingUseCase
+	inventoryUC       InventoryUseCase
+}
+
+func NewCartUseCase(db *gorm.DB,
+	cartRepo repo.CartRepository,
+	productRepo repo.ProductRepository,
+	productVarianRepo repo.ProductVarianRepository,
+	customerRepo repo.CustomerRepository,
+	pricingUC PricingUseCase,
+	inventoryUC InventoryUseCase) CartUseCase {
+	return &cartUseCase{
+		db:                db,
+		cartRepo:          cartRepo,
+		productRepo:       productRepo,
+		productVarianRepo: productVarianRepo,
+		customerRepo:      customerRepo,
+		pricingUC:         pricingUC,
+		inventoryUC:       inventoryUC,
+	}
+}
+
+// cartLine status satu item keranjang terhadap data produk saat ini
+type cartLine struct {
+	item      models.CartItem
+	product   models.Product
+	varian    models.ProductVarian
+	stock     int
+	priceable bool   // produk / varian ada dan aktif, harga bisa dihitung
+	message   string // kosong = item bisa dibeli
+	field     string
+}
+
+func (uc *cartUseCase) GetCart(ctx context.Context) (response.CartResponse, error) {
+	owner := cartOwnerFromCtx(ctx)
+	if owner.IsEmpty() {
+		return response.CartResponse{Items: []response.CartItemResponse{}}, nil
+	}
+
+	cart, err := uc.loadCart(ctx, owner)
+	if err != nil {
+		return response.CartResponse{}, err
+	}
+
+	return uc.buildCartResponse(ctx, owner, cart)
+}
+
+// AddCartItem quantity ditambahkan ke item yang sudah ada. Pengunjung tanpa user / guest token
+// dibuatkan guest token baru yang dikembalikan di response.
+func (uc *cartUseCase) AddCartItem(ctx context.Context, req *request.ReqCartItem) (response.CartResponse, error) {
+	if err := req.ValidateRequestAdd(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.CartResponse{}, err
+	}
+
+	owner := cartOwnerFromCtx(ctx)
+	var guestToken string
+	if owner.IsEmpty() {
+		var err error
+		owner, guestToken, err = newGuestCartOwner(ctx)
+		if err != nil {
+			return response.CartResponse{}, err
+		}
+	}
+
+	cart, err := uc.updateCart(ctx, owner, func(cart *models.Cart) error {
+		idx := cart.FindItem(req.ProductID, req.VarianID)
+		quantity := req.Quantity
+		if idx >= 0 {
+			quantity += cart.Items[idx].Quantity
+		} else if len(cart.Items) >= request.MaxCartItems {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCartFull, constanta.FieldProduct)
+		}
+
+		if err := uc.checkCartItem(ctx, req.ProductID, req.VarianID, quantity); err != nil {
+			return err
+		}
+
+		if idx >= 0 {
+			cart.Items[idx].Quantity = quantity
+			return nil
+		}
+		cart.Items = append(cart.Items, models.CartItem{
+			ProductID: req.ProductID,
+			VarianID:  req.VarianID,
+			Quantity:  quantity,
+		})
+		return nil
+	})
+	if err != nil {
+		return response.CartResponse{}, err
+	}
+
+	res, err := uc.buildCartResponse(ctx, owner, cart)
+	if err != nil {
+		return response.CartResponse{}, err
+	}
+	res.GuestToken = guestToken
+	return res, nil
+}
+
+// UpdateCartItem mengganti quantity item, quantity 0 = hapus item
+func (uc *cartUseCase) UpdateCartItem(ctx context.Context, req *request.ReqCartItem) (response.CartResponse, error) {
+	if err := req.ValidateRequestUpdate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.CartResponse{}, err
+	}
+
+	owner := cartOwnerFromCtx(ctx)
+	if owner.IsEmpty() {
+		return response.CartResponse{}, errorutils.ErrDataNotFound
+	}
+
+	cart, err := uc.updateCart(ctx, owner, func(cart *models.Cart) error {
+		idx := cart.FindItem(req.ProductID, req.VarianID)
+		if idx < 0 {
+			return errorutils.ErrDataNotFound
+		}
+
+		if req.Quantity == 0 {
+			cart.Items = append(cart.Items[:idx], cart.Items[idx+1:]...)
+			return nil
+		}
+
+		if err := uc.checkCartItem(ctx, req.ProductID, req.VarianID, req.Quantity); err != nil {
+			return err
+		}
+		cart.Items[idx].Quantity = req.Quantity
+		return nil
+	})
+	if err != nil {
+		return response.CartResponse{}, err
+	}
+
+	return uc.buildCartResponse(ctx, owner, cart)
+}
+
+func (uc *cartUseCase) RemoveCartItem(ctx context.Context, productID, varianID int64) (response.CartResponse, error) {
+	owner := cartOwnerFromCtx(ctx)
+	if owner.IsEmpty() {
+		return response.CartResponse{}, errorutils.ErrDataNotFound
+	}
+
+	cart, err := uc.updateCart(ctx, owner, func(cart *models.Cart) error {
+		idx := cart.FindItem(productID, varianID)
+		if idx < 0 {
+			return errorutils.ErrDataNotFound
+		}
+		cart.Items = append(cart.Items[:idx], cart.Items[idx+1:]...)
+		return nil
+	})
+	if err != nil {
+		return response.CartResponse{}, err
+	}
+
+	return uc.buildCartResponse(ctx, owner, cart)
+}
+
+func (uc *cartUseCase) ClearCart(ctx context.Context) error {
+	owner := cartOwnerFromCtx(ctx)
+	if owner.IsEmpty() {
+		return nil
+	}
+
+	_, err := uc.updateCart(ctx, owner, func(cart *models.Cart) error {
+		cart.Items = nil
+		return nil
+	})
+	return err
+}
+
+// MergeGuestCart dipanggil saat guest login: item keranjang guest digabung ke keranjang user
+// (quantity dijumlah lalu dibatasi stok, item yang tidak tersedia dibuang), data customer guest
+// dipindahkan ke user, lalu guest token tidak berlaku lagi
+func (uc *cartUseCase) MergeGuestCart(ctx context.Context, userID int64, guestToken string) error {
+	claims, err := utils.ParseGuestToken(guestToken)
+	if err != nil {
+		return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageInvalidOrExpiredToken)
+	}
+
+	revoked, err := utils.IsGuestTokenRevoked(ctx, claims.GuestID)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+	if revoked {
+		return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageInvalidOrExpiredToken)
+	}
+
+	guestOwner := models.CartOwner{GuestID: claims.GuestID}
+	_, err = uc.updateCart(ctx, models.CartOwner{UserID: userID}, func(cart *models.Cart) error {
+		guestCart, err := uc.cartRepo.DeleteCartByOwner(ctx, guestOwner)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if guestCart.ExpiresAt.After(time.Now()) {
+			if err := uc.mergeCartItems(ctx, cart, guestCart.Items); err != nil {
+				return err
+			}
+		}
+
+		err = uc.customerRepo.LinkGuestCustomerToUser(ctx, guestToken, userID)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.customerRepo))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := redis.DeleteFromRedis(ctx, cartKey(guestOwner)); err != nil {
+		logger.Error(ctx, "Failed to delete guest cart from Redis", err)
+	}
+
+	if err := utils.RevokeGuestToken(ctx, claims); err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+	return nil
+}
+
+// mergeCartItems hanya item yang berasal dari keranjang guest yang disesuaikan dengan stok,
+// item milik user yang tidak ikut digabung dibiarkan apa adanya
+func (uc *cartUseCase) mergeCartItems(ctx context.Context, cart *models.Cart, guestItems []models.CartItem) error {
+	if len(guestItems) == 0 {
+		return nil
+	}
+
+	merged := make([]models.CartItem, 0, len(guestItems))
+	for _, item := range guestItems {
+		if idx := cart.FindItem(item.ProductID, item.VarianID); idx >= 0 {
+			item.Quantity += cart.Items[idx].Quantity
+		}
+		merged = append(merged, item)
+	}
+
+	lines, err := uc.getCartLines(ctx, merged)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		idx := cart.FindItem(line.item.ProductID, line.item.VarianID)
+		if !line.priceable {
+			continue
+		}
+
+		quantity := min(line.item.Quantity, line.stock)
+		switch {
+		case idx >= 0:
+			if quantity > 0 {
+				cart.Items[idx].Quantity = quantity
+			}
+		case quantity > 0 && len(cart.Items) < request.MaxCartItems:
+			cart.Items = append(cart.Items, models.CartItem{
+				ProductID: line.item.ProductID,
+				VarianID:  line.item.VarianID,
+				Quantity:  quantity,
+				CreatedAt: line.item.CreatedAt,
+			})
+		}
+	}
+	return nil
+}
+
+// loadCart keranjang dari Redis, bila key tidak ada (hilang / Redis restart) dipulihkan dari Postgres.
+// Keranjang yang belum pernah dibuat atau sudah kadaluwarsa dikembalikan kosong.
+func (uc *cartUseCase) loadCart(ctx context.Context, owner models.CartOwner) (models.Cart, error) {
+	value, err := redis.GetFromRedis(ctx, cartKey(owner))
+	if err != nil {
+		logger.Error(ctx, "Failed to get cart from Redis, fallback to database", err)
+	}
+	if value != "" {
+		var cart models.Cart
+		err := json.Unmarshal([]byte(value), &cart)
+		if err == nil {
+			return cart, nil
+		}
+		logger.Error(ctx, "Failed to decode cart from Redis, fallback to database", err)
+	}
+
+	cart, err := uc.cartRepo.GetCartByOwner(ctx, owner)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Cart{}, nil
+		}
+		return models.Cart{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	ttl := time.Until(cart.ExpiresAt)
+	if ttl <= 0 {
+		return models.Cart{}, nil
+	}
+
+	// SETNX supaya tidak menimpa keranjang yang baru saja diubah request lain
+	b, err := json.Marshal(cart)
+	if err == nil {
+		_, err = redis.SetIfNotExistsWithTTL(ctx, cartKey(owner), string(b), ttl)
+	}
+	if err != nil {
+		logger.Error(ctx, "Failed to restore cart to Redis", err)
+	}
+	return cart, nil
+}
+
+// updateCart fn dijalankan terhadap isi keranjang terbaru di Postgres selagi baris keranjang
+// terkunci, hasilnya disimpan ke Postgres lalu Redis. Redis ditulis sebelum commit (masih
+// terkunci) supaya urutan tulis ke Redis sama dengan urutan commit; bila transaksi gagal
+// key Redis dihapus dan akan dipulihkan dari Postgres saat dibaca.
+func (uc *cartUseCase) updateCart(ctx context.Context, owner models.CartOwner, fn func(cart *models.Cart) error) (models.Cart, error) {
+	now := time.Now()
+	expiresAt := now.Add(cartTTL)
+
+	var (
+		cart   models.Cart
+		cached bool
+	)
+	err := processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		locked, err := uc.cartRepo.LockCartByOwner(ctx, owner, expiresAt)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.cartRepo))
+		}
+
+		// keranjang kadaluwarsa yang belum dibersihkan dimulai dari kosong
+		if locked.ExpiresAt.Before(now) {
+			locked.Items = nil
+		}
+
+		if err := fn(&locked); err != nil {
+			return err
+		}
+
+		err = uc.cartRepo.ReplaceCartItems(ctx, locked.ID, locked.Items, expiresAt)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.cartRepo))
+		}
+		locked.ExpiresAt = expiresAt
+		locked.UpdatedAt = now
+		cart = locked
+
+		cached = true
+		if err := saveCartToRedis(ctx, owner, cart); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		if cached {
+			if err := redis.DeleteFromRedis(ctx, cartKey(owner)); err != nil {
+				logger.Error(ctx, "Failed to delete cart from Redis", err)
+			}
+		}
+		return models.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+// checkCartItem produk / varian harus aktif dan stoknya cukup untuk quantity
+func (uc *cartUseCase) checkCartItem(ctx context.Context, productID, varianID int64, quantity int) error {
+	lines, err := uc.getCartLines(ctx, []models.CartItem{{
+		ProductID: productID,
+		VarianID:  varianID,
+		Quantity:  quantity,
+	}})
+	if err != nil {
+		return err
+	}
+
+	if lines[0].message != "" {
+		return errorutils.HandleCustomError(ctx, nil, lines[0].message, lines[0].field)
+	}
+	return nil
+}
+
+func (uc *cartUseCase) getCartLines(ctx context.Context, items []models.CartItem) ([]cartLine, error) {
+	if len(items) == 0 {
+		return []cartLine{}, nil
+	}
+
+	var productIDs, varianIDs []int64
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+		if item.VarianID != 0 {
+			varianIDs = append(varianIDs, item.VarianID)
+		}
+	}
+
+	products, err := uc.productRepo.GetProductByListIDs(ctx, uniqueInt64s(productIDs))
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	productMap := make(map[int64]models.Product, len(products))
+	var bundleIDs []int64
+	for _, p := range products {
+		productMap[p.ID] = p
+		if p.ProductType == models.ProductTypeBundle {
+			bundleIDs = append(bundleIDs, p.ID)
+		}
+	}
+
+	varianMap := make(map[int64]models.ProductVarian, len(varianIDs))
+	if len(varianIDs) > 0 {
+		varians, err := uc.productVarianRepo.GetProductVarianByListIDs(ctx, uniqueInt64s(varianIDs))
+		if err != nil {
+			return nil, errorutils.HandleRepoError(ctx, err)
+		}
+		for _, v := range varians {
+			varianMap[v.ID] = v
+		}
+	}
+
+	availability, err := uc.inventoryUC.GetBundleAvailability(ctx, bundleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]cartLine, 0, len(items))
+	for _, item := range items {
+		line := cartLine{item: item}
+		product, ok := productMap[item.ProductID]
+		line.product = product
+
+		switch {
+		case !ok:
+			line.message, line.field = errorutils.ErrMessageDataNotFound, constanta.FieldProduct
+		case !product.IsActive:
+			line.message, line.field = errorutils.ErrMessageProductInactive, constanta.FieldProduct
+		case item.VarianID != 0:
+			varian, ok := varianMap[item.VarianID]
+			line.varian = varian
+			switch {
+			case !ok || varian.ProductID != product.ID:
+				line.message, line.field = errorutils.ErrMessageDataNotFound, constanta.FieldVarian
+			case !varian.IsActive:
+				line.message, line.field = errorutils.ErrMessageVarianInactive, constanta.FieldVarian
+			default:
+				line.stock = varian.Stock
+				line.priceable = true
+			}
+		case product.HasVarian:
+			line.message, line.field = errorutils.ErrMessageVarianRequired, constanta.FieldVarian
+		case product.ProductType == models.ProductTypeBundle:
+			line.stock = availability[product.ID]
+			line.priceable = true
+		default:
+			line.stock = product.Stock
+			line.priceable = true
+		}
+
+		if line.priceable && item.Quantity > line.stock {
+			line.message, line.field = errorutils.ErrMessageStockInsufficient, constanta.FieldStock
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// buildCartResponse harga dihitung ulang dengan harga pelanggan (price list) saat ini
+func (uc *cartUseCase) buildCartResponse(ctx context.Context, owner models.CartOwner, cart models.Cart) (response.CartResponse, error) {
+	res := response.CartResponse{Items: make([]response.CartItemResponse, 0, len(cart.Items))}
+	if cart.ID != 0 {
+		expiresAt := cart.ExpiresAt
+		res.ExpiresAt = &expiresAt
+	}
+	if len(cart.Items) == 0 {
+		return res, nil
+	}
+
+	lines, err := uc.getCartLines(ctx, cart.Items)
+	if err != nil {
+		return response.CartResponse{}, err
+	}
+
+	queries := make([]models.PriceQuery, 0, len(lines))
+	for _, line := range lines {
+		if line.priceable {
+			queries = append(queries, models.PriceQuery{
+				ProductID: line.item.ProductID,
+				VarianID:  line.item.VarianID,
+				Quantity:  line.item.Quantity,
+			})
+		}
+	}
+
+	var prices []models.ResolvedPrice
+	if len(queries) > 0 {
+		pricingCtx, err := uc.getPricingContext(ctx, owner)
+		if err != nil {
+			return response.CartResponse{}, err
+		}
+		prices, err = uc.pricingUC.ResolvePrices(ctx, pricingCtx, queries)
+		if err != nil {
+			return response.CartResponse{}, err
+		}
+	}
+
+	for _, line := range lines {
+		item := response.CartItemResponse{
+			ProductID:   line.item.ProductID,
+			VarianID:    line.item.VarianID,
+			ProductName: line.product.Name,
+			ProductSlug: line.product.Slug,
+			VarianName:  line.varian.Name,
+			Quantity:    line.item.Quantity,
+			Stock:       line.stock,
+			IsAvailable: line.message == "",
+			Message:     line.message,
+		}
+
+		if line.priceable {
+			price := prices[0]
+			prices = prices[1:]
+			item.BasePrice = price.BasePrice
+			item.UnitPrice = price.UnitPrice
+			item.PriceSource = price.Source
+			item.LineTotal = utils.RoundTo2Digits(price.UnitPrice * float64(line.item.Quantity))
+		}
+
+		res.TotalQuantity += item.Quantity
+		if item.IsAvailable {
+			res.Subtotal += item.LineTotal
+		}
+		res.Items = append(res.Items, item)
+	}
+	res.Subtotal = utils.RoundTo2Digits(res.Subtotal)
+
+	return res, nil
+}
+
+// getPricingContext guest selalu memakai harga umum
+func (uc *cartUseCase) getPricingContext(ctx context.Context, owner models.CartOwner) (models.PricingContext, error) {
+	pricingCtx := models.PricingContext{At: time.Now()}
+	if owner.UserID == 0 {
+		return pricingCtx, nil
+	}
+
+	customer, err := uc.customerRepo.GetCustomerByUserID(ctx, owner.UserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.PricingContext{}, errorutils.HandleRepoError(ctx, err)
+	}
+	pricingCtx.CustomerID = customer.ID
+	pricingCtx.CustomerGroupID = customer.CustomerGroupID
+	return pricingCtx, nil
+}
+
+// cartOwnerFromCtx user login diutamakan, selain itu guest id dari guest token
+func cartOwnerFromCtx(ctx context.Context) models.CartOwner {
+	if userID, _ := utils.GetUserIDFromCtx(ctx); userID != 0 {
+		return models.CartOwner{UserID: userID}
+	}
+	return models.CartOwner{GuestID: utils.GetGuestIDFromCtx(ctx)}
+}
+
+func newGuestCartOwner(ctx context.Context) (models.CartOwner, string, error) {
+	token, err := utils.GenerateGuestToken("", "", cartGuestSrc)
+	if err != nil {
+		logger.Error(ctx, "Failed to generate guest token", err)
+		return models.CartOwner{}, "", errorutils.ErrGenerateGuestToken
+	}
+
+	claims, err := utils.ParseGuestToken(token)
+	if err != nil {
+		logger.Error(ctx, "Failed to parse guest token", err)
+		return models.CartOwner{}, "", errorutils.ErrGenerateGuestToken
+	}
+
+	return models.CartOwner{GuestID: claims.GuestID}, token, nil
+}
+
+func cartKey(owner models.CartOwner) string {
+	return cartKeyPrefix + owner.Key()
+}
+
+func saveCartToRedis(ctx context.Context, owner models.CartOwner, cart models.Cart) error {
+	b, err := json.Marshal(cart)
+	if err != nil {
+		return err
+	}
+	return redis.SetToRedisWithTTL(ctx, cartKey(owner), string(b), cartTTL)
+}
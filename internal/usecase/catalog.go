@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"context"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/storage"
+	"strings"
+)
+
+var (
+	// filter & order yang boleh dipakai dari storefront, selain ini diabaikan
+	// agar field internal (cost_price, created_by, ...) tidak bisa ditebak lewat filter
+	CatalogProductFilterAllowed = map[string]bool{
+		"name":        true,
+		"brand":       true,
+		"price":       true,
+		"category_id": true,
+	}
+	CatalogProductOrderAllowed = map[string]bool{
+		"id":    true,
+		"name":  true,
+		"price": true,
+	}
+)
+
+type CatalogUseCase interface {
+	GetListProduct(ctx context.Context, listStruct *models.GetListStruct) (response.ProductListFacetResponse[response.PublicProductResponse], error)
+	GetProductBySlug(ctx context.Context, slug string) (response.PublicProductDetailResponse, error)
+	GetCategoryTree(ctx context.Context) ([]response.PublicCategoryTreeResponse, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (response.PublicCategoryDetailResponse, error)
+	SearchProduct(ctx context.Context, req *request.ReqSearch) (response.ListResponse[response.PublicProductSearchResponse], error)
+}
+
+type catalogUseCase struct {
+	productRepo      repo.ProductRepository
+	categoryRepo     repo.CategoryRepository
+	attributeRepo    repo.AttributeRepository
+	productMediaRepo repo.ProductMediaRepository
+	store            storage.Storage
+	pricingUC        PricingUseCase
+}
+
+func NewCatalogUseCase(productRepo repo.ProductRepository,
+	categoryRepo repo.CategoryRepository,
+	attributeRepo repo.AttributeRepository,
+	productMediaRepo repo.ProductMediaRepository,
+	store storage.Storage,
+	pricingUC PricingUseCase) CatalogUseCase {
+	return &catalogUseCase{
+		productRepo:      productRepo,
+		categoryRepo:     categoryRepo,
+		attributeRepo:    attributeRepo,
+		productMediaRepo: productMediaRepo,
+		store:            store,
+		pricingUC:        pricingUC,
+	}
+}
+
+func (uc *catalogUseCase) GetListProduct(ctx context.Context, listStruct *models.GetListStruct) (response.ProductListFacetResponse[response.PublicProductResponse], error) {
+	// filter atribut (attr.<code>) selalu diizinkan, nilainya hanya dicocokkan ke tabel nilai atribut
+	attrFilters := make(map[string][2]interface{})
+	for k, v := range listStruct.Filters {
+		if strings.HasPrefix(k, models.AttributeFilterPrefix) {
+			attrFilters[k] = v
+		}
+	}
+
+	listStruct = sanitizeListStruct(listStruct, CatalogProductFilterAllowed, CatalogProductOrderAllowed)
+	if err := validateCategoryFilter(ctx, listStruct); err != nil {
+		return response.ProductListFacetResponse[response.PublicProductResponse]{}, err
+	}
+	for k, v := range attrFilters {
+		listStruct.Filters[k] = v
+	}
+
+	// storefront hanya menampilkan produk aktif
+	listStruct.Filters["is_active"] = [2]interface{}{"=", true}
+
+	products, count, err := uc.productRepo.GetListProduct(ctx, listStruct)
+	if err != nil {
+		return response.ProductListFacetResponse[response.PublicProductResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	// facet tetap menghormati filter is_active di atas
+	facets, err := uc.productRepo.GetProductFacets(ctx, listStruct)
+	if err != nil {
+		return response.ProductListFacetResponse[response.PublicProductResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	list := response.SetResponseListPublicProduct(products)
+	prices, err := uc.getPublicPrices(ctx, products)
+	if err != nil {
+		return response.ProductListFacetResponse[response.PublicProductResponse]{}, err
+	}
+	for i := range list {
+		list[i].FinalPrice = prices[i]
+	}
+
+	if err := uc.setPrimaryImages(ctx, products, list); err != nil {
+		return response.ProductListFacetResponse[response.PublicProductResponse]{}, err
+	}
+
+	return response.ProductListFacetResponse[response.PublicProductResponse]{
+		ListResponse: response.MapToListResponse(list, count, listStruct, mapKeys(CatalogProductFilterAllowed)),
+		Facets:       response.SetProductFacetResponse(facets),
+	}, nil
+}
+
+func (uc *catalogUseCase) GetProductBySlug(ctx context.Context, slug string) (response.PublicProductDetailResponse, error) {
+	product, err := uc.productRepo.GetActiveProductBySlug(ctx, slug)
+	if err != nil {
+		return response.PublicProductDetailResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	res := response.SetPublicProductDetailResponse(product)
+
+	prices, err := uc.getPublicPrices(ctx, []models.Product{product})
+	if err != nil {
+		return response.PublicProductDetailResponse{}, err
+	}
+	res.FinalPrice = prices[0]
+
+	attributes, err := uc.attributeRepo.GetValueByProductID(ctx, product.ID)
+	if err != nil {
+		return response.PublicProductDetailResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	res.Attributes = response.SetResponseListProductAttribute(attributes)
+
+	media, err := uc.productMediaRepo.GetMediaByProductIDs(ctx, []int64{product.ID})
+	if err != nil {
+		return response.PublicProductDetailResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	res.Media = response.SetResponseListPublicProductMedia(media, uc.store.URL)
+	if len(media) > 0 {
+		res.ImageURL = primaryImageURL(media[0], uc.store.URL)
+	}
+
+	breadcrumbs, err := getProductBreadcrumbs(ctx, uc.categoryRepo, *product.ProductCategory)
+	if err != nil {
+		return response.PublicProductDetailResponse{}, err
+	}
+
+	res.Breadcrumbs = make([][]response.PublicCategoryResponse, 0, len(breadcrumbs))
+	for _, b := range breadcrumbs {
+		res.Breadcrumbs = append(res.Breadcrumbs, response.SetPublicBreadcrumbResponse(b))
+	}
+
+	return res, nil
+}
+
+func (uc *catalogUseCase) SearchProduct(ctx context.Context, req *request.ReqSearch) (response.ListResponse[response.PublicProductSearchResponse], error) {
+	if err := req.ValidateRequest(); err != nil {
+		return response.ListResponse[response.PublicProductSearchResponse]{}, err
+	}
+
+	params := models.SearchProductStruct{
+		Query:      req.Query,
+		ActiveOnly: true,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}
+
+	results, count, err := uc.productRepo.SearchProduct(ctx, params)
+	if err != nil {
+		return response.ListResponse[response.PublicProductSearchResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	list := response.SetResponseListPublicProductSearch(results)
+	products := make([]models.Product, 0, len(results))
+	for _, r := range results {
+		products = append(products, r.Product)
+	}
+
+	prices, err := uc.getPublicPrices(ctx, products)
+	if err != nil {
+		return response.ListResponse[response.PublicProductSearchResponse]{}, err
+	}
+	for i := range list {
+		list[i].FinalPrice = prices[i]
+	}
+
+	listResponse := response.MapToListResponse(list, count, &models.GetListStruct{Page: req.Page, Limit: req.Limit}, []string{})
+	return listResponse, nil
+}
+
+func (uc *catalogUseCase) GetCategoryTree(ctx context.Context) ([]response.PublicCategoryTreeResponse, error) {
+	categories, err := uc.categoryRepo.GetCategoryTree(ctx, "")
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	return response.SetPublicCategoryTreeResponse(response.SetCategoryTreeResponse(categories)), nil
+}
+
+func (uc *catalogUseCase) GetCategoryBySlug(ctx context.Context, slug string) (response.PublicCategoryDetailResponse, error) {
+	category, err := uc.categoryRepo.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		return response.PublicCategoryDetailResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	subtree, err := uc.categoryRepo.GetCategoryTree(ctx, category.Path)
+	if err != nil {
+		return response.PublicCategoryDetailResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	breadcrumbs, err := getCategoryBreadcrumbs(ctx, uc.categoryRepo, []models.Category{category})
+	if err != nil {
+		return response.PublicCategoryDetailResponse{}, err
+	}
+	// path kategori rusak / kosong: tidak ada breadcrumb, diperlakukan sebagai tidak ditemukan
+	if len(breadcrumbs) == 0 {
+		return response.PublicCategoryDetailResponse{}, errorutils.ErrDataNotFound
+	}
+
+	// subtree diawali kategori itu sendiri sebagai root
+	var children []response.PublicCategoryTreeResponse
+	if tree := response.SetPublicCategoryTreeResponse(response.SetCategoryTreeResponse(subtree)); len(tree) > 0 {
+		children = tree[0].Children
+	}
+
+	return response.PublicCategoryDetailResponse{
+		PublicCategoryResponse: response.SetPublicCategoryResponse(category),
+		Breadcrumbs:            response.SetPublicBreadcrumbResponse(breadcrumbs[0]),
+		Children:               children,
+	}, nil
+}
+
+// getPublicPrices harga satuan (qty 1) untuk pengunjung tanpa login, urut sesuai products.
+// Harga khusus pelanggan tidak ditampilkan di sini karena response katalog di-cache publik.
+func (uc *catalogUseCase) getPublicPrices(ctx context.Context, products []models.Product) ([]float64, error) {
+	queries := make([]models.PriceQuery, 0, len(products))
+	for _, p := range products {
+		queries = append(queries, models.PriceQuery{ProductID: p.ID, Quantity: 1})
+	}
+
+	resolved, err := uc.pricingUC.ResolvePrices(ctx, models.PricingContext{}, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]float64, 0, len(resolved))
+	for _, r := range resolved {
+		prices = append(prices, r.UnitPrice)
+	}
+	return prices, nil
+}
+
+// setPrimaryImages mengisi image_url list produk dari gambar utama masing-masing produk
+func (uc *catalogUseCase) setPrimaryImages(ctx context.Context, products []models.Product, list []response.PublicProductResponse) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(products))
+	for _, p := range products {
+		ids = append(ids, p.ID)
+	}
+
+	media, err := uc.productMediaRepo.GetPrimaryMediaByProductIDs(ctx, ids)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	images := make(map[int64]string, len(media))
+	for _, m := range media {
+		images[m.ProductID] = primaryImageURL(m, uc.store.URL)
+	}
+	for i, p := range products {
+		list[i].ImageURL = images[p.ID]
+	}
+	return nil
+}
+
+// primaryImageURL thumbnail ukuran medium untuk kartu produk, fallback ke file asli
+func primaryImageURL(m models.ProductMedia, urlFn func(key string) string) string {
+	if key, ok := m.Thumbnails["medium"]; ok {
+		return urlFn(key)
+	}
+	return urlFn(m.StorageKey)
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"pleasurelove/internal/constanta"
 	"pleasurelove/internal/dto/request"
 	"pleasurelove/internal/dto/response"
@@ -11,6 +12,7 @@ import (
 	"pleasurelove/internal/utils"
 	"pleasurelove/internal/utils/errorutils"
 	"pleasurelove/pkg/logger"
+	"sort"
 	"time"
 
 	"gorm.io/gorm"
@@ -20,19 +22,26 @@ type CategoryUseCase interface {
 	CreateCategory(ctx context.Context, req *request.ReqCategory) error
 	GetCategoryByID(ctx context.Context, id int64) (response.CategoryResponse, error)
 	GetListCategory(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.CategoryResponse], error)
+	ExportListCategory(ctx context.Context, listStruct *models.GetListStruct, req *request.ReqExport) (response.FileStreamResponse, error)
+	BulkMoveCategory(ctx context.Context, req *request.ReqCategoryBulkMove) (response.BulkResponse, error)
+	BulkDeleteCategory(ctx context.Context, req *request.ReqBulkDelete) (response.BulkResponse, error)
 	UpdateCategoryByID(ctx context.Context, req *request.ReqCategoryUpdate) (response.CategoryResponse, error)
 	DeleteCategoryByID(ctx context.Context, id int64, ureqData request.AbstractRequest) error
+	GetCategoryTree(ctx context.Context, rootID int64) ([]response.CategoryTreeResponse, error)
+	MoveCategoryByID(ctx context.Context, req *request.ReqCategoryMove) (response.CategoryResponse, error)
 }
 
 type categoryUseCase struct {
 	db           *gorm.DB
 	categoryRepo repo.CategoryRepository
+	taxClassRepo repo.TaxClassRepository
 }
 
-func NewCategoryUseCase(db *gorm.DB, categoryRepo repo.CategoryRepository) CategoryUseCase {
+func NewCategoryUseCase(db *gorm.DB, categoryRepo repo.CategoryRepository, taxClassRepo repo.TaxClassRepository) CategoryUseCase {
 	return &categoryUseCase{
 		db:           db,
 		categoryRepo: categoryRepo,
+		taxClassRepo: taxClassRepo,
 	}
 }
 
@@ -50,12 +59,31 @@ func (uc *categoryUseCase) CreateCategory(ctx context.Context, req *request.ReqC
 
 	// TODO: get data category by code or name
 
+	var parent models.Category
+	if req.ParentID != nil {
+		parent, err = uc.categoryRepo.GetCategoryByID(ctx, *req.ParentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldParent)
+			}
+			return errorutils.HandleRepoError(ctx, err)
+		}
+	}
+
+	err = validateTaxClass(ctx, uc.taxClassRepo, req.TaxClassID)
+	if err != nil {
+		return err
+	}
+
 	category := models.Category{
-		Name:      req.Name,
-		Code:      req.Code,
-		Slug:      req.Slug,
-		CreatedBy: userLogin,
-		UpdatedBy: userLogin,
+		Name:       req.Name,
+		Code:       req.Code,
+		Slug:       req.Slug,
+		ParentID:   req.ParentID,
+		SortOrder:  req.SortOrder,
+		TaxClassID: req.TaxClassID,
+		CreatedBy:  userLogin,
+		UpdatedBy:  userLogin,
 	}
 
 	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
@@ -63,6 +91,17 @@ func (uc *categoryUseCase) CreateCategory(ctx context.Context, req *request.ReqC
 		if err != nil {
 			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.categoryRepo))
 		}
+
+		// path baru bisa dibentuk setelah id kategori didapat
+		depth := 0
+		if req.ParentID != nil {
+			depth = parent.Depth + 1
+		}
+
+		err = uc.categoryRepo.UpdateCategoryPath(ctx, category.ID, models.BuildCategoryPath(parent.Path, category.ID), depth)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.categoryRepo))
+		}
 		return nil
 	})
 }
@@ -115,6 +154,11 @@ func (uc *categoryUseCase) UpdateCategoryByID(ctx context.Context, req *request.
 		return response.CategoryResponse{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessaageDataAlreadyExists, constanta.FieldName, constanta.FieldCode)
 	}
 
+	err = validateTaxClass(ctx, uc.taxClassRepo, req.TaxClassID)
+	if err != nil {
+		return response.CategoryResponse{}, err
+	}
+
 	userLogin, err := utils.GetUserIDFromCtx(ctx)
 	if err != nil {
 		logger.Error(ctx, "Failed to get user id from context", err)
@@ -122,14 +166,19 @@ func (uc *categoryUseCase) UpdateCategoryByID(ctx context.Context, req *request.
 	}
 
 	category := models.Category{
-		ID:        req.ID,
-		Name:      req.Name,
-		Code:      req.Code,
-		Slug:      req.Slug,
-		CreatedAt: catDb.CreatedAt,
-		CreatedBy: catDb.CreatedBy,
-		UpdatedAt: time.Now(),
-		UpdatedBy: userLogin,
+		ID:         req.ID,
+		Name:       req.Name,
+		Code:       req.Code,
+		Slug:       req.Slug,
+		ParentID:   catDb.ParentID,
+		Depth:      catDb.Depth,
+		Path:       catDb.Path,
+		SortOrder:  catDb.SortOrder,
+		TaxClassID: req.TaxClassID,
+		CreatedAt:  catDb.CreatedAt,
+		CreatedBy:  catDb.CreatedBy,
+		UpdatedAt:  time.Now(),
+		UpdatedBy:  userLogin,
 	}
 
 	var (
@@ -138,6 +187,9 @@ func (uc *categoryUseCase) UpdateCategoryByID(ctx context.Context, req *request.
 	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
 		res, err = uc.categoryRepo.UpdateCategoryByID(ctx, req.ID, req.UpdatedAt, category)
 		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
 			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.categoryRepo))
 		}
 
@@ -166,11 +218,333 @@ func (uc *categoryUseCase) DeleteCategoryByID(ctx context.Context, id int64, req
 		return errorutils.ErrDataDataUpdated
 	}
 
+	children, err := uc.categoryRepo.CountChildrenByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if children > 0 {
+		return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCategoryHasChildren, constanta.FieldCategory)
+	}
+
 	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
 		err := uc.categoryRepo.DeleteCategoryByID(ctx, id, reqData.UpdatedAt)
 		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
 			return errorutils.HandleRepoError(ctx, err)
 		}
 		return nil
 	})
 }
+
+func (uc *categoryUseCase) GetCategoryTree(ctx context.Context, rootID int64) ([]response.CategoryTreeResponse, error) {
+	var rootPath string
+	if rootID != 0 {
+		root, err := uc.categoryRepo.GetCategoryByID(ctx, rootID)
+		if err != nil {
+			return nil, errorutils.HandleRepoError(ctx, err)
+		}
+		rootPath = root.Path
+	}
+
+	categories, err := uc.categoryRepo.GetCategoryTree(ctx, rootPath)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	return response.SetCategoryTreeResponse(categories), nil
+}
+
+// MoveCategoryByID memindahkan kategori (beserta seluruh turunannya) ke parent lain
+// atau hanya mengubah urutan jika parent tidak berubah
+func (uc *categoryUseCase) MoveCategoryByID(ctx context.Context, req *request.ReqCategoryMove) (response.CategoryResponse, error) {
+	if err := req.ValidateRequestMove(); err != nil {
+		return response.CategoryResponse{}, err
+	}
+
+	userLogin, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.CategoryResponse{}, errorutils.ErrDataNotFound
+	}
+
+	var res models.Category
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		// kategori dan parent baru dibaca ulang dengan lock supaya pemindahan bersamaan
+		// tidak lolos cek cycle dengan path yang sudah berubah
+		catDb, err := uc.categoryRepo.GetCategoryByIDForUpdate(ctx, req.ID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, catDb.UpdatedAt) {
+			return errorutils.ErrDataDataUpdated
+		}
+
+		var (
+			parentPath string
+			depth      int
+		)
+		if req.ParentID != nil {
+			parent, err := uc.categoryRepo.GetCategoryByIDForUpdate(ctx, *req.ParentID)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldParent)
+				}
+				return errorutils.HandleRepoError(ctx, err)
+			}
+
+			// cegah cycle: parent baru tidak boleh berada di subtree kategori ini
+			if parent.IsDescendantOf(catDb) {
+				return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCategoryCycle, constanta.FieldParent)
+			}
+
+			parentPath = parent.Path
+			depth = parent.Depth + 1
+		}
+
+		category := catDb
+		category.ParentID = req.ParentID
+		category.Path = models.BuildCategoryPath(parentPath, catDb.ID)
+		category.Depth = depth
+		category.SortOrder = req.SortOrder
+		category.UpdatedAt = time.Now()
+		category.UpdatedBy = userLogin
+
+		res, err = uc.categoryRepo.MoveCategoryByID(ctx, req.ID, req.UpdatedAt, category)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.categoryRepo))
+		}
+
+		if category.Path != catDb.Path {
+			err = uc.categoryRepo.UpdateSubtreePath(ctx, catDb.Path, category.Path, category.Depth-catDb.Depth)
+			if err != nil {
+				return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.categoryRepo))
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return response.CategoryResponse{}, err
+	}
+
+	return response.SetCategoryResponse(res), nil
+}
+
+// getProductBreadcrumbs menyusun breadcrumb untuk setiap kategori produk, contoh: Wellness > Massage > Oils
+func getProductBreadcrumbs(ctx context.Context, categoryRepo repo.CategoryRepository, productCategories []models.ProductCategory) ([][]response.CategoryBreadcrumbResponse, error) {
+	var categories []models.Category
+	for _, pc := range productCategories {
+		if pc.Category != nil {
+			categories = append(categories, *pc.Category)
+		}
+	}
+
+	return getCategoryBreadcrumbs(ctx, categoryRepo, categories)
+}
+
+func getCategoryBreadcrumbs(ctx context.Context, categoryRepo repo.CategoryRepository, categories []models.Category) ([][]response.CategoryBreadcrumbResponse, error) {
+	var ancestorIDs []int64
+	for _, c := range categories {
+		ancestorIDs = append(ancestorIDs, c.AncestorIDs()...)
+	}
+
+	if len(ancestorIDs) == 0 {
+		return [][]response.CategoryBreadcrumbResponse{}, nil
+	}
+
+	ancestorDb, err := categoryRepo.GetCategoryByListIDs(ctx, ancestorIDs)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	ancestors := make(map[int64]models.Category, len(ancestorDb))
+	for _, c := range ancestorDb {
+		ancestors[c.ID] = c
+	}
+
+	breadcrumbs := make([][]response.CategoryBreadcrumbResponse, 0, len(categories))
+	for _, c := range categories {
+		breadcrumbs = append(breadcrumbs, response.SetCategoryBreadcrumbResponse(c, ancestors))
+	}
+
+	return breadcrumbs, nil
+}
+
+func (uc *categoryUseCase) ExportListCategory(ctx context.Context, listStruct *models.GetListStruct, req *request.ReqExport) (response.FileStreamResponse, error) {
+	return exportList(ctx, uc.db, req, "category",
+		func(ctx context.Context, fn func([]models.Category) error) error {
+			return uc.categoryRepo.ExportListCategory(ctx, listStruct, fn)
+		},
+		func(ctx context.Context, categories []models.Category) ([]response.CategoryResponse, error) {
+			return response.SetResponseListCategory(categories), nil
+		})
+}
+
+// BulkMoveCategory memindahkan beberapa kategori ke parent yang sama (urutan tiap kategori tetap).
+// Kategori yang saling turunan atau parent tujuan yang berada di dalam salah satu kategori ditolak
+// karena path-nya ikut berubah di tengah proses
+func (uc *categoryUseCase) BulkMoveCategory(ctx context.Context, req *request.ReqCategoryBulkMove) (response.BulkResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	categoryMap, err := uc.getBulkCategories(ctx, req.Items)
+	if err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	for _, c := range categoryMap {
+		for _, other := range categoryMap {
+			if c.ID != other.ID && c.IsDescendantOf(other) {
+				msg := fmt.Sprintf("kategori %s sudah ikut berpindah bersama %s", c.Name, other.Name)
+				return response.BulkResponse{}, errorutils.HandleCustomError(ctx, nil, msg, constanta.FieldCategory)
+			}
+		}
+	}
+
+	if req.ParentID != nil {
+		parent, err := uc.categoryRepo.GetCategoryByID(ctx, *req.ParentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return response.BulkResponse{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldParent)
+			}
+			return response.BulkResponse{}, errorutils.HandleRepoError(ctx, err)
+		}
+
+		for _, c := range categoryMap {
+			if parent.IsDescendantOf(c) {
+				return response.BulkResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCategoryCycle, constanta.FieldParent)
+			}
+		}
+	}
+
+	var res response.BulkResponse
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		res = response.NewBulkResponse(len(req.Items))
+		for i, item := range req.Items {
+			var moved response.CategoryResponse
+			err := processWithSavepoint(ctx, fmt.Sprintf("bulk_%d", i), func(ctx context.Context) (err error) {
+				category, ok := categoryMap[item.ID]
+				if !ok {
+					return errorutils.ErrDataNotFound
+				}
+
+				moved, err = uc.MoveCategoryByID(ctx, &request.ReqCategoryMove{
+					ID:              item.ID,
+					ParentID:        req.ParentID,
+					SortOrder:       category.SortOrder,
+					AbstractRequest: item.AbstractRequest,
+				})
+				return err
+			})
+			if err != nil {
+				res.AddFailed(item.ID, errorMessage(err))
+				continue
+			}
+			res.AddSuccess(item.ID, &moved.UpdatedAt)
+		}
+		return nil
+	})
+	if err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	return res, nil
+}
+
+// BulkDeleteCategory kategori terdalam dihapus lebih dulu sehingga parent dan turunannya bisa dihapus
+// dalam satu request, hasil tetap mengikuti urutan request
+func (uc *categoryUseCase) BulkDeleteCategory(ctx context.Context, req *request.ReqBulkDelete) (response.BulkResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	categoryMap, err := uc.getBulkCategories(ctx, req.Items)
+	if err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	items := make([]request.ReqBulkItem, len(req.Items))
+	copy(items, req.Items)
+	sort.SliceStable(items, func(i, j int) bool {
+		return categoryMap[items[i].ID].Depth > categoryMap[items[j].ID].Depth
+	})
+
+	errs := make(map[int64]error, len(items))
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		for i, item := range items {
+			errs[item.ID] = processWithSavepoint(ctx, fmt.Sprintf("bulk_%d", i), func(ctx context.Context) error {
+				return uc.deleteBulkCategory(ctx, categoryMap[item.ID], item)
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	res := response.NewBulkResponse(len(req.Items))
+	for _, item := range req.Items {
+		if err := errs[item.ID]; err != nil {
+			res.AddFailed(item.ID, errorMessage(err))
+			continue
+		}
+		res.AddSuccess(item.ID, nil)
+	}
+	return res, nil
+}
+
+func (uc *categoryUseCase) getBulkCategories(ctx context.Context, items []request.ReqBulkItem) (map[int64]models.Category, error) {
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+
+	categories, err := uc.categoryRepo.GetCategoryByListIDs(ctx, ids)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	categoryMap := make(map[int64]models.Category, len(categories))
+	for _, c := range categories {
+		categoryMap[c.ID] = c
+	}
+	return categoryMap, nil
+}
+
+func (uc *categoryUseCase) deleteBulkCategory(ctx context.Context, category models.Category, item request.ReqBulkItem) error {
+	if category.ID == 0 {
+		return errorutils.ErrDataNotFound
+	}
+	if err := item.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+	if !utils.ValidateUpdatedAtRequest(item.UpdatedAt, category.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+
+	// dihitung di dalam transaksi supaya turunan yang sudah dihapus di request yang sama tidak terhitung
+	children, err := uc.categoryRepo.CountChildrenByID(ctx, category.ID)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+	if children > 0 {
+		return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCategoryHasChildren, constanta.FieldCategory)
+	}
+
+	err = uc.categoryRepo.DeleteCategoryByID(ctx, category.ID, item.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorutils.ErrDataDataUpdated
+		}
+		return errorutils.HandleRepoError(ctx, err)
+	}
+	return nil
+}
@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/promotion"
+	"pleasurelove/pkg/shipping"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CheckoutUseCase mengubah keranjang user login / guest menjadi order. Seluruh langkah (harga ulang,
+// promosi & voucher, customer, stok, order, kosongkan keranjang) berjalan dalam satu transaksi
+// selagi baris keranjang terkunci, sehingga checkout ganda dari pemilik yang sama berjalan berurutan
+// dan request ulang dengan Idempotency-Key yang sama mendapat order yang sudah dibuat.
+type CheckoutUseCase interface {
+	Checkout(ctx context.Context, req *request.ReqCheckout) (response.OrderResponse, error)
+}
+
+type checkoutUseCase struct {
+	order        *orderUseCase // snapshot item, simpan order dan pakai stok
+	cart         *cartUseCase  // kunci keranjang dan sinkron ke Redis
+	shipping     *shippingUseCase
+	tax          *taxUseCase
+	orderRepo    repo.OrderRepository
+	customerRepo repo.CustomerRepository
+	addressRepo  repo.AddressRepository
+	promotionUC  PromotionUseCase
+	voucherUC    VoucherUseCase
+	regionUC     RegionUseCase
+}
+
+func NewCheckoutUseCase(db *gorm.DB,
+	cartRepo repo.CartRepository,
+	orderRepo repo.OrderRepository,
+	customerRepo repo.CustomerRepository,
+	addressRepo repo.AddressRepository,
+	productRepo repo.ProductRepository,
+	productVarianRepo repo.ProductVarianRepository,
+	pricingUC PricingUseCase,
+	promotionUC PromotionUseCase,
+	voucherUC VoucherUseCase,
+	inventoryUC InventoryUseCase,
+	regionUC RegionUseCase,
+	shipmentRepo repo.ShipmentRepository,
+	shippingCfg shipping.Config,
+	taxUC TaxUseCase) CheckoutUseCase {
+	shippingUC := NewShippingUseCase(db, cartRepo, orderRepo, customerRepo, addressRepo, shipmentRepo,
+		productRepo, productVarianRepo, pricingUC, inventoryUC, regionUC, shippingCfg).(*shippingUseCase)
+	return &checkoutUseCase{
+		order:        shippingUC.order,
+		cart:         shippingUC.cart,
+		shipping:     shippingUC,
+		tax:          taxUC.(*taxUseCase),
+		orderRepo:    orderRepo,
+		customerRepo: customerRepo,
+		addressRepo:  addressRepo,
+		promotionUC:  promotionUC,
+		voucherUC:    voucherUC,
+		regionUC:     regionUC,
+	}
+}
+
+func (uc *checkoutUseCase) Checkout(ctx context.Context, req *request.ReqCheckout) (response.OrderResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.OrderResponse{}, err
+	}
+
+	owner := cartOwnerFromCtx(ctx)
+	if owner.IsEmpty() {
+		return response.OrderResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCartEmpty, constanta.FieldCart)
+	}
+
+	// ongkir memanggil provider lewat HTTP, dihitung sebelum keranjang dikunci
+	quote, err := uc.quoteCheckout(ctx, owner, req)
+	if err != nil {
+		return response.OrderResponse{}, err
+	}
+
+	var orderID int64
+	var reservation VoucherReservation
+	_, err = uc.cart.updateCart(ctx, owner, func(cart *models.Cart) error {
+		customer, err := uc.getCustomer(ctx, owner)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		// request ulang menunggu kunci keranjang dilepas lalu mendapat order yang sudah dibuat
+		if customer.ID != 0 {
+			existing, err := uc.orderRepo.GetOrderByIdempotencyKey(ctx, customer.ID, req.IdempotencyKey)
+			if err == nil {
+				orderID = existing.ID
+				return nil
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+
+		if len(cart.Items) == 0 {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCartEmpty, constanta.FieldCart)
+		}
+
+		if customer.ID == 0 {
+			customer, err = uc.createCustomer(ctx, owner, req.Customer)
+		} else {
+			customer, err = uc.completeCustomerContact(ctx, customer, req.Customer)
+		}
+		if err != nil {
+			return err
+		}
+
+		order, result, err := uc.newCheckoutOrder(ctx, owner, customer, quote, cart.Items, req)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.order.placeOrder(ctx, &order); err != nil {
+			return err
+		}
+
+		promoCustomerID := promotionCustomerID(customer)
+		if err := uc.promotionUC.RecordUsage(ctx, promoCustomerID, order.OrderNumber, result); err != nil {
+			return err
+		}
+
+		// voucher diredeem paling akhir karena kuotanya ikut dipesan di Redis
+		if order.VoucherCode != "" {
+			reservation, err = uc.voucherUC.RedeemVoucher(ctx, order.VoucherCode, promoCustomerID, order.OrderNumber, voucherDiscount(result))
+			if err != nil {
+				return err
+			}
+		}
+
+		orderID = order.ID
+		cart.Items = nil
+		return nil
+	})
+	if err != nil {
+		// transaksi checkout batal: kuota voucher yang sudah dipesan dikembalikan
+		reservation.Release(ctx)
+		return response.OrderResponse{}, err
+	}
+
+	return uc.order.GetOrderByID(ctx, orderID)
+}
+
+// checkoutQuote alamat kirim dan layanan ongkir pilihan, dihitung dari isi keranjang sebelum transaksi
+type checkoutQuote struct {
+	address    models.OrderAddress
+	weightGram int
+	rate       shipping.Rate
+}
+
+// quoteCheckout ongkir terbaru dari provider untuk isi keranjang saat ini, layanan yang dipilih harus
+// masih tersedia. Keranjang kosong (mis. request ulang setelah order dibuat) tidak dihitung, transaksi
+// checkout yang menentukan hasilnya.
+func (uc *checkoutUseCase) quoteCheckout(ctx context.Context, owner models.CartOwner, req *request.ReqCheckout) (checkoutQuote, error) {
+	cart, err := uc.cart.loadCart(ctx, owner)
+	if err != nil {
+		return checkoutQuote{}, err
+	}
+	if len(cart.Items) == 0 {
+		return checkoutQuote{}, nil
+	}
+
+	customer, err := uc.getCustomer(ctx, owner)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return checkoutQuote{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	address, err := uc.getShippingAddress(ctx, owner, customer, req)
+	if err != nil {
+		return checkoutQuote{}, err
+	}
+
+	pricingCtx := models.PricingContext{
+		CustomerID:      customer.ID,
+		CustomerGroupID: customer.CustomerGroupID,
+		At:              time.Now(),
+	}
+	items, _, err := uc.order.newOrderItems(ctx, pricingCtx, cartPriceQueries(cart.Items))
+	if err != nil {
+		return checkoutQuote{}, err
+	}
+
+	rates, err := uc.shipping.quote(ctx, address, items)
+	if err != nil {
+		return checkoutQuote{}, err
+	}
+	rate, err := selectRate(ctx, rates, req.ShippingCourier, req.ShippingService)
+	if err != nil {
+		return checkoutQuote{}, err
+	}
+
+	return checkoutQuote{
+		address:    address,
+		weightGram: shipping.ChargeableWeight(shippingItems(items)),
+		rate:       rate,
+	}, nil
+}
+
+// newCheckoutOrder order pending_payment dari isi keranjang dengan harga, diskon, ongkir dan pajak terbaru.
+// Ongkir dari quoteCheckout hanya berlaku bila berat isi keranjang yang terkunci masih sama.
+func (uc *checkoutUseCase) newCheckoutOrder(ctx context.Context, owner models.CartOwner, customer models.Customer, quote checkoutQuote, cartItems []models.CartItem, req *request.ReqCheckout) (models.Order, promotion.Result, error) {
+	pricingCtx := models.PricingContext{
+		CustomerID:      customer.ID,
+		CustomerGroupID: customer.CustomerGroupID,
+		At:              time.Now(),
+	}
+
+	items, prices, err := uc.order.newOrderItems(ctx, pricingCtx, cartPriceQueries(cartItems))
+	if err != nil {
+		return models.Order{}, promotion.Result{}, err
+	}
+
+	if shipping.ChargeableWeight(shippingItems(items)) != quote.weightGram {
+		return models.Order{}, promotion.Result{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCheckoutCartChanged, constanta.FieldCart)
+	}
+	address, rate := quote.address, quote.rate
+
+	promoCustomerID := promotionCustomerID(customer)
+	var result promotion.Result
+	if req.VoucherCode != "" {
+		result, err = uc.voucherUC.ApplyVoucher(ctx, req.VoucherCode, promoCustomerID, pricingCtx.At, prices)
+	} else {
+		result, err = uc.promotionUC.ApplyPromotions(ctx, promoCustomerID, pricingCtx.At, prices)
+	}
+	if err != nil {
+		return models.Order{}, promotion.Result{}, err
+	}
+
+	for _, applied := range result.Applied {
+		for _, line := range applied.Lines {
+			items[line.Index].DiscountAmount += line.Amount
+		}
+	}
+	for i := range items {
+		items[i].DiscountAmount = utils.RoundTo2Digits(items[i].DiscountAmount)
+	}
+
+	order, err := newOrder(customer.ID, items, req.Notes, owner.UserID)
+	if err != nil {
+		return models.Order{}, promotion.Result{}, err
+	}
+
+	order.ShippingAddress = &address
+	order.IdempotencyKey = &req.IdempotencyKey
+	order.VoucherCode = req.VoucherCode
+	order.DiscountTotal = utils.RoundTo2Digits(result.TotalDiscount)
+	order.ShippingTotal = utils.RoundTo2Digits(rate.Cost)
+	order.ShippingCourier = rate.Courier
+	order.ShippingService = rate.Service
+	if err := uc.tax.applyOrderTax(ctx, &order, pricingCtx.At); err != nil {
+		return models.Order{}, promotion.Result{}, err
+	}
+	order.History = []models.OrderStatusHistory{
+		newOrderHistory("", models.OrderStatusPendingPayment, "", models.OrderActorCustomer, customer.ID),
+	}
+
+	return order, result, nil
+}
+
+// getCustomer customer milik user login, atau customer guest yang dibuat dengan guest token yang sama
+func (uc *checkoutUseCase) getCustomer(ctx context.Context, owner models.CartOwner) (models.Customer, error) {
+	if owner.UserID != 0 {
+		return uc.customerRepo.GetCustomerByUserID(ctx, owner.UserID)
+	}
+	return uc.customerRepo.GetCustomerByGuestToken(ctx, utils.GetGuestTokenFromCtx(ctx))
+}
+
+// createCustomer sesuai catatan di customerUseCase.CreateCustomer: checkout pertama kali membuat
+// row customer, guest disimpan dengan guest token-nya supaya bisa dipindahkan ke user saat login
+func (uc *checkoutUseCase) createCustomer(ctx context.Context, owner models.CartOwner, contact *request.ReqCheckoutContact) (models.Customer, error) {
+	if contact == nil {
+		return models.Customer{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCheckoutContact, constanta.FieldCustomer)
+	}
+
+	customer := models.Customer{
+		Name:   contact.Name,
+		Email:  contact.Email,
+		Phone:  contact.Phone,
+		UserID: owner.UserID,
+	}
+	if owner.UserID == 0 {
+		customer.IsGuest = true
+		customer.GuestToken = utils.GetGuestTokenFromCtx(ctx)
+	}
+
+	if err := uc.customerRepo.Create(ctx, &customer); err != nil {
+		return models.Customer{}, errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.customerRepo))
+	}
+	return customer, nil
+}
+
+// completeCustomerContact customer yang belum punya email (dibuat dari buku alamat guest)
+// dilengkapi dari kontak pemesan
+func (uc *checkoutUseCase) completeCustomerContact(ctx context.Context, customer models.Customer, contact *request.ReqCheckoutContact) (models.Customer, error) {
+	if customer.Email != "" {
+		return customer, nil
+	}
+	if contact == nil {
+		return models.Customer{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCheckoutContact, constanta.FieldCustomer)
+	}
+
+	if err := uc.customerRepo.UpdateCustomerContact(ctx, customer.ID, contact.Name, contact.Email, contact.Phone); err != nil {
+		return models.Customer{}, errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.customerRepo))
+	}
+	customer.Name = contact.Name
+	customer.Email = contact.Email
+	customer.Phone = contact.Phone
+	return customer, nil
+}
+
+// getShippingAddress snapshot alamat kirim dari buku alamat pemesan (address_id) atau dari request
+func (uc *checkoutUseCase) getShippingAddress(ctx context.Context, owner models.CartOwner, customer models.Customer, req *request.ReqCheckout) (models.OrderAddress, error) {
+	if req.ShippingAddress != nil {
+		return uc.getRequestAddress(ctx, req.ShippingAddress)
+	}
+
+	addressOwner := models.AddressOwner{UserID: owner.UserID, CustomerID: customer.ID}
+	address, err := uc.addressRepo.GetAddressByOwner(ctx, addressOwner, req.AddressID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.OrderAddress{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldAddress)
+		}
+		return models.OrderAddress{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if address.AddressType != models.AddressTypeShipping {
+		return models.OrderAddress{}, errorutils.HandleCustomError(ctx, nil, "alamat yang dipilih bukan alamat pengiriman", constanta.FieldAddress)
+	}
+	return address.ToOrderAddress(), nil
+}
+
+// getRequestAddress alamat kirim yang diisi langsung, nama wilayah dan kode pos diambil dari data wilayah
+func (uc *checkoutUseCase) getRequestAddress(ctx context.Context, req *request.ReqOrderAddress) (models.OrderAddress, error) {
+	region, err := uc.regionUC.ResolveRegion(ctx, req.Region())
+	if err != nil {
+		return models.OrderAddress{}, err
+	}
+	if err := requireRegionNames(ctx, region); err != nil {
+		return models.OrderAddress{}, err
+	}
+
+	return models.OrderAddress{
+		RecipientName: req.RecipientName,
+		PhoneNumber:   req.PhoneNumber,
+		AddressLine1:  req.AddressLine1,
+		AddressLine2:  req.AddressLine2,
+		ProvinceID:    region.ProvinceID,
+		RegencyID:     region.RegencyID,
+		DistrictID:    region.DistrictID,
+		VillageID:     region.VillageID,
+		Village:       region.VillageName,
+		Subdistrict:   region.DistrictName,
+		City:          region.RegencyName,
+		Province:      region.ProvinceName,
+		PostalCode:    region.PostalCode,
+		Country:       req.Country,
+	}, nil
+}
+
+// promotionCustomerID batas pemakaian per pelanggan hanya berlaku untuk pelanggan terdaftar
+func promotionCustomerID(customer models.Customer) int64 {
+	if customer.IsGuest {
+		return 0
+	}
+	return customer.ID
+}
+
+func voucherDiscount(result promotion.Result) float64 {
+	var amount float64
+	for _, applied := range result.Applied {
+		if applied.Source == promotion.SourceVoucher {
+			amount += applied.Amount
+		}
+	}
+	return amount
+}
+
+// cartPriceQueries query harga untuk seluruh item keranjang
+func cartPriceQueries(items []models.CartItem) []models.PriceQuery {
+	queries := make([]models.PriceQuery, 0, len(items))
+	for _, item := range items {
+		queries = append(queries, models.PriceQuery{
+			ProductID: item.ProductID,
+			VarianID:  item.VarianID,
+			Quantity:  item.Quantity,
+		})
+	}
+	return queries
+}
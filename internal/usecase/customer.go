@@ -19,6 +19,7 @@ type CustomerUseCase interface {
 	CreateCustomer(ctx context.Context, req *request.ReqCustomer) error
 	GetCustomerByID(ctx context.Context, id int64) (response.CustomerResponse, error)
 	GetListCustomer(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.CustomerResponse], error)
+	ExportListCustomer(ctx context.Context, listStruct *models.GetListStruct, req *request.ReqExport) (response.FileStreamResponse, error)
 	UpdateCustomerByID(ctx context.Context, req *request.ReqCustomerUpdate) (response.CustomerResponse, error)
 	DeleteCustomerByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
 }
@@ -183,3 +184,13 @@ func (uc *customerUseCase) DeleteCustomerByID(ctx context.Context, id int64, req
 		return nil
 	})
 }
+
+func (uc *customerUseCase) ExportListCustomer(ctx context.Context, listStruct *models.GetListStruct, req *request.ReqExport) (response.FileStreamResponse, error) {
+	return exportList(ctx, uc.db, req, "customer",
+		func(ctx context.Context, fn func([]models.Customer) error) error {
+			return uc.customerRepo.ExportListCustomer(ctx, listStruct, fn)
+		},
+		func(ctx context.Context, customers []models.Customer) ([]response.CustomerResponse, error) {
+			return response.SetResponseListCustomer(customers), nil
+		})
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CustomerGroupUseCase interface {
+	CreateCustomerGroup(ctx context.Context, req *request.ReqCustomerGroup) error
+	GetCustomerGroupByID(ctx context.Context, id int64) (response.CustomerGroupResponse, error)
+	GetListCustomerGroup(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.CustomerGroupResponse], error)
+	UpdateCustomerGroupByID(ctx context.Context, req *request.ReqCustomerGroupUpdate) (response.CustomerGroupResponse, error)
+	DeleteCustomerGroupByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
+	AddCustomerGroupMember(ctx context.Context, req *request.ReqCustomerGroupMember) error
+	RemoveCustomerGroupMember(ctx context.Context, req *request.ReqCustomerGroupMember) error
+}
+
+type customerGroupUseCase struct {
+	db                *gorm.DB
+	customerGroupRepo repo.CustomerGroupRepository
+}
+
+func NewCustomerGroupUseCase(db *gorm.DB, customerGroupRepo repo.CustomerGroupRepository) CustomerGroupUseCase {
+	return &customerGroupUseCase{
+		db:                db,
+		customerGroupRepo: customerGroupRepo,
+	}
+}
+
+func (uc *customerGroupUseCase) CreateCustomerGroup(ctx context.Context, req *request.ReqCustomerGroup) error {
+	if err := req.ValidateRequestCreate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return errorutils.ErrDataNotFound
+	}
+
+	group := models.CustomerGroup{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedBy:   userID,
+		UpdatedBy:   userID,
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		err := uc.customerGroupRepo.Create(ctx, &group)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.customerGroupRepo))
+		}
+		return nil
+	})
+}
+
+func (uc *customerGroupUseCase) GetCustomerGroupByID(ctx context.Context, id int64) (response.CustomerGroupResponse, error) {
+	group, err := uc.customerGroupRepo.GetCustomerGroupByID(ctx, id)
+	if err != nil {
+		return response.CustomerGroupResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	return response.SetCustomerGroupResponse(group), nil
+}
+
+func (uc *customerGroupUseCase) GetListCustomerGroup(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.CustomerGroupResponse], error) {
+	groups, count, err := uc.customerGroupRepo.GetListCustomerGroup(ctx, listStruct)
+	if err != nil {
+		return response.ListResponse[response.CustomerGroupResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListCustomerGroup(groups), count, listStruct, repo.GetFilterAvailableFromRepo(uc.customerGroupRepo))
+	return listResponse, nil
+}
+
+func (uc *customerGroupUseCase) UpdateCustomerGroupByID(ctx context.Context, req *request.ReqCustomerGroupUpdate) (response.CustomerGroupResponse, error) {
+	if err := req.ValidateUpdatedAt(); err != nil {
+		return response.CustomerGroupResponse{}, err
+	}
+
+	if err := req.ValidateRequestCreate(); err != nil {
+		return response.CustomerGroupResponse{}, err
+	}
+
+	groupDb, err := uc.customerGroupRepo.GetCustomerGroupByID(ctx, req.ID)
+	if err != nil {
+		return response.CustomerGroupResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, groupDb.UpdatedAt) {
+		return response.CustomerGroupResponse{}, errorutils.ErrDataDataUpdated
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.CustomerGroupResponse{}, errorutils.ErrDataNotFound
+	}
+
+	group := models.CustomerGroup{
+		ID:          req.ID,
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedAt:   groupDb.CreatedAt,
+		CreatedBy:   groupDb.CreatedBy,
+		UpdatedAt:   time.Now(),
+		UpdatedBy:   userID,
+	}
+
+	var res models.CustomerGroup
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		res, err = uc.customerGroupRepo.UpdateCustomerGroupByID(ctx, req.ID, req.UpdatedAt, group)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.customerGroupRepo))
+		}
+		return nil
+	})
+	if err != nil {
+		return response.CustomerGroupResponse{}, err
+	}
+
+	return response.SetCustomerGroupResponse(res), nil
+}
+
+func (uc *customerGroupUseCase) DeleteCustomerGroupByID(ctx context.Context, id int64, reqData request.AbstractRequest) error {
+	if err := reqData.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+
+	group, err := uc.customerGroupRepo.GetCustomerGroupByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(reqData.UpdatedAt, group.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		// pelanggan anggota grup otomatis keluar dari grup (ON DELETE SET NULL)
+		err := uc.customerGroupRepo.DeleteCustomerGroupByID(ctx, id, reqData.UpdatedAt)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+	})
+}
+
+func (uc *customerGroupUseCase) AddCustomerGroupMember(ctx context.Context, req *request.ReqCustomerGroupMember) error {
+	if _, err := uc.customerGroupRepo.GetCustomerGroupByID(ctx, req.ID); err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	customerIDs := uniqueInt64s(req.CustomerIDs)
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		affected, err := uc.customerGroupRepo.AddCustomerGroupMember(ctx, req.ID, customerIDs)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		if affected != int64(len(customerIDs)) {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldCustomer)
+		}
+		return nil
+	})
+}
+
+func (uc *customerGroupUseCase) RemoveCustomerGroupMember(ctx context.Context, req *request.ReqCustomerGroupMember) error {
+	if _, err := uc.customerGroupRepo.GetCustomerGroupByID(ctx, req.ID); err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		// pelanggan yang bukan anggota grup ini diabaikan
+		_, err := uc.customerGroupRepo.RemoveCustomerGroupMember(ctx, req.ID, uniqueInt64s(req.CustomerIDs))
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/sheet"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// exportList menyiapkan file export list. Format dan kolom divalidasi di sini (error dikembalikan
+// sebagai response biasa), data baru dibaca saat file ditulis ke response: per batch di dalam satu
+// transaksi snapshot lalu langsung ditulis, sehingga memori tidak bergantung jumlah data
+func exportList[M any, T any](ctx context.Context, db *gorm.DB, req *request.ReqExport, name string,
+	fetch func(ctx context.Context, fn func([]M) error) error,
+	toResponse func(ctx context.Context, items []M) ([]T, error)) (response.FileStreamResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		return response.FileStreamResponse{}, err
+	}
+
+	columns, err := response.GetExportColumns[T](req.FieldList())
+	if err != nil {
+		return response.FileStreamResponse{}, errorutils.HandleCustomError(ctx, err, err.Error(), constanta.FieldFields)
+	}
+
+	return response.FileStreamResponse{
+		FileName:    fmt.Sprintf("%s_%s.%s", name, time.Now().Format("20060102_150405"), req.Format),
+		ContentType: sheet.ContentType(req.Format),
+		Write: func(ctx context.Context, w io.Writer) error {
+			sw, err := sheet.NewWriter(req.Format, w)
+			if err != nil {
+				return err
+			}
+
+			if err := sw.WriteRow(response.ExportHeader(columns)); err != nil {
+				return err
+			}
+
+			err = processWithSnapshot(ctx, db, func(ctx context.Context) error {
+				return fetch(ctx, func(items []M) error {
+					list, err := toResponse(ctx, items)
+					if err != nil {
+						return err
+					}
+					for _, item := range list {
+						if err := sw.WriteRow(response.ExportRow(columns, item)); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			})
+			if err != nil {
+				return err
+			}
+
+			return sw.Close()
+		},
+	}, nil
+}
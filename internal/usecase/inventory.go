@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils/errorutils"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// InventoryUseCase stok produk / varian. Produk bundle tidak punya stok sendiri,
+// ketersediaannya dihitung dari stok komponen dan penjualannya mengurangi stok komponen.
+type InventoryUseCase interface {
+	GetBundleAvailability(ctx context.Context, bundleProductIDs []int64) (map[int64]int, error)
+	ConsumeStock(ctx context.Context, items []models.StockItem) error
+	RestoreStock(ctx context.Context, items []models.StockItem) error
+	AdjustStock(ctx context.Context, req *request.ReqStockAdjust) error
+}
+
+type inventoryUseCase struct {
+	db                *gorm.DB
+	productRepo       repo.ProductRepository
+	productVarianRepo repo.ProductVarianRepository
+	productBundleRepo repo.ProductBundleRepository
+}
+
+func NewInventoryUseCase(db *gorm.DB,
+	productRepo repo.ProductRepository,
+	productVarianRepo repo.ProductVarianRepository,
+	productBundleRepo repo.ProductBundleRepository) InventoryUseCase {
+	return &inventoryUseCase{
+		db:                db,
+		productRepo:       productRepo,
+		productVarianRepo: productVarianRepo,
+		productBundleRepo: productBundleRepo,
+	}
+}
+
+// GetBundleAvailability jumlah bundle yang bisa dijual = minimum (stok komponen / qty komponen).
+// Komponen yang tidak aktif dianggap tidak tersedia.
+func (uc *inventoryUseCase) GetBundleAvailability(ctx context.Context, bundleProductIDs []int64) (map[int64]int, error) {
+	availability := make(map[int64]int, len(bundleProductIDs))
+	if len(bundleProductIDs) == 0 {
+		return availability, nil
+	}
+
+	items, err := uc.productBundleRepo.GetBundleItemByBundleProductIDs(ctx, uniqueInt64s(bundleProductIDs))
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	stocks, err := uc.getComponentStocks(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range bundleProductIDs {
+		availability[id] = 0
+	}
+
+	seen := make(map[int64]bool, len(bundleProductIDs))
+	for _, item := range items {
+		qty := stocks[priceKey(item.ComponentProductID, bundleVarianID(item))] / item.Quantity
+		if !seen[item.BundleProductID] || qty < availability[item.BundleProductID] {
+			availability[item.BundleProductID] = qty
+			seen[item.BundleProductID] = true
+		}
+	}
+
+	return availability, nil
+}
+
+// ConsumeStock mengurangi stok untuk barang terjual, bundle dipecah menjadi komponennya.
+// Ikut transaksi pemanggil bila ada, semua baris gagal bila salah satu stok tidak mencukupi.
+func (uc *inventoryUseCase) ConsumeStock(ctx context.Context, items []models.StockItem) error {
+	return uc.updateStock(ctx, items, -1)
+}
+
+// RestoreStock mengembalikan stok (order batal / retur) ke komponen bundle saat ini
+func (uc *inventoryUseCase) RestoreStock(ctx context.Context, items []models.StockItem) error {
+	return uc.updateStock(ctx, items, 1)
+}
+
+func (uc *inventoryUseCase) AdjustStock(ctx context.Context, req *request.ReqStockAdjust) error {
+	product, err := uc.productRepo.GetProductByID(ctx, req.ProductID)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if product.ProductType == models.ProductTypeBundle {
+		return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageStockBundle, constanta.FieldStock)
+	}
+
+	if req.VarianID != 0 {
+		varian, err := uc.productVarianRepo.GetProductVarianByID(ctx, req.VarianID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if varian.ProductID != product.ID {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldVarian)
+		}
+	} else if product.HasVarian {
+		return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageStockVarian, constanta.FieldVarian)
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		return uc.updateStockRow(ctx, models.StockItem{ProductID: req.ProductID, VarianID: req.VarianID, Quantity: req.Quantity})
+	})
+}
+
+func (uc *inventoryUseCase) updateStock(ctx context.Context, items []models.StockItem, sign int) error {
+	components, err := uc.expandBundles(ctx, items)
+	if err != nil {
+		return err
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		for _, c := range components {
+			c.Quantity *= sign
+			if err := uc.updateStockRow(ctx, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// updateStockRow Quantity = perubahan stok (negatif = berkurang)
+func (uc *inventoryUseCase) updateStockRow(ctx context.Context, item models.StockItem) error {
+	var (
+		ok  bool
+		err error
+	)
+	if item.VarianID != 0 {
+		ok, err = uc.productVarianRepo.UpdateStockByID(ctx, item.VarianID, item.Quantity)
+	} else {
+		ok, err = uc.productRepo.UpdateStockByID(ctx, item.ProductID, item.Quantity)
+	}
+	if err != nil {
+		return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productRepo))
+	}
+
+	if !ok {
+		if item.Quantity > 0 {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldProduct)
+		}
+		msg := fmt.Sprintf("%s (product_id %d, varian_id %d)", errorutils.ErrMessageStockInsufficient, item.ProductID, item.VarianID)
+		return errorutils.HandleCustomError(ctx, nil, msg, constanta.FieldStock)
+	}
+	return nil
+}
+
+// expandBundles memecah bundle menjadi komponen, menggabungkan baris yang sama dan
+// mengurutkannya supaya urutan lock baris stok selalu sama antar transaksi
+func (uc *inventoryUseCase) expandBundles(ctx context.Context, items []models.StockItem) ([]models.StockItem, error) {
+	productIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		if item.Quantity < 1 {
+			return nil, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageStockQuantity, constanta.FieldStock)
+		}
+		productIDs = append(productIDs, item.ProductID)
+	}
+	productIDs = uniqueInt64s(productIDs)
+
+	products, err := uc.productRepo.GetProductByListIDs(ctx, productIDs)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	if len(products) != len(productIDs) {
+		return nil, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldProduct)
+	}
+
+	productMap := make(map[int64]models.Product, len(products))
+	var bundleIDs []int64
+	for _, p := range products {
+		productMap[p.ID] = p
+		if p.ProductType == models.ProductTypeBundle {
+			bundleIDs = append(bundleIDs, p.ID)
+		}
+	}
+
+	bundleItems := make(map[int64][]models.ProductBundleItem, len(bundleIDs))
+	if len(bundleIDs) > 0 {
+		list, err := uc.productBundleRepo.GetBundleItemByBundleProductIDs(ctx, bundleIDs)
+		if err != nil {
+			return nil, errorutils.HandleRepoError(ctx, err)
+		}
+		for _, item := range list {
+			bundleItems[item.BundleProductID] = append(bundleItems[item.BundleProductID], item)
+		}
+	}
+
+	quantities := make(map[[2]int64]int)
+	for _, item := range items {
+		product := productMap[item.ProductID]
+		if product.ProductType != models.ProductTypeBundle {
+			if product.HasVarian && item.VarianID == 0 {
+				return nil, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageVarianRequired, constanta.FieldVarian)
+			}
+			quantities[[2]int64{item.ProductID, item.VarianID}] += item.Quantity
+			continue
+		}
+
+		components := bundleItems[product.ID]
+		if len(components) == 0 {
+			return nil, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageBundleComponent, constanta.FieldBundle)
+		}
+		for _, c := range components {
+			quantities[[2]int64{c.ComponentProductID, bundleVarianID(c)}] += c.Quantity * item.Quantity
+		}
+	}
+
+	keys := make([][2]int64, 0, len(quantities))
+	for k := range quantities {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+
+	res := make([]models.StockItem, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, models.StockItem{ProductID: k[0], VarianID: k[1], Quantity: quantities[k]})
+	}
+	return res, nil
+}
+
+// getComponentStocks stok komponen per produk / varian (key priceKey), komponen tidak aktif = 0
+func (uc *inventoryUseCase) getComponentStocks(ctx context.Context, items []models.ProductBundleItem) (map[string]int, error) {
+	var productIDs, varianIDs []int64
+	for _, item := range items {
+		productIDs = append(productIDs, item.ComponentProductID)
+		if item.ComponentVarianID != nil {
+			varianIDs = append(varianIDs, *item.ComponentVarianID)
+		}
+	}
+
+	stocks := make(map[string]int, len(items))
+	if len(productIDs) == 0 {
+		return stocks, nil
+	}
+
+	products, err := uc.productRepo.GetProductByListIDs(ctx, uniqueInt64s(productIDs))
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	active := make(map[int64]bool, len(products))
+	for _, p := range products {
+		active[p.ID] = p.IsActive
+		if p.IsActive {
+			stocks[priceKey(p.ID, 0)] = p.Stock
+		}
+	}
+
+	if len(varianIDs) > 0 {
+		varians, err := uc.productVarianRepo.GetProductVarianByListIDs(ctx, uniqueInt64s(varianIDs))
+		if err != nil {
+			return nil, errorutils.HandleRepoError(ctx, err)
+		}
+		for _, v := range varians {
+			if v.IsActive && active[v.ProductID] {
+				stocks[priceKey(v.ProductID, v.ID)] = v.Stock
+			}
+		}
+	}
+
+	return stocks, nil
+}
+
+func bundleVarianID(item models.ProductBundleItem) int64 {
+	if item.ComponentVarianID == nil {
+		return 0
+	}
+	return *item.ComponentVarianID
+}
@@ -0,0 +1,399 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/invoice"
+	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/tax"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InvoiceUseCase invoice order dan nota kredit atas refund. Invoice terbit otomatis saat order dibayar,
+// nota kredit saat dana dikembalikan. Nomor urut per jenis dokumen, cabang user penerbit dan tahun,
+// diambil di transaksi yang sama dengan penyimpanan dokumen sehingga tidak ada nomor yang loncat.
+type InvoiceUseCase interface {
+	IssueInvoice(ctx context.Context, orderID int64) (response.InvoiceResponse, error)
+	GetInvoiceByID(ctx context.Context, id int64) (response.InvoiceResponse, error)
+	GetListInvoice(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.InvoiceResponse], error)
+	GetListInvoiceByOrderID(ctx context.Context, orderID int64) ([]response.InvoiceResponse, error)
+	DownloadInvoice(ctx context.Context, req *request.ReqInvoiceDocument) (response.FileResponse, error)
+	PrintInvoice(ctx context.Context, req *request.ReqInvoiceDocument) (response.FileResponse, error)
+}
+
+type invoiceUseCase struct {
+	db          *gorm.DB
+	invoiceRepo repo.InvoiceRepository
+	orderRepo   repo.OrderRepository
+	userRepo    repo.UserRepository
+	cfg         invoice.Config
+}
+
+func NewInvoiceUseCase(db *gorm.DB,
+	invoiceRepo repo.InvoiceRepository,
+	orderRepo repo.OrderRepository,
+	userRepo repo.UserRepository,
+	cfg invoice.Config) InvoiceUseCase {
+	return &invoiceUseCase{
+		db:          db,
+		invoiceRepo: invoiceRepo,
+		orderRepo:   orderRepo,
+		userRepo:    userRepo,
+		cfg:         cfg,
+	}
+}
+
+// IssueInvoice terbitkan invoice order yang sudah dibayar (mis. order sebelum fitur invoice ada),
+// invoice yang sudah ada dikembalikan apa adanya
+func (uc *invoiceUseCase) IssueInvoice(ctx context.Context, orderID int64) (response.InvoiceResponse, error) {
+	order, err := uc.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return response.InvoiceResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if order.Status == models.OrderStatusPendingPayment || order.Status == models.OrderStatusCancelled {
+		return response.InvoiceResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageInvoiceOrderStatus, constanta.FieldStatus)
+	}
+
+	inv, err := uc.issueInvoice(ctx, order.ID, nil)
+	if err != nil {
+		return response.InvoiceResponse{}, err
+	}
+	return uc.GetInvoiceByID(ctx, inv.ID)
+}
+
+func (uc *invoiceUseCase) GetInvoiceByID(ctx context.Context, id int64) (response.InvoiceResponse, error) {
+	inv, err := uc.invoiceRepo.GetInvoiceByID(ctx, id)
+	if err != nil {
+		return response.InvoiceResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	return response.SetInvoiceResponse(inv), nil
+}
+
+func (uc *invoiceUseCase) GetListInvoice(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.InvoiceResponse], error) {
+	invoices, count, err := uc.invoiceRepo.GetListInvoice(ctx, listStruct)
+	if err != nil {
+		return response.ListResponse[response.InvoiceResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListInvoice(invoices), count, listStruct, repo.GetFilterAvailableFromRepo(uc.invoiceRepo))
+	return listResponse, nil
+}
+
+func (uc *invoiceUseCase) GetListInvoiceByOrderID(ctx context.Context, orderID int64) ([]response.InvoiceResponse, error) {
+	if _, err := uc.orderRepo.GetOrderByID(ctx, orderID); err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	invoices, err := uc.invoiceRepo.GetListInvoiceByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	return response.SetResponseListInvoice(invoices), nil
+}
+
+// DownloadInvoice dokumen asli untuk dikirim ke pelanggan / arsip, tidak dihitung sebagai cetak
+func (uc *invoiceUseCase) DownloadInvoice(ctx context.Context, req *request.ReqInvoiceDocument) (response.FileResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.FileResponse{}, err
+	}
+
+	inv, err := uc.invoiceRepo.GetInvoiceByID(ctx, req.ID)
+	if err != nil {
+		return response.FileResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	return uc.render(ctx, inv, invoice.Format(req.Format), 0)
+}
+
+// PrintInvoice cetak dokumen (struk POS / A4). Jumlah cetak dicatat, cetakan setelah yang pertama
+// ditandai "SALINAN KE-n" supaya tidak dianggap dokumen asli.
+func (uc *invoiceUseCase) PrintInvoice(ctx context.Context, req *request.ReqInvoiceDocument) (response.FileResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.FileResponse{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.FileResponse{}, errorutils.ErrDataNotFound
+	}
+
+	var file response.FileResponse
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		count, err := uc.invoiceRepo.IncrementPrintCount(ctx, req.ID, userID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		inv, err := uc.invoiceRepo.GetInvoiceByID(ctx, req.ID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		// dokumen gagal dibuat = cetak tidak dihitung
+		file, err = uc.render(ctx, inv, invoice.Format(req.Format), count-1)
+		return err
+	})
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+	return file, nil
+}
+
+// issueInvoice invoice order, invoice yang sudah ada dikembalikan. Dipanggil saat order dibayar,
+// ikut transaksi pembayaran bila ada.
+func (uc *invoiceUseCase) issueInvoice(ctx context.Context, orderID int64, paymentID *int64) (models.Invoice, error) {
+	var inv models.Invoice
+	err := processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		existing, err := uc.invoiceRepo.GetInvoiceByOrderID(ctx, orderID)
+		if err == nil {
+			inv = existing
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		order, err := uc.orderRepo.GetOrderByID(ctx, orderID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		inv = models.Invoice{
+			DocumentType:  invoice.TypeInvoice,
+			OrderID:       order.ID,
+			PaymentID:     paymentID,
+			TaxMode:       order.TaxMode,
+			Subtotal:      order.Subtotal,
+			DiscountTotal: order.DiscountTotal,
+			ShippingTotal: order.ShippingTotal,
+			TaxTotal:      order.TaxTotal,
+			GrandTotal:    order.GrandTotal,
+		}
+		setInvoiceCustomer(&inv, order)
+		return uc.create(ctx, &inv)
+	})
+	return inv, err
+}
+
+// issueCreditNote nota kredit sebesar amount atas invoice order (invoice diterbitkan dulu bila belum
+// ada). Pajak nota kredit sebanding porsi refund terhadap total invoice, refund penuh menyalin
+// seluruh nominal invoice.
+func (uc *invoiceUseCase) issueCreditNote(ctx context.Context, orderID int64, paymentID *int64, amount float64, reason string) error {
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		parent, err := uc.issueInvoice(ctx, orderID, paymentID)
+		if err != nil {
+			return err
+		}
+
+		note := models.Invoice{
+			DocumentType:    invoice.TypeCreditNote,
+			OrderID:         orderID,
+			InvoiceID:       &parent.ID,
+			PaymentID:       paymentID,
+			Reason:          reason,
+			TaxMode:         parent.TaxMode,
+			CustomerName:    parent.CustomerName,
+			CustomerPhone:   parent.CustomerPhone,
+			CustomerEmail:   parent.CustomerEmail,
+			CustomerAddress: parent.CustomerAddress,
+			GrandTotal:      utils.RoundTo2Digits(amount),
+		}
+		if note.GrandTotal >= parent.GrandTotal {
+			note.Subtotal = parent.Subtotal
+			note.DiscountTotal = parent.DiscountTotal
+			note.ShippingTotal = parent.ShippingTotal
+			note.TaxTotal = parent.TaxTotal
+			note.GrandTotal = parent.GrandTotal
+		} else {
+			if parent.GrandTotal > 0 {
+				note.TaxTotal = utils.RoundTo2Digits(parent.TaxTotal * note.GrandTotal / parent.GrandTotal)
+			}
+			note.Subtotal = note.GrandTotal
+			if note.TaxMode != tax.ModeInclusive {
+				note.Subtotal = utils.RoundTo2Digits(note.GrandTotal - note.TaxTotal)
+			}
+		}
+		return uc.create(ctx, &note)
+	})
+}
+
+// create simpan dokumen dengan nomor urut berikutnya, wajib di dalam transaksi
+func (uc *invoiceUseCase) create(ctx context.Context, inv *models.Invoice) error {
+	branchID, err := uc.branchID(ctx)
+	if err != nil {
+		return err
+	}
+
+	userID, _ := utils.GetUserIDFromCtx(ctx)
+	inv.IssuedAt = time.Now()
+	inv.BranchID = branchID
+	inv.Year = inv.IssuedAt.Year()
+	inv.CreatedBy = userID
+	inv.UpdatedBy = userID
+
+	inv.Sequence, err = uc.invoiceRepo.NextSequence(ctx, inv.DocumentType, inv.BranchID, inv.Year)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+	inv.Number = uc.cfg.Number(inv.DocumentType, inv.BranchID, inv.Year, inv.Sequence)
+
+	if err := uc.invoiceRepo.Create(ctx, inv); err != nil {
+		return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.invoiceRepo))
+	}
+	return nil
+}
+
+// branchID cabang user yang menerbitkan dokumen, 0 untuk proses sistem / toko online
+func (uc *invoiceUseCase) branchID(ctx context.Context) (int64, error) {
+	userID, _ := utils.GetUserIDFromCtx(ctx)
+	if userID == 0 {
+		return 0, nil
+	}
+	branchID, err := uc.userRepo.GetUserBranchID(ctx, userID)
+	if err != nil {
+		return 0, errorutils.HandleRepoError(ctx, err)
+	}
+	return branchID, nil
+}
+
+func (uc *invoiceUseCase) render(ctx context.Context, inv models.Invoice, format invoice.Format, copyNo int) (response.FileResponse, error) {
+	if uc.cfg.Company.Name == "" {
+		return response.FileResponse{}, errorutils.HandleCustomError(ctx, invoice.ErrNotConfigured, errorutils.ErrMessageInvoiceNotConfigured, constanta.FieldInvoice)
+	}
+
+	content, err := invoice.Render(format, uc.cfg.Company, invoiceDocument(inv, copyNo))
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to render invoice %s", inv.Number), err)
+		if errors.Is(err, invoice.ErrUnknownFormat) {
+			return response.FileResponse{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageInvoiceFormat, constanta.FieldInvoice)
+		}
+		return response.FileResponse{}, errorutils.ErrInternalServerError
+	}
+
+	return response.FileResponse{
+		FileName:    strings.ReplaceAll(inv.Number, "/", "-") + format.Extension(),
+		ContentType: format.ContentType(),
+		Content:     content,
+	}, nil
+}
+
+// invoiceDocument isi dokumen dari data yang disalin saat terbit serta item & pajak order.
+// Nota kredit sebagian dicetak sebagai satu baris refund dengan rincian pajak proporsional.
+func invoiceDocument(inv models.Invoice, copyNo int) invoice.Document {
+	doc := invoice.Document{
+		Type:            inv.DocumentType,
+		Number:          inv.Number,
+		IssuedAt:        inv.IssuedAt,
+		Note:            inv.Reason,
+		Copy:            copyNo,
+		CustomerName:    inv.CustomerName,
+		CustomerPhone:   inv.CustomerPhone,
+		CustomerEmail:   inv.CustomerEmail,
+		CustomerAddress: inv.CustomerAddress,
+		TaxInclusive:    inv.TaxMode == tax.ModeInclusive,
+		Subtotal:        inv.Subtotal,
+		DiscountTotal:   inv.DiscountTotal,
+		ShippingTotal:   inv.ShippingTotal,
+		TaxTotal:        inv.TaxTotal,
+		GrandTotal:      inv.GrandTotal,
+	}
+
+	var (
+		lines []invoice.Line
+		taxes []invoice.Tax
+	)
+	if inv.Order != nil {
+		doc.OrderNumber = inv.Order.OrderNumber
+		for _, item := range inv.Order.Items {
+			name, code := item.ProductName, item.ProductCode
+			if item.VarianName != "" {
+				name += " - " + item.VarianName
+			}
+			if item.VarianCode != "" {
+				code = item.VarianCode
+			}
+			lines = append(lines, invoice.Line{
+				Name:      name,
+				Code:      code,
+				Quantity:  item.Quantity,
+				UnitPrice: item.UnitPrice,
+				Discount:  item.DiscountAmount,
+				Amount:    utils.RoundTo2Digits(item.LineTotal - item.DiscountAmount),
+			})
+		}
+		for _, t := range inv.Order.Taxes {
+			name := t.RateName
+			if name == "" {
+				name = t.TaxClassName
+			}
+			taxes = append(taxes, invoice.Tax{
+				Name:          name,
+				Rate:          t.Rate,
+				TaxableAmount: t.TaxableAmount,
+				TaxAmount:     t.TaxAmount,
+			})
+		}
+	}
+
+	doc.Lines, doc.Taxes = lines, taxes
+	if inv.DocumentType != invoice.TypeCreditNote || inv.Invoice == nil {
+		return doc
+	}
+
+	doc.Reference = inv.Invoice.Number
+	if inv.GrandTotal >= inv.Invoice.GrandTotal {
+		return doc
+	}
+	doc.Lines = []invoice.Line{{
+		Name:      "Refund atas invoice " + inv.Invoice.Number,
+		Quantity:  1,
+		UnitPrice: inv.Subtotal,
+		Amount:    inv.Subtotal,
+	}}
+	doc.Taxes = nil
+	if inv.Invoice.GrandTotal > 0 {
+		doc.Taxes = invoice.ScaleTaxes(taxes, inv.GrandTotal/inv.Invoice.GrandTotal, inv.TaxTotal)
+	}
+	return doc
+}
+
+// setInvoiceCustomer salin data pelanggan dan alamat order ke dokumen
+func setInvoiceCustomer(inv *models.Invoice, order models.Order) {
+	if order.Customer != nil {
+		inv.CustomerName = order.Customer.Name
+		inv.CustomerPhone = order.Customer.Phone
+		inv.CustomerEmail = order.Customer.Email
+	}
+
+	address := order.ShippingAddress
+	if address == nil {
+		return
+	}
+	if inv.CustomerName == "" {
+		inv.CustomerName = address.RecipientName
+	}
+	if inv.CustomerPhone == "" {
+		inv.CustomerPhone = address.PhoneNumber
+	}
+
+	var parts []string
+	for _, part := range []string{address.AddressLine1, address.AddressLine2, address.Village, address.Subdistrict,
+		address.City, strings.TrimSpace(address.Province + " " + address.PostalCode)} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	inv.CustomerAddress = strings.Join(parts, ", ")
+}
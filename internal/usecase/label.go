@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/label"
+	"pleasurelove/pkg/logger"
+	"time"
+)
+
+type LabelUseCase interface {
+	GenerateLabel(ctx context.Context, req *request.ReqLabel) (response.FileResponse, error)
+}
+
+type labelUseCase struct {
+	productRepo       repo.ProductRepository
+	productVarianRepo repo.ProductVarianRepository
+	pricingUC         PricingUseCase
+}
+
+func NewLabelUseCase(productRepo repo.ProductRepository, productVarianRepo repo.ProductVarianRepository, pricingUC PricingUseCase) LabelUseCase {
+	return &labelUseCase{
+		productRepo:       productRepo,
+		productVarianRepo: productVarianRepo,
+		pricingUC:         pricingUC,
+	}
+}
+
+func (uc *labelUseCase) GenerateLabel(ctx context.Context, req *request.ReqLabel) (response.FileResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.FileResponse{}, err
+	}
+
+	products, varians, err := uc.getLabelData(ctx, req.Items)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	// label rak memakai harga umum (tanpa pelanggan) untuk qty 1
+	queries := make([]models.PriceQuery, 0, len(req.Items))
+	for _, item := range req.Items {
+		queries = append(queries, models.PriceQuery{ProductID: item.ProductID, VarianID: item.VarianID, Quantity: 1})
+	}
+
+	prices, err := uc.pricingUC.ResolvePrices(ctx, models.PricingContext{}, queries)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	symbology := label.Symbology(req.Symbology)
+	var labels []label.Label
+	for i, item := range req.Items {
+		product := products[item.ProductID]
+
+		l := label.Label{
+			Name:  product.Name,
+			Code:  labelCode(symbology, product.Code, product.Barcode),
+			Price: prices[i].UnitPrice,
+		}
+
+		if item.VarianID != 0 {
+			varian := varians[item.VarianID]
+			if varian.ProductID != product.ID {
+				return response.FileResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldVarian)
+			}
+
+			l.Name = product.Name + " - " + varian.Name
+			l.Code = labelCode(symbology, varian.Code, varian.Barcode)
+		}
+
+		if err := label.ValidateCode(symbology, l.Code); err != nil {
+			return response.FileResponse{}, errorutils.HandleCustomError(ctx, err, err.Error(), constanta.FieldCode)
+		}
+
+		for i := 0; i < item.Quantity; i++ {
+			labels = append(labels, l)
+		}
+	}
+
+	format := label.Format(req.Format)
+	content, err := label.Render(format, symbology, labels)
+	if err != nil {
+		logger.Error(ctx, "Failed to render label", err)
+		return response.FileResponse{}, err
+	}
+
+	return response.FileResponse{
+		FileName:    fmt.Sprintf("label-%s%s", time.Now().Format("20060102150405"), format.Extension()),
+		ContentType: format.ContentType(),
+		Content:     content,
+	}, nil
+}
+
+func (uc *labelUseCase) getLabelData(ctx context.Context, items []request.ReqLabelItem) (map[int64]models.Product, map[int64]models.ProductVarian, error) {
+	var (
+		productIDs []int64
+		varianIDs  []int64
+	)
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+		if item.VarianID != 0 {
+			varianIDs = append(varianIDs, item.VarianID)
+		}
+	}
+
+	productDb, err := uc.productRepo.GetProductByListIDs(ctx, productIDs)
+	if err != nil {
+		return nil, nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	products := make(map[int64]models.Product, len(productDb))
+	for _, p := range productDb {
+		products[p.ID] = p
+	}
+
+	for _, id := range productIDs {
+		if _, ok := products[id]; !ok {
+			return nil, nil, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldProduct)
+		}
+	}
+
+	varians := make(map[int64]models.ProductVarian)
+	if len(varianIDs) > 0 {
+		varianDb, err := uc.productVarianRepo.GetProductVarianByListIDs(ctx, varianIDs)
+		if err != nil {
+			return nil, nil, errorutils.HandleRepoError(ctx, err)
+		}
+
+		for _, v := range varianDb {
+			varians[v.ID] = v
+		}
+
+		for _, id := range varianIDs {
+			if _, ok := varians[id]; !ok {
+				return nil, nil, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldVarian)
+			}
+		}
+	}
+
+	return products, varians, nil
+}
+
+// labelCode memilih isi barcode: EAN-13 wajib memakai barcode numerik,
+// selain itu barcode dipakai jika ada dan kode produk sebagai cadangan
+func labelCode(symbology label.Symbology, code, barcode string) string {
+	if symbology == label.SymbologyEAN13 || barcode != "" {
+		return barcode
+	}
+	return code
+}
@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const orderNumberCodeLength = 6
+
+// OrderUseCase order dan state machine statusnya (lihat models.CanTransitionOrder).
+// Stok dipakai saat order dibuat dan dikembalikan saat order batal / refund sebelum dikirim.
+type OrderUseCase interface {
+	CreateOrder(ctx context.Context, req *request.ReqOrder) (response.OrderResponse, error)
+	GetOrderByID(ctx context.Context, id int64) (response.OrderResponse, error)
+	GetListOrder(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.OrderResponse], error)
+	UpdateOrderStatusByID(ctx context.Context, req *request.ReqOrderStatus) (response.OrderResponse, error)
+}
+
+type orderUseCase struct {
+	db                *gorm.DB
+	orderRepo         repo.OrderRepository
+	customerRepo      repo.CustomerRepository
+	productRepo       repo.ProductRepository
+	productVarianRepo repo.ProductVarianRepository
+	pricingUC         PricingUseCase
+	inventoryUC       InventoryUseCase
+	tax               *taxUseCase
+}
+
+func NewOrderUseCase(db *gorm.DB,
+	orderRepo repo.OrderRepository,
+	customerRepo repo.CustomerRepository,
+	productRepo repo.ProductRepository,
+	productVarianRepo repo.ProductVarianRepository,
+	pricingUC PricingUseCase,
+	inventoryUC InventoryUseCase,
+	taxUC TaxUseCase) OrderUseCase {
+	return &orderUseCase{
+		db:                db,
+		orderRepo:         orderRepo,
+		customerRepo:      customerRepo,
+		productRepo:       productRepo,
+		productVarianRepo: productVarianRepo,
+		pricingUC:         pricingUC,
+		inventoryUC:       inventoryUC,
+		tax:               taxUC.(*taxUseCase),
+	}
+}
+
+// CreateOrder order manual dari dashboard, harga mengikuti harga pelanggan saat ini
+func (uc *orderUseCase) CreateOrder(ctx context.Context, req *request.ReqOrder) (response.OrderResponse, error) {
+	if err := req.ValidateRequestCreate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.OrderResponse{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.OrderResponse{}, errorutils.ErrDataNotFound
+	}
+
+	customer, err := uc.customerRepo.GetCustomerByID(ctx, req.CustomerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.OrderResponse{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldCustomer)
+		}
+		return response.OrderResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	queries := make([]models.PriceQuery, 0, len(req.Items))
+	for _, item := range req.Items {
+		queries = append(queries, models.PriceQuery{
+			ProductID: item.ProductID,
+			VarianID:  item.VarianID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	pricingCtx := models.PricingContext{
+		CustomerID:      customer.ID,
+		CustomerGroupID: customer.CustomerGroupID,
+		At:              time.Now(),
+	}
+	items, _, err := uc.newOrderItems(ctx, pricingCtx, queries)
+	if err != nil {
+		return response.OrderResponse{}, err
+	}
+
+	order, err := newOrder(customer.ID, items, req.Notes, userID)
+	if err != nil {
+		return response.OrderResponse{}, err
+	}
+	if err := uc.tax.applyOrderTax(ctx, &order, pricingCtx.At); err != nil {
+		return response.OrderResponse{}, err
+	}
+	order.History = []models.OrderStatusHistory{
+		newOrderHistory("", models.OrderStatusPendingPayment, "", models.OrderActorUser, userID),
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		return uc.placeOrder(ctx, &order)
+	})
+	if err != nil {
+		return response.OrderResponse{}, err
+	}
+
+	return uc.GetOrderByID(ctx, order.ID)
+}
+
+func (uc *orderUseCase) GetOrderByID(ctx context.Context, id int64) (response.OrderResponse, error) {
+	order, err := uc.orderRepo.GetOrderByID(ctx, id)
+	if err != nil {
+		return response.OrderResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	return response.SetOrderResponse(order), nil
+}
+
+func (uc *orderUseCase) GetListOrder(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.OrderResponse], error) {
+	orders, count, err := uc.orderRepo.GetListOrder(ctx, listStruct)
+	if err != nil {
+		return response.ListResponse[response.OrderResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListOrder(orders), count, listStruct, repo.GetFilterAvailableFromRepo(uc.orderRepo))
+	return listResponse, nil
+}
+
+func (uc *orderUseCase) UpdateOrderStatusByID(ctx context.Context, req *request.ReqOrderStatus) (response.OrderResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.OrderResponse{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.OrderResponse{}, errorutils.ErrDataNotFound
+	}
+
+	order, err := uc.orderRepo.GetOrderByID(ctx, req.ID)
+	if err != nil {
+		return response.OrderResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, order.UpdatedAt) {
+		return response.OrderResponse{}, errorutils.ErrDataDataUpdated
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		return uc.transitionOrder(ctx, order, req.Status, req.Note, models.OrderActorUser, userID)
+	})
+	if err != nil {
+		return response.OrderResponse{}, err
+	}
+
+	return uc.GetOrderByID(ctx, order.ID)
+}
+
+// transitionOrder mengubah status sesuai state machine dan mencatat riwayatnya,
+// wajib dipanggil di dalam transaksi. order harus berisi Items bila stok perlu dikembalikan.
+func (uc *orderUseCase) transitionOrder(ctx context.Context, order models.Order, toStatus, note, actorType string, actorID int64) error {
+	if !models.CanTransitionOrder(order.Status, toStatus) {
+		msg := fmt.Sprintf("status order tidak bisa diubah dari %s ke %s", order.Status, toStatus)
+		return errorutils.HandleCustomError(ctx, nil, msg, constanta.FieldStatus)
+	}
+
+	var updatedBy int64
+	if actorType == models.OrderActorUser {
+		updatedBy = actorID
+	}
+
+	_, err := uc.orderRepo.UpdateOrderStatusByID(ctx, order.ID, order.UpdatedAt, order.Status, toStatus, updatedBy)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorutils.ErrDataDataUpdated
+		}
+		return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.orderRepo))
+	}
+
+	history := newOrderHistory(order.Status, toStatus, note, actorType, actorID)
+	history.OrderID = order.ID
+	if err := uc.orderRepo.CreateStatusHistory(ctx, &history); err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if models.IsStockRestoredOnTransition(order.Status, toStatus) {
+		if err := uc.inventoryUC.RestoreStock(ctx, order.StockItems()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// placeOrder menyimpan order lalu memakai stok, wajib dipanggil di dalam transaksi
+func (uc *orderUseCase) placeOrder(ctx context.Context, order *models.Order) error {
+	err := uc.orderRepo.Create(ctx, order)
+	if err != nil {
+		return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.orderRepo))
+	}
+
+	return uc.inventoryUC.ConsumeStock(ctx, order.StockItems())
+}
+
+// newOrderItems snapshot produk / varian (harus aktif) dengan harga efektif dari PricingUseCase,
+// urutan item dan harga sesuai urutan queries
+func (uc *orderUseCase) newOrderItems(ctx context.Context, pricingCtx models.PricingContext, queries []models.PriceQuery) ([]models.OrderItem, []models.ResolvedPrice, error) {
+	var productIDs, varianIDs []int64
+	for _, q := range queries {
+		productIDs = append(productIDs, q.ProductID)
+		if q.VarianID != 0 {
+			varianIDs = append(varianIDs, q.VarianID)
+		}
+	}
+
+	products, err := uc.productRepo.GetProductByListIDs(ctx, uniqueInt64s(productIDs))
+	if err != nil {
+		return nil, nil, errorutils.HandleRepoError(ctx, err)
+	}
+	productMap := make(map[int64]models.Product, len(products))
+	for _, p := range products {
+		productMap[p.ID] = p
+	}
+
+	varianMap := make(map[int64]models.ProductVarian, len(varianIDs))
+	if len(varianIDs) > 0 {
+		varians, err := uc.productVarianRepo.GetProductVarianByListIDs(ctx, uniqueInt64s(varianIDs))
+		if err != nil {
+			return nil, nil, errorutils.HandleRepoError(ctx, err)
+		}
+		for _, v := range varians {
+			varianMap[v.ID] = v
+		}
+	}
+
+	items := make([]models.OrderItem, 0, len(queries))
+	for _, q := range queries {
+		product, ok := productMap[q.ProductID]
+		if !ok {
+			return nil, nil, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldProduct)
+		}
+		if !product.IsActive {
+			return nil, nil, errorutils.HandleCustomError(ctx, nil, fmt.Sprintf("%s (%s)", errorutils.ErrMessageProductInactive, product.Name), constanta.FieldProduct)
+		}
+
+		item := models.OrderItem{
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			ProductCode: product.Code,
+			Quantity:    q.Quantity,
+			Price:       product.Price,
+			Discount:    product.Discount,
+			CostPrice:   product.CostPrice,
+			WeightGram:  product.WeightGram,
+			LengthCm:    product.LengthCm,
+			WidthCm:     product.WidthCm,
+			HeightCm:    product.HeightCm,
+		}
+
+		if q.VarianID != 0 {
+			varian, ok := varianMap[q.VarianID]
+			if !ok || varian.ProductID != product.ID {
+				return nil, nil, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldVarian)
+			}
+			if !varian.IsActive {
+				return nil, nil, errorutils.HandleCustomError(ctx, nil, fmt.Sprintf("%s (%s %s)", errorutils.ErrMessageVarianInactive, product.Name, varian.Name), constanta.FieldVarian)
+			}
+			item.VarianID = varian.ID
+			item.VarianName = varian.Name
+			item.VarianCode = varian.Code
+			item.Price = varian.Price
+			item.Discount = varian.Discount
+			item.CostPrice = varian.CostPrice
+			// berat / dimensi varian 0 = mengikuti produk
+			if varian.WeightGram > 0 {
+				item.WeightGram = varian.WeightGram
+			}
+			if varian.LengthCm > 0 || varian.WidthCm > 0 || varian.HeightCm > 0 {
+				item.LengthCm, item.WidthCm, item.HeightCm = varian.LengthCm, varian.WidthCm, varian.HeightCm
+			}
+		} else if product.HasVarian {
+			return nil, nil, errorutils.HandleCustomError(ctx, nil, fmt.Sprintf("%s (%s)", errorutils.ErrMessageVarianRequired, product.Name), constanta.FieldVarian)
+		}
+
+		items = append(items, item)
+	}
+
+	prices, err := uc.pricingUC.ResolvePrices(ctx, pricingCtx, queries)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, price := range prices {
+		items[i].UnitPrice = price.UnitPrice
+		items[i].PriceSource = price.Source
+		items[i].LineTotal = utils.RoundTo2Digits(price.UnitPrice * float64(items[i].Quantity))
+	}
+
+	return items, prices, nil
+}
+
+// newOrder order baru berstatus pending_payment, total = jumlah line_total item
+func newOrder(customerID int64, items []models.OrderItem, notes string, userID int64) (models.Order, error) {
+	orderNumber, err := generateOrderNumber()
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	var subtotal float64
+	for _, item := range items {
+		subtotal += item.LineTotal
+	}
+	subtotal = utils.RoundTo2Digits(subtotal)
+
+	return models.Order{
+		OrderNumber: orderNumber,
+		CustomerID:  customerID,
+		Status:      models.OrderStatusPendingPayment,
+		Subtotal:    subtotal,
+		GrandTotal:  subtotal,
+		Notes:       notes,
+		Items:       items,
+		CreatedBy:   userID,
+		UpdatedBy:   userID,
+	}, nil
+}
+
+func newOrderHistory(from, to, note, actorType string, actorID int64) models.OrderStatusHistory {
+	return models.OrderStatusHistory{
+		FromStatus: from,
+		ToStatus:   to,
+		Note:       note,
+		ActorType:  actorType,
+		ActorID:    actorID,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// generateOrderNumber format ORD-YYYYMMDD-XXXXXX, bentrok ditangani unique constraint
+func generateOrderNumber() (string, error) {
+	code, err := utils.GenerateRandomCode(orderNumberCodeLength)
+	if err != nil {
+		return "", errorutils.ErrInternalServerError
+	}
+	return fmt.Sprintf("ORD-%s-%s", time.Now().Format("20060102"), code), nil
+}
@@ -0,0 +1,623 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/media"
+	"pleasurelove/pkg/payment"
+	"pleasurelove/pkg/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const contentTypePDF = "application/pdf"
+
+// PaymentUseCase pembayaran order lewat payment.Provider. Pelanggan membuat pembayaran untuk order
+// pending_payment; status dari webhook (gateway) atau konfirmasi staf (transfer manual) mengubah
+// order ke paid, refund penuh mengubah order ke refunded sesuai state machine order. Invoice terbit
+// saat order dibayar, setiap refund menerbitkan nota kredit sebesar dana yang dikembalikan.
+type PaymentUseCase interface {
+	CreatePayment(ctx context.Context, req *request.ReqPayment) (response.PaymentResponse, error)
+	GetPaymentByID(ctx context.Context, id int64) (response.PaymentResponse, error)
+	UploadPaymentProof(ctx context.Context, req *request.ReqPaymentProof) (response.PaymentResponse, error)
+	HandleWebhook(ctx context.Context, providerName string, header http.Header, body []byte) error
+
+	GetListPayment(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.PaymentResponse], error)
+	GetListPaymentByOrderID(ctx context.Context, orderID int64) ([]response.PaymentResponse, error)
+	GetPaymentDetailByID(ctx context.Context, id int64) (response.PaymentResponse, error)
+	ConfirmPayment(ctx context.Context, req *request.ReqPaymentConfirm) (response.PaymentResponse, error)
+	RefundPayment(ctx context.Context, req *request.ReqPaymentRefund) (response.PaymentResponse, error)
+}
+
+type paymentUseCase struct {
+	db           *gorm.DB
+	order        *orderUseCase // transisi status order
+	invoice      *invoiceUseCase
+	orderRepo    repo.OrderRepository
+	customerRepo repo.CustomerRepository
+	paymentRepo  repo.PaymentRepository
+	store        storage.Storage
+	cfg          payment.Config
+}
+
+func NewPaymentUseCase(db *gorm.DB,
+	orderRepo repo.OrderRepository,
+	customerRepo repo.CustomerRepository,
+	paymentRepo repo.PaymentRepository,
+	productRepo repo.ProductRepository,
+	productVarianRepo repo.ProductVarianRepository,
+	pricingUC PricingUseCase,
+	inventoryUC InventoryUseCase,
+	invoiceUC InvoiceUseCase,
+	store storage.Storage,
+	cfg payment.Config) PaymentUseCase {
+	return &paymentUseCase{
+		db: db,
+		order: &orderUseCase{
+			db:                db,
+			orderRepo:         orderRepo,
+			customerRepo:      customerRepo,
+			productRepo:       productRepo,
+			productVarianRepo: productVarianRepo,
+			pricingUC:         pricingUC,
+			inventoryUC:       inventoryUC,
+		},
+		invoice:      invoiceUC.(*invoiceUseCase),
+		orderRepo:    orderRepo,
+		customerRepo: customerRepo,
+		paymentRepo:  paymentRepo,
+		store:        store,
+		cfg:          cfg,
+	}
+}
+
+// CreatePayment instruksi bayar (nomor VA, QRIS, deeplink e-wallet atau rekening toko). Pembayaran
+// pending dengan metode yang sama dikembalikan lagi, transaksi di provider dibuat sebelum disimpan.
+func (uc *paymentUseCase) CreatePayment(ctx context.Context, req *request.ReqPayment) (response.PaymentResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.PaymentResponse{}, err
+	}
+
+	order, err := uc.getCustomerOrder(ctx, req.OrderID)
+	if err != nil {
+		return response.PaymentResponse{}, err
+	}
+	if order.Status != models.OrderStatusPendingPayment {
+		return response.PaymentResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePaymentOrderStatus, constanta.FieldStatus)
+	}
+
+	provider, err := uc.cfg.ProviderFor(req.Method)
+	if err != nil {
+		return response.PaymentResponse{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessagePaymentMethod, constanta.FieldPayment)
+	}
+
+	payments, err := uc.paymentRepo.GetListPaymentByOrderID(ctx, order.ID)
+	if err != nil {
+		return response.PaymentResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	for _, p := range payments {
+		if p.Status != models.PaymentStatusPending {
+			continue
+		}
+		if p.IsExpired(time.Now()) {
+			if err := uc.paymentRepo.UpdatePaymentStatusByID(ctx, p.ID, models.PaymentStatusExpired, nil, 0); err != nil {
+				return response.PaymentResponse{}, errorutils.HandleRepoError(ctx, err)
+			}
+			continue
+		}
+		if p.Method == req.Method && (req.Channel == "" || p.Channel == req.Channel) {
+			return uc.paymentResponse(p), nil
+		}
+		return response.PaymentResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePaymentPending, constanta.FieldPayment)
+	}
+
+	charge, err := provider.Charge(ctx, payment.ChargeRequest{
+		Reference: fmt.Sprintf("%s-%d", order.OrderNumber, len(payments)+1),
+		Amount:    order.GrandTotal,
+		Method:    req.Method,
+		Channel:   req.Channel,
+		Customer:  paymentCustomer(order),
+		ExpiresAt: time.Now().Add(uc.cfg.Expiry),
+	})
+	if err != nil {
+		return response.PaymentResponse{}, handlePaymentError(ctx, err)
+	}
+
+	userID, _ := utils.GetUserIDFromCtx(ctx)
+	p := models.Payment{
+		OrderID:           order.ID,
+		Provider:          provider.Name(),
+		Method:            charge.Method,
+		Channel:           charge.Channel,
+		Reference:         charge.Reference,
+		TransactionID:     charge.TransactionID,
+		Amount:            utils.RoundTo2Digits(charge.Amount),
+		Status:            models.PaymentStatusPending,
+		VANumber:          charge.VANumber,
+		QRString:          charge.QRString,
+		RedirectURL:       charge.RedirectURL,
+		BankName:          charge.BankName,
+		BankAccountNumber: charge.BankAccountNumber,
+		BankAccountName:   charge.BankAccountName,
+		CreatedBy:         userID,
+		UpdatedBy:         userID,
+	}
+	if !charge.ExpiresAt.IsZero() {
+		p.ExpiresAt = &charge.ExpiresAt
+	}
+
+	// transaksi di provider yang gagal disimpan dibiarkan kedaluwarsa
+	if err := uc.paymentRepo.Create(ctx, &p); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to save payment %s (%s)", p.Reference, p.Provider), err)
+		return response.PaymentResponse{}, errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.paymentRepo))
+	}
+	return uc.paymentResponse(p), nil
+}
+
+// GetPaymentByID pembayaran milik pemesan, status pembayaran pending dicek ulang ke provider
+// supaya tetap sesuai bila webhook terlambat
+func (uc *paymentUseCase) GetPaymentByID(ctx context.Context, id int64) (response.PaymentResponse, error) {
+	p, err := uc.paymentRepo.GetPaymentByID(ctx, id)
+	if err != nil {
+		return response.PaymentResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if _, err := uc.getCustomerOrder(ctx, p.OrderID); err != nil {
+		return response.PaymentResponse{}, err
+	}
+
+	if p.Status == models.PaymentStatusPending {
+		if err := uc.syncPayment(ctx, p); err != nil {
+			return response.PaymentResponse{}, err
+		}
+		if p, err = uc.paymentRepo.GetPaymentByID(ctx, id); err != nil {
+			return response.PaymentResponse{}, errorutils.HandleRepoError(ctx, err)
+		}
+	}
+	return uc.paymentResponse(p), nil
+}
+
+// UploadPaymentProof bukti transfer manual (gambar / PDF), bukti lama diganti
+func (uc *paymentUseCase) UploadPaymentProof(ctx context.Context, req *request.ReqPaymentProof) (response.PaymentResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		return response.PaymentResponse{}, err
+	}
+	if len(req.Data) > media.MaxUploadSize {
+		msg := fmt.Sprintf("ukuran file maksimal %d MB", media.MaxUploadSize>>20)
+		return response.PaymentResponse{}, errorutils.HandleCustomError(ctx, nil, msg, constanta.FieldPayment)
+	}
+
+	contentType := http.DetectContentType(req.Data)
+	ext, ok := media.Extensions[contentType]
+	if contentType == contentTypePDF {
+		ext, ok = ".pdf", true
+	}
+	if !ok {
+		return response.PaymentResponse{}, errorutils.HandleCustomError(ctx, nil, "bukti transfer harus berupa gambar atau PDF", constanta.FieldPayment)
+	}
+
+	p, err := uc.paymentRepo.GetPaymentByID(ctx, req.ID)
+	if err != nil {
+		return response.PaymentResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if _, err := uc.getCustomerOrder(ctx, p.OrderID); err != nil {
+		return response.PaymentResponse{}, err
+	}
+	if p.Provider != payment.DriverManual {
+		return response.PaymentResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePaymentNotManual, constanta.FieldPayment)
+	}
+	if p.Status != models.PaymentStatusPending || p.IsExpired(time.Now()) {
+		return response.PaymentResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePaymentNotPending, constanta.FieldPayment)
+	}
+
+	key := fmt.Sprintf("payment/%d/%s%s", p.ID, uuid.NewString(), ext)
+	if err := uc.store.Put(ctx, key, req.Data, contentType); err != nil {
+		logger.Error(ctx, "Failed to store payment proof", err)
+		return response.PaymentResponse{}, errorutils.ErrInternalServerError
+	}
+	if err := uc.paymentRepo.UpdatePaymentProofByID(ctx, p.ID, key); err != nil {
+		removeMediaFiles(ctx, uc.store, []string{key})
+		return response.PaymentResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if p.ProofKey != "" {
+		removeMediaFiles(ctx, uc.store, []string{p.ProofKey})
+	}
+
+	p, err = uc.paymentRepo.GetPaymentByID(ctx, p.ID)
+	if err != nil {
+		return response.PaymentResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	return uc.paymentResponse(p), nil
+}
+
+// HandleWebhook notifikasi dari provider: signature diverifikasi, event yang sudah pernah diproses
+// diabaikan (provider mengirim ulang notifikasi sampai dijawab 200)
+func (uc *paymentUseCase) HandleWebhook(ctx context.Context, providerName string, header http.Header, body []byte) error {
+	provider, err := uc.cfg.Provider(providerName)
+	if err != nil {
+		return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldPayment)
+	}
+
+	n, err := provider.VerifyWebhook(ctx, header, body)
+	if err != nil {
+		return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessagePaymentNotification, constanta.FieldPayment)
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		p, err := uc.paymentRepo.GetPaymentByReferenceForUpdate(ctx, provider.Name(), n.Reference)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		created, err := uc.paymentRepo.CreateWebhookEvent(ctx, &models.PaymentWebhookEvent{
+			Provider:  provider.Name(),
+			EventKey:  n.EventID,
+			PaymentID: &p.ID,
+			Status:    n.Status,
+			Payload:   string(n.Raw),
+		})
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if !created {
+			logger.Info(ctx, "Duplicate payment notification ignored", map[string]interface{}{"provider": provider.Name(), "event": n.EventID})
+			return nil
+		}
+
+		return uc.applyNotification(ctx, p, n)
+	})
+}
+
+func (uc *paymentUseCase) GetListPayment(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.PaymentResponse], error) {
+	payments, count, err := uc.paymentRepo.GetListPayment(ctx, listStruct)
+	if err != nil {
+		return response.ListResponse[response.PaymentResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListPayment(payments, uc.store.URL), count, listStruct, repo.GetFilterAvailableFromRepo(uc.paymentRepo))
+	return listResponse, nil
+}
+
+func (uc *paymentUseCase) GetListPaymentByOrderID(ctx context.Context, orderID int64) ([]response.PaymentResponse, error) {
+	if _, err := uc.orderRepo.GetOrderByID(ctx, orderID); err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	payments, err := uc.paymentRepo.GetListPaymentByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	return response.SetResponseListPayment(payments, uc.store.URL), nil
+}
+
+func (uc *paymentUseCase) GetPaymentDetailByID(ctx context.Context, id int64) (response.PaymentResponse, error) {
+	p, err := uc.paymentRepo.GetPaymentByID(ctx, id)
+	if err != nil {
+		return response.PaymentResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	return uc.paymentResponse(p), nil
+}
+
+// ConfirmPayment staf menyetujui (order menjadi paid) atau menolak transfer manual setelah
+// mencocokkan bukti transfer dengan mutasi rekening
+func (uc *paymentUseCase) ConfirmPayment(ctx context.Context, req *request.ReqPaymentConfirm) (response.PaymentResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.PaymentResponse{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.PaymentResponse{}, errorutils.ErrDataNotFound
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		p, err := uc.paymentRepo.GetPaymentByIDForUpdate(ctx, req.ID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, p.UpdatedAt) {
+			return errorutils.ErrDataDataUpdated
+		}
+		if p.Provider != payment.DriverManual {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePaymentNotManual, constanta.FieldPayment)
+		}
+		if p.Status != models.PaymentStatusPending {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePaymentNotPending, constanta.FieldPayment)
+		}
+
+		if !req.Approved {
+			if err := uc.paymentRepo.UpdatePaymentConfirmByID(ctx, p.ID, models.PaymentStatusFailed, req.Note, userID); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+			return nil
+		}
+
+		if p.ProofKey == "" {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePaymentProofRequired, constanta.FieldPayment)
+		}
+		order, err := uc.orderRepo.GetOrderByID(ctx, p.OrderID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if err := uc.paymentRepo.UpdatePaymentConfirmByID(ctx, p.ID, models.PaymentStatusPaid, req.Note, userID); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		note := fmt.Sprintf("Transfer %s dikonfirmasi", p.Reference)
+		if err := uc.order.transitionOrder(ctx, order, models.OrderStatusPaid, note, models.OrderActorUser, userID); err != nil {
+			return err
+		}
+		_, err = uc.invoice.issueInvoice(ctx, order.ID, &p.ID)
+		return err
+	})
+	if err != nil {
+		return response.PaymentResponse{}, err
+	}
+
+	return uc.GetPaymentDetailByID(ctx, req.ID)
+}
+
+// RefundPayment kembalikan sebagian / seluruh dana. Refund key diturunkan dari total yang sudah
+// dikembalikan, sehingga request ganda pada kondisi yang sama tidak mengembalikan dana dua kali.
+func (uc *paymentUseCase) RefundPayment(ctx context.Context, req *request.ReqPaymentRefund) (response.PaymentResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.PaymentResponse{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.PaymentResponse{}, errorutils.ErrDataNotFound
+	}
+
+	p, err := uc.paymentRepo.GetPaymentByID(ctx, req.ID)
+	if err != nil {
+		return response.PaymentResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, p.UpdatedAt) {
+		return response.PaymentResponse{}, errorutils.ErrDataDataUpdated
+	}
+	if p.Status != models.PaymentStatusPaid {
+		return response.PaymentResponse{}, errorutils.HandleCustomError(ctx, nil, "hanya pembayaran yang sudah dibayar yang bisa di-refund", constanta.FieldPayment)
+	}
+
+	remaining := utils.RoundTo2Digits(p.Amount - p.RefundedAmount)
+	amount := req.Amount
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount > remaining {
+		return response.PaymentResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePaymentRefund, constanta.FieldPayment)
+	}
+
+	provider, err := uc.cfg.Provider(p.Provider)
+	if err != nil {
+		return response.PaymentResponse{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessagePaymentMethod, constanta.FieldPayment)
+	}
+	refund, err := provider.Refund(ctx, payment.RefundRequest{
+		Reference:     p.Reference,
+		TransactionID: p.TransactionID,
+		RefundKey:     fmt.Sprintf("%s-R%d", p.Reference, int64(math.Round(p.RefundedAmount*100))),
+		Amount:        amount,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		return response.PaymentResponse{}, handlePaymentError(ctx, err)
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		locked, err := uc.paymentRepo.GetPaymentByIDForUpdate(ctx, p.ID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		// webhook refund yang lebih dulu masuk sudah mencatat nominal yang sama
+		refunded := math.Max(locked.RefundedAmount, p.RefundedAmount+refund.Amount)
+		note := fmt.Sprintf("Refund %s: %s", p.Reference, req.Reason)
+		return uc.applyRefund(ctx, locked, refunded, note, models.OrderActorUser, userID)
+	})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to save refund %s for payment %s", refund.RefundID, p.Reference), err)
+		return response.PaymentResponse{}, err
+	}
+
+	return uc.GetPaymentDetailByID(ctx, p.ID)
+}
+
+// syncPayment pembayaran pending yang sudah lewat batas waktu ditandai expired, status pembayaran
+// gateway dicek ke provider. Transfer manual hanya berubah lewat konfirmasi staf.
+func (uc *paymentUseCase) syncPayment(ctx context.Context, p models.Payment) error {
+	provider, err := uc.cfg.Provider(p.Provider)
+	if err != nil {
+		return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessagePaymentMethod, constanta.FieldPayment)
+	}
+
+	n, err := provider.Status(ctx, p.Reference)
+	if errors.Is(err, payment.ErrNotSupported) {
+		if !p.IsExpired(time.Now()) {
+			return nil
+		}
+		n = payment.Notification{Reference: p.Reference, Status: payment.StatusExpired}
+	} else if err != nil {
+		// status tetap dari data terakhir, pelanggan bisa cek lagi nanti
+		logger.Error(ctx, "Failed to get payment status from provider", err)
+		return nil
+	}
+	if n.Status == payment.StatusPending {
+		return nil
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		locked, err := uc.paymentRepo.GetPaymentByIDForUpdate(ctx, p.ID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return uc.applyNotification(ctx, locked, n)
+	})
+}
+
+// applyNotification status dari provider ke pembayaran dan order, wajib dipanggil di dalam transaksi
+// dengan baris pembayaran terkunci. Status yang sama / mundur diabaikan.
+func (uc *paymentUseCase) applyNotification(ctx context.Context, p models.Payment, n payment.Notification) error {
+	if n.Amount != 0 && math.Abs(n.Amount-p.Amount) >= 0.01 {
+		msg := fmt.Sprintf("nominal notifikasi %.2f tidak sama dengan pembayaran %s (%.2f)", n.Amount, p.Reference, p.Amount)
+		return errorutils.HandleCustomError(ctx, errors.New(msg), errorutils.ErrMessagePaymentNotification, constanta.FieldPayment)
+	}
+
+	switch n.Status {
+	case payment.StatusPaid:
+		// notifikasi bayar bisa datang setelah pembayaran ditandai expired dari sisi toko
+		if p.Status != models.PaymentStatusPending && p.Status != models.PaymentStatusExpired {
+			return nil
+		}
+		paidAt := time.Now()
+		if n.PaidAt != nil {
+			paidAt = *n.PaidAt
+		}
+		if err := uc.paymentRepo.UpdatePaymentStatusByID(ctx, p.ID, models.PaymentStatusPaid, &paidAt, 0); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.paymentRepo))
+		}
+
+		order, err := uc.orderRepo.GetOrderByID(ctx, p.OrderID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if order.Status != models.OrderStatusPendingPayment {
+			// mis. order sudah dibatalkan, dana perlu dikembalikan staf
+			logger.Error(ctx, fmt.Sprintf("Payment %s paid for order %s with status %s", p.Reference, order.OrderNumber, order.Status), errors.New("payment needs review"))
+			return nil
+		}
+		note := fmt.Sprintf("Pembayaran %s diterima", p.Reference)
+		if err := uc.order.transitionOrder(ctx, order, models.OrderStatusPaid, note, models.OrderActorSystem, 0); err != nil {
+			return err
+		}
+		_, err = uc.invoice.issueInvoice(ctx, order.ID, &p.ID)
+		return err
+
+	case payment.StatusExpired, payment.StatusFailed:
+		if p.Status != models.PaymentStatusPending {
+			return nil
+		}
+		if err := uc.paymentRepo.UpdatePaymentStatusByID(ctx, p.ID, n.Status, nil, 0); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+
+	case payment.StatusRefunded:
+		if p.Status != models.PaymentStatusPaid {
+			return nil
+		}
+		refunded := n.RefundedAmount
+		if refunded == 0 {
+			refunded = p.Amount
+		}
+		if refunded <= p.RefundedAmount {
+			return nil
+		}
+		note := fmt.Sprintf("Refund %s dari %s", p.Reference, p.Provider)
+		return uc.applyRefund(ctx, p, refunded, note, models.OrderActorSystem, 0)
+	}
+	return nil
+}
+
+// applyRefund total dana yang sudah dikembalikan, selisih dengan refund sebelumnya diterbitkan sebagai
+// nota kredit. Refund penuh mengubah order ke refunded bila status order memungkinkan (order yang
+// sudah dikirim diselesaikan staf)
+func (uc *paymentUseCase) applyRefund(ctx context.Context, p models.Payment, refunded float64, note, actorType string, actorID int64) error {
+	refunded = math.Min(utils.RoundTo2Digits(refunded), p.Amount)
+	status := models.PaymentStatusPaid
+	if refunded >= p.Amount {
+		status = models.PaymentStatusRefunded
+	}
+	if err := uc.paymentRepo.UpdatePaymentRefundByID(ctx, p.ID, refunded, status, actorID); err != nil {
+		return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.paymentRepo))
+	}
+	if amount := utils.RoundTo2Digits(refunded - p.RefundedAmount); amount > 0 {
+		if err := uc.invoice.issueCreditNote(ctx, p.OrderID, &p.ID, amount, note); err != nil {
+			return err
+		}
+	}
+	if status != models.PaymentStatusRefunded {
+		return nil
+	}
+
+	order, err := uc.orderRepo.GetOrderByID(ctx, p.OrderID)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+	if !models.CanTransitionOrder(order.Status, models.OrderStatusRefunded) {
+		return nil
+	}
+	return uc.order.transitionOrder(ctx, order, models.OrderStatusRefunded, note, actorType, actorID)
+}
+
+// getCustomerOrder order milik user login / guest yang sedang mengakses, order pelanggan lain = tidak ditemukan
+func (uc *paymentUseCase) getCustomerOrder(ctx context.Context, orderID int64) (models.Order, error) {
+	var (
+		customer models.Customer
+		err      error
+	)
+	userID, _ := utils.GetUserIDFromCtx(ctx)
+	switch {
+	case userID != 0:
+		customer, err = uc.customerRepo.GetCustomerByUserID(ctx, userID)
+	case utils.GetGuestTokenFromCtx(ctx) != "":
+		customer, err = uc.customerRepo.GetCustomerByGuestToken(ctx, utils.GetGuestTokenFromCtx(ctx))
+	default:
+		return models.Order{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageUserNotLogin, constanta.FieldUserID)
+	}
+	if err != nil {
+		return models.Order{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	order, err := uc.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return models.Order{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if order.CustomerID != customer.ID {
+		return models.Order{}, errorutils.ErrDataNotFound
+	}
+	return order, nil
+}
+
+func (uc *paymentUseCase) paymentResponse(p models.Payment) response.PaymentResponse {
+	return response.SetPaymentResponse(p, uc.store.URL)
+}
+
+func paymentCustomer(order models.Order) payment.Customer {
+	if order.Customer == nil {
+		return payment.Customer{}
+	}
+	return payment.Customer{
+		Name:  order.Customer.Name,
+		Email: order.Customer.Email,
+		Phone: order.Customer.Phone,
+	}
+}
+
+func handlePaymentError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, payment.ErrUnsupportedMethod), errors.Is(err, payment.ErrUnsupportedChannel):
+		return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessagePaymentMethod, constanta.FieldPayment)
+	case errors.Is(err, payment.ErrInvalidAmount), errors.Is(err, payment.ErrNotSupported):
+		return errorutils.HandleCustomError(ctx, err, err.Error(), constanta.FieldPayment)
+	case errors.Is(err, payment.ErrPaymentNotFound):
+		return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldPayment)
+	}
+	logger.Error(ctx, "Payment provider error", err)
+	return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessagePaymentProvider, constanta.FieldPayment)
+}
@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PriceListUseCase interface {
+	CreatePriceList(ctx context.Context, req *request.ReqPriceList) error
+	GetPriceListByID(ctx context.Context, id int64) (response.PriceListDetailResponse, error)
+	GetListPriceList(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.PriceListResponse], error)
+	UpdatePriceListByID(ctx context.Context, req *request.ReqPriceListUpdate) (response.PriceListDetailResponse, error)
+	DeletePriceListByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
+}
+
+type priceListUseCase struct {
+	db                *gorm.DB
+	priceListRepo     repo.PriceListRepository
+	productRepo       repo.ProductRepository
+	productVarianRepo repo.ProductVarianRepository
+	customerRepo      repo.CustomerRepository
+	customerGroupRepo repo.CustomerGroupRepository
+}
+
+func NewPriceListUseCase(db *gorm.DB,
+	priceListRepo repo.PriceListRepository,
+	productRepo repo.ProductRepository,
+	productVarianRepo repo.ProductVarianRepository,
+	customerRepo repo.CustomerRepository,
+	customerGroupRepo repo.CustomerGroupRepository) PriceListUseCase {
+	return &priceListUseCase{
+		db:                db,
+		priceListRepo:     priceListRepo,
+		productRepo:       productRepo,
+		productVarianRepo: productVarianRepo,
+		customerRepo:      customerRepo,
+		customerGroupRepo: customerGroupRepo,
+	}
+}
+
+func (uc *priceListUseCase) CreatePriceList(ctx context.Context, req *request.ReqPriceList) error {
+	if err := req.ValidateRequestCreate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return err
+	}
+
+	if err := uc.validateReferences(ctx, req); err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return errorutils.ErrDataNotFound
+	}
+
+	priceList := models.PriceList{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		Priority:    req.Priority,
+		IsDefault:   req.IsDefault,
+		IsActive:    req.IsActive,
+		ValidFrom:   req.ValidFrom,
+		ValidTo:     req.ValidTo,
+		CreatedBy:   userID,
+		UpdatedBy:   userID,
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		err := uc.priceListRepo.Create(ctx, &priceList)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.priceListRepo))
+		}
+
+		return uc.saveDetails(ctx, priceList.ID, req, userID)
+	})
+}
+
+func (uc *priceListUseCase) GetPriceListByID(ctx context.Context, id int64) (response.PriceListDetailResponse, error) {
+	priceList, err := uc.priceListRepo.GetPriceListByID(ctx, id)
+	if err != nil {
+		return response.PriceListDetailResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	return response.SetPriceListDetailResponse(priceList), nil
+}
+
+func (uc *priceListUseCase) GetListPriceList(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.PriceListResponse], error) {
+	priceLists, count, err := uc.priceListRepo.GetListPriceList(ctx, listStruct)
+	if err != nil {
+		return response.ListResponse[response.PriceListResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListPriceList(priceLists), count, listStruct, repo.GetFilterAvailableFromRepo(uc.priceListRepo))
+	return listResponse, nil
+}
+
+// UpdatePriceListByID mengganti header price list beserta seluruh item dan assignment-nya
+func (uc *priceListUseCase) UpdatePriceListByID(ctx context.Context, req *request.ReqPriceListUpdate) (response.PriceListDetailResponse, error) {
+	if err := req.ValidateUpdatedAt(); err != nil {
+		return response.PriceListDetailResponse{}, err
+	}
+
+	if err := req.ValidateRequestCreate(); err != nil {
+		return response.PriceListDetailResponse{}, err
+	}
+
+	priceListDb, err := uc.priceListRepo.GetPriceListByID(ctx, req.ID)
+	if err != nil {
+		return response.PriceListDetailResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, priceListDb.UpdatedAt) {
+		return response.PriceListDetailResponse{}, errorutils.ErrDataDataUpdated
+	}
+
+	if err := uc.validateReferences(ctx, &req.ReqPriceList); err != nil {
+		return response.PriceListDetailResponse{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.PriceListDetailResponse{}, errorutils.ErrDataNotFound
+	}
+
+	priceList := models.PriceList{
+		ID:          req.ID,
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		Priority:    req.Priority,
+		IsDefault:   req.IsDefault,
+		IsActive:    req.IsActive,
+		ValidFrom:   req.ValidFrom,
+		ValidTo:     req.ValidTo,
+		CreatedAt:   priceListDb.CreatedAt,
+		CreatedBy:   priceListDb.CreatedBy,
+		UpdatedAt:   time.Now(),
+		UpdatedBy:   userID,
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		_, err := uc.priceListRepo.UpdatePriceListByID(ctx, req.ID, req.UpdatedAt, priceList)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.priceListRepo))
+		}
+
+		if err := uc.priceListRepo.DeleteItemByPriceListID(ctx, req.ID); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		if err := uc.priceListRepo.DeleteAssignmentByPriceListID(ctx, req.ID); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		return uc.saveDetails(ctx, req.ID, &req.ReqPriceList, userID)
+	})
+	if err != nil {
+		return response.PriceListDetailResponse{}, err
+	}
+
+	return uc.GetPriceListByID(ctx, req.ID)
+}
+
+func (uc *priceListUseCase) DeletePriceListByID(ctx context.Context, id int64, reqData request.AbstractRequest) error {
+	if err := reqData.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+
+	priceList, err := uc.priceListRepo.GetPriceListByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(reqData.UpdatedAt, priceList.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		// item & assignment ikut terhapus (ON DELETE CASCADE)
+		err := uc.priceListRepo.DeletePriceListByID(ctx, id, reqData.UpdatedAt)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+	})
+}
+
+func (uc *priceListUseCase) saveDetails(ctx context.Context, priceListID int64, req *request.ReqPriceList, userID int64) error {
+	if len(req.Items) > 0 {
+		items := make([]models.PriceListItem, 0, len(req.Items))
+		for _, item := range req.Items {
+			items = append(items, models.PriceListItem{
+				PriceListID:     priceListID,
+				ProductID:       item.ProductID,
+				ProductVarianID: item.VarianID,
+				MinQty:          item.MinQty,
+				Price:           item.Price,
+				ValidFrom:       item.ValidFrom,
+				ValidTo:         item.ValidTo,
+				CreatedBy:       userID,
+				UpdatedBy:       userID,
+			})
+		}
+
+		if err := uc.priceListRepo.CreateItemBulk(ctx, items); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.priceListRepo))
+		}
+	}
+
+	if len(req.Assignments) > 0 {
+		assignments := make([]models.PriceListAssignment, 0, len(req.Assignments))
+		for _, a := range req.Assignments {
+			assignments = append(assignments, models.PriceListAssignment{
+				PriceListID:     priceListID,
+				CustomerID:      a.CustomerID,
+				CustomerGroupID: a.CustomerGroupID,
+				CreatedBy:       userID,
+				UpdatedBy:       userID,
+			})
+		}
+
+		if err := uc.priceListRepo.CreateAssignmentBulk(ctx, assignments); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.priceListRepo))
+		}
+	}
+
+	return nil
+}
+
+// validateReferences memastikan produk, varian, pelanggan dan grup pada request ada di database
+func (uc *priceListUseCase) validateReferences(ctx context.Context, req *request.ReqPriceList) error {
+	var productIDs, varianIDs []int64
+	for _, item := range req.Items {
+		productIDs = append(productIDs, item.ProductID)
+		if item.VarianID != nil {
+			varianIDs = append(varianIDs, *item.VarianID)
+		}
+	}
+
+	if len(productIDs) > 0 {
+		products, err := uc.productRepo.GetProductByListIDs(ctx, productIDs)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		found := make(map[int64]bool, len(products))
+		for _, p := range products {
+			found[p.ID] = true
+		}
+		for _, id := range productIDs {
+			if !found[id] {
+				return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldProduct)
+			}
+		}
+	}
+
+	if len(varianIDs) > 0 {
+		varians, err := uc.productVarianRepo.GetProductVarianByListIDs(ctx, varianIDs)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		varianProduct := make(map[int64]int64, len(varians))
+		for _, v := range varians {
+			varianProduct[v.ID] = v.ProductID
+		}
+		for _, item := range req.Items {
+			if item.VarianID != nil && varianProduct[*item.VarianID] != item.ProductID {
+				return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldVarian)
+			}
+		}
+	}
+
+	for _, a := range req.Assignments {
+		var err error
+		field := constanta.FieldCustomer
+		if a.CustomerID != nil {
+			_, err = uc.customerRepo.GetCustomerByID(ctx, *a.CustomerID)
+		} else {
+			field = constanta.FieldCustomerGroup
+			_, err = uc.customerGroupRepo.GetCustomerGroupByID(ctx, *a.CustomerGroupID)
+		}
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, field)
+			}
+			return errorutils.HandleRepoError(ctx, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// batas jadwal yang diterapkan dalam sekali jalan scheduler
+const priceScheduleBatchSize = 100
+
+type PriceScheduleUseCase interface {
+	CreatePriceSchedule(ctx context.Context, req *request.ReqPriceSchedule) (response.PriceScheduleResponse, error)
+	GetListPriceSchedule(ctx context.Context, productID int64, listStruct *models.GetListStruct) (response.ListResponse[response.PriceScheduleResponse], error)
+	CancelPriceSchedule(ctx context.Context, id int64, reqData request.AbstractRequest) error
+	GetPriceTimeline(ctx context.Context, productID int64, listStruct *models.GetListStruct) (response.ListResponse[response.PriceHistoryResponse], error)
+	ApplyDueSchedules(ctx context.Context, now time.Time) (applied int, failed int, err error)
+}
+
+type priceScheduleUseCase struct {
+	db                *gorm.DB
+	priceHistoryRepo  repo.PriceHistoryRepository
+	productRepo       repo.ProductRepository
+	productVarianRepo repo.ProductVarianRepository
+}
+
+func NewPriceScheduleUseCase(db *gorm.DB,
+	priceHistoryRepo repo.PriceHistoryRepository,
+	productRepo repo.ProductRepository,
+	productVarianRepo repo.ProductVarianRepository) PriceScheduleUseCase {
+	return &priceScheduleUseCase{
+		db:                db,
+		priceHistoryRepo:  priceHistoryRepo,
+		productRepo:       productRepo,
+		productVarianRepo: productVarianRepo,
+	}
+}
+
+func (uc *priceScheduleUseCase) CreatePriceSchedule(ctx context.Context, req *request.ReqPriceSchedule) (response.PriceScheduleResponse, error) {
+	if err := req.ValidateRequestCreate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.PriceScheduleResponse{}, err
+	}
+
+	if _, err := uc.productRepo.GetProductByID(ctx, req.ProductID); err != nil {
+		return response.PriceScheduleResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	if req.VarianID != nil {
+		varian, err := uc.productVarianRepo.GetProductVarianByID(ctx, *req.VarianID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.PriceScheduleResponse{}, errorutils.HandleRepoError(ctx, err)
+		}
+
+		if varian.ProductID != req.ProductID {
+			return response.PriceScheduleResponse{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldVarian)
+		}
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.PriceScheduleResponse{}, errorutils.ErrDataNotFound
+	}
+
+	schedule := models.PriceSchedule{
+		ProductID:       req.ProductID,
+		ProductVarianID: req.VarianID,
+		Price:           req.Price,
+		CostPrice:       req.CostPrice,
+		Discount:        req.Discount,
+		EffectiveAt:     req.EffectiveAt,
+		Status:          models.PriceScheduleStatusPending,
+		Note:            req.Note,
+		CreatedBy:       userID,
+		UpdatedBy:       userID,
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.priceHistoryRepo.CreateSchedule(ctx, &schedule); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.priceHistoryRepo))
+		}
+		return nil
+	})
+	if err != nil {
+		return response.PriceScheduleResponse{}, err
+	}
+
+	return response.SetPriceScheduleResponse(schedule), nil
+}
+
+func (uc *priceScheduleUseCase) GetListPriceSchedule(ctx context.Context, productID int64, listStruct *models.GetListStruct) (response.ListResponse[response.PriceScheduleResponse], error) {
+	schedules, count, err := uc.priceHistoryRepo.GetListScheduleByProductID(ctx, productID, listStruct)
+	if err != nil {
+		return response.ListResponse[response.PriceScheduleResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListPriceSchedule(schedules), count, listStruct, repo.GetFilterAvailableFromRepo(uc.priceHistoryRepo))
+	return listResponse, nil
+}
+
+func (uc *priceScheduleUseCase) CancelPriceSchedule(ctx context.Context, id int64, reqData request.AbstractRequest) error {
+	if err := reqData.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+
+	schedule, err := uc.priceHistoryRepo.GetScheduleByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(reqData.UpdatedAt, schedule.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+
+	if schedule.Status != models.PriceScheduleStatusPending {
+		return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageScheduleNotPending)
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return errorutils.ErrDataNotFound
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		err := uc.priceHistoryRepo.CancelScheduleByID(ctx, id, reqData.UpdatedAt, userID)
+		if err != nil {
+			// jadwal sudah diterapkan scheduler / diubah user lain di antara pengecekan
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+	})
+}
+
+func (uc *priceScheduleUseCase) GetPriceTimeline(ctx context.Context, productID int64, listStruct *models.GetListStruct) (response.ListResponse[response.PriceHistoryResponse], error) {
+	if _, err := uc.productRepo.GetProductByID(ctx, productID); err != nil {
+		return response.ListResponse[response.PriceHistoryResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	timeline, count, err := uc.priceHistoryRepo.GetPriceTimeline(ctx, productID, listStruct)
+	if err != nil {
+		return response.ListResponse[response.PriceHistoryResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListPriceHistory(timeline), count, listStruct, repo.GetFilterAvailableFromRepo(uc.priceHistoryRepo))
+	return listResponse, nil
+}
+
+// ApplyDueSchedules menerapkan jadwal yang sudah jatuh tempo, satu jadwal satu transaksi
+// supaya satu jadwal gagal tidak membatalkan jadwal lain. Jadwal yang gagal ditandai failed
+// beserta alasannya sehingga jadwal berikutnya tetap berjalan.
+func (uc *priceScheduleUseCase) ApplyDueSchedules(ctx context.Context, now time.Time) (applied int, failed int, err error) {
+	for applied+failed < priceScheduleBatchSize {
+		var schedule models.PriceSchedule
+		var applyErr error
+		err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+			var err error
+			schedule, err = uc.priceHistoryRepo.GetDueScheduleForUpdate(ctx, now)
+			if err != nil {
+				return err
+			}
+
+			applyErr = uc.applySchedule(ctx, schedule, now)
+			return applyErr
+		})
+		if errors.Is(err, gorm.ErrRecordNotFound) && schedule.ID == 0 {
+			return applied, failed, nil
+		}
+		if err != nil && applyErr == nil {
+			logger.Error(ctx, "Failed to get due price schedule", err)
+			return applied, failed, err
+		}
+
+		if applyErr != nil {
+			logger.Error(ctx, "Failed to apply price schedule", applyErr)
+			if err := uc.priceHistoryRepo.MarkScheduleFailed(ctx, schedule.ID, applyErr.Error(), now); err != nil {
+				logger.Error(ctx, "Failed to mark price schedule failed", err)
+				return applied, failed, err
+			}
+			failed++
+			continue
+		}
+		applied++
+	}
+
+	return applied, failed, nil
+}
+
+func (uc *priceScheduleUseCase) applySchedule(ctx context.Context, schedule models.PriceSchedule, now time.Time) error {
+	price := models.PriceValue{
+		Price:     schedule.Price,
+		CostPrice: schedule.CostPrice,
+		Discount:  schedule.Discount,
+	}
+
+	var old models.PriceValue
+	if schedule.ProductVarianID != nil {
+		varian, err := uc.productVarianRepo.GetProductVarianByIDForUpdate(ctx, *schedule.ProductVarianID)
+		if err != nil {
+			return err
+		}
+		old = models.PriceValue{Price: varian.Price, CostPrice: varian.CostPrice, Discount: varian.Discount}
+
+		if err := uc.productVarianRepo.UpdateProductVarianPriceByID(ctx, varian.ID, price, schedule.CreatedBy); err != nil {
+			return err
+		}
+	} else {
+		product, err := uc.productRepo.GetProductByIDForUpdate(ctx, schedule.ProductID)
+		if err != nil {
+			return err
+		}
+		old = models.PriceValue{Price: product.Price, CostPrice: product.CostPrice, Discount: product.Discount}
+
+		if err := uc.productRepo.UpdateProductPriceByID(ctx, product.ID, price, schedule.CreatedBy); err != nil {
+			return err
+		}
+	}
+
+	history := models.NewPriceHistory(schedule.ProductID, schedule.ProductVarianID, &old, price, models.PriceChangeSourceSchedule, schedule.CreatedBy)
+	history.ScheduleID = &schedule.ID
+	history.ChangedAt = now
+	if err := uc.priceHistoryRepo.Create(ctx, &history); err != nil {
+		return err
+	}
+
+	return uc.priceHistoryRepo.MarkScheduleApplied(ctx, schedule.ID, now)
+}
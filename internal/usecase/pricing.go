@@ -0,0 +1,331 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PricingUseCase satu-satunya sumber harga jual efektif (price list, tier qty, harga dasar).
+// Semua tempat yang menampilkan atau menagih harga wajib lewat sini.
+type PricingUseCase interface {
+	ResolvePrices(ctx context.Context, pricingCtx models.PricingContext, queries []models.PriceQuery) ([]models.ResolvedPrice, error)
+	QuotePrice(ctx context.Context, req *request.ReqPriceQuote) (response.PriceQuoteResponse, error)
+	QuotePriceCustomer(ctx context.Context, req *request.ReqPriceQuote) (response.PriceQuoteResponse, error)
+}
+
+type pricingUseCase struct {
+	priceListRepo     repo.PriceListRepository
+	productRepo       repo.ProductRepository
+	productVarianRepo repo.ProductVarianRepository
+	customerRepo      repo.CustomerRepository
+	productBundleRepo repo.ProductBundleRepository
+}
+
+func NewPricingUseCase(priceListRepo repo.PriceListRepository,
+	productRepo repo.ProductRepository,
+	productVarianRepo repo.ProductVarianRepository,
+	customerRepo repo.CustomerRepository,
+	productBundleRepo repo.ProductBundleRepository) PricingUseCase {
+	return &pricingUseCase{
+		priceListRepo:     priceListRepo,
+		productRepo:       productRepo,
+		productVarianRepo: productVarianRepo,
+		customerRepo:      customerRepo,
+		productBundleRepo: productBundleRepo,
+	}
+}
+
+func (uc *pricingUseCase) ResolvePrices(ctx context.Context, pricingCtx models.PricingContext, queries []models.PriceQuery) ([]models.ResolvedPrice, error) {
+	if len(queries) == 0 {
+		return []models.ResolvedPrice{}, nil
+	}
+
+	if pricingCtx.At.IsZero() {
+		pricingCtx.At = time.Now()
+	}
+
+	if pricingCtx.CustomerID != 0 && pricingCtx.CustomerGroupID == nil {
+		customer, err := uc.customerRepo.GetCustomerByID(ctx, pricingCtx.CustomerID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldCustomer)
+			}
+			return nil, errorutils.HandleRepoError(ctx, err)
+		}
+		pricingCtx.CustomerGroupID = customer.CustomerGroupID
+	}
+
+	basePrices, err := uc.getBasePrices(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	productIDs := make([]int64, 0, len(queries))
+	for _, q := range queries {
+		productIDs = append(productIDs, q.ProductID)
+	}
+
+	candidates, err := uc.priceListRepo.GetApplicableItems(ctx, pricingCtx, productIDs)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	results := make([]models.ResolvedPrice, 0, len(queries))
+	for _, q := range queries {
+		base := basePrices[priceKey(q.ProductID, q.VarianID)]
+		resolved := models.ResolvedPrice{
+			ProductID: q.ProductID,
+			VarianID:  q.VarianID,
+			Quantity:  q.Quantity,
+			BasePrice: base,
+			UnitPrice: base,
+			Source:    models.PriceSourceBase,
+		}
+
+		if item, ok := selectPriceListItem(candidates, q); ok {
+			resolved.UnitPrice = utils.RoundTo2Digits(item.Price)
+			resolved.Source = models.PriceSourcePriceList
+			resolved.PriceListID = item.PriceListID
+			resolved.PriceListCode = item.PriceListCode
+			resolved.MinQty = item.MinQty
+		}
+
+		results = append(results, resolved)
+	}
+
+	return results, nil
+}
+
+// getBasePrices harga dasar (setelah diskon default) per produk / varian
+func (uc *pricingUseCase) getBasePrices(ctx context.Context, queries []models.PriceQuery) (map[string]float64, error) {
+	var productIDs, varianIDs []int64
+	for _, q := range queries {
+		productIDs = append(productIDs, q.ProductID)
+		if q.VarianID != 0 {
+			varianIDs = append(varianIDs, q.VarianID)
+		}
+	}
+
+	products, err := uc.productRepo.GetProductByListIDs(ctx, productIDs)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	prices := make(map[string]float64, len(queries))
+	for _, p := range products {
+		prices[priceKey(p.ID, 0)] = utils.PriceAfterDiscount(p.Price, p.Discount)
+	}
+
+	if err := uc.setBundlePrices(ctx, products, prices); err != nil {
+		return nil, err
+	}
+
+	if len(varianIDs) > 0 {
+		varians, err := uc.productVarianRepo.GetProductVarianByListIDs(ctx, varianIDs)
+		if err != nil {
+			return nil, errorutils.HandleRepoError(ctx, err)
+		}
+		for _, v := range varians {
+			prices[priceKey(v.ProductID, v.ID)] = utils.PriceAfterDiscount(v.Price, v.Discount)
+		}
+	}
+
+	for _, q := range queries {
+		if _, ok := prices[priceKey(q.ProductID, q.VarianID)]; !ok {
+			field := constanta.FieldProduct
+			if q.VarianID != 0 {
+				field = constanta.FieldVarian
+			}
+			return nil, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, field)
+		}
+	}
+
+	return prices, nil
+}
+
+// setBundlePrices harga bundle bertipe sum = total harga dasar komponen x qty, lalu dikurangi diskon produk bundle
+func (uc *pricingUseCase) setBundlePrices(ctx context.Context, products []models.Product, prices map[string]float64) error {
+	var bundleIDs []int64
+	discounts := make(map[int64]float64)
+	for _, p := range products {
+		if p.ProductType == models.ProductTypeBundle && p.BundlePricing == models.BundlePricingSum {
+			bundleIDs = append(bundleIDs, p.ID)
+			discounts[p.ID] = p.Discount
+		}
+	}
+	if len(bundleIDs) == 0 {
+		return nil
+	}
+
+	items, err := uc.productBundleRepo.GetBundleItemByBundleProductIDs(ctx, bundleIDs)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	var productIDs, varianIDs []int64
+	for _, item := range items {
+		productIDs = append(productIDs, item.ComponentProductID)
+		if item.ComponentVarianID != nil {
+			varianIDs = append(varianIDs, *item.ComponentVarianID)
+		}
+	}
+
+	componentPrices := make(map[string]float64, len(items))
+	if len(productIDs) > 0 {
+		components, err := uc.productRepo.GetProductByListIDs(ctx, uniqueInt64s(productIDs))
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		for _, c := range components {
+			componentPrices[priceKey(c.ID, 0)] = utils.PriceAfterDiscount(c.Price, c.Discount)
+		}
+	}
+	if len(varianIDs) > 0 {
+		varians, err := uc.productVarianRepo.GetProductVarianByListIDs(ctx, uniqueInt64s(varianIDs))
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		for _, v := range varians {
+			componentPrices[priceKey(v.ProductID, v.ID)] = utils.PriceAfterDiscount(v.Price, v.Discount)
+		}
+	}
+
+	totals := make(map[int64]float64, len(bundleIDs))
+	for _, item := range items {
+		price, ok := componentPrices[priceKey(item.ComponentProductID, bundleVarianID(item))]
+		if !ok {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageBundleComponent, constanta.FieldBundle)
+		}
+		totals[item.BundleProductID] += price * float64(item.Quantity)
+	}
+
+	for _, id := range bundleIDs {
+		prices[priceKey(id, 0)] = utils.PriceAfterDiscount(utils.RoundTo2Digits(totals[id]), discounts[id])
+	}
+	return nil
+}
+
+func (uc *pricingUseCase) QuotePrice(ctx context.Context, req *request.ReqPriceQuote) (response.PriceQuoteResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.PriceQuoteResponse{}, err
+	}
+
+	return uc.quote(ctx, models.PricingContext{CustomerID: req.CustomerID}, req)
+}
+
+// QuotePriceCustomer harga untuk pelanggan yang sedang login (storefront). customer_id dan at dari request
+// diabaikan supaya harga jadwal / price list yang belum berlaku tidak bisa diintip
+func (uc *pricingUseCase) QuotePriceCustomer(ctx context.Context, req *request.ReqPriceQuote) (response.PriceQuoteResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.PriceQuoteResponse{}, err
+	}
+	req.At = nil
+
+	pricingCtx := models.PricingContext{}
+	if userID, _ := utils.GetUserIDFromCtx(ctx); userID != 0 {
+		customer, err := uc.customerRepo.GetCustomerByUserID(ctx, userID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.PriceQuoteResponse{}, errorutils.HandleRepoError(ctx, err)
+		}
+		pricingCtx.CustomerID = customer.ID
+		pricingCtx.CustomerGroupID = customer.CustomerGroupID
+	}
+
+	return uc.quote(ctx, pricingCtx, req)
+}
+
+func (uc *pricingUseCase) quote(ctx context.Context, pricingCtx models.PricingContext, req *request.ReqPriceQuote) (response.PriceQuoteResponse, error) {
+	pricingCtx.At = time.Now()
+	if req.At != nil {
+		pricingCtx.At = *req.At
+	}
+
+	queries := make([]models.PriceQuery, 0, len(req.Items))
+	for _, item := range req.Items {
+		queries = append(queries, models.PriceQuery{
+			ProductID: item.ProductID,
+			VarianID:  item.VarianID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	prices, err := uc.ResolvePrices(ctx, pricingCtx, queries)
+	if err != nil {
+		return response.PriceQuoteResponse{}, err
+	}
+
+	res := response.PriceQuoteResponse{
+		At:    pricingCtx.At,
+		Items: make([]response.PriceQuoteItemResponse, 0, len(prices)),
+	}
+	for _, p := range prices {
+		lineTotal := utils.RoundTo2Digits(p.UnitPrice * float64(p.Quantity))
+		res.Items = append(res.Items, response.PriceQuoteItemResponse{
+			ResolvedPrice: p,
+			LineTotal:     lineTotal,
+		})
+		res.Total += lineTotal
+	}
+	res.Total = utils.RoundTo2Digits(res.Total)
+
+	return res, nil
+}
+
+// selectPriceListItem memilih item price list yang berlaku untuk query:
+// prioritas price list tertinggi, lalu harga khusus varian, lalu tier min_qty terbesar yang terpenuhi,
+// terakhir harga termurah bila masih sama
+func selectPriceListItem(candidates []models.PriceListItemCandidate, q models.PriceQuery) (models.PriceListItemCandidate, bool) {
+	var (
+		best  models.PriceListItemCandidate
+		found bool
+	)
+	for _, c := range candidates {
+		if c.ProductID != q.ProductID || c.MinQty > q.Quantity {
+			continue
+		}
+		if c.ProductVarianID != nil && *c.ProductVarianID != q.VarianID {
+			continue
+		}
+
+		if !found || betterPriceListItem(c, best) {
+			best = c
+			found = true
+		}
+	}
+	return best, found
+}
+
+func betterPriceListItem(a, b models.PriceListItemCandidate) bool {
+	if a.PriceListPriority != b.PriceListPriority {
+		return a.PriceListPriority > b.PriceListPriority
+	}
+
+	aVarian, bVarian := a.ProductVarianID != nil, b.ProductVarianID != nil
+	if aVarian != bVarian {
+		return aVarian
+	}
+
+	if a.MinQty != b.MinQty {
+		return a.MinQty > b.MinQty
+	}
+
+	return a.Price < b.Price
+}
+
+func priceKey(productID, varianID int64) string {
+	return strconv.FormatInt(productID, 10) + ":" + strconv.FormatInt(varianID, 10)
+}
@@ -11,6 +11,7 @@ import (
 	"pleasurelove/internal/utils"
 	"pleasurelove/internal/utils/errorutils"
 	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/storage"
 	"time"
 
 	"gorm.io/gorm"
@@ -19,9 +20,15 @@ import (
 type ProductUseCase interface {
 	CreateProduct(ctx context.Context, req *request.ReqProduct) error
 	GetProductByID(ctx context.Context, id int64) (response.DetailProductResponse, error)
-	GetListProduct(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.ProductResponse], error)
+	GetListProduct(ctx context.Context, listStruct *models.GetListStruct) (response.ProductListFacetResponse[response.ProductResponse], error)
+	ExportListProduct(ctx context.Context, listStruct *models.GetListStruct, req *request.ReqExport) (response.FileStreamResponse, error)
+	BulkUpdateProduct(ctx context.Context, req *request.ReqProductBulkUpdate) (response.BulkResponse, error)
+	BulkDeleteProduct(ctx context.Context, req *request.ReqBulkDelete) (response.BulkResponse, error)
 	UpdateProductByID(ctx context.Context, req *request.ReqProductUpdate) (response.ProductResponse, error)
 	DeleteProductByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
+	SearchProduct(ctx context.Context, req *request.ReqSearch) (response.ListResponse[response.ProductSearchResponse], error)
+	ImportProduct(ctx context.Context, req *request.ReqProductImport) (response.ProductImportResponse, error)
+	GetProductImportStatus(ctx context.Context, id string) (response.ProductImportResponse, error)
 }
 
 type productUseCase struct {
@@ -29,17 +36,41 @@ type productUseCase struct {
 	productRepo         repo.ProductRepository
 	categoryRepo        repo.CategoryRepository
 	productCategoryRepo repo.ProductCategoryRepository
+	priceHistoryRepo    repo.PriceHistoryRepository
+	productVarianRepo   repo.ProductVarianRepository
+	productBundleRepo   repo.ProductBundleRepository
+	attributeRepo       repo.AttributeRepository
+	productMediaRepo    repo.ProductMediaRepository
+	taxClassRepo        repo.TaxClassRepository
+	store               storage.Storage
+	inventoryUC         InventoryUseCase
 }
 
 func NewProductUseCase(db *gorm.DB,
 	productRepo repo.ProductRepository,
 	categoryRepo repo.CategoryRepository,
-	productCategoryRepo repo.ProductCategoryRepository) ProductUseCase {
+	productCategoryRepo repo.ProductCategoryRepository,
+	priceHistoryRepo repo.PriceHistoryRepository,
+	productVarianRepo repo.ProductVarianRepository,
+	productBundleRepo repo.ProductBundleRepository,
+	attributeRepo repo.AttributeRepository,
+	productMediaRepo repo.ProductMediaRepository,
+	taxClassRepo repo.TaxClassRepository,
+	store storage.Storage,
+	inventoryUC InventoryUseCase) ProductUseCase {
 	return &productUseCase{
 		db:                  db,
 		productRepo:         productRepo,
 		categoryRepo:        categoryRepo,
 		productCategoryRepo: productCategoryRepo,
+		priceHistoryRepo:    priceHistoryRepo,
+		productVarianRepo:   productVarianRepo,
+		productBundleRepo:   productBundleRepo,
+		attributeRepo:       attributeRepo,
+		productMediaRepo:    productMediaRepo,
+		taxClassRepo:        taxClassRepo,
+		store:               store,
+		inventoryUC:         inventoryUC,
 	}
 }
 
@@ -55,6 +86,21 @@ func (uc *productUseCase) CreateProduct(ctx context.Context, req *request.ReqPro
 		return err
 	}
 
+	err = uc.validateBundleItems(ctx, 0, req.BundleItems)
+	if err != nil {
+		return err
+	}
+
+	attributeValues, err := uc.validateAttributes(ctx, 0, req.CategoryID, req.Attributes)
+	if err != nil {
+		return err
+	}
+
+	err = validateTaxClass(ctx, uc.taxClassRepo, req.TaxClassID)
+	if err != nil {
+		return err
+	}
+
 	userID, err := utils.GetUserIDFromCtx(ctx)
 	if err != nil {
 		logger.Error(ctx, "Failed to get user id from context", err)
@@ -64,6 +110,7 @@ func (uc *productUseCase) CreateProduct(ctx context.Context, req *request.ReqPro
 	product := models.Product{
 		Name:        req.Name,
 		Code:        req.Code,
+		Slug:        req.Slug,
 		Barcode:     req.Barcode,
 		Description: req.Description,
 		Brand:       req.Brand,
@@ -75,6 +122,17 @@ func (uc *productUseCase) CreateProduct(ctx context.Context, req *request.ReqPro
 		HasVarian:   req.HasVarian,
 		CreatedBy:   userID,
 		UpdatedBy:   userID,
+
+		ProductType:   req.ProductType,
+		BundlePricing: req.BundlePricing,
+		Stock:         req.Stock,
+
+		WeightGram: req.WeightGram,
+		LengthCm:   req.LengthCm,
+		WidthCm:    req.WidthCm,
+		HeightCm:   req.HeightCm,
+
+		TaxClassID: req.TaxClassID,
 	}
 
 	//TODO: validate and add product_varian
@@ -85,6 +143,11 @@ func (uc *productUseCase) CreateProduct(ctx context.Context, req *request.ReqPro
 			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productRepo))
 		}
 
+		history := models.NewPriceHistory(product.ID, nil, nil, productPriceValue(product), models.PriceChangeSourceCreate, userID)
+		if err := uc.priceHistoryRepo.Create(ctx, &history); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
 		// insert product_categories
 		if len(req.CategoryID) > 0 {
 			var pcs []models.ProductCategory
@@ -103,7 +166,11 @@ func (uc *productUseCase) CreateProduct(ctx context.Context, req *request.ReqPro
 			}
 		}
 
-		return nil
+		if err := uc.saveAttributeValues(ctx, product.ID, attributeValues, userID); err != nil {
+			return err
+		}
+
+		return uc.saveBundleItems(ctx, product.ID, req.BundleItems, userID)
 	})
 }
 
@@ -112,16 +179,104 @@ func (uc *productUseCase) GetProductByID(ctx context.Context, id int64) (respons
 	if err != nil {
 		return response.DetailProductResponse{}, errorutils.HandleRepoError(ctx, err)
 	}
-	return response.SetDetailProductResponse(product), nil
+
+	res := response.SetDetailProductResponse(product)
+	res.Breadcrumbs, err = getProductBreadcrumbs(ctx, uc.categoryRepo, *product.ProductCategory)
+	if err != nil {
+		return response.DetailProductResponse{}, err
+	}
+
+	attributes, err := uc.attributeRepo.GetValueByProductID(ctx, product.ID)
+	if err != nil {
+		return response.DetailProductResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	res.Attributes = response.SetResponseListProductAttribute(attributes)
+
+	media, err := uc.productMediaRepo.GetMediaByProductIDs(ctx, []int64{product.ID})
+	if err != nil {
+		return response.DetailProductResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	res.Media = response.SetResponseListProductMedia(media, uc.store.URL)
+
+	if product.ProductType == models.ProductTypeBundle {
+		items, err := uc.productBundleRepo.GetBundleItemByBundleProductIDs(ctx, []int64{product.ID})
+		if err != nil {
+			return response.DetailProductResponse{}, errorutils.HandleRepoError(ctx, err)
+		}
+		res.BundleItems = response.SetResponseListProductBundleItem(items)
+
+		availability, err := uc.inventoryUC.GetBundleAvailability(ctx, []int64{product.ID})
+		if err != nil {
+			return response.DetailProductResponse{}, err
+		}
+		res.AvailableStock = availability[product.ID]
+	}
+
+	return res, nil
 }
 
-func (uc *productUseCase) GetListProduct(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.ProductResponse], error) {
+func (uc *productUseCase) GetListProduct(ctx context.Context, listStruct *models.GetListStruct) (response.ProductListFacetResponse[response.ProductResponse], error) {
+	if err := validateCategoryFilter(ctx, listStruct); err != nil {
+		return response.ProductListFacetResponse[response.ProductResponse]{}, err
+	}
+
 	products, count, err := uc.productRepo.GetListProduct(ctx, listStruct)
 	if err != nil {
-		return response.ListResponse[response.ProductResponse]{}, errorutils.HandleRepoError(ctx, err)
+		return response.ProductListFacetResponse[response.ProductResponse]{}, errorutils.HandleRepoError(ctx, err)
 	}
 
-	listResponse := response.MapToListResponse(response.SetResponseListProduct(products), count, listStruct, repo.GetFilterAvailableFromRepo(uc.productRepo))
+	facets, err := uc.productRepo.GetProductFacets(ctx, listStruct)
+	if err != nil {
+		return response.ProductListFacetResponse[response.ProductResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	list := response.SetResponseListProduct(products)
+	if err := uc.setBundleAvailability(ctx, list); err != nil {
+		return response.ProductListFacetResponse[response.ProductResponse]{}, err
+	}
+
+	return response.ProductListFacetResponse[response.ProductResponse]{
+		ListResponse: response.MapToListResponse(list, count, listStruct, repo.GetFilterAvailableFromRepo(uc.productRepo)),
+		Facets:       response.SetProductFacetResponse(facets),
+	}, nil
+}
+
+// ExportListProduct stok tersedia bundle dihitung per batch seperti di list
+func (uc *productUseCase) ExportListProduct(ctx context.Context, listStruct *models.GetListStruct, req *request.ReqExport) (response.FileStreamResponse, error) {
+	if err := validateCategoryFilter(ctx, listStruct); err != nil {
+		return response.FileStreamResponse{}, err
+	}
+
+	return exportList(ctx, uc.db, req, "product",
+		func(ctx context.Context, fn func([]models.Product) error) error {
+			return uc.productRepo.ExportListProduct(ctx, listStruct, fn)
+		},
+		func(ctx context.Context, products []models.Product) ([]response.ProductResponse, error) {
+			list := response.SetResponseListProduct(products)
+			if err := uc.setBundleAvailability(ctx, list); err != nil {
+				return nil, err
+			}
+			return list, nil
+		})
+}
+
+func (uc *productUseCase) SearchProduct(ctx context.Context, req *request.ReqSearch) (response.ListResponse[response.ProductSearchResponse], error) {
+	if err := req.ValidateRequest(); err != nil {
+		return response.ListResponse[response.ProductSearchResponse]{}, err
+	}
+
+	params := models.SearchProductStruct{
+		Query: req.Query,
+		Page:  req.Page,
+		Limit: req.Limit,
+	}
+
+	results, count, err := uc.productRepo.SearchProduct(ctx, params)
+	if err != nil {
+		return response.ListResponse[response.ProductSearchResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListProductSearch(results), count, &models.GetListStruct{Page: req.Page, Limit: req.Limit}, []string{})
 	return listResponse, nil
 }
 
@@ -130,10 +285,6 @@ func (uc *productUseCase) UpdateProductByID(ctx context.Context, req *request.Re
 		return response.ProductResponse{}, err
 	}
 
-	if err := req.ValidateRequestCreate(); err != nil {
-		return response.ProductResponse{}, err
-	}
-
 	productDb, err := uc.productRepo.GetProductByID(ctx, req.ID)
 	if err != nil {
 		return response.ProductResponse{}, errorutils.HandleRepoError(ctx, err)
@@ -143,6 +294,15 @@ func (uc *productUseCase) UpdateProductByID(ctx context.Context, req *request.Re
 		return response.ProductResponse{}, errorutils.ErrDataDataUpdated
 	}
 
+	// slug kosong = slug lama tetap dipakai, url storefront tidak berubah
+	if req.Slug == "" {
+		req.Slug = productDb.Slug
+	}
+
+	if err := req.ValidateRequestCreate(); err != nil {
+		return response.ProductResponse{}, err
+	}
+
 	validateUnique, err := uc.productRepo.GetProductByCode(ctx, req.Code)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return response.ProductResponse{}, errorutils.HandleRepoError(ctx, err)
@@ -152,6 +312,25 @@ func (uc *productUseCase) UpdateProductByID(ctx context.Context, req *request.Re
 		return response.ProductResponse{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessaageDataAlreadyExists, constanta.FieldCode, constanta.FieldName)
 	}
 
+	if req.ProductType != productDb.ProductType {
+		return response.ProductResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageProductTypeChanged, constanta.FieldBundle)
+	}
+
+	err = uc.validateBundleItems(ctx, req.ID, req.BundleItems)
+	if err != nil {
+		return response.ProductResponse{}, err
+	}
+
+	attributeValues, err := uc.validateAttributes(ctx, req.ID, req.CategoryID, req.Attributes)
+	if err != nil {
+		return response.ProductResponse{}, err
+	}
+
+	err = validateTaxClass(ctx, uc.taxClassRepo, req.TaxClassID)
+	if err != nil {
+		return response.ProductResponse{}, err
+	}
+
 	isUpdateCategory := uc.validateUpdateCategoryData(ctx, req.CategoryID, *productDb.ProductCategory)
 
 	if isUpdateCategory {
@@ -171,6 +350,7 @@ func (uc *productUseCase) UpdateProductByID(ctx context.Context, req *request.Re
 		ID:          req.ID,
 		Name:        req.Name,
 		Code:        req.Code,
+		Slug:        req.Slug,
 		Barcode:     req.Barcode,
 		Description: req.Description,
 		Brand:       req.Brand,
@@ -184,15 +364,37 @@ func (uc *productUseCase) UpdateProductByID(ctx context.Context, req *request.Re
 		CreatedBy:   productDb.CreatedBy,
 		UpdatedAt:   time.Now(),
 		UpdatedBy:   userID,
+
+		BundlePricing: req.BundlePricing,
+
+		WeightGram: req.WeightGram,
+		LengthCm:   req.LengthCm,
+		WidthCm:    req.WidthCm,
+		HeightCm:   req.HeightCm,
+
+		TaxClassID: req.TaxClassID,
 	}
 
 	var updated models.Product
 	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
 		updated, err = uc.productRepo.UpdateProductByID(ctx, req.ID, req.UpdatedAt, product)
 		if err != nil {
+			// diubah user lain di antara pengecekan updated_at dan penyimpanan
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
 			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productRepo))
 		}
 
+		// catat riwayat hanya jika harga jual / modal / diskon berubah
+		oldPrice, newPrice := productPriceValue(productDb), productPriceValue(product)
+		if oldPrice != newPrice {
+			history := models.NewPriceHistory(req.ID, nil, &oldPrice, newPrice, models.PriceChangeSourceManual, userID)
+			if err := uc.priceHistoryRepo.Create(ctx, &history); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+
 		if isUpdateCategory {
 			err = uc.updateDataCategory(ctx, &product, req, userID)
 			if err != nil {
@@ -200,6 +402,23 @@ func (uc *productUseCase) UpdateProductByID(ctx context.Context, req *request.Re
 			}
 		}
 
+		// nilai atribut selalu diganti sesuai request
+		err = uc.attributeRepo.DeleteValueByProductID(ctx, req.ID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if err := uc.saveAttributeValues(ctx, req.ID, attributeValues, userID); err != nil {
+			return err
+		}
+
+		if productDb.ProductType == models.ProductTypeBundle {
+			err = uc.productBundleRepo.DeleteBundleItemByBundleProductID(ctx, req.ID)
+			if err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+			return uc.saveBundleItems(ctx, req.ID, req.BundleItems, userID)
+		}
+
 		return nil
 	})
 
@@ -224,18 +443,35 @@ func (uc *productUseCase) DeleteProductByID(ctx context.Context, id int64, reqDa
 		return errorutils.ErrDataDataUpdated
 	}
 
-	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+	media, err := uc.productMediaRepo.GetMediaByProductIDs(ctx, []int64{product.ID})
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
 		err = uc.productCategoryRepo.DeleteProductCategoryByProductID(ctx, product.ID)
 		if err != nil {
 			return errorutils.HandleRepoError(ctx, err)
 		}
 
+		// baris product_media ikut terhapus (ON DELETE CASCADE)
 		err := uc.productRepo.DeleteProductByID(ctx, id, reqData.UpdatedAt)
 		if err != nil {
-			return errorutils.HandleRepoError(ctx, err)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productRepo))
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	for _, m := range media {
+		removeMediaFiles(ctx, uc.store, m.StorageKeys())
+	}
+	return nil
 }
 
 func (uc *productUseCase) validateCategory(ctx context.Context, req []int64) (err error) {
@@ -302,3 +538,235 @@ func (uc *productUseCase) updateDataCategory(ctx context.Context, product *model
 
 	return
 }
+
+// validateBundleItems komponen harus produk single yang ada, bukan bundle itu sendiri,
+// dan varian wajib diisi bila produk komponen memiliki varian
+func (uc *productUseCase) validateBundleItems(ctx context.Context, bundleID int64, items []request.ReqBundleItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	var productIDs, varianIDs []int64
+	for _, item := range items {
+		if item.ProductID == bundleID {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageBundleSelf, constanta.FieldBundle)
+		}
+		productIDs = append(productIDs, item.ProductID)
+		if item.VarianID != 0 {
+			varianIDs = append(varianIDs, item.VarianID)
+		}
+	}
+	productIDs = uniqueInt64s(productIDs)
+	varianIDs = uniqueInt64s(varianIDs)
+
+	products, err := uc.productRepo.GetProductByListIDs(ctx, productIDs)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+	if len(products) != len(productIDs) {
+		return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldProduct)
+	}
+
+	productMap := make(map[int64]models.Product, len(products))
+	for _, p := range products {
+		productMap[p.ID] = p
+	}
+
+	varianProduct := make(map[int64]int64, len(varianIDs))
+	if len(varianIDs) > 0 {
+		varians, err := uc.productVarianRepo.GetProductVarianByListIDs(ctx, varianIDs)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		for _, v := range varians {
+			varianProduct[v.ID] = v.ProductID
+		}
+	}
+
+	for _, item := range items {
+		product := productMap[item.ProductID]
+		if product.ProductType == models.ProductTypeBundle {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageBundleNested, constanta.FieldBundle)
+		}
+
+		if item.VarianID == 0 {
+			if product.HasVarian {
+				return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageBundleVarianRequired, constanta.FieldVarian)
+			}
+			continue
+		}
+
+		if varianProduct[item.VarianID] != item.ProductID {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldVarian)
+		}
+	}
+
+	return nil
+}
+
+// validateAttributes atribut yang berlaku = definisi milik kategori produk beserta seluruh parent-nya.
+// Nilai divalidasi sesuai tipe, atribut wajib harus terisi di produk atau salah satu varian.
+func (uc *productUseCase) validateAttributes(ctx context.Context, productID int64, categoryIDs []int64, attrs []request.ReqProductAttribute) ([]models.ProductAttributeValue, error) {
+	var ancestorIDs []int64
+	if len(categoryIDs) > 0 {
+		categories, err := uc.categoryRepo.GetCategoryByListIDs(ctx, categoryIDs)
+		if err != nil {
+			return nil, errorutils.HandleRepoError(ctx, err)
+		}
+		for _, c := range categories {
+			ancestorIDs = append(ancestorIDs, c.AncestorIDs()...)
+		}
+	}
+
+	definitions := make(map[int64]models.AttributeDefinition)
+	if len(ancestorIDs) > 0 {
+		list, err := uc.attributeRepo.GetAttributeByCategoryIDs(ctx, uniqueInt64s(ancestorIDs))
+		if err != nil {
+			return nil, errorutils.HandleRepoError(ctx, err)
+		}
+		for _, d := range list {
+			definitions[d.ID] = d
+		}
+	}
+
+	varianIDs := make(map[int64]bool)
+	for _, attr := range attrs {
+		if attr.VarianID == 0 {
+			continue
+		}
+		if productID == 0 {
+			return nil, errorutils.HandleCustomError(ctx, nil, "nilai atribut varian hanya bisa diisi setelah varian dibuat", constanta.FieldAttribute)
+		}
+
+		varians, err := uc.productVarianRepo.GetProductVarianByProductID(ctx, productID)
+		if err != nil {
+			return nil, errorutils.HandleRepoError(ctx, err)
+		}
+		for _, v := range varians {
+			varianIDs[v.ID] = true
+		}
+		break
+	}
+
+	values := make([]models.ProductAttributeValue, 0, len(attrs))
+	filled := make(map[int64]bool, len(attrs))
+	seen := make(map[[2]int64]bool, len(attrs))
+	for _, attr := range attrs {
+		definition, ok := definitions[attr.AttributeID]
+		if !ok {
+			return nil, errorutils.HandleCustomError(ctx, nil, "atribut tidak berlaku untuk kategori produk", constanta.FieldAttribute)
+		}
+
+		key := [2]int64{attr.AttributeID, attr.VarianID}
+		if seen[key] {
+			return nil, errorutils.HandleCustomError(ctx, nil, "nilai atribut tidak boleh duplikat", constanta.FieldAttribute)
+		}
+		seen[key] = true
+
+		text, number, err := definition.NormalizeValue(attr.Value)
+		if err != nil {
+			return nil, errorutils.HandleCustomError(ctx, err, err.Error(), constanta.FieldAttribute)
+		}
+
+		value := models.ProductAttributeValue{
+			AttributeID: attr.AttributeID,
+			ValueText:   text,
+			ValueNumber: number,
+		}
+		if attr.VarianID != 0 {
+			if !varianIDs[attr.VarianID] {
+				return nil, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldVarian)
+			}
+			varianID := attr.VarianID
+			value.ProductVarianID = &varianID
+		}
+
+		values = append(values, value)
+		filled[attr.AttributeID] = true
+	}
+
+	for _, d := range definitions {
+		if d.IsRequired && !filled[d.ID] {
+			return nil, errorutils.HandleCustomError(ctx, nil, "atribut "+d.Code+" wajib diisi", constanta.FieldAttribute)
+		}
+	}
+
+	return values, nil
+}
+
+func (uc *productUseCase) saveAttributeValues(ctx context.Context, productID int64, values []models.ProductAttributeValue, userID int64) error {
+	for i := range values {
+		values[i].ProductID = productID
+		values[i].CreatedBy = userID
+	}
+
+	err := uc.attributeRepo.CreateValueBulk(ctx, values)
+	if err != nil {
+		return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.attributeRepo))
+	}
+	return nil
+}
+
+func (uc *productUseCase) saveBundleItems(ctx context.Context, bundleID int64, items []request.ReqBundleItem, userID int64) error {
+	bundleItems := make([]models.ProductBundleItem, 0, len(items))
+	for _, item := range items {
+		bundleItem := models.ProductBundleItem{
+			BundleProductID:    bundleID,
+			ComponentProductID: item.ProductID,
+			Quantity:           item.Quantity,
+			CreatedBy:          userID,
+		}
+		if item.VarianID != 0 {
+			varianID := item.VarianID
+			bundleItem.ComponentVarianID = &varianID
+		}
+		bundleItems = append(bundleItems, bundleItem)
+	}
+
+	err := uc.productBundleRepo.CreateBulk(ctx, bundleItems)
+	if err != nil {
+		return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productBundleRepo))
+	}
+	return nil
+}
+
+func (uc *productUseCase) setBundleAvailability(ctx context.Context, list []response.ProductResponse) error {
+	var bundleIDs []int64
+	for _, p := range list {
+		if p.ProductType == models.ProductTypeBundle {
+			bundleIDs = append(bundleIDs, p.ID)
+		}
+	}
+	if len(bundleIDs) == 0 {
+		return nil
+	}
+
+	availability, err := uc.inventoryUC.GetBundleAvailability(ctx, bundleIDs)
+	if err != nil {
+		return err
+	}
+	for i := range list {
+		if list[i].ProductType == models.ProductTypeBundle {
+			list[i].AvailableStock = availability[list[i].ID]
+		}
+	}
+	return nil
+}
+
+func productPriceValue(product models.Product) models.PriceValue {
+	return models.PriceValue{
+		Price:     utils.RoundTo2Digits(product.Price),
+		CostPrice: utils.RoundTo2Digits(product.CostPrice),
+		Discount:  utils.RoundTo2Digits(product.Discount),
+	}
+}
+
+// validateCategoryFilter filter category_id mencakup seluruh sub kategori, hanya = dan IN yang didukung
+func validateCategoryFilter(ctx context.Context, listStruct *models.GetListStruct) error {
+	for k, v := range listStruct.Filters {
+		if models.FilterField(k) == "category_id" && v[0] != "=" && v[0] != "IN" {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCategoryFilter, constanta.FieldCategory)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BulkUpdateProduct patch diterapkan ke semua item dalam satu transaksi, tiap item di dalam savepoint
+// sehingga item yang gagal (data sudah berubah, tidak ditemukan, dll) tidak membatalkan item lain
+func (uc *productUseCase) BulkUpdateProduct(ctx context.Context, req *request.ReqProductBulkUpdate) (response.BulkResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	if len(req.Patch.AddCategoryIDs) > 0 {
+		req.Patch.AddCategoryIDs = uniqueInt64s(req.Patch.AddCategoryIDs)
+		if err := uc.validateCategory(ctx, req.Patch.AddCategoryIDs); err != nil {
+			return response.BulkResponse{}, err
+		}
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.BulkResponse{}, errorutils.ErrDataNotFound
+	}
+
+	productMap, err := uc.getBulkProducts(ctx, req.Items)
+	if err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	var res response.BulkResponse
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		res = response.NewBulkResponse(len(req.Items))
+		for i, item := range req.Items {
+			var updatedAt time.Time
+			err := processWithSavepoint(ctx, fmt.Sprintf("bulk_%d", i), func(ctx context.Context) (err error) {
+				updatedAt, err = uc.patchProduct(ctx, productMap[item.ID], item, req.Patch, userID)
+				return err
+			})
+			if err != nil {
+				res.AddFailed(item.ID, errorMessage(err))
+				continue
+			}
+			res.AddSuccess(item.ID, &updatedAt)
+		}
+		return nil
+	})
+	if err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	return res, nil
+}
+
+// BulkDeleteProduct file media produk yang berhasil dihapus dibersihkan setelah commit
+func (uc *productUseCase) BulkDeleteProduct(ctx context.Context, req *request.ReqBulkDelete) (response.BulkResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	productMap, err := uc.getBulkProducts(ctx, req.Items)
+	if err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	productIDs := make([]int64, 0, len(productMap))
+	for id := range productMap {
+		productIDs = append(productIDs, id)
+	}
+
+	mediaMap := make(map[int64][]models.ProductMedia)
+	if len(productIDs) > 0 {
+		media, err := uc.productMediaRepo.GetMediaByProductIDs(ctx, productIDs)
+		if err != nil {
+			return response.BulkResponse{}, errorutils.HandleRepoError(ctx, err)
+		}
+		for _, m := range media {
+			mediaMap[m.ProductID] = append(mediaMap[m.ProductID], m)
+		}
+	}
+
+	var res response.BulkResponse
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		res = response.NewBulkResponse(len(req.Items))
+		for i, item := range req.Items {
+			err := processWithSavepoint(ctx, fmt.Sprintf("bulk_%d", i), func(ctx context.Context) error {
+				return uc.deleteBulkProduct(ctx, productMap[item.ID], item)
+			})
+			if err != nil {
+				res.AddFailed(item.ID, errorMessage(err))
+				continue
+			}
+			res.AddSuccess(item.ID, nil)
+		}
+		return nil
+	})
+	if err != nil {
+		return response.BulkResponse{}, err
+	}
+
+	for _, result := range res.Results {
+		if !result.Success {
+			continue
+		}
+		for _, m := range mediaMap[result.ID] {
+			removeMediaFiles(ctx, uc.store, m.StorageKeys())
+		}
+	}
+
+	return res, nil
+}
+
+// getBulkProducts produk di luar scope user (own) dianggap tidak ditemukan
+func (uc *productUseCase) getBulkProducts(ctx context.Context, items []request.ReqBulkItem) (map[int64]models.Product, error) {
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+
+	products, err := uc.productRepo.GetProductByListIDs(ctx, ids)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	productMap := make(map[int64]models.Product, len(products))
+	for _, p := range products {
+		productMap[p.ID] = p
+	}
+	return productMap, nil
+}
+
+func validateBulkItem(product models.Product, item *request.ReqBulkItem) error {
+	if product.ID == 0 {
+		return errorutils.ErrDataNotFound
+	}
+	if err := item.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+	if !utils.ValidateUpdatedAtRequest(item.UpdatedAt, product.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+	return nil
+}
+
+func (uc *productUseCase) patchProduct(ctx context.Context, product models.Product, item request.ReqBulkItem, patch request.ReqProductBulkPatch, userID int64) (time.Time, error) {
+	if err := validateBulkItem(product, &item); err != nil {
+		return time.Time{}, err
+	}
+
+	now := time.Now()
+	values := map[string]interface{}{
+		"updated_at": now,
+		"updated_by": userID,
+	}
+
+	oldPrice := productPriceValue(product)
+	if patch.IsActive != nil {
+		values["is_active"] = *patch.IsActive
+	}
+	if patch.Discount != nil {
+		values["discount"] = *patch.Discount
+		product.Discount = *patch.Discount
+	}
+	if patch.Brand != nil {
+		values["brand"] = *patch.Brand
+	}
+
+	err := uc.productRepo.PatchProductByID(ctx, product.ID, item.UpdatedAt, values)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return time.Time{}, errorutils.ErrDataDataUpdated
+		}
+		return time.Time{}, errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productRepo))
+	}
+
+	if newPrice := productPriceValue(product); newPrice != oldPrice {
+		history := models.NewPriceHistory(product.ID, nil, &oldPrice, newPrice, models.PriceChangeSourceManual, userID)
+		if err := uc.priceHistoryRepo.Create(ctx, &history); err != nil {
+			return time.Time{}, errorutils.HandleRepoError(ctx, err)
+		}
+	}
+
+	if len(patch.RemoveCategoryIDs) > 0 {
+		err = uc.productCategoryRepo.DeleteProductCategoryByCategoryIDs(ctx, product.ID, patch.RemoveCategoryIDs)
+		if err != nil {
+			return time.Time{}, errorutils.HandleRepoError(ctx, err)
+		}
+	}
+
+	if len(patch.AddCategoryIDs) > 0 {
+		pcs := make([]models.ProductCategory, 0, len(patch.AddCategoryIDs))
+		for _, categoryID := range patch.AddCategoryIDs {
+			pcs = append(pcs, models.ProductCategory{
+				ProductID:    product.ID,
+				CategoriesID: categoryID,
+				CreatedBy:    userID,
+				UpdatedBy:    userID,
+			})
+		}
+		err = uc.productCategoryRepo.CreateBulkIgnoreExisting(ctx, pcs)
+		if err != nil {
+			return time.Time{}, errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productCategoryRepo))
+		}
+	}
+
+	return now, nil
+}
+
+func (uc *productUseCase) deleteBulkProduct(ctx context.Context, product models.Product, item request.ReqBulkItem) error {
+	if err := validateBulkItem(product, &item); err != nil {
+		return err
+	}
+
+	err := uc.productCategoryRepo.DeleteProductCategoryByProductID(ctx, product.ID)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	err = uc.productRepo.DeleteProductByID(ctx, product.ID, item.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorutils.ErrDataDataUpdated
+		}
+		return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productRepo))
+	}
+	return nil
+}
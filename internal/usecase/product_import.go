@@ -0,0 +1,769 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/redis"
+	"pleasurelove/pkg/sheet"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	productImportBatchSize = 100
+	productImportJobTTL    = 24 * time.Hour
+	productImportKeyPrefix = "product_import:"
+)
+
+// Kolom file import (baris pertama = header, tidak case sensitive). Produk bervarian ditulis satu baris
+// per varian dengan code produk yang sama, kolom produk diambil dari baris pertama.
+// category_codes dipisah "|" atau ",". stock / varian_stock hanya dipakai saat produk / varian baru dibuat,
+// perubahan stok produk yang sudah ada lewat penyesuaian stok.
+const (
+	importColCode          = "code"
+	importColName          = "name"
+	importColSlug          = "slug"
+	importColBarcode       = "barcode"
+	importColDescription   = "description"
+	importColBrand         = "brand"
+	importColUnit          = "unit"
+	importColPrice         = "price"
+	importColCostPrice     = "cost_price"
+	importColDiscount      = "discount"
+	importColIsActive      = "is_active"
+	importColStock         = "stock"
+	importColCategoryCodes = "category_codes"
+	importColWeight        = "weight_gram"
+	importColLength        = "length_cm"
+	importColWidth         = "width_cm"
+	importColHeight        = "height_cm"
+	importColVarianCode    = "varian_code"
+	importColVarianName    = "varian_name"
+	importColVarianBarcode = "varian_barcode"
+	importColVarianPrice   = "varian_price"
+	importColVarianCost    = "varian_cost_price"
+	importColVarianDisc    = "varian_discount"
+	importColVarianActive  = "varian_is_active"
+	importColVarianStock   = "varian_stock"
+	importColVarianWeight  = "varian_weight_gram"
+	importColVarianLength  = "varian_length_cm"
+	importColVarianWidth   = "varian_width_cm"
+	importColVarianHeight  = "varian_height_cm"
+)
+
+// importProduct satu produk di file import beserta varian dan hasil validasinya
+type importProduct struct {
+	Rows          []int
+	Req           request.ReqProduct
+	CategoryCodes []string
+	Varians       []importVarian
+	Existing      *models.Product
+	Err           error
+}
+
+type importVarian struct {
+	Row      int
+	Varian   models.ProductVarian
+	Existing *models.ProductVarian
+}
+
+// ImportProduct dry run divalidasi langsung dan mengembalikan laporan per baris, import sebenarnya
+// diproses di background per batch, progress dibaca lewat GetProductImportStatus
+func (uc *productUseCase) ImportProduct(ctx context.Context, req *request.ReqProductImport) (response.ProductImportResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		return response.ProductImportResponse{}, err
+	}
+
+	format, err := sheet.DetectFormat(req.FileName, req.Data)
+	if err != nil {
+		return response.ProductImportResponse{}, err
+	}
+
+	rows, err := sheet.Read(format, req.Data)
+	if err != nil {
+		logger.Error(ctx, "Failed to read import file", err)
+		return response.ProductImportResponse{}, fmt.Errorf("file import tidak bisa dibaca: %w", err)
+	}
+
+	products, totalRows, err := parseImportRows(rows)
+	if err != nil {
+		return response.ProductImportResponse{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.ProductImportResponse{}, errorutils.ErrDataNotFound
+	}
+
+	job := models.ProductImportJob{
+		ID:        uuid.NewString(),
+		FileName:  req.FileName,
+		Status:    models.ProductImportStatusRunning,
+		DryRun:    req.DryRun,
+		TotalRows: totalRows,
+		Errors:    []models.ProductImportRowError{},
+		CreatedBy: userID,
+		StartedAt: time.Now(),
+	}
+
+	if req.DryRun {
+		if err := uc.validateImportProducts(ctx, products); err != nil {
+			return response.ProductImportResponse{}, err
+		}
+		for _, p := range products {
+			job.ProcessedRows += len(p.Rows)
+			switch {
+			case p.Err != nil:
+				job.Failed++
+				job.Errors = append(job.Errors, importRowError(p, p.Err))
+			case p.Existing != nil:
+				job.Updated++
+			default:
+				job.Created++
+			}
+		}
+		finishImportJob(&job, models.ProductImportStatusDone)
+		return response.SetProductImportResponse(job), nil
+	}
+
+	if err := saveImportJob(ctx, job); err != nil {
+		return response.ProductImportResponse{}, err
+	}
+
+	// proses tetap berjalan walaupun request sudah selesai
+	go uc.runProductImport(context.WithoutCancel(ctx), job, products)
+
+	return response.SetProductImportResponse(job), nil
+}
+
+func (uc *productUseCase) GetProductImportStatus(ctx context.Context, id string) (response.ProductImportResponse, error) {
+	value, err := redis.GetFromRedis(ctx, productImportKeyPrefix+id)
+	if err != nil {
+		return response.ProductImportResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if value == "" {
+		return response.ProductImportResponse{}, errorutils.ErrDataNotFound
+	}
+
+	var job models.ProductImportJob
+	if err := json.Unmarshal([]byte(value), &job); err != nil {
+		return response.ProductImportResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+	return response.SetProductImportResponse(job), nil
+}
+
+func (uc *productUseCase) runProductImport(ctx context.Context, job models.ProductImportJob, products []*importProduct) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(ctx, "Product import panic", fmt.Errorf("%v", r))
+			finishImportJob(&job, models.ProductImportStatusFailed)
+			_ = saveImportJob(ctx, job)
+		}
+	}()
+
+	if err := uc.validateImportProducts(ctx, products); err != nil {
+		logger.Error(ctx, "Failed to validate product import", err)
+		finishImportJob(&job, models.ProductImportStatusFailed)
+		_ = saveImportJob(ctx, job)
+		return
+	}
+
+	var valid []*importProduct
+	for _, p := range products {
+		if p.Err != nil {
+			job.ProcessedRows += len(p.Rows)
+			job.Failed++
+			job.Errors = append(job.Errors, importRowError(p, p.Err))
+			continue
+		}
+		valid = append(valid, p)
+	}
+	_ = saveImportJob(ctx, job)
+
+	userID := job.CreatedBy
+	for start := 0; start < len(valid); start += productImportBatchSize {
+		batch := valid[start:min(start+productImportBatchSize, len(valid))]
+
+		// satu transaksi per batch, produk yang gagal hanya membatalkan savepoint-nya sendiri
+		var (
+			created, updated int
+			rowErrs          []models.ProductImportRowError
+		)
+		err := processWithTx(ctx, uc.db, func(ctx context.Context) error {
+			created, updated, rowErrs = 0, 0, nil
+			for i, p := range batch {
+				err := processWithSavepoint(ctx, fmt.Sprintf("import_%d", i), func(ctx context.Context) error {
+					return uc.saveImportProduct(ctx, p, userID)
+				})
+				switch {
+				case err != nil:
+					rowErrs = append(rowErrs, importRowError(p, err))
+				case p.Existing != nil:
+					updated++
+				default:
+					created++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			logger.Error(ctx, "Failed to commit product import batch", err)
+			created, updated, rowErrs = 0, 0, nil
+			for _, p := range batch {
+				rowErrs = append(rowErrs, importRowError(p, errors.New(errorutils.ErrMessageInternalServerError)))
+			}
+		}
+
+		for _, p := range batch {
+			job.ProcessedRows += len(p.Rows)
+		}
+		job.Created += created
+		job.Updated += updated
+		job.Failed += len(rowErrs)
+		job.Errors = append(job.Errors, rowErrs...)
+		_ = saveImportJob(ctx, job)
+	}
+
+	finishImportJob(&job, models.ProductImportStatusDone)
+	_ = saveImportJob(ctx, job)
+}
+
+// parseImportRows mengelompokkan baris per code produk, error format nilai dicatat di produknya
+func parseImportRows(rows [][]string) ([]*importProduct, int, error) {
+	if len(rows) == 0 {
+		return nil, 0, errors.New("file import kosong")
+	}
+
+	header := make(map[string]int, len(rows[0]))
+	for i, h := range rows[0] {
+		header[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := header[importColCode]; !ok {
+		return nil, 0, fmt.Errorf("kolom %s wajib ada di header", importColCode)
+	}
+
+	var (
+		products  []*importProduct
+		byCode    = make(map[string]*importProduct)
+		totalRows int
+	)
+	for i, row := range rows[1:] {
+		rowNum := i + 2
+		get := func(col string) string {
+			idx, ok := header[col]
+			if !ok || idx >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[idx])
+		}
+
+		if isEmptyRow(row) {
+			continue
+		}
+		totalRows++
+
+		code := get(importColCode)
+		p, ok := byCode[code]
+		if !ok {
+			p = &importProduct{}
+			byCode[code] = p
+			products = append(products, p)
+		}
+		p.Rows = append(p.Rows, rowNum)
+
+		var rowErr error
+		if !ok {
+			rowErr = p.parseProduct(code, get)
+		}
+		if varianCode := get(importColVarianCode); rowErr == nil && varianCode != "" {
+			rowErr = p.parseVarian(rowNum, varianCode, get)
+		}
+		if rowErr != nil && p.Err == nil {
+			p.Err = fmt.Errorf("baris %d: %w", rowNum, rowErr)
+		}
+	}
+
+	if totalRows == 0 {
+		return nil, 0, errors.New("file import tidak memiliki data")
+	}
+	return products, totalRows, nil
+}
+
+func (p *importProduct) parseProduct(code string, get func(col string) string) error {
+	var err error
+	p.Req = request.ReqProduct{
+		Code:        code,
+		Name:        get(importColName),
+		Slug:        get(importColSlug),
+		Barcode:     get(importColBarcode),
+		Description: get(importColDescription),
+		Brand:       get(importColBrand),
+		Unit:        get(importColUnit),
+		ProductType: models.ProductTypeSingle,
+	}
+
+	if p.Req.Price, err = parseImportFloat(importColPrice, get(importColPrice)); err != nil {
+		return err
+	}
+	if p.Req.CostPrice, err = parseImportFloat(importColCostPrice, get(importColCostPrice)); err != nil {
+		return err
+	}
+	if p.Req.Discount, err = parseImportFloat(importColDiscount, get(importColDiscount)); err != nil {
+		return err
+	}
+	if p.Req.IsActive, err = parseImportBool(importColIsActive, get(importColIsActive), true); err != nil {
+		return err
+	}
+	if p.Req.Stock, err = parseImportInt(importColStock, get(importColStock)); err != nil {
+		return err
+	}
+	if p.Req.WeightGram, err = parseImportInt(importColWeight, get(importColWeight)); err != nil {
+		return err
+	}
+	if p.Req.LengthCm, err = parseImportFloat(importColLength, get(importColLength)); err != nil {
+		return err
+	}
+	if p.Req.WidthCm, err = parseImportFloat(importColWidth, get(importColWidth)); err != nil {
+		return err
+	}
+	if p.Req.HeightCm, err = parseImportFloat(importColHeight, get(importColHeight)); err != nil {
+		return err
+	}
+
+	for _, c := range strings.FieldsFunc(get(importColCategoryCodes), func(r rune) bool { return r == '|' || r == ',' }) {
+		if c = strings.TrimSpace(c); c != "" {
+			p.CategoryCodes = append(p.CategoryCodes, c)
+		}
+	}
+	return nil
+}
+
+func (p *importProduct) parseVarian(row int, code string, get func(col string) string) error {
+	v := importVarian{
+		Row: row,
+		Varian: models.ProductVarian{
+			Code:    code,
+			Name:    get(importColVarianName),
+			Barcode: get(importColVarianBarcode),
+		},
+	}
+
+	var err error
+	if v.Varian.Price, err = parseImportFloat(importColVarianPrice, get(importColVarianPrice)); err != nil {
+		return err
+	}
+	if v.Varian.CostPrice, err = parseImportFloat(importColVarianCost, get(importColVarianCost)); err != nil {
+		return err
+	}
+	if v.Varian.Discount, err = parseImportFloat(importColVarianDisc, get(importColVarianDisc)); err != nil {
+		return err
+	}
+	if v.Varian.IsActive, err = parseImportBool(importColVarianActive, get(importColVarianActive), true); err != nil {
+		return err
+	}
+	if v.Varian.Stock, err = parseImportInt(importColVarianStock, get(importColVarianStock)); err != nil {
+		return err
+	}
+	if v.Varian.WeightGram, err = parseImportInt(importColVarianWeight, get(importColVarianWeight)); err != nil {
+		return err
+	}
+	if v.Varian.LengthCm, err = parseImportFloat(importColVarianLength, get(importColVarianLength)); err != nil {
+		return err
+	}
+	if v.Varian.WidthCm, err = parseImportFloat(importColVarianWidth, get(importColVarianWidth)); err != nil {
+		return err
+	}
+	if v.Varian.HeightCm, err = parseImportFloat(importColVarianHeight, get(importColVarianHeight)); err != nil {
+		return err
+	}
+
+	p.Varians = append(p.Varians, v)
+	return nil
+}
+
+// validateImportProducts validasi sama dengan CreateProduct (ValidateRequestCreate, kode unik,
+// validateCategory), data pembanding diambil sekaligus untuk seluruh file
+func (uc *productUseCase) validateImportProducts(ctx context.Context, products []*importProduct) error {
+	var codes, categoryCodes, varianCodes, slugs []string
+	for _, p := range products {
+		codes = append(codes, p.Req.Code)
+		categoryCodes = append(categoryCodes, p.CategoryCodes...)
+		for _, v := range p.Varians {
+			varianCodes = append(varianCodes, v.Varian.Code)
+		}
+	}
+
+	existing := make(map[string]models.Product, len(codes))
+	if list, err := uc.productRepo.GetProductByCodes(ctx, codes); err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	} else {
+		for _, product := range list {
+			existing[product.Code] = product
+		}
+	}
+
+	categories := make(map[string]int64)
+	if len(categoryCodes) > 0 {
+		list, err := uc.categoryRepo.GetCategoryByCodes(ctx, categoryCodes)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		for _, c := range list {
+			categories[c.Code] = c.ID
+		}
+	}
+
+	existingVarians := make(map[string]models.ProductVarian)
+	if len(varianCodes) > 0 {
+		list, err := uc.productVarianRepo.GetProductVarianByCodes(ctx, varianCodes)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		for _, v := range list {
+			existingVarians[v.Code] = v
+		}
+	}
+
+	for _, p := range products {
+		if p.Err != nil {
+			continue
+		}
+		if product, ok := existing[p.Req.Code]; ok {
+			p.Existing = &product
+		}
+		p.Err = uc.validateImportProduct(ctx, p, categories, existingVarians)
+		if p.Err == nil {
+			slugs = append(slugs, p.Req.Slug)
+		}
+	}
+
+	// slug harus unik di file maupun terhadap produk lain di database
+	slugOwner := make(map[string]int64)
+	if len(slugs) > 0 {
+		list, err := uc.productRepo.GetProductBySlugs(ctx, slugs)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		for _, product := range list {
+			slugOwner[product.Slug] = product.ID
+		}
+	}
+
+	seenSlug := make(map[string]bool)
+	seenVarian := make(map[string]bool)
+	for _, p := range products {
+		if p.Err != nil {
+			continue
+		}
+
+		var productID int64
+		if p.Existing != nil {
+			productID = p.Existing.ID
+		}
+		if owner, ok := slugOwner[p.Req.Slug]; (ok && owner != productID) || seenSlug[p.Req.Slug] {
+			p.Err = fmt.Errorf("slug %s sudah digunakan produk lain", p.Req.Slug)
+			continue
+		}
+		seenSlug[p.Req.Slug] = true
+
+		for _, v := range p.Varians {
+			if seenVarian[v.Varian.Code] {
+				p.Err = fmt.Errorf("baris %d: kode varian %s duplikat di file", v.Row, v.Varian.Code)
+				break
+			}
+			seenVarian[v.Varian.Code] = true
+		}
+	}
+
+	return nil
+}
+
+func (uc *productUseCase) validateImportProduct(ctx context.Context, p *importProduct, categories map[string]int64, existingVarians map[string]models.ProductVarian) error {
+	if p.Existing != nil {
+		if p.Existing.ProductType == models.ProductTypeBundle {
+			return errors.New("produk bundle tidak bisa diubah lewat import")
+		}
+		// slug kosong = slug lama tetap dipakai, url storefront tidak berubah
+		if p.Req.Slug == "" {
+			p.Req.Slug = p.Existing.Slug
+		}
+	} else if p.Req.Name == "" {
+		return errors.New("name wajib diisi untuk produk baru")
+	}
+
+	p.Req.HasVarian = len(p.Varians) > 0 || (p.Existing != nil && p.Existing.HasVarian)
+	if p.Req.HasVarian && p.Req.Stock != 0 {
+		return errors.New("stok produk bervarian diisi per varian (varian_stock)")
+	}
+
+	if err := p.Req.ValidateRequestCreate(); err != nil {
+		return err
+	}
+
+	for _, code := range p.CategoryCodes {
+		id, ok := categories[code]
+		if !ok {
+			return fmt.Errorf("kategori %s tidak ditemukan", code)
+		}
+		p.Req.CategoryID = append(p.Req.CategoryID, id)
+	}
+	p.Req.CategoryID = uniqueInt64s(p.Req.CategoryID)
+	if len(p.Req.CategoryID) > 0 {
+		if err := uc.validateCategory(ctx, p.Req.CategoryID); err != nil {
+			return err
+		}
+	}
+
+	for i := range p.Varians {
+		v := &p.Varians[i]
+		if err := utils.ValidateCode(v.Varian.Code); err != nil {
+			return fmt.Errorf("baris %d: varian %w", v.Row, err)
+		}
+		if v.Varian.Name == "" {
+			v.Varian.Name = v.Varian.Code
+		}
+		if err := validateImportPrice(v.Varian.Price, v.Varian.CostPrice, v.Varian.Discount); err != nil {
+			return fmt.Errorf("baris %d: varian %w", v.Row, err)
+		}
+		if v.Varian.Stock < 0 {
+			return fmt.Errorf("baris %d: stok varian tidak boleh negatif", v.Row)
+		}
+		if err := request.ValidateDimension(v.Varian.WeightGram, v.Varian.LengthCm, v.Varian.WidthCm, v.Varian.HeightCm); err != nil {
+			return fmt.Errorf("baris %d: varian %w", v.Row, err)
+		}
+		v.Varian.Price = utils.RoundTo2Digits(v.Varian.Price)
+		v.Varian.CostPrice = utils.RoundTo2Digits(v.Varian.CostPrice)
+		v.Varian.Discount = utils.RoundTo2Digits(v.Varian.Discount)
+
+		if existing, ok := existingVarians[v.Varian.Code]; ok {
+			if p.Existing == nil || existing.ProductID != p.Existing.ID {
+				return fmt.Errorf("baris %d: kode varian %s sudah digunakan produk lain", v.Row, v.Varian.Code)
+			}
+			v.Existing = &existing
+		}
+	}
+
+	return nil
+}
+
+// saveImportProduct upsert produk berdasarkan code beserta kategori dan variannya
+func (uc *productUseCase) saveImportProduct(ctx context.Context, p *importProduct, userID int64) error {
+	product := models.Product{
+		Name:        p.Req.Name,
+		Code:        p.Req.Code,
+		Slug:        p.Req.Slug,
+		Barcode:     p.Req.Barcode,
+		Description: p.Req.Description,
+		Brand:       p.Req.Brand,
+		Unit:        p.Req.Unit,
+		Price:       p.Req.Price,
+		CostPrice:   p.Req.CostPrice,
+		Discount:    p.Req.Discount,
+		IsActive:    p.Req.IsActive,
+		HasVarian:   p.Req.HasVarian,
+		UpdatedBy:   userID,
+
+		ProductType:   models.ProductTypeSingle,
+		BundlePricing: models.BundlePricingFixed,
+
+		WeightGram: p.Req.WeightGram,
+		LengthCm:   p.Req.LengthCm,
+		WidthCm:    p.Req.WidthCm,
+		HeightCm:   p.Req.HeightCm,
+	}
+
+	if p.Existing == nil {
+		product.CreatedBy = userID
+		product.Stock = p.Req.Stock
+		if err := uc.productRepo.Create(ctx, &product); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productRepo))
+		}
+
+		history := models.NewPriceHistory(product.ID, nil, nil, productPriceValue(product), models.PriceChangeSourceImport, userID)
+		if err := uc.priceHistoryRepo.Create(ctx, &history); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		if len(p.Req.CategoryID) > 0 {
+			var pcs []models.ProductCategory
+			for _, id := range p.Req.CategoryID {
+				pcs = append(pcs, models.ProductCategory{
+					ProductID:    product.ID,
+					CategoriesID: id,
+					CreatedBy:    userID,
+					UpdatedBy:    userID,
+				})
+			}
+			if err := uc.productCategoryRepo.CreateBulk(ctx, pcs); err != nil {
+				return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productCategoryRepo))
+			}
+		}
+	} else {
+		product.ID = p.Existing.ID
+		if product.Name == "" {
+			product.Name = p.Existing.Name
+		}
+		product.BundlePricing = p.Existing.BundlePricing
+		product.TaxClassID = p.Existing.TaxClassID
+		product.CreatedAt = p.Existing.CreatedAt
+		product.CreatedBy = p.Existing.CreatedBy
+		product.UpdatedAt = time.Now()
+
+		if _, err := uc.productRepo.UpdateProductByID(ctx, product.ID, p.Existing.UpdatedAt, product); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productRepo))
+		}
+
+		oldPrice, newPrice := productPriceValue(*p.Existing), productPriceValue(product)
+		if oldPrice != newPrice {
+			history := models.NewPriceHistory(product.ID, nil, &oldPrice, newPrice, models.PriceChangeSourceImport, userID)
+			if err := uc.priceHistoryRepo.Create(ctx, &history); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+
+		// kategori hanya diganti bila kolom category_codes diisi
+		if len(p.Req.CategoryID) > 0 {
+			if err := uc.updateDataCategory(ctx, &product, &request.ReqProductUpdate{ReqProduct: p.Req}, userID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, v := range p.Varians {
+		varian := v.Varian
+		varian.ProductID = product.ID
+		varian.UpdatedBy = userID
+		newPrice := models.PriceValue{Price: varian.Price, CostPrice: varian.CostPrice, Discount: varian.Discount}
+
+		if v.Existing == nil {
+			varian.CreatedBy = userID
+			if err := uc.productVarianRepo.Create(ctx, &varian); err != nil {
+				return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productVarianRepo))
+			}
+			history := models.NewPriceHistory(product.ID, &varian.ID, nil, newPrice, models.PriceChangeSourceImport, userID)
+			if err := uc.priceHistoryRepo.Create(ctx, &history); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+			continue
+		}
+
+		varian.ID = v.Existing.ID
+		varian.UpdatedAt = time.Now()
+		if err := uc.productVarianRepo.UpdateProductVarianByID(ctx, varian); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productVarianRepo))
+		}
+
+		oldPrice := models.PriceValue{Price: v.Existing.Price, CostPrice: v.Existing.CostPrice, Discount: v.Existing.Discount}
+		if oldPrice != newPrice {
+			history := models.NewPriceHistory(product.ID, &varian.ID, &oldPrice, newPrice, models.PriceChangeSourceImport, userID)
+			if err := uc.priceHistoryRepo.Create(ctx, &history); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func saveImportJob(ctx context.Context, job models.ProductImportJob) error {
+	b, err := json.Marshal(job)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+	if err := redis.SetToRedisWithTTL(ctx, productImportKeyPrefix+job.ID, string(b), productImportJobTTL); err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+	return nil
+}
+
+func finishImportJob(job *models.ProductImportJob, status string) {
+	now := time.Now()
+	job.Status = status
+	job.FinishedAt = &now
+}
+
+func importRowError(p *importProduct, err error) models.ProductImportRowError {
+	row := 0
+	if len(p.Rows) > 0 {
+		row = p.Rows[0]
+	}
+
+	return models.ProductImportRowError{Row: row, Code: p.Req.Code, Message: errorMessage(err)}
+}
+
+func validateImportPrice(price, costPrice, discount float64) error {
+	if price < 0 || price > 9999999999.99 {
+		return errors.New("harga jual harus antara 0 - 9999999999.99")
+	}
+	if costPrice < 0 || costPrice > 9999999999.99 {
+		return errors.New("harga modal harus antara 0 - 9999999999.99")
+	}
+	if discount < 0 || discount > 100 {
+		return errors.New("diskon harus antara 0 - 100 persen")
+	}
+	return nil
+}
+
+// parseImportFloat koma diterima sebagai pemisah desimal bila tidak ada titik (format Excel lokal)
+func parseImportFloat(col, value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if !strings.Contains(value, ".") {
+		value = strings.ReplaceAll(value, ",", ".")
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s harus berupa angka", col)
+	}
+	return f, nil
+}
+
+func parseImportInt(col, value string) (int, error) {
+	f, err := parseImportFloat(col, value)
+	if err != nil {
+		return 0, err
+	}
+	if f != float64(int(f)) {
+		return 0, fmt.Errorf("%s harus berupa bilangan bulat", col)
+	}
+	return int(f), nil
+}
+
+func parseImportBool(col, value string, def bool) (bool, error) {
+	switch strings.ToLower(value) {
+	case "":
+		return def, nil
+	case "1", "true", "ya", "y", "yes", "aktif":
+		return true, nil
+	case "0", "false", "tidak", "n", "no", "nonaktif":
+		return false, nil
+	}
+	return false, fmt.Errorf("%s harus berupa true / false", col)
+}
+
+func isEmptyRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/media"
+	"pleasurelove/pkg/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProductMediaUseCase interface {
+	UploadMedia(ctx context.Context, req *request.ReqProductMediaUpload) (response.ProductMediaResponse, error)
+	GetListMediaByProductID(ctx context.Context, productID int64) ([]response.ProductMediaResponse, error)
+	UpdateMediaByID(ctx context.Context, req *request.ReqProductMediaUpdate) (response.ProductMediaResponse, error)
+	DeleteMediaByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
+}
+
+type productMediaUseCase struct {
+	db               *gorm.DB
+	productRepo      repo.ProductRepository
+	productMediaRepo repo.ProductMediaRepository
+	store            storage.Storage
+}
+
+func NewProductMediaUseCase(db *gorm.DB,
+	productRepo repo.ProductRepository,
+	productMediaRepo repo.ProductMediaRepository,
+	store storage.Storage) ProductMediaUseCase {
+	return &productMediaUseCase{
+		db:               db,
+		productRepo:      productRepo,
+		productMediaRepo: productMediaRepo,
+		store:            store,
+	}
+}
+
+// UploadMedia file asli dan thumbnail disimpan ke storage lebih dulu, bila insert database gagal
+// file yang sudah terupload dihapus kembali
+func (uc *productMediaUseCase) UploadMedia(ctx context.Context, req *request.ReqProductMediaUpload) (response.ProductMediaResponse, error) {
+	if err := req.ValidateRequestCreate(); err != nil {
+		return response.ProductMediaResponse{}, err
+	}
+
+	if len(req.Data) > media.MaxUploadSize {
+		msg := fmt.Sprintf("ukuran file maksimal %d MB", media.MaxUploadSize>>20)
+		return response.ProductMediaResponse{}, errorutils.HandleCustomError(ctx, nil, msg, constanta.FieldMedia)
+	}
+
+	if _, err := uc.productRepo.GetProductByID(ctx, req.ProductID); err != nil {
+		return response.ProductMediaResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	contentType, err := media.DetectContentType(req.Data)
+	if err != nil {
+		return response.ProductMediaResponse{}, errorutils.HandleCustomError(ctx, err, err.Error(), constanta.FieldMedia)
+	}
+
+	img, err := media.Decode(req.Data, contentType)
+	if err != nil {
+		return response.ProductMediaResponse{}, errorutils.HandleCustomError(ctx, err, err.Error(), constanta.FieldMedia)
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.ProductMediaResponse{}, errorutils.ErrDataNotFound
+	}
+
+	baseKey := fmt.Sprintf("product/%d/%s", req.ProductID, uuid.NewString())
+	productMedia := models.ProductMedia{
+		ProductID:   req.ProductID,
+		StorageKey:  baseKey + media.Extensions[contentType],
+		ContentType: contentType,
+		SizeBytes:   int64(len(req.Data)),
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+		Thumbnails:  models.MediaThumbnails{},
+		AltText:     req.AltText,
+		SortOrder:   req.SortOrder,
+		IsPrimary:   req.IsPrimary,
+		CreatedBy:   userID,
+		UpdatedBy:   userID,
+	}
+
+	var uploaded []string
+	upload := func(key string, data []byte, contentType string) error {
+		if err := uc.store.Put(ctx, key, data, contentType); err != nil {
+			return err
+		}
+		uploaded = append(uploaded, key)
+		return nil
+	}
+
+	err = upload(productMedia.StorageKey, req.Data, contentType)
+	for _, size := range media.ThumbnailSizes {
+		if err != nil {
+			break
+		}
+		var thumb media.Image
+		thumb, err = media.Thumbnail(img, contentType, size.MaxSide)
+		if err != nil {
+			break
+		}
+		key := baseKey + "_" + size.Name + media.Extensions[thumb.ContentType]
+		if err = upload(key, thumb.Data, thumb.ContentType); err == nil {
+			productMedia.Thumbnails[size.Name] = key
+		}
+	}
+	if err != nil {
+		logger.Error(ctx, "Failed to store media file", err)
+		removeMediaFiles(ctx, uc.store, uploaded)
+		return response.ProductMediaResponse{}, errorutils.ErrInternalServerError
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		if productMedia.IsPrimary {
+			if err := uc.productMediaRepo.UnsetPrimaryByProductID(ctx, req.ProductID); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+
+		if err := uc.productMediaRepo.Create(ctx, &productMedia); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productMediaRepo))
+		}
+
+		// gambar pertama otomatis menjadi gambar utama
+		if err := uc.productMediaRepo.SetFirstMediaAsPrimary(ctx, req.ProductID); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		removeMediaFiles(ctx, uc.store, uploaded)
+		return response.ProductMediaResponse{}, err
+	}
+
+	productMedia, err = uc.productMediaRepo.GetMediaByID(ctx, productMedia.ID)
+	if err != nil {
+		return response.ProductMediaResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	return response.SetProductMediaResponse(productMedia, uc.store.URL), nil
+}
+
+func (uc *productMediaUseCase) GetListMediaByProductID(ctx context.Context, productID int64) ([]response.ProductMediaResponse, error) {
+	if _, err := uc.productRepo.GetProductByID(ctx, productID); err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	list, err := uc.productMediaRepo.GetMediaByProductIDs(ctx, []int64{productID})
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+
+	return response.SetResponseListProductMedia(list, uc.store.URL), nil
+}
+
+// UpdateMediaByID gambar utama hanya bisa dipindah dengan menjadikan gambar lain sebagai gambar utama
+func (uc *productMediaUseCase) UpdateMediaByID(ctx context.Context, req *request.ReqProductMediaUpdate) (response.ProductMediaResponse, error) {
+	if err := req.ValidateUpdatedAt(); err != nil {
+		return response.ProductMediaResponse{}, err
+	}
+
+	if err := req.ValidateRequestUpdate(); err != nil {
+		return response.ProductMediaResponse{}, err
+	}
+
+	mediaDb, err := uc.productMediaRepo.GetMediaByID(ctx, req.ID)
+	if err != nil {
+		return response.ProductMediaResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, mediaDb.UpdatedAt) {
+		return response.ProductMediaResponse{}, errorutils.ErrDataDataUpdated
+	}
+
+	if mediaDb.IsPrimary && !req.IsPrimary {
+		return response.ProductMediaResponse{}, errorutils.HandleCustomError(ctx, nil, "pilih gambar lain sebagai gambar utama", constanta.FieldMedia)
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.ProductMediaResponse{}, errorutils.ErrDataNotFound
+	}
+
+	productMedia := mediaDb
+	productMedia.AltText = req.AltText
+	productMedia.SortOrder = req.SortOrder
+	productMedia.IsPrimary = req.IsPrimary
+	productMedia.UpdatedAt = time.Now()
+	productMedia.UpdatedBy = userID
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		if req.IsPrimary && !mediaDb.IsPrimary {
+			if err := uc.productMediaRepo.UnsetPrimaryByProductID(ctx, mediaDb.ProductID); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+
+		_, err := uc.productMediaRepo.UpdateMediaByID(ctx, req.ID, req.UpdatedAt, productMedia)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.productMediaRepo))
+		}
+		return nil
+	})
+	if err != nil {
+		return response.ProductMediaResponse{}, err
+	}
+
+	return response.SetProductMediaResponse(productMedia, uc.store.URL), nil
+}
+
+func (uc *productMediaUseCase) DeleteMediaByID(ctx context.Context, id int64, reqData request.AbstractRequest) error {
+	if err := reqData.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+
+	productMedia, err := uc.productMediaRepo.GetMediaByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(reqData.UpdatedAt, productMedia.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		err := uc.productMediaRepo.DeleteMediaByID(ctx, id, reqData.UpdatedAt)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		if productMedia.IsPrimary {
+			if err := uc.productMediaRepo.SetFirstMediaAsPrimary(ctx, productMedia.ProductID); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// file dihapus setelah commit, kegagalan hanya dicatat karena data sudah terhapus
+	removeMediaFiles(ctx, uc.store, productMedia.StorageKeys())
+	return nil
+}
+
+func removeMediaFiles(ctx context.Context, store storage.Storage, keys []string) {
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			logger.Error(ctx, "Failed to delete media file "+key, err)
+		}
+	}
+}
@@ -0,0 +1,410 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/promotion"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PromotionUseCase interface {
+	CreatePromotion(ctx context.Context, req *request.ReqPromotion) error
+	GetPromotionByID(ctx context.Context, id int64) (response.PromotionResponse, error)
+	GetListPromotion(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.PromotionResponse], error)
+	UpdatePromotionByID(ctx context.Context, req *request.ReqPromotionUpdate) (response.PromotionResponse, error)
+	DeletePromotionByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
+	EvaluatePromotion(ctx context.Context, req *request.ReqPriceQuote) (response.PromotionEvaluateResponse, error)
+	ApplyPromotions(ctx context.Context, customerID int64, at time.Time, prices []models.ResolvedPrice, extraRules ...promotion.Rule) (promotion.Result, error)
+	RecordUsage(ctx context.Context, customerID int64, reference string, result promotion.Result) error
+}
+
+type promotionUseCase struct {
+	db                  *gorm.DB
+	promotionRepo       repo.PromotionRepository
+	productRepo         repo.ProductRepository
+	categoryRepo        repo.CategoryRepository
+	productCategoryRepo repo.ProductCategoryRepository
+	pricingUC           PricingUseCase
+}
+
+func NewPromotionUseCase(db *gorm.DB,
+	promotionRepo repo.PromotionRepository,
+	productRepo repo.ProductRepository,
+	categoryRepo repo.CategoryRepository,
+	productCategoryRepo repo.ProductCategoryRepository,
+	pricingUC PricingUseCase) PromotionUseCase {
+	return &promotionUseCase{
+		db:                  db,
+		promotionRepo:       promotionRepo,
+		productRepo:         productRepo,
+		categoryRepo:        categoryRepo,
+		productCategoryRepo: productCategoryRepo,
+		pricingUC:           pricingUC,
+	}
+}
+
+func (uc *promotionUseCase) CreatePromotion(ctx context.Context, req *request.ReqPromotion) error {
+	if err := req.ValidateRequestCreate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return err
+	}
+
+	if err := uc.validateReferences(ctx, req); err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return errorutils.ErrDataNotFound
+	}
+
+	promo := models.Promotion{
+		Code:             req.Code,
+		Name:             req.Name,
+		Description:      req.Description,
+		Priority:         req.Priority,
+		Stackable:        req.Stackable,
+		IsActive:         req.IsActive,
+		StartsAt:         req.StartsAt,
+		EndsAt:           req.EndsAt,
+		UsageLimit:       req.UsageLimit,
+		PerCustomerLimit: req.PerCustomerLimit,
+		Conditions:       req.Conditions,
+		Actions:          req.Actions,
+		CreatedBy:        userID,
+		UpdatedBy:        userID,
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		err := uc.promotionRepo.Create(ctx, &promo)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.promotionRepo))
+		}
+		return nil
+	})
+}
+
+func (uc *promotionUseCase) GetPromotionByID(ctx context.Context, id int64) (response.PromotionResponse, error) {
+	promo, err := uc.promotionRepo.GetPromotionByID(ctx, id)
+	if err != nil {
+		return response.PromotionResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	return response.SetPromotionResponse(promo), nil
+}
+
+func (uc *promotionUseCase) GetListPromotion(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.PromotionResponse], error) {
+	promotions, count, err := uc.promotionRepo.GetListPromotion(ctx, listStruct)
+	if err != nil {
+		return response.ListResponse[response.PromotionResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListPromotion(promotions), count, listStruct, repo.GetFilterAvailableFromRepo(uc.promotionRepo))
+	return listResponse, nil
+}
+
+func (uc *promotionUseCase) UpdatePromotionByID(ctx context.Context, req *request.ReqPromotionUpdate) (response.PromotionResponse, error) {
+	if err := req.ValidateUpdatedAt(); err != nil {
+		return response.PromotionResponse{}, err
+	}
+
+	if err := req.ValidateRequestCreate(); err != nil {
+		return response.PromotionResponse{}, err
+	}
+
+	promotionDb, err := uc.promotionRepo.GetPromotionByID(ctx, req.ID)
+	if err != nil {
+		return response.PromotionResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, promotionDb.UpdatedAt) {
+		return response.PromotionResponse{}, errorutils.ErrDataDataUpdated
+	}
+
+	if err := uc.validateReferences(ctx, &req.ReqPromotion); err != nil {
+		return response.PromotionResponse{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.PromotionResponse{}, errorutils.ErrDataNotFound
+	}
+
+	promo := models.Promotion{
+		ID:               req.ID,
+		Code:             req.Code,
+		Name:             req.Name,
+		Description:      req.Description,
+		Priority:         req.Priority,
+		Stackable:        req.Stackable,
+		IsActive:         req.IsActive,
+		StartsAt:         req.StartsAt,
+		EndsAt:           req.EndsAt,
+		UsageLimit:       req.UsageLimit,
+		PerCustomerLimit: req.PerCustomerLimit,
+		UsageCount:       promotionDb.UsageCount,
+		Conditions:       req.Conditions,
+		Actions:          req.Actions,
+		CreatedAt:        promotionDb.CreatedAt,
+		CreatedBy:        promotionDb.CreatedBy,
+		UpdatedAt:        time.Now(),
+		UpdatedBy:        userID,
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		_, err := uc.promotionRepo.UpdatePromotionByID(ctx, req.ID, req.UpdatedAt, promo)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.promotionRepo))
+		}
+		return nil
+	})
+	if err != nil {
+		return response.PromotionResponse{}, err
+	}
+
+	return response.SetPromotionResponse(promo), nil
+}
+
+func (uc *promotionUseCase) DeletePromotionByID(ctx context.Context, id int64, reqData request.AbstractRequest) error {
+	if err := reqData.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+
+	promo, err := uc.promotionRepo.GetPromotionByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(reqData.UpdatedAt, promo.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		err := uc.promotionRepo.DeletePromotionByID(ctx, id, reqData.UpdatedAt)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+	})
+}
+
+// EvaluatePromotion simulasi keranjang: harga efektif dari pricing service lalu promosi yang berlaku
+func (uc *promotionUseCase) EvaluatePromotion(ctx context.Context, req *request.ReqPriceQuote) (response.PromotionEvaluateResponse, error) {
+	quote, err := uc.pricingUC.QuotePrice(ctx, req)
+	if err != nil {
+		return response.PromotionEvaluateResponse{}, err
+	}
+
+	prices := make([]models.ResolvedPrice, 0, len(quote.Items))
+	for _, item := range quote.Items {
+		prices = append(prices, item.ResolvedPrice)
+	}
+
+	result, err := uc.ApplyPromotions(ctx, req.CustomerID, quote.At, prices)
+	if err != nil {
+		return response.PromotionEvaluateResponse{}, err
+	}
+
+	return response.PromotionEvaluateResponse{
+		At:     quote.At,
+		Items:  quote.Items,
+		Result: result,
+	}, nil
+}
+
+// ApplyPromotions mengevaluasi promosi aktif (ditambah extraRules, mis. voucher) terhadap harga
+// yang sudah di-resolve pricing service. Index LineDiscount pada hasil sesuai urutan prices.
+func (uc *promotionUseCase) ApplyPromotions(ctx context.Context, customerID int64, at time.Time, prices []models.ResolvedPrice, extraRules ...promotion.Rule) (promotion.Result, error) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	promotions, err := uc.promotionRepo.GetActivePromotions(ctx, at)
+	if err != nil {
+		return promotion.Result{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	// pemakaian per pelanggan hanya dihitung untuk pelanggan terdaftar
+	customerUsage := map[int64]int{}
+	if customerID != 0 {
+		var limitedIDs []int64
+		for _, p := range promotions {
+			if p.PerCustomerLimit > 0 {
+				limitedIDs = append(limitedIDs, p.ID)
+			}
+		}
+		if len(limitedIDs) > 0 {
+			customerUsage, err = uc.promotionRepo.CountUsageByCustomerID(ctx, customerID, limitedIDs)
+			if err != nil {
+				return promotion.Result{}, errorutils.HandleRepoError(ctx, err)
+			}
+		}
+	}
+
+	rules := make([]promotion.Rule, 0, len(promotions)+len(extraRules))
+	for _, p := range promotions {
+		rules = append(rules, p.ToRule(customerUsage[p.ID]))
+	}
+	rules = append(rules, extraRules...)
+
+	cart, err := uc.buildCart(ctx, at, prices)
+	if err != nil {
+		return promotion.Result{}, err
+	}
+
+	return promotion.Evaluate(cart, rules), nil
+}
+
+// RecordUsage mencatat pemakaian promosi yang diterapkan, dipanggil di dalam transaksi order
+func (uc *promotionUseCase) RecordUsage(ctx context.Context, customerID int64, reference string, result promotion.Result) error {
+	for _, applied := range result.Applied {
+		if applied.Source != promotion.SourcePromotion {
+			continue
+		}
+
+		promotionDb, err := uc.promotionRepo.GetPromotionByID(ctx, applied.RuleID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		if customerID != 0 && promotionDb.PerCustomerLimit > 0 {
+			usage, err := uc.promotionRepo.CountUsageByCustomerID(ctx, customerID, []int64{applied.RuleID})
+			if err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+			if usage[applied.RuleID] >= promotionDb.PerCustomerLimit {
+				return errorutils.HandleCustomError(ctx, nil, promotion.ReasonCustomerLimit, constanta.FieldPromotion)
+			}
+		}
+
+		ok, err := uc.promotionRepo.IncrementUsageCount(ctx, applied.RuleID)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if !ok {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePromotionQuota, constanta.FieldPromotion)
+		}
+
+		usage := models.PromotionUsage{
+			PromotionID:    applied.RuleID,
+			Reference:      reference,
+			DiscountAmount: applied.Amount,
+			UsedAt:         time.Now(),
+		}
+		if customerID != 0 {
+			usage.CustomerID = &customerID
+		}
+		if err := uc.promotionRepo.CreateUsage(ctx, &usage); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+	}
+
+	return nil
+}
+
+// buildCart menyusun keranjang evaluator, kategori item termasuk seluruh leluhurnya
+// sehingga promosi kategori induk berlaku untuk produk di sub kategori
+func (uc *promotionUseCase) buildCart(ctx context.Context, at time.Time, prices []models.ResolvedPrice) (promotion.Cart, error) {
+	cart := promotion.Cart{
+		At:    at,
+		Items: make([]promotion.CartItem, 0, len(prices)),
+	}
+	if len(prices) == 0 {
+		return cart, nil
+	}
+
+	productIDs := make([]int64, 0, len(prices))
+	for _, p := range prices {
+		productIDs = append(productIDs, p.ProductID)
+	}
+
+	paths, err := uc.productCategoryRepo.GetCategoryPathByProductIDs(ctx, uniqueInt64s(productIDs))
+	if err != nil {
+		return promotion.Cart{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	categories := make(map[int64][]int64, len(paths))
+	for _, p := range paths {
+		categories[p.ProductID] = append(categories[p.ProductID], categoryPathIDs(p.Path)...)
+	}
+
+	for _, p := range prices {
+		cart.Items = append(cart.Items, promotion.CartItem{
+			ProductID:   p.ProductID,
+			VarianID:    p.VarianID,
+			CategoryIDs: uniqueInt64s(categories[p.ProductID]),
+			Quantity:    p.Quantity,
+			UnitPrice:   p.UnitPrice,
+		})
+	}
+
+	return cart, nil
+}
+
+// validateReferences memastikan produk dan kategori pada kondisi / aksi ada di database
+func (uc *promotionUseCase) validateReferences(ctx context.Context, req *request.ReqPromotion) error {
+	var productIDs, categoryIDs []int64
+	for _, c := range req.Conditions {
+		productIDs = append(productIDs, c.ProductIDs...)
+		categoryIDs = append(categoryIDs, c.CategoryIDs...)
+	}
+	for _, a := range req.Actions {
+		productIDs = append(productIDs, a.ProductIDs...)
+		productIDs = append(productIDs, a.BundleProductIDs...)
+		categoryIDs = append(categoryIDs, a.CategoryIDs...)
+	}
+
+	productIDs = uniqueInt64s(productIDs)
+	if len(productIDs) > 0 {
+		products, err := uc.productRepo.GetProductByListIDs(ctx, productIDs)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if len(products) != len(productIDs) {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldProduct)
+		}
+	}
+
+	categoryIDs = uniqueInt64s(categoryIDs)
+	if len(categoryIDs) > 0 {
+		categories, err := uc.categoryRepo.GetCategoryByListIDs(ctx, categoryIDs)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if len(categories) != len(categoryIDs) {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldCategory)
+		}
+	}
+
+	return nil
+}
+
+// categoryPathIDs mengambil id kategori dari path, contoh /1/5/12/ -> [1 5 12]
+func categoryPathIDs(path string) []int64 {
+	var ids []int64
+	for _, s := range strings.Split(strings.Trim(path, "/"), "/") {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	regionSearchMinLength = 3
+	regionSearchLimit     = 20
+)
+
+// RegionUseCase data wilayah administratif (provinsi, kabupaten/kota, kecamatan, kelurahan)
+// untuk dropdown, autocomplete dan validasi alamat
+type RegionUseCase interface {
+	GetListProvince(ctx context.Context) ([]models.Province, error)
+	GetListRegency(ctx context.Context, provinceID int64) ([]models.Regency, error)
+	GetListDistrict(ctx context.Context, regencyID int64) ([]models.District, error)
+	GetListVillage(ctx context.Context, districtID int64) ([]models.Village, error)
+	SearchRegion(ctx context.Context, keyword string) ([]models.RegionPath, error)
+	GetRegionByPostalCode(ctx context.Context, postalCode string) ([]models.RegionPath, error)
+	ResolveRegion(ctx context.Context, query models.RegionQuery) (models.RegionPath, error)
+}
+
+type regionUseCase struct {
+	regionRepo repo.RegionRepository
+}
+
+func NewRegionUseCase(regionRepo repo.RegionRepository) RegionUseCase {
+	return &regionUseCase{
+		regionRepo: regionRepo,
+	}
+}
+
+func (uc *regionUseCase) GetListProvince(ctx context.Context) ([]models.Province, error) {
+	provinces, err := uc.regionRepo.GetListProvince(ctx)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	return provinces, nil
+}
+
+func (uc *regionUseCase) GetListRegency(ctx context.Context, provinceID int64) ([]models.Regency, error) {
+	regencies, err := uc.regionRepo.GetListRegencyByProvinceID(ctx, provinceID)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	return regencies, nil
+}
+
+func (uc *regionUseCase) GetListDistrict(ctx context.Context, regencyID int64) ([]models.District, error) {
+	districts, err := uc.regionRepo.GetListDistrictByRegencyID(ctx, regencyID)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	return districts, nil
+}
+
+func (uc *regionUseCase) GetListVillage(ctx context.Context, districtID int64) ([]models.Village, error) {
+	villages, err := uc.regionRepo.GetListVillageByDistrictID(ctx, districtID)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	return villages, nil
+}
+
+// SearchRegion autocomplete kecamatan / kelurahan berdasarkan awalan nama
+func (uc *regionUseCase) SearchRegion(ctx context.Context, keyword string) ([]models.RegionPath, error) {
+	keyword = strings.TrimSpace(keyword)
+	if len(keyword) < regionSearchMinLength {
+		return nil, fmt.Errorf("kata kunci minimal %d karakter", regionSearchMinLength)
+	}
+
+	paths, err := uc.regionRepo.SearchRegion(ctx, keyword, regionSearchLimit)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	return paths, nil
+}
+
+func (uc *regionUseCase) GetRegionByPostalCode(ctx context.Context, postalCode string) ([]models.RegionPath, error) {
+	if err := utils.ValidatePostalCode(postalCode); err != nil {
+		return nil, err
+	}
+
+	paths, err := uc.regionRepo.GetVillagePathByPostalCode(ctx, postalCode)
+	if err != nil {
+		return nil, errorutils.HandleRepoError(ctx, err)
+	}
+	return paths, nil
+}
+
+// ResolveRegion pastikan id wilayah pada alamat saling cocok (kelurahan di dalam kecamatan, dst)
+// dan kode pos sesuai wilayah. Kode pos kosong diisi dari kelurahan. Selama data kecamatan /
+// kelurahan suatu daerah belum di-seed, kode wilayah hanya dicek strukturnya dan nama wilayah
+// diambil dari teks bebas request.
+func (uc *regionUseCase) ResolveRegion(ctx context.Context, query models.RegionQuery) (models.RegionPath, error) {
+	if query.VillageID != 0 {
+		path, err := uc.regionRepo.GetVillagePath(ctx, query.VillageID)
+		if err == nil {
+			return uc.checkRegion(ctx, path, query)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.RegionPath{}, errorutils.HandleRepoError(ctx, err)
+		}
+		villages, err := uc.regionRepo.GetListVillageByDistrictID(ctx, query.DistrictID)
+		if err != nil {
+			return models.RegionPath{}, errorutils.HandleRepoError(ctx, err)
+		}
+		if len(villages) > 0 || query.VillageID/10000 != query.DistrictID {
+			return models.RegionPath{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageRegionNotFound, constanta.FieldRegion)
+		}
+		// kelurahan kecamatan ini belum di-seed, alamat berhenti di kecamatan
+		query.VillageID = 0
+	}
+
+	path, err := uc.regionRepo.GetDistrictPath(ctx, query.DistrictID)
+	if err == nil {
+		path.VillageName = query.VillageName
+		return uc.checkRegion(ctx, path, query)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.RegionPath{}, errorutils.HandleRepoError(ctx, err)
+	}
+	return uc.resolveUnseededRegion(ctx, query)
+}
+
+func (uc *regionUseCase) checkRegion(ctx context.Context, path models.RegionPath, query models.RegionQuery) (models.RegionPath, error) {
+	if path.ProvinceID != query.ProvinceID || path.RegencyID != query.RegencyID || path.DistrictID != query.DistrictID {
+		return models.RegionPath{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageRegionMismatch, constanta.FieldRegion)
+	}
+
+	// kelurahan dengan kode pos: kode pos harus sama
+	if path.PostalCode != "" {
+		if query.PostalCode != "" && query.PostalCode != path.PostalCode {
+			return models.RegionPath{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePostalCodeMismatch, constanta.FieldPostalCode)
+		}
+		return path, nil
+	}
+
+	// tanpa kelurahan: kode pos harus salah satu kode pos di kecamatan (bila datanya ada)
+	if query.PostalCode == "" {
+		return models.RegionPath{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePostalCodeRequired, constanta.FieldPostalCode)
+	}
+	postalCodes, err := uc.regionRepo.GetPostalCodesByDistrictID(ctx, path.DistrictID)
+	if err != nil {
+		return models.RegionPath{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if len(postalCodes) > 0 && !slices.Contains(postalCodes, query.PostalCode) {
+		return models.RegionPath{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePostalCodeMismatch, constanta.FieldPostalCode)
+	}
+	path.PostalCode = query.PostalCode
+	return path, nil
+}
+
+// resolveUnseededRegion kecamatan yang tidak ada di data wilayah. Hanya diterima bila kecamatan
+// kabupaten / kota tersebut memang belum di-seed (kabupaten yang sudah lengkap tetap ditolak) dan
+// kode wilayah mengikuti struktur kode Kemendagri (31 / 3173 / 317301).
+func (uc *regionUseCase) resolveUnseededRegion(ctx context.Context, query models.RegionQuery) (models.RegionPath, error) {
+	districts, err := uc.regionRepo.GetListDistrictByRegencyID(ctx, query.RegencyID)
+	if err != nil {
+		return models.RegionPath{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if len(districts) > 0 {
+		return models.RegionPath{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageRegionNotFound, constanta.FieldRegion)
+	}
+	if query.DistrictID/100 != query.RegencyID || query.RegencyID/100 != query.ProvinceID {
+		return models.RegionPath{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageRegionMismatch, constanta.FieldRegion)
+	}
+	if query.PostalCode == "" {
+		return models.RegionPath{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessagePostalCodeRequired, constanta.FieldPostalCode)
+	}
+
+	// provinsi selalu lengkap di dataset bawaan
+	provinces, err := uc.regionRepo.GetListProvince(ctx)
+	if err != nil {
+		return models.RegionPath{}, errorutils.HandleRepoError(ctx, err)
+	}
+	idx := slices.IndexFunc(provinces, func(p models.Province) bool { return p.ID == query.ProvinceID })
+	if idx < 0 {
+		return models.RegionPath{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageRegionNotFound, constanta.FieldRegion)
+	}
+
+	path := models.RegionPath{
+		ProvinceID:   query.ProvinceID,
+		ProvinceName: provinces[idx].Name,
+		RegencyID:    query.RegencyID,
+		RegencyName:  query.RegencyName,
+		DistrictID:   query.DistrictID,
+		DistrictName: query.DistrictName,
+		VillageName:  query.VillageName,
+		PostalCode:   query.PostalCode,
+	}
+
+	regencies, err := uc.regionRepo.GetListRegencyByProvinceID(ctx, query.ProvinceID)
+	if err != nil {
+		return models.RegionPath{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if idx := slices.IndexFunc(regencies, func(r models.Regency) bool { return r.ID == query.RegencyID }); idx >= 0 {
+		path.RegencyName = regencies[idx].Name
+	}
+	return path, nil
+}
+
+// requireRegionNames alamat yang disimpan wajib punya nama kota dan kecamatan, dari data wilayah
+// atau teks bebas bila wilayah belum di-seed
+func requireRegionNames(ctx context.Context, path models.RegionPath) error {
+	if path.RegencyName == "" || path.DistrictName == "" {
+		return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageRegionNameRequired, constanta.FieldRegion)
+	}
+	return nil
+}
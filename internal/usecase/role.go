@@ -21,6 +21,7 @@ type RoleUseCase interface {
 	CreateRole(ctx context.Context, req *request.ReqRoles) error
 	GetRoleByID(ctx context.Context, id int64) (response.RolesResponse, error)
 	GetListRole(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.RolesResponse], error)
+	ExportListRole(ctx context.Context, listStruct *models.GetListStruct, req *request.ReqExport) (response.FileStreamResponse, error)
 	UpdateRoleByID(ctx context.Context, req *request.ReqRoleUpdate) (response.RolesResponse, error)
 	DeleteRoleByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
 }
@@ -271,3 +272,13 @@ func (uc *roleUseCase) DeleteRoleByID(ctx context.Context, id int64, reqData req
 		return nil
 	})
 }
+
+func (uc *roleUseCase) ExportListRole(ctx context.Context, listStruct *models.GetListStruct, req *request.ReqExport) (response.FileStreamResponse, error) {
+	return exportList(ctx, uc.db, req, "role",
+		func(ctx context.Context, fn func([]models.Roles) error) error {
+			return uc.roleRepo.ExportListRole(ctx, listStruct, fn)
+		},
+		func(ctx context.Context, roles []models.Roles) ([]response.RolesResponse, error) {
+			return response.SetListResponseRole(roles), nil
+		})
+}
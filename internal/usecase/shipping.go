@@ -0,0 +1,470 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/shipping"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ShippingUseCase ongkir dan pengiriman lewat shipping.Provider (flat per zona atau agregator kurir).
+// Ongkir dihitung dari berat / dimensi item (varian mengikuti produk bila 0) ke wilayah alamat tujuan,
+// asal pengiriman dari konfigurasi gudang (shipping.Config.Sender).
+type ShippingUseCase interface {
+	QuoteCart(ctx context.Context, req *request.ReqShippingQuote) (response.ShippingQuoteResponse, error)
+	CreateShipment(ctx context.Context, req *request.ReqShipment) (models.Shipment, error)
+	GetShipmentTracking(ctx context.Context, orderID int64) (response.ShipmentTrackingResponse, error)
+	GetShipmentLabel(ctx context.Context, orderID int64) (response.FileResponse, error)
+}
+
+type shippingUseCase struct {
+	db           *gorm.DB
+	order        *orderUseCase   // snapshot item keranjang dan transisi status order
+	cart         *cartUseCase    // isi keranjang untuk cek ongkir
+	address      *addressUseCase // alamat dari buku alamat dan validasi wilayah
+	orderRepo    repo.OrderRepository
+	shipmentRepo repo.ShipmentRepository
+	provider     shipping.Provider
+	sender       shipping.Contact
+}
+
+func NewShippingUseCase(db *gorm.DB,
+	cartRepo repo.CartRepository,
+	orderRepo repo.OrderRepository,
+	customerRepo repo.CustomerRepository,
+	addressRepo repo.AddressRepository,
+	shipmentRepo repo.ShipmentRepository,
+	productRepo repo.ProductRepository,
+	productVarianRepo repo.ProductVarianRepository,
+	pricingUC PricingUseCase,
+	inventoryUC InventoryUseCase,
+	regionUC RegionUseCase,
+	shippingCfg shipping.Config) ShippingUseCase {
+	return &shippingUseCase{
+		db: db,
+		order: &orderUseCase{
+			db:                db,
+			orderRepo:         orderRepo,
+			customerRepo:      customerRepo,
+			productRepo:       productRepo,
+			productVarianRepo: productVarianRepo,
+			pricingUC:         pricingUC,
+			inventoryUC:       inventoryUC,
+		},
+		cart: &cartUseCase{
+			db:                db,
+			cartRepo:          cartRepo,
+			productRepo:       productRepo,
+			productVarianRepo: productVarianRepo,
+			customerRepo:      customerRepo,
+			pricingUC:         pricingUC,
+			inventoryUC:       inventoryUC,
+		},
+		address: &addressUseCase{
+			db:           db,
+			addressRepo:  addressRepo,
+			customerRepo: customerRepo,
+			regionUC:     regionUC,
+		},
+		orderRepo:    orderRepo,
+		shipmentRepo: shipmentRepo,
+		provider:     shippingCfg.Provider,
+		sender:       shippingCfg.Sender,
+	}
+}
+
+// QuoteCart pilihan layanan kirim untuk isi keranjang saat ini
+func (uc *shippingUseCase) QuoteCart(ctx context.Context, req *request.ReqShippingQuote) (response.ShippingQuoteResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.ShippingQuoteResponse{}, err
+	}
+
+	owner := cartOwnerFromCtx(ctx)
+	if owner.IsEmpty() {
+		return response.ShippingQuoteResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCartEmpty, constanta.FieldCart)
+	}
+	cart, err := uc.cart.loadCart(ctx, owner)
+	if err != nil {
+		return response.ShippingQuoteResponse{}, err
+	}
+	if len(cart.Items) == 0 {
+		return response.ShippingQuoteResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageCartEmpty, constanta.FieldCart)
+	}
+
+	address, err := uc.getQuoteAddress(ctx, req)
+	if err != nil {
+		return response.ShippingQuoteResponse{}, err
+	}
+
+	pricingCtx, err := uc.cart.getPricingContext(ctx, owner)
+	if err != nil {
+		return response.ShippingQuoteResponse{}, err
+	}
+	items, _, err := uc.order.newOrderItems(ctx, pricingCtx, cartPriceQueries(cart.Items))
+	if err != nil {
+		return response.ShippingQuoteResponse{}, err
+	}
+
+	rates, err := uc.quote(ctx, address, items)
+	if err != nil {
+		return response.ShippingQuoteResponse{}, err
+	}
+	return response.ShippingQuoteResponse{
+		WeightGram: shipping.ChargeableWeight(shippingItems(items)),
+		Rates:      rates,
+	}, nil
+}
+
+// CreateShipment buat resi ke provider lalu order diubah ke shipped. Resi dibuat sebelum transaksi
+// karena memanggil layanan luar; bila simpan gagal nomor resi dicatat di log untuk dibatalkan manual.
+func (uc *shippingUseCase) CreateShipment(ctx context.Context, req *request.ReqShipment) (models.Shipment, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return models.Shipment{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return models.Shipment{}, errorutils.ErrDataNotFound
+	}
+
+	order, err := uc.orderRepo.GetOrderByID(ctx, req.OrderID)
+	if err != nil {
+		return models.Shipment{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, order.UpdatedAt) {
+		return models.Shipment{}, errorutils.ErrDataDataUpdated
+	}
+	if order.Status != models.OrderStatusProcessing {
+		return models.Shipment{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageShipmentStatus, constanta.FieldStatus)
+	}
+	if order.ShippingAddress == nil {
+		return models.Shipment{}, errorutils.HandleCustomError(ctx, nil, "order tidak memiliki alamat kirim", constanta.FieldAddress)
+	}
+
+	_, err = uc.shipmentRepo.GetShipmentByOrderID(ctx, order.ID)
+	if err == nil {
+		return models.Shipment{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageShipmentExists, constanta.FieldShipping)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Shipment{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	courier, service := req.Courier, req.Service
+	if courier == "" {
+		courier, service = order.ShippingCourier, order.ShippingService
+	}
+	if courier == "" {
+		return models.Shipment{}, errorutils.HandleCustomError(ctx, nil, "courier dan service wajib diisi", constanta.FieldShipping)
+	}
+
+	items := shippingItems(order.Items)
+	result, err := uc.provider.CreateShipment(ctx, shipping.ShipmentRequest{
+		Reference: order.OrderNumber,
+		Courier:   courier,
+		Service:   service,
+		Sender:    uc.sender,
+		Recipient: orderRecipient(*order.ShippingAddress),
+		Items:     items,
+	})
+	if err != nil {
+		return models.Shipment{}, handleShippingError(ctx, err)
+	}
+
+	shipment := models.Shipment{
+		OrderID:        order.ID,
+		Provider:       uc.provider.Name(),
+		Courier:        result.Courier,
+		Service:        result.Service,
+		TrackingNumber: result.TrackingNumber,
+		Cost:           utils.RoundTo2Digits(result.Cost),
+		WeightGram:     shipping.ChargeableWeight(items),
+		Status:         models.ShipmentStatusCreated,
+		CreatedBy:      userID,
+		UpdatedBy:      userID,
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.shipmentRepo.Create(ctx, &shipment); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.shipmentRepo))
+		}
+		note := fmt.Sprintf("Resi %s %s", strings.ToUpper(shipment.Courier), shipment.TrackingNumber)
+		return uc.order.transitionOrder(ctx, order, models.OrderStatusShipped, note, models.OrderActorUser, userID)
+	})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Failed to save shipment %s (%s) for order %s", shipment.TrackingNumber, shipment.Courier, order.OrderNumber), err)
+		return models.Shipment{}, err
+	}
+
+	return shipment, nil
+}
+
+// GetShipmentTracking status dari kurir; paket yang sudah diterima otomatis mengubah order ke delivered.
+// Provider tanpa tracking (flat) memakai riwayat status order.
+func (uc *shippingUseCase) GetShipmentTracking(ctx context.Context, orderID int64) (response.ShipmentTrackingResponse, error) {
+	shipment, order, err := uc.getShipment(ctx, orderID)
+	if err != nil {
+		return response.ShipmentTrackingResponse{}, err
+	}
+
+	tracking, err := uc.provider.Track(ctx, shipment.Courier, shipment.TrackingNumber)
+	if errors.Is(err, shipping.ErrNotSupported) {
+		return response.ShipmentTrackingResponse{Shipment: shipment, Tracking: orderTracking(shipment, order)}, nil
+	}
+	if err != nil {
+		return response.ShipmentTrackingResponse{}, handleShippingError(ctx, err)
+	}
+
+	status := models.ShipmentStatusInTransit
+	if tracking.Delivered {
+		status = models.ShipmentStatusDelivered
+	}
+	if status != shipment.Status && shipment.Status != models.ShipmentStatusDelivered {
+		if err := uc.updateShipmentStatus(ctx, &shipment, order, status); err != nil {
+			return response.ShipmentTrackingResponse{}, err
+		}
+	}
+
+	return response.ShipmentTrackingResponse{Shipment: shipment, Tracking: tracking}, nil
+}
+
+func (uc *shippingUseCase) GetShipmentLabel(ctx context.Context, orderID int64) (response.FileResponse, error) {
+	shipment, order, err := uc.getShipment(ctx, orderID)
+	if err != nil {
+		return response.FileResponse{}, err
+	}
+
+	req := shipping.LabelRequest{
+		Reference:      order.OrderNumber,
+		TrackingNumber: shipment.TrackingNumber,
+		Courier:        shipment.Courier,
+		Service:        shipment.Service,
+		Sender:         uc.sender,
+		WeightGram:     shipment.WeightGram,
+	}
+	if order.ShippingAddress != nil {
+		req.Recipient = orderRecipient(*order.ShippingAddress)
+	}
+
+	label, err := uc.provider.Label(ctx, req)
+	if err != nil {
+		return response.FileResponse{}, handleShippingError(ctx, err)
+	}
+	return response.FileResponse{
+		FileName:    fmt.Sprintf("resi-%s.pdf", order.OrderNumber),
+		ContentType: label.ContentType,
+		Content:     label.Data,
+	}, nil
+}
+
+// getShipment pengiriman order beserta order-nya, hanya bisa diproses provider yang membuatnya
+func (uc *shippingUseCase) getShipment(ctx context.Context, orderID int64) (models.Shipment, models.Order, error) {
+	order, err := uc.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return models.Shipment{}, models.Order{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	shipment, err := uc.shipmentRepo.GetShipmentByOrderID(ctx, orderID)
+	if err != nil {
+		return models.Shipment{}, models.Order{}, errorutils.HandleRepoError(ctx, err)
+	}
+	if shipment.Provider != uc.provider.Name() {
+		msg := fmt.Sprintf("pengiriman dibuat lewat provider %s, provider aktif %s", shipment.Provider, uc.provider.Name())
+		return models.Shipment{}, models.Order{}, errorutils.HandleCustomError(ctx, nil, msg, constanta.FieldShipping)
+	}
+	return shipment, order, nil
+}
+
+func (uc *shippingUseCase) updateShipmentStatus(ctx context.Context, shipment *models.Shipment, order models.Order, status string) error {
+	var deliveredAt *time.Time
+	if status == models.ShipmentStatusDelivered {
+		now := time.Now()
+		deliveredAt = &now
+	}
+
+	err := processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.shipmentRepo.UpdateShipmentStatusByID(ctx, shipment.ID, status, deliveredAt); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if status == models.ShipmentStatusDelivered && order.Status == models.OrderStatusShipped {
+			return uc.order.transitionOrder(ctx, order, models.OrderStatusDelivered, "Paket diterima (tracking kurir)", models.OrderActorSystem, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	shipment.Status = status
+	shipment.DeliveredAt = deliveredAt
+	return nil
+}
+
+// getQuoteAddress alamat dari buku alamat pemesan atau wilayah tujuan yang divalidasi
+func (uc *shippingUseCase) getQuoteAddress(ctx context.Context, req *request.ReqShippingQuote) (models.OrderAddress, error) {
+	if req.AddressID == 0 {
+		region, err := uc.address.regionUC.ResolveRegion(ctx, req.Region())
+		if err != nil {
+			return models.OrderAddress{}, err
+		}
+		return models.OrderAddress{
+			ProvinceID: region.ProvinceID,
+			RegencyID:  region.RegencyID,
+			DistrictID: region.DistrictID,
+			VillageID:  region.VillageID,
+			PostalCode: region.PostalCode,
+		}, nil
+	}
+
+	owner, err := uc.address.getOwner(ctx)
+	if err != nil {
+		return models.OrderAddress{}, err
+	}
+	if owner.IsEmpty() {
+		return models.OrderAddress{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldAddress)
+	}
+	address, err := uc.address.addressRepo.GetAddressByOwner(ctx, owner, req.AddressID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.OrderAddress{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldAddress)
+		}
+		return models.OrderAddress{}, errorutils.HandleRepoError(ctx, err)
+	}
+	return address.ToOrderAddress(), nil
+}
+
+// quote ongkir item order ke alamat tujuan, dipakai cek ongkir dan checkout
+func (uc *shippingUseCase) quote(ctx context.Context, address models.OrderAddress, items []models.OrderItem) ([]shipping.Rate, error) {
+	destination := shipping.Location{
+		ProvinceID: address.ProvinceID,
+		RegencyID:  address.RegencyID,
+		DistrictID: address.DistrictID,
+		VillageID:  address.VillageID,
+		PostalCode: address.PostalCode,
+	}
+
+	rates, err := uc.provider.Quote(ctx, shipping.QuoteRequest{
+		Origin:      uc.sender.Location,
+		Destination: destination,
+		Items:       shippingItems(items),
+	})
+	if err != nil {
+		return nil, handleShippingError(ctx, err)
+	}
+	return rates, nil
+}
+
+// selectRate layanan pilihan pelanggan, kosong = layanan termurah
+func selectRate(ctx context.Context, rates []shipping.Rate, courier, service string) (shipping.Rate, error) {
+	if courier == "" {
+		cheapest := rates[0]
+		for _, rate := range rates[1:] {
+			if rate.Cost < cheapest.Cost {
+				cheapest = rate
+			}
+		}
+		return cheapest, nil
+	}
+
+	for _, rate := range rates {
+		if strings.EqualFold(rate.Courier, courier) && strings.EqualFold(rate.Service, service) {
+			return rate, nil
+		}
+	}
+	return shipping.Rate{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageShippingService, constanta.FieldShipping)
+}
+
+func handleShippingError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, shipping.ErrNoRate), errors.Is(err, shipping.ErrInvalidLocation), errors.Is(err, shipping.ErrNotConfigured):
+		return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageShippingUnavailable, constanta.FieldShipping)
+	case errors.Is(err, shipping.ErrShipmentNotFound):
+		return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldShipping)
+	case errors.Is(err, shipping.ErrNotSupported):
+		return errorutils.HandleCustomError(ctx, err, err.Error(), constanta.FieldShipping)
+	}
+	logger.Error(ctx, "Shipping provider error", err)
+	return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageShippingProvider, constanta.FieldShipping)
+}
+
+func shippingItems(items []models.OrderItem) []shipping.Item {
+	result := make([]shipping.Item, 0, len(items))
+	for _, item := range items {
+		name := item.ProductName
+		if item.VarianName != "" {
+			name += " - " + item.VarianName
+		}
+		result = append(result, shipping.Item{
+			Name:       name,
+			Quantity:   item.Quantity,
+			WeightGram: item.WeightGram,
+			LengthCm:   item.LengthCm,
+			WidthCm:    item.WidthCm,
+			HeightCm:   item.HeightCm,
+			Value:      item.UnitPrice,
+		})
+	}
+	return result
+}
+
+func orderRecipient(address models.OrderAddress) shipping.Contact {
+	var parts []string
+	for _, part := range []string{address.AddressLine1, address.AddressLine2, address.Village, address.Subdistrict} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return shipping.Contact{
+		Name:    address.RecipientName,
+		Phone:   address.PhoneNumber,
+		Address: strings.Join(parts, ", "),
+		City:    strings.Trim(address.City+", "+address.Province, ", "),
+		Location: shipping.Location{
+			ProvinceID: address.ProvinceID,
+			RegencyID:  address.RegencyID,
+			DistrictID: address.DistrictID,
+			VillageID:  address.VillageID,
+			PostalCode: address.PostalCode,
+		},
+	}
+}
+
+// orderTracking pengiriman tanpa data kurir: status dan riwayat diambil dari status order sejak dikirim
+func orderTracking(shipment models.Shipment, order models.Order) shipping.Tracking {
+	tracking := shipping.Tracking{
+		TrackingNumber: shipment.TrackingNumber,
+		Courier:        shipment.Courier,
+		Status:         order.Status,
+		Delivered:      order.Status == models.OrderStatusDelivered || order.Status == models.OrderStatusCompleted,
+		Events:         []shipping.TrackingEvent{},
+	}
+
+	shipped := false
+	for _, history := range order.History {
+		shipped = shipped || history.ToStatus == models.OrderStatusShipped
+		if !shipped {
+			continue
+		}
+		tracking.Events = append(tracking.Events, shipping.TrackingEvent{
+			Time:        history.CreatedAt,
+			Status:      history.ToStatus,
+			Description: history.Note,
+		})
+	}
+	return tracking
+}
@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/tax"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TaxUseCase interface {
+	CreateTaxClass(ctx context.Context, req *request.ReqTaxClass) error
+	GetTaxClassByID(ctx context.Context, id int64) (response.TaxClassResponse, error)
+	GetListTaxClass(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.TaxClassResponse], error)
+	UpdateTaxClassByID(ctx context.Context, req *request.ReqTaxClassUpdate) (response.TaxClassResponse, error)
+	DeleteTaxClassByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
+}
+
+type taxUseCase struct {
+	db           *gorm.DB
+	taxClassRepo repo.TaxClassRepository
+	cfg          tax.Config
+}
+
+func NewTaxUseCase(db *gorm.DB, taxClassRepo repo.TaxClassRepository, cfg tax.Config) TaxUseCase {
+	return &taxUseCase{
+		db:           db,
+		taxClassRepo: taxClassRepo,
+		cfg:          cfg,
+	}
+}
+
+func (uc *taxUseCase) CreateTaxClass(ctx context.Context, req *request.ReqTaxClass) error {
+	if err := req.ValidateRequestCreate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return errorutils.ErrDataNotFound
+	}
+
+	taxClass := models.TaxClass{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		IsDefault:   req.IsDefault,
+		CreatedBy:   userID,
+		UpdatedBy:   userID,
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		if req.IsDefault {
+			if err := uc.taxClassRepo.ClearDefaultTaxClass(ctx, 0); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+
+		err := uc.taxClassRepo.Create(ctx, &taxClass)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.taxClassRepo))
+		}
+
+		return uc.saveRates(ctx, taxClass.ID, req.Rates, userID)
+	})
+}
+
+func (uc *taxUseCase) GetTaxClassByID(ctx context.Context, id int64) (response.TaxClassResponse, error) {
+	taxClass, err := uc.taxClassRepo.GetTaxClassByID(ctx, id)
+	if err != nil {
+		return response.TaxClassResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	return response.SetTaxClassResponse(taxClass), nil
+}
+
+func (uc *taxUseCase) GetListTaxClass(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.TaxClassResponse], error) {
+	taxClasses, count, err := uc.taxClassRepo.GetListTaxClass(ctx, listStruct)
+	if err != nil {
+		return response.ListResponse[response.TaxClassResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListTaxClass(taxClasses), count, listStruct, repo.GetFilterAvailableFromRepo(uc.taxClassRepo))
+	return listResponse, nil
+}
+
+// UpdateTaxClassByID mengganti kelas pajak beserta seluruh tarifnya. Order lama tidak berubah
+// karena pajak order disimpan sebagai snapshot.
+func (uc *taxUseCase) UpdateTaxClassByID(ctx context.Context, req *request.ReqTaxClassUpdate) (response.TaxClassResponse, error) {
+	if err := req.ValidateUpdatedAt(); err != nil {
+		return response.TaxClassResponse{}, err
+	}
+
+	if err := req.ValidateRequestCreate(); err != nil {
+		return response.TaxClassResponse{}, err
+	}
+
+	taxClassDb, err := uc.taxClassRepo.GetTaxClassByID(ctx, req.ID)
+	if err != nil {
+		return response.TaxClassResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, taxClassDb.UpdatedAt) {
+		return response.TaxClassResponse{}, errorutils.ErrDataDataUpdated
+	}
+
+	// kelas default hanya berpindah dengan menjadikan kelas lain default
+	if taxClassDb.IsDefault && !req.IsDefault {
+		return response.TaxClassResponse{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageTaxClassDefault, constanta.FieldTaxClass)
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.TaxClassResponse{}, errorutils.ErrDataNotFound
+	}
+
+	taxClass := models.TaxClass{
+		ID:          req.ID,
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		IsDefault:   req.IsDefault,
+		CreatedAt:   taxClassDb.CreatedAt,
+		CreatedBy:   taxClassDb.CreatedBy,
+		UpdatedAt:   time.Now(),
+		UpdatedBy:   userID,
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		if req.IsDefault {
+			if err := uc.taxClassRepo.ClearDefaultTaxClass(ctx, req.ID); err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+		}
+
+		_, err := uc.taxClassRepo.UpdateTaxClassByID(ctx, req.ID, req.UpdatedAt, taxClass)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.taxClassRepo))
+		}
+
+		if err := uc.taxClassRepo.DeleteRateByTaxClassID(ctx, req.ID); err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+
+		return uc.saveRates(ctx, req.ID, req.Rates, userID)
+	})
+	if err != nil {
+		return response.TaxClassResponse{}, err
+	}
+
+	return uc.GetTaxClassByID(ctx, req.ID)
+}
+
+func (uc *taxUseCase) DeleteTaxClassByID(ctx context.Context, id int64, reqData request.AbstractRequest) error {
+	if err := reqData.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+
+	taxClass, err := uc.taxClassRepo.GetTaxClassByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(reqData.UpdatedAt, taxClass.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+
+	if taxClass.IsDefault {
+		return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageTaxClassDefault, constanta.FieldTaxClass)
+	}
+
+	usage, err := uc.taxClassRepo.CountTaxClassUsage(ctx, id)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+	if usage > 0 {
+		return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageTaxClassInUse, constanta.FieldTaxClass)
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		// tarif ikut terhapus (ON DELETE CASCADE)
+		err := uc.taxClassRepo.DeleteTaxClassByID(ctx, id, reqData.UpdatedAt)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+	})
+}
+
+func (uc *taxUseCase) saveRates(ctx context.Context, taxClassID int64, reqRates []request.ReqTaxRate, userID int64) error {
+	rates := make([]models.TaxRate, 0, len(reqRates))
+	for _, r := range reqRates {
+		rates = append(rates, models.TaxRate{
+			TaxClassID:    taxClassID,
+			Name:          r.Name,
+			Rate:          r.Rate,
+			EffectiveFrom: r.EffectiveFrom,
+			CreatedBy:     userID,
+			UpdatedBy:     userID,
+		})
+	}
+
+	if err := uc.taxClassRepo.CreateRateBulk(ctx, rates); err != nil {
+		return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.taxClassRepo))
+	}
+	return nil
+}
+
+// applyOrderTax menghitung pajak order dengan tarif yang berlaku pada at. Kelas pajak item diambil dari
+// produk, kategori terdekat lalu kelas default; tanpa kelas default item tidak dikenai pajak.
+// Nilai baris = LineTotal - DiscountAmount, sudah / belum termasuk pajak sesuai mode harga.
+func (uc *taxUseCase) applyOrderTax(ctx context.Context, order *models.Order, at time.Time) error {
+	productIDs := make([]int64, 0, len(order.Items))
+	for _, item := range order.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	productClasses, err := uc.taxClassRepo.GetProductTaxClass(ctx, productIDs)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	classOfProduct := make(map[int64]int64, len(productClasses))
+	var classIDs []int64
+	for _, pc := range productClasses {
+		if pc.TaxClassID != nil {
+			classOfProduct[pc.ProductID] = *pc.TaxClassID
+			classIDs = append(classIDs, *pc.TaxClassID)
+		}
+	}
+
+	classes := map[int64]models.TaxClass{}
+	if len(classIDs) > 0 {
+		taxClasses, err := uc.taxClassRepo.GetTaxClassByListIDs(ctx, classIDs)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		for _, tc := range taxClasses {
+			classes[tc.ID] = tc
+		}
+	}
+
+	var defaultClass *models.TaxClass
+	if len(classOfProduct) < len(order.Items) {
+		tc, err := uc.taxClassRepo.GetDefaultTaxClass(ctx)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if err == nil {
+			defaultClass = &tc
+		}
+	}
+
+	lines := make([]tax.Line, len(order.Items))
+	for i, item := range order.Items {
+		lines[i].Amount = item.LineTotal - item.DiscountAmount
+
+		taxClass, ok := classes[classOfProduct[item.ProductID]]
+		if !ok {
+			if defaultClass == nil {
+				continue
+			}
+			taxClass = *defaultClass
+		}
+
+		rate, ok := taxClass.ToClass().RateAt(at)
+		if !ok {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageTaxRateNotEffective, constanta.FieldTaxClass)
+		}
+		lines[i].Rate = rate
+	}
+
+	res := tax.Calculate(uc.cfg, lines)
+	for i := range order.Items {
+		item := &order.Items[i]
+		item.TaxClassCode = lines[i].Rate.ClassCode
+		item.TaxRate = lines[i].Rate.Percent
+		item.TaxableAmount = res.Lines[i].Net
+		item.TaxAmount = res.Lines[i].Tax
+	}
+
+	order.Taxes = nil
+	for _, b := range res.Breakdown {
+		// item tanpa kelas pajak tidak masuk rekap
+		if b.ClassID == 0 {
+			continue
+		}
+		order.Taxes = append(order.Taxes, models.OrderTax{
+			TaxClassID:    b.ClassID,
+			TaxClassCode:  b.ClassCode,
+			TaxClassName:  b.ClassName,
+			RateName:      b.RateName,
+			Rate:          b.Percent,
+			TaxableAmount: b.TaxableAmount,
+			TaxAmount:     b.TaxAmount,
+		})
+	}
+
+	order.TaxMode = uc.cfg.Mode
+	order.TaxTotal = res.TaxTotal
+	order.GrandTotal = utils.RoundTo2Digits(res.GrossTotal + order.ShippingTotal)
+	return nil
+}
+
+// validateTaxClass memastikan kelas pajak pada request produk / kategori ada di database
+func validateTaxClass(ctx context.Context, taxClassRepo repo.TaxClassRepository, id *int64) error {
+	if id == nil {
+		return nil
+	}
+
+	_, err := taxClassRepo.GetTaxClassByID(ctx, *id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageDataNotFound, constanta.FieldTaxClass)
+		}
+		return errorutils.HandleRepoError(ctx, err)
+	}
+	return nil
+}
@@ -2,12 +2,22 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/utils/errorutils"
+	"sort"
 
 	"gorm.io/gorm"
 )
 
 func processWithTx(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+    // sudah di dalam transaksi (mis. dipanggil dari proses order), ikut transaksi luar
+    if _, ok := ctx.Value(constanta.Tx).(*gorm.DB); ok {
+        return fn(ctx)
+    }
+
     tx := db.Begin()
     if tx.Error != nil {
         return tx.Error
@@ -37,3 +47,89 @@ func processWithTx(ctx context.Context, db *gorm.DB, fn func(ctx context.Context
     return tx.Commit().Error
 }
 
+// processWithSavepoint menjalankan fn di dalam savepoint transaksi ctx. Bila fn gagal hanya perubahan fn
+// yang dibatalkan sehingga transaksi tetap bisa dilanjutkan (mis. import per produk dalam satu batch)
+func processWithSavepoint(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	tx, ok := ctx.Value(constanta.Tx).(*gorm.DB)
+	if !ok {
+		return fn(ctx)
+	}
+
+	if err := tx.SavePoint(name).Error; err != nil {
+		return err
+	}
+
+	if err := fn(ctx); err != nil {
+		if rbErr := tx.RollbackTo(name).Error; rbErr != nil {
+			return rbErr
+		}
+		return err
+	}
+	return nil
+}
+
+
+// sanitizeListStruct membuang filter dan order yang tidak diizinkan (dipakai untuk endpoint publik)
+func sanitizeListStruct(listStruct *models.GetListStruct, allowedFilters map[string]bool, allowedOrder map[string]bool) *models.GetListStruct {
+	filters := make(map[string][2]interface{}, len(listStruct.Filters))
+	for k, v := range listStruct.Filters {
+		if allowedFilters[models.FilterField(k)] {
+			filters[k] = v
+		}
+	}
+
+	params := *listStruct
+	params.Filters = filters
+
+	if !allowedOrder[params.OrderBy] {
+		params.OrderBy = "id"
+	}
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 10
+	}
+
+	return &params
+}
+
+func mapKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func uniqueInt64s(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	res := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+// processWithSnapshot menjalankan fn di dalam transaksi read only REPEATABLE READ supaya query yang
+// dijalankan bertahap (mis. export per batch) membaca data pada titik waktu yang sama
+func processWithSnapshot(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, constanta.Tx, tx))
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
+// errorMessage pesan error untuk laporan per item (import / bulk), CustomError memakai pesannya saja
+func errorMessage(err error) string {
+	var customErr *errorutils.CustomError
+	if errors.As(err, &customErr) {
+		return customErr.Message
+	}
+	return err.Error()
+}
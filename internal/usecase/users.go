@@ -22,6 +22,7 @@ type UserUseCase interface {
 	GetUserByID(ctx context.Context, user int64) (response.UserResponse, error)
 	CreateUserDashboard(ctx context.Context, user *request.ReqUser) error
 	GetListUser(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.UserResponse], error)
+	ExportListUser(ctx context.Context, listStruct *models.GetListStruct, req *request.ReqExport) (response.FileStreamResponse, error)
 	UpdateUserByID(ctx context.Context, user *request.ReqUserUpdate) (response.UserResponse, error)
 	DeleteUserByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
 }
@@ -246,3 +247,13 @@ func (u *userUseCase) getDataRole(ctx context.Context, roleID int64) (res models
 	}
 	return
 }
+
+func (u *userUseCase) ExportListUser(ctx context.Context, listStruct *models.GetListStruct, req *request.ReqExport) (response.FileStreamResponse, error) {
+	return exportList(ctx, u.db, req, "user",
+		func(ctx context.Context, fn func([]models.User) error) error {
+			return u.UserRepo.ExportListUser(ctx, listStruct, fn)
+		},
+		func(ctx context.Context, users []models.User) ([]response.UserResponse, error) {
+			return response.SetResponseListUser(users), nil
+		})
+}
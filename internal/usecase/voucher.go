@@ -0,0 +1,608 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pleasurelove/internal/constanta"
+	"pleasurelove/internal/dto/request"
+	"pleasurelove/internal/dto/response"
+	"pleasurelove/internal/models"
+	"pleasurelove/internal/repo"
+	"pleasurelove/internal/utils"
+	"pleasurelove/internal/utils/errorutils"
+	"pleasurelove/pkg/logger"
+	"pleasurelove/pkg/promotion"
+	"pleasurelove/pkg/redis"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// counter Redis disinkron ulang dari database setelah TTL habis
+	voucherCounterTTL = 10 * time.Minute
+	// percobaan generate ulang bila kode acak bentrok dengan kode yang sudah ada
+	voucherGenerateAttempts = 5
+)
+
+type VoucherUseCase interface {
+	CreateVoucher(ctx context.Context, req *request.ReqVoucher) error
+	GetVoucherByID(ctx context.Context, id int64) (response.VoucherResponse, error)
+	GetListVoucher(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.VoucherResponse], error)
+	UpdateVoucherByID(ctx context.Context, req *request.ReqVoucherUpdate) (response.VoucherResponse, error)
+	DeleteVoucherByID(ctx context.Context, id int64, reqData request.AbstractRequest) error
+	GetListVoucherCode(ctx context.Context, voucherID int64, listStruct *models.GetListStruct) (response.ListResponse[response.VoucherCodeResponse], error)
+	CreateVoucherCode(ctx context.Context, req *request.ReqVoucherCode) error
+	GenerateVoucherCode(ctx context.Context, req *request.ReqVoucherGenerate) (response.VoucherGenerateResponse, error)
+	CheckVoucher(ctx context.Context, req *request.ReqVoucherCheck) (response.VoucherCheckResponse, error)
+	ApplyVoucher(ctx context.Context, code string, customerID int64, at time.Time, prices []models.ResolvedPrice) (promotion.Result, error)
+	RedeemVoucher(ctx context.Context, code string, customerID int64, reference string, discountAmount float64) (VoucherReservation, error)
+}
+
+type voucherUseCase struct {
+	db           *gorm.DB
+	voucherRepo  repo.VoucherRepository
+	productRepo  repo.ProductRepository
+	categoryRepo repo.CategoryRepository
+	pricingUC    PricingUseCase
+	promotionUC  PromotionUseCase
+}
+
+func NewVoucherUseCase(db *gorm.DB,
+	voucherRepo repo.VoucherRepository,
+	productRepo repo.ProductRepository,
+	categoryRepo repo.CategoryRepository,
+	pricingUC PricingUseCase,
+	promotionUC PromotionUseCase) VoucherUseCase {
+	return &voucherUseCase{
+		db:           db,
+		voucherRepo:  voucherRepo,
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+		pricingUC:    pricingUC,
+		promotionUC:  promotionUC,
+	}
+}
+
+func (uc *voucherUseCase) CreateVoucher(ctx context.Context, req *request.ReqVoucher) error {
+	if err := req.ValidateRequestCreate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return err
+	}
+
+	if err := uc.validateReferences(ctx, req); err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return errorutils.ErrDataNotFound
+	}
+
+	voucher := models.Voucher{
+		Name:             req.Name,
+		Description:      req.Description,
+		DiscountType:     req.DiscountType,
+		Value:            req.Value,
+		MaxDiscount:      req.MaxDiscount,
+		MinSpend:         req.MinSpend,
+		ProductIDs:       uniqueInt64s(req.ProductIDs),
+		CategoryIDs:      uniqueInt64s(req.CategoryIDs),
+		IsActive:         req.IsActive,
+		StartsAt:         req.StartsAt,
+		EndsAt:           req.EndsAt,
+		UsageLimit:       req.UsageLimit,
+		PerCustomerLimit: req.PerCustomerLimit,
+		CreatedBy:        userID,
+		UpdatedBy:        userID,
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		err := uc.voucherRepo.Create(ctx, &voucher)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.voucherRepo))
+		}
+
+		if req.Code == "" {
+			return nil
+		}
+
+		code := models.VoucherCode{
+			VoucherID: voucher.ID,
+			Code:      req.Code,
+			CreatedBy: userID,
+		}
+		if err := uc.voucherRepo.CreateCode(ctx, &code); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.voucherRepo))
+		}
+		return nil
+	})
+}
+
+func (uc *voucherUseCase) GetVoucherByID(ctx context.Context, id int64) (response.VoucherResponse, error) {
+	voucher, err := uc.voucherRepo.GetVoucherByID(ctx, id)
+	if err != nil {
+		return response.VoucherResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	return response.SetVoucherResponse(voucher), nil
+}
+
+func (uc *voucherUseCase) GetListVoucher(ctx context.Context, listStruct *models.GetListStruct) (response.ListResponse[response.VoucherResponse], error) {
+	vouchers, count, err := uc.voucherRepo.GetListVoucher(ctx, listStruct)
+	if err != nil {
+		return response.ListResponse[response.VoucherResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListVoucher(vouchers), count, listStruct, repo.GetFilterAvailableFromRepo(uc.voucherRepo))
+	return listResponse, nil
+}
+
+// UpdateVoucherByID mengubah aturan voucher, kode voucher dikelola lewat endpoint kode
+func (uc *voucherUseCase) UpdateVoucherByID(ctx context.Context, req *request.ReqVoucherUpdate) (response.VoucherResponse, error) {
+	if err := req.ValidateUpdatedAt(); err != nil {
+		return response.VoucherResponse{}, err
+	}
+
+	if err := req.ValidateRequestCreate(); err != nil {
+		return response.VoucherResponse{}, err
+	}
+
+	voucherDb, err := uc.voucherRepo.GetVoucherByID(ctx, req.ID)
+	if err != nil {
+		return response.VoucherResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(req.UpdatedAt, voucherDb.UpdatedAt) {
+		return response.VoucherResponse{}, errorutils.ErrDataDataUpdated
+	}
+
+	if err := uc.validateReferences(ctx, &req.ReqVoucher); err != nil {
+		return response.VoucherResponse{}, err
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.VoucherResponse{}, errorutils.ErrDataNotFound
+	}
+
+	voucher := models.Voucher{
+		ID:               req.ID,
+		Name:             req.Name,
+		Description:      req.Description,
+		DiscountType:     req.DiscountType,
+		Value:            req.Value,
+		MaxDiscount:      req.MaxDiscount,
+		MinSpend:         req.MinSpend,
+		ProductIDs:       uniqueInt64s(req.ProductIDs),
+		CategoryIDs:      uniqueInt64s(req.CategoryIDs),
+		IsActive:         req.IsActive,
+		StartsAt:         req.StartsAt,
+		EndsAt:           req.EndsAt,
+		UsageLimit:       req.UsageLimit,
+		PerCustomerLimit: req.PerCustomerLimit,
+		UsageCount:       voucherDb.UsageCount,
+		CreatedAt:        voucherDb.CreatedAt,
+		CreatedBy:        voucherDb.CreatedBy,
+		UpdatedAt:        time.Now(),
+		UpdatedBy:        userID,
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		_, err := uc.voucherRepo.UpdateVoucherByID(ctx, req.ID, req.UpdatedAt, voucher)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorutils.ErrDataDataUpdated
+			}
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.voucherRepo))
+		}
+		return nil
+	})
+	if err != nil {
+		return response.VoucherResponse{}, err
+	}
+
+	return response.SetVoucherResponse(voucher), nil
+}
+
+func (uc *voucherUseCase) DeleteVoucherByID(ctx context.Context, id int64, reqData request.AbstractRequest) error {
+	if err := reqData.ValidateUpdatedAt(); err != nil {
+		return err
+	}
+
+	voucher, err := uc.voucherRepo.GetVoucherByID(ctx, id)
+	if err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	if !utils.ValidateUpdatedAtRequest(reqData.UpdatedAt, voucher.UpdatedAt) {
+		return errorutils.ErrDataDataUpdated
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		// kode & riwayat redeem ikut terhapus (ON DELETE CASCADE)
+		err := uc.voucherRepo.DeleteVoucherByID(ctx, id, reqData.UpdatedAt)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		return nil
+	})
+}
+
+func (uc *voucherUseCase) GetListVoucherCode(ctx context.Context, voucherID int64, listStruct *models.GetListStruct) (response.ListResponse[response.VoucherCodeResponse], error) {
+	codes, count, err := uc.voucherRepo.GetListVoucherCode(ctx, voucherID, listStruct)
+	if err != nil {
+		return response.ListResponse[response.VoucherCodeResponse]{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	listResponse := response.MapToListResponse(response.SetResponseListVoucherCode(codes), count, listStruct, repo.GetFilterAvailableFromRepo(uc.voucherRepo))
+	return listResponse, nil
+}
+
+func (uc *voucherUseCase) CreateVoucherCode(ctx context.Context, req *request.ReqVoucherCode) error {
+	if err := req.ValidateRequestCreate(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return err
+	}
+
+	if _, err := uc.voucherRepo.GetVoucherByID(ctx, req.ID); err != nil {
+		return errorutils.HandleRepoError(ctx, err)
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return errorutils.ErrDataNotFound
+	}
+
+	code := models.VoucherCode{
+		VoucherID:      req.ID,
+		Code:           req.Code,
+		MaxRedemptions: req.MaxRedemptions,
+		CreatedBy:      userID,
+	}
+
+	return processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		if err := uc.voucherRepo.CreateCode(ctx, &code); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.voucherRepo))
+		}
+		return nil
+	})
+}
+
+// GenerateVoucherCode membuat kode unik sekali pakai (max_redemptions = 1) sebanyak quantity
+func (uc *voucherUseCase) GenerateVoucherCode(ctx context.Context, req *request.ReqVoucherGenerate) (response.VoucherGenerateResponse, error) {
+	if err := req.ValidateRequest(); err != nil {
+		logger.Error(ctx, "Failed to validate request", err)
+		return response.VoucherGenerateResponse{}, err
+	}
+
+	if _, err := uc.voucherRepo.GetVoucherByID(ctx, req.ID); err != nil {
+		return response.VoucherGenerateResponse{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	userID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to get user id from context", err)
+		return response.VoucherGenerateResponse{}, errorutils.ErrDataNotFound
+	}
+
+	res := response.VoucherGenerateResponse{
+		VoucherID: req.ID,
+		Codes:     make([]string, 0, req.Quantity),
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		for attempt := 0; attempt < voucherGenerateAttempts && len(res.Codes) < req.Quantity; attempt++ {
+			codes, err := generateVoucherCodes(req.Prefix, req.Length, req.Quantity-len(res.Codes))
+			if err != nil {
+				logger.Error(ctx, "Failed to generate voucher code", err)
+				return errorutils.ErrInternalServerError
+			}
+
+			inserted, err := uc.voucherRepo.CreateCodeBulkIgnoreDuplicate(ctx, req.ID, codes, 1, userID)
+			if err != nil {
+				return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.voucherRepo))
+			}
+			res.Codes = append(res.Codes, inserted...)
+		}
+
+		if len(res.Codes) < req.Quantity {
+			return fmt.Errorf("hanya %d dari %d kode unik yang berhasil dibuat, gunakan length yang lebih panjang", len(res.Codes), req.Quantity)
+		}
+		return nil
+	})
+	if err != nil {
+		return response.VoucherGenerateResponse{}, err
+	}
+
+	res.Generated = len(res.Codes)
+	return res, nil
+}
+
+// CheckVoucher simulasi voucher terhadap keranjang pelanggan (customer_id kosong = guest)
+func (uc *voucherUseCase) CheckVoucher(ctx context.Context, req *request.ReqVoucherCheck) (response.VoucherCheckResponse, error) {
+	quote, err := uc.pricingUC.QuotePrice(ctx, &req.ReqPriceQuote)
+	if err != nil {
+		return response.VoucherCheckResponse{}, err
+	}
+
+	prices := make([]models.ResolvedPrice, 0, len(quote.Items))
+	for _, item := range quote.Items {
+		prices = append(prices, item.ResolvedPrice)
+	}
+
+	result, err := uc.ApplyVoucher(ctx, req.Code, req.CustomerID, quote.At, prices)
+	if err != nil {
+		return response.VoucherCheckResponse{}, err
+	}
+
+	res := response.VoucherCheckResponse{
+		PromotionEvaluateResponse: response.PromotionEvaluateResponse{
+			At:     quote.At,
+			Items:  quote.Items,
+			Result: result,
+		},
+	}
+	for _, applied := range result.Applied {
+		if applied.Source == promotion.SourceVoucher {
+			res.Code = applied.RuleCode
+			res.VoucherID = applied.RuleID
+			res.VoucherName = applied.RuleName
+			res.VoucherDiscount = applied.Amount
+		}
+	}
+
+	return res, nil
+}
+
+// ApplyVoucher mengevaluasi promosi otomatis beserta voucher, error bila voucher tidak bisa dipakai
+func (uc *voucherUseCase) ApplyVoucher(ctx context.Context, code string, customerID int64, at time.Time, prices []models.ResolvedPrice) (promotion.Result, error) {
+	voucherCode, err := uc.getActiveVoucherCode(ctx, code)
+	if err != nil {
+		return promotion.Result{}, err
+	}
+	voucher := *voucherCode.Voucher
+
+	if voucherCode.MaxRedemptions > 0 && voucherCode.RedeemedCount >= voucherCode.MaxRedemptions {
+		return promotion.Result{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageVoucherUsed, constanta.FieldVoucher)
+	}
+
+	var customerUsage int
+	if voucher.PerCustomerLimit > 0 {
+		if customerID == 0 {
+			return promotion.Result{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageVoucherGuest, constanta.FieldVoucher)
+		}
+		customerUsage, err = uc.voucherRepo.CountRedemptionByCustomerID(ctx, voucher.ID, customerID)
+		if err != nil {
+			return promotion.Result{}, errorutils.HandleRepoError(ctx, err)
+		}
+	}
+
+	result, err := uc.promotionUC.ApplyPromotions(ctx, customerID, at, prices, voucher.ToRule(voucherCode.Code, customerUsage))
+	if err != nil {
+		return promotion.Result{}, err
+	}
+
+	for _, applied := range result.Applied {
+		if applied.Source == promotion.SourceVoucher {
+			return result, nil
+		}
+	}
+	for _, skipped := range result.Skipped {
+		if skipped.Source == promotion.SourceVoucher {
+			return promotion.Result{}, errorutils.HandleCustomError(ctx, nil, "voucher tidak bisa digunakan: "+skipped.Reason, constanta.FieldVoucher)
+		}
+	}
+	return promotion.Result{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageVoucherInvalid, constanta.FieldVoucher)
+}
+
+// RedeemVoucher mencatat pemakaian voucher secara atomik. Kuota dipesan dulu lewat counter Redis
+// (menahan lonjakan request bersamaan), lalu dikunci di database lewat update bersyarat,
+// check constraint dan unique index pemakaian per pelanggan. Bila ikut transaksi luar, pemanggil
+// wajib melepas reservasi yang dikembalikan saat transaksi luar gagal.
+func (uc *voucherUseCase) RedeemVoucher(ctx context.Context, code string, customerID int64, reference string, discountAmount float64) (VoucherReservation, error) {
+	voucherCode, err := uc.getActiveVoucherCode(ctx, code)
+	if err != nil {
+		return VoucherReservation{}, err
+	}
+	voucher := *voucherCode.Voucher
+
+	if voucher.PerCustomerLimit > 0 && customerID == 0 {
+		return VoucherReservation{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageVoucherGuest, constanta.FieldVoucher)
+	}
+
+	var customerUsage int
+	if customerID != 0 {
+		customerUsage, err = uc.voucherRepo.CountRedemptionByCustomerID(ctx, voucher.ID, customerID)
+		if err != nil {
+			return VoucherReservation{}, errorutils.HandleRepoError(ctx, err)
+		}
+	}
+
+	counters := []voucherCounter{
+		{key: fmt.Sprintf("voucher:usage:%d", voucher.ID), limit: voucher.UsageLimit, current: voucher.UsageCount, message: errorutils.ErrMessageVoucherQuota},
+		{key: fmt.Sprintf("voucher:code:%d", voucherCode.ID), limit: voucherCode.MaxRedemptions, current: voucherCode.RedeemedCount, message: errorutils.ErrMessageVoucherUsed},
+	}
+	if customerID != 0 {
+		counters = append(counters, voucherCounter{
+			key:     fmt.Sprintf("voucher:customer:%d:%d", voucher.ID, customerID),
+			limit:   voucher.PerCustomerLimit,
+			current: customerUsage,
+			message: errorutils.ErrMessageVoucherCustomerLimit,
+		})
+	}
+
+	reserved, err := reserveVoucherCounters(ctx, counters)
+	if err != nil {
+		return VoucherReservation{}, err
+	}
+
+	err = processWithTx(ctx, uc.db, func(ctx context.Context) error {
+		ok, err := uc.voucherRepo.IncrementUsageCount(ctx, voucher.ID)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.voucherRepo))
+		}
+		if !ok {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageVoucherQuota, constanta.FieldVoucher)
+		}
+
+		ok, err = uc.voucherRepo.IncrementCodeRedeemedCount(ctx, voucherCode.ID)
+		if err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.voucherRepo))
+		}
+		if !ok {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageVoucherUsed, constanta.FieldVoucher)
+		}
+
+		redemption := models.VoucherRedemption{
+			VoucherID:      voucher.ID,
+			VoucherCodeID:  voucherCode.ID,
+			Reference:      reference,
+			DiscountAmount: utils.RoundTo2Digits(discountAmount),
+			RedeemedAt:     time.Now(),
+		}
+		if customerID != 0 {
+			count, err := uc.voucherRepo.CountRedemptionByCustomerID(ctx, voucher.ID, customerID)
+			if err != nil {
+				return errorutils.HandleRepoError(ctx, err)
+			}
+			if voucher.PerCustomerLimit > 0 && count >= voucher.PerCustomerLimit {
+				return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageVoucherCustomerLimit, constanta.FieldVoucher)
+			}
+
+			// redeem bersamaan oleh pelanggan yang sama mendapat seq yang sama dan ditolak unique index
+			seq := count + 1
+			redemption.CustomerID = &customerID
+			redemption.CustomerSeq = &seq
+		}
+
+		if err := uc.voucherRepo.CreateRedemption(ctx, &redemption); err != nil {
+			return errorutils.HandleRepoErrorWrite(ctx, err, repo.GetContraintErrMessage(uc.voucherRepo))
+		}
+		return nil
+	})
+	if err != nil {
+		releaseVoucherCounters(ctx, reserved)
+		return VoucherReservation{}, err
+	}
+
+	return VoucherReservation{counters: reserved}, nil
+}
+
+func (uc *voucherUseCase) getActiveVoucherCode(ctx context.Context, code string) (models.VoucherCode, error) {
+	code, err := request.NormalizeVoucherCode(code)
+	if err != nil {
+		return models.VoucherCode{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageVoucherInvalid, constanta.FieldVoucher)
+	}
+
+	voucherCode, err := uc.voucherRepo.GetVoucherCodeByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.VoucherCode{}, errorutils.HandleCustomError(ctx, err, errorutils.ErrMessageVoucherInvalid, constanta.FieldVoucher)
+		}
+		return models.VoucherCode{}, errorutils.HandleRepoError(ctx, err)
+	}
+
+	if voucherCode.Voucher == nil || !voucherCode.Voucher.IsActive {
+		return models.VoucherCode{}, errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageVoucherInvalid, constanta.FieldVoucher)
+	}
+
+	return voucherCode, nil
+}
+
+// validateReferences memastikan produk dan kategori eligible ada di database
+func (uc *voucherUseCase) validateReferences(ctx context.Context, req *request.ReqVoucher) error {
+	productIDs := uniqueInt64s(req.ProductIDs)
+	if len(productIDs) > 0 {
+		products, err := uc.productRepo.GetProductByListIDs(ctx, productIDs)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if len(products) != len(productIDs) {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldProduct)
+		}
+	}
+
+	categoryIDs := uniqueInt64s(req.CategoryIDs)
+	if len(categoryIDs) > 0 {
+		categories, err := uc.categoryRepo.GetCategoryByListIDs(ctx, categoryIDs)
+		if err != nil {
+			return errorutils.HandleRepoError(ctx, err)
+		}
+		if len(categories) != len(categoryIDs) {
+			return errorutils.HandleCustomError(ctx, nil, errorutils.ErrMessageDataNotFound, constanta.FieldCategory)
+		}
+	}
+
+	return nil
+}
+
+type voucherCounter struct {
+	key     string
+	limit   int
+	current int
+	message string
+}
+
+// VoucherReservation kuota voucher yang sudah dipesan di Redis oleh RedeemVoucher
+type VoucherReservation struct {
+	counters []voucherCounter
+}
+
+// Release mengembalikan kuota, dipanggil bila transaksi luar yang memuat redeem gagal
+func (r VoucherReservation) Release(ctx context.Context) {
+	releaseVoucherCounters(ctx, r.counters)
+}
+
+// reserveVoucherCounters memesan kuota di Redis untuk counter yang punya batas. Bila Redis
+// bermasalah, pemesanan dilewati dan batas tetap dijaga constraint database.
+func reserveVoucherCounters(ctx context.Context, counters []voucherCounter) ([]voucherCounter, error) {
+	var reserved []voucherCounter
+	for _, c := range counters {
+		if c.limit <= 0 {
+			continue
+		}
+
+		ok, err := redis.IncrWithLimit(ctx, c.key, int64(c.limit), int64(c.current), voucherCounterTTL)
+		if err != nil {
+			logger.Error(ctx, "Failed to reserve voucher counter, fallback to database constraint", err)
+			continue
+		}
+		if !ok {
+			releaseVoucherCounters(ctx, reserved)
+			return nil, errorutils.HandleCustomError(ctx, nil, c.message, constanta.FieldVoucher)
+		}
+		reserved = append(reserved, c)
+	}
+	return reserved, nil
+}
+
+func releaseVoucherCounters(ctx context.Context, counters []voucherCounter) {
+	for _, c := range counters {
+		if err := redis.DecrCounter(ctx, c.key); err != nil {
+			logger.Error(ctx, "Failed to release voucher counter", err)
+		}
+	}
+}
+
+func generateVoucherCodes(prefix string, length, quantity int) ([]string, error) {
+	seen := make(map[string]bool, quantity)
+	codes := make([]string, 0, quantity)
+	for len(codes) < quantity {
+		random, err := utils.GenerateRandomCode(length)
+		if err != nil {
+			return nil, err
+		}
+		code := prefix + random
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
@@ -26,6 +26,59 @@ const (
 	ErrMessaageDataRequired         = "data tidak boleh kosong"
 	ErrMessageUserNotLogin          = "silahkan login terlebih dahulu"
 	ErrMessageInternalServerError   = "terjadi kesalahan pada server, silahkan hubungi admin"
+	ErrMessageCategoryCycle         = "kategori tidak boleh dipindah ke dalam sub kategorinya sendiri"
+	ErrMessageCategoryHasChildren   = "kategori masih memiliki sub kategori"
+	ErrMessageCategoryFilter        = "filter category_id hanya mendukung operator = dan in"
+	ErrMessageScheduleNotPending    = "jadwal harga sudah diterapkan atau dibatalkan"
+	ErrMessagePromotionQuota        = "kuota promosi sudah habis"
+	ErrMessageVoucherInvalid        = "kode voucher tidak valid atau tidak aktif"
+	ErrMessageVoucherUsed           = "kode voucher sudah digunakan"
+	ErrMessageVoucherQuota          = "kuota voucher sudah habis"
+	ErrMessageVoucherCustomerLimit  = "batas pemakaian voucher per pelanggan sudah tercapai"
+	ErrMessageVoucherGuest          = "voucher hanya bisa digunakan pelanggan terdaftar"
+	ErrMessageStockInsufficient     = "stok tidak mencukupi"
+	ErrMessageStockQuantity         = "quantity minimal 1"
+	ErrMessageStockBundle           = "stok produk bundle mengikuti stok komponen"
+	ErrMessageStockVarian           = "stok produk bervarian disimpan per varian"
+	ErrMessageBundleComponent       = "komponen bundle tidak valid"
+	ErrMessageBundleSelf            = "bundle tidak boleh berisi dirinya sendiri"
+	ErrMessageBundleNested          = "komponen bundle tidak boleh berupa bundle"
+	ErrMessageBundleVarianRequired  = "varian komponen wajib dipilih untuk produk bervarian"
+	ErrMessageProductTypeChanged    = "product_type tidak bisa diubah"
+	ErrMessageProductInactive       = "produk tidak aktif"
+	ErrMessageVarianInactive        = "varian produk tidak aktif"
+	ErrMessageVarianRequired        = "varian produk wajib dipilih"
+	ErrMessageCartFull              = "jumlah produk di keranjang sudah maksimal"
+	ErrMessageCartEmpty             = "keranjang belanja kosong"
+	ErrMessageCheckoutContact       = "nama, email dan nomor telepon pemesan wajib diisi"
+	ErrMessageCheckoutCartChanged   = "isi keranjang berubah saat checkout, silakan ulangi checkout"
+	ErrMessageAddressFull           = "jumlah alamat sudah maksimal"
+	ErrMessageAddressDefault        = "pilih alamat lain sebagai alamat utama"
+	ErrMessageRegionNotFound        = "wilayah tidak ditemukan"
+	ErrMessageRegionMismatch        = "provinsi, kota, kecamatan dan kelurahan tidak sesuai"
+	ErrMessagePostalCodeMismatch    = "kode pos tidak sesuai dengan wilayah"
+	ErrMessagePostalCodeRequired    = "kode pos wajib diisi"
+	ErrMessageRegionNameRequired    = "wilayah belum tersedia di data wilayah, isi nama kota (city) dan kecamatan (subdistrict)"
+	ErrMessageShippingUnavailable   = "layanan pengiriman tidak tersedia ke alamat tujuan"
+	ErrMessageShippingService       = "layanan pengiriman yang dipilih tidak tersedia"
+	ErrMessageShipmentStatus        = "pengiriman hanya bisa dibuat untuk order berstatus processing"
+	ErrMessageShipmentExists        = "order sudah memiliki pengiriman"
+	ErrMessageShippingProvider      = "layanan pengiriman sedang tidak dapat dihubungi, coba beberapa saat lagi"
+	ErrMessagePaymentOrderStatus    = "order tidak sedang menunggu pembayaran"
+	ErrMessagePaymentMethod         = "metode pembayaran tidak tersedia"
+	ErrMessagePaymentProvider       = "layanan pembayaran sedang tidak dapat dihubungi, coba beberapa saat lagi"
+	ErrMessagePaymentPending        = "order masih memiliki pembayaran lain yang belum selesai"
+	ErrMessagePaymentNotPending     = "pembayaran sudah diproses atau kedaluwarsa"
+	ErrMessagePaymentNotManual      = "hanya pembayaran transfer manual yang dikonfirmasi staf"
+	ErrMessagePaymentProofRequired  = "bukti transfer belum diunggah"
+	ErrMessagePaymentRefund         = "nominal refund melebihi dana yang bisa dikembalikan"
+	ErrMessagePaymentNotification   = "notifikasi pembayaran tidak valid"
+	ErrMessageTaxClassInUse         = "kelas pajak masih digunakan produk / kategori"
+	ErrMessageTaxClassDefault       = "kelas pajak default tidak bisa dihapus"
+	ErrMessageTaxRateNotEffective   = "belum ada tarif pajak yang berlaku"
+	ErrMessageInvoiceOrderStatus    = "order belum dibayar, invoice belum bisa diterbitkan"
+	ErrMessageInvoiceFormat         = "format dokumen tidak dikenali (pdf, escpos58, escpos80)"
+	ErrMessageInvoiceNotConfigured  = "identitas perusahaan pada invoice belum diatur (INVOICE_COMPANY_NAME)"
 )
 
 var (
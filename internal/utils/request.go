@@ -42,7 +42,6 @@ func GetFiltersAndPagination(c *fiber.Ctx) *models.GetListStruct {
 
 		// Pisahkan field dan operator (contoh: age__gte=18)
 		parts := strings.Split(k, "__")
-		field := parts[0]
 		operator := "=" // Default operator
 
 		if len(parts) > 1 {
@@ -81,7 +80,8 @@ func GetFiltersAndPagination(c *fiber.Ctx) *models.GetListStruct {
 			value = v
 		}
 
-		filters[field] = [2]interface{}{operator, value}
+		// key tetap memakai operatornya supaya price__gte dan price__lte bisa dipakai bersamaan
+		filters[k] = [2]interface{}{operator, value}
 	}
 
 	// Ambil pagination dengan default
@@ -101,6 +101,15 @@ func GetFiltersAndPagination(c *fiber.Ctx) *models.GetListStruct {
 	}
 }
 
+// GetFiltersForExport filter dan urutan sama dengan GetFiltersAndPagination, parameter export
+// (format, fields) tidak ikut menjadi filter
+func GetFiltersForExport(c *fiber.Ctx) *models.GetListStruct {
+	listStruct := GetFiltersAndPagination(c)
+	delete(listStruct.Filters, "format")
+	delete(listStruct.Filters, "fields")
+	return listStruct
+}
+
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	return string(bytes), err
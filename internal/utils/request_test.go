@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"pleasurelove/internal/models"
+	"reflect"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestGetFiltersAndPagination(t *testing.T) {
+	var listStruct *models.GetListStruct
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		listStruct = GetFiltersAndPagination(c)
+		return nil
+	})
+
+	_, err := app.Test(httptest.NewRequest("GET", "/?price__gte=50000&price__lte=150000&brand=Kaos&name__like=polos&category_id__in=1,2&page=2&limit=20&sort__x=1", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][2]interface{}{
+		"price__gte":      {">=", 50000},
+		"price__lte":      {"<=", 150000},
+		"brand":           {"=", "Kaos"},
+		"name__like":      {"LIKE", "%polos%"},
+		"category_id__in": {"IN", []string{"1", "2"}},
+	}
+	if !reflect.DeepEqual(listStruct.Filters, want) {
+		t.Errorf("Filters = %v, want %v", listStruct.Filters, want)
+	}
+	if listStruct.Page != 2 || listStruct.Limit != 20 {
+		t.Errorf("page / limit = %d / %d, want 2 / 20", listStruct.Page, listStruct.Limit)
+	}
+}
@@ -2,8 +2,11 @@ package utils
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
+	"math/big"
 	"pleasurelove/internal/constanta"
+	"pleasurelove/pkg/redis"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -25,6 +28,41 @@ func GetUserIDFromCtx(ctx context.Context) (int64, error) {
 	return userID.(int64), nil
 }
 
+// guestTokenRevokedPrefix guest token yang sudah tidak berlaku (guest sudah login) disimpan
+// di Redis sampai waktu expired token
+const guestTokenRevokedPrefix = "guest_token_revoked:"
+
+func RevokeGuestToken(ctx context.Context, claims GuestClaims) error {
+	if claims.ExpiresAt == nil {
+		return nil
+	}
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+	return redis.SetToRedisWithTTL(ctx, guestTokenRevokedPrefix+claims.GuestID, true, ttl)
+}
+
+func IsGuestTokenRevoked(ctx context.Context, guestID string) (bool, error) {
+	value, err := redis.GetFromRedis(ctx, guestTokenRevokedPrefix+guestID)
+	if err != nil {
+		return false, err
+	}
+	return value != "", nil
+}
+
+// GetGuestIDFromCtx guest id dari header guest token, kosong bila bukan guest
+func GetGuestIDFromCtx(ctx context.Context) string {
+	guestID, _ := ctx.Value(constanta.AuthGuestID).(string)
+	return guestID
+}
+
+// GetGuestTokenFromCtx guest token mentah dari header, dipakai untuk mencari customer guest
+func GetGuestTokenFromCtx(ctx context.Context) string {
+	guestToken, _ := ctx.Value(constanta.AuthGuestToken).(string)
+	return guestToken
+}
+
 func GetContext(c *fiber.Ctx) context.Context {
 	return c.UserContext()
 }
@@ -67,10 +105,45 @@ func GenerateGuestToken(email, phone, source string) (string, error) {
 		return "", errors.New("guest secret key not found in environment variables")
 	}
 
-	signedToken, err := token.SignedString(guestSecret)
+	signedToken, err := token.SignedString([]byte(guestSecret))
 	if err != nil {
 		return "", err
 	}
 
 	return signedToken, nil
 }
+
+// ParseGuestToken validasi guest token (signature & expired) dan ambil claims-nya
+func ParseGuestToken(tokenString string) (GuestClaims, error) {
+	var claims GuestClaims
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(GetOSEnvGuestSecretKey()), nil
+	})
+	if err != nil {
+		return GuestClaims{}, err
+	}
+	if !token.Valid || claims.GuestID == "" {
+		return GuestClaims{}, errors.New("invalid guest token")
+	}
+	return claims, nil
+}
+
+// alphabet kode acak tanpa karakter yang mirip (0/O, 1/I/L)
+const randomCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// GenerateRandomCode membuat kode acak uppercase (crypto/rand) sepanjang length
+func GenerateRandomCode(length int) (string, error) {
+	code := make([]byte, length)
+	max := big.NewInt(int64(len(randomCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = randomCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
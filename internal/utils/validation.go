@@ -126,6 +126,19 @@ func RoundTo2Digits(value float64) float64 {
 	return math.Round(value*100) / 100
 }
 
+// PriceAfterDiscount menghitung harga jual setelah diskon persen
+func PriceAfterDiscount(price, discount float64) float64 {
+	return RoundTo2Digits(price - (price * discount / 100))
+}
+
+func ValidatePostalCode(postalCode string) error {
+	re := regexp.MustCompile(constanta.PostalCodeRegex)
+	if !re.MatchString(strings.TrimSpace(postalCode)) {
+		return errors.New("format kode pos tidak valid (5 digit angka)")
+	}
+	return nil
+}
+
 func ValidatePhone(phone string) error {
 	phone = strings.TrimSpace(phone)
 
@@ -0,0 +1,24 @@
+-- +migrate Up
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id BIGINT;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS depth INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS path VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS sort_order INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE categories ADD CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories (id) ON DELETE RESTRICT;
+ALTER TABLE categories ADD CONSTRAINT chk_categories_parent_not_self CHECK (parent_id IS NULL OR parent_id <> id);
+
+-- path berisi id leluhur sampai dirinya sendiri, contoh: /1/5/12/
+UPDATE categories SET path = '/' || id || '/', depth = 0 WHERE path = '';
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+CREATE INDEX IF NOT EXISTS idx_categories_path ON categories (path varchar_pattern_ops);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_categories_path;
+DROP INDEX IF EXISTS idx_categories_parent_id;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS chk_categories_parent_not_self;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS fk_categories_parent;
+ALTER TABLE categories DROP COLUMN IF EXISTS sort_order;
+ALTER TABLE categories DROP COLUMN IF EXISTS path;
+ALTER TABLE categories DROP COLUMN IF EXISTS depth;
+ALTER TABLE categories DROP COLUMN IF EXISTS parent_id;
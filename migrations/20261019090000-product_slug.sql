@@ -0,0 +1,16 @@
+-- +migrate Up
+ALTER TABLE product ADD COLUMN IF NOT EXISTS slug VARCHAR;
+
+-- slug lama dibentuk dari nama + kode agar tetap unik
+UPDATE product
+SET slug = trim(both '-' from lower(regexp_replace(name || '-' || code, '[^a-zA-Z0-9]+', '-', 'g')))
+WHERE slug IS NULL OR slug = '';
+
+ALTER TABLE product ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_slug ON product (slug);
+CREATE INDEX IF NOT EXISTS idx_product_is_active ON product (is_active);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_product_is_active;
+DROP INDEX IF EXISTS idx_product_slug;
+ALTER TABLE product DROP COLUMN IF EXISTS slug;
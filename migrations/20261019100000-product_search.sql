@@ -0,0 +1,103 @@
+-- +migrate Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE product ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+-- search_vector dibentuk dari nama (A), brand (B), nama kategori (C) dan deskripsi (D)
+-- memakai stemming bahasa Indonesia dan Inggris sekaligus
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION product_search_vector(p_id BIGINT, p_name TEXT, p_brand TEXT, p_description TEXT)
+RETURNS tsvector AS $$
+DECLARE
+    category_names TEXT;
+BEGIN
+    SELECT string_agg(c.name, ' ') INTO category_names
+    FROM product_categories pc
+    JOIN categories c ON c.id = pc.categories_id
+    WHERE pc.product_id = p_id;
+
+    RETURN
+        setweight(to_tsvector('indonesian', coalesce(p_name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(p_name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(p_brand, '')), 'B') ||
+        setweight(to_tsvector('indonesian', coalesce(category_names, '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(category_names, '')), 'C') ||
+        setweight(to_tsvector('indonesian', coalesce(p_description, '')), 'D') ||
+        setweight(to_tsvector('english', coalesce(p_description, '')), 'D');
+END;
+$$ LANGUAGE plpgsql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION product_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := product_search_vector(NEW.id, NEW.name, NEW.brand, NEW.description);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION product_categories_search_vector_trigger() RETURNS trigger AS $$
+DECLARE
+    affected_product_id BIGINT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        affected_product_id := OLD.product_id;
+    ELSE
+        affected_product_id := NEW.product_id;
+    END IF;
+
+    UPDATE product p
+    SET search_vector = product_search_vector(p.id, p.name, p.brand, p.description)
+    WHERE p.id = affected_product_id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION categories_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    IF NEW.name IS DISTINCT FROM OLD.name THEN
+        UPDATE product p
+        SET search_vector = product_search_vector(p.id, p.name, p.brand, p.description)
+        WHERE p.id IN (SELECT product_id FROM product_categories WHERE categories_id = NEW.id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+DROP TRIGGER IF EXISTS trg_product_search_vector ON product;
+CREATE TRIGGER trg_product_search_vector
+    BEFORE INSERT OR UPDATE OF name, brand, description ON product
+    FOR EACH ROW EXECUTE FUNCTION product_search_vector_trigger();
+
+DROP TRIGGER IF EXISTS trg_product_categories_search_vector ON product_categories;
+CREATE TRIGGER trg_product_categories_search_vector
+    AFTER INSERT OR UPDATE OR DELETE ON product_categories
+    FOR EACH ROW EXECUTE FUNCTION product_categories_search_vector_trigger();
+
+DROP TRIGGER IF EXISTS trg_categories_search_vector ON categories;
+CREATE TRIGGER trg_categories_search_vector
+    AFTER UPDATE OF name ON categories
+    FOR EACH ROW EXECUTE FUNCTION categories_search_vector_trigger();
+
+UPDATE product SET search_vector = product_search_vector(id, name, brand, description);
+
+CREATE INDEX IF NOT EXISTS idx_product_search_vector ON product USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_product_name_trgm ON product USING GIN (name gin_trgm_ops);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_product_name_trgm;
+DROP INDEX IF EXISTS idx_product_search_vector;
+DROP TRIGGER IF EXISTS trg_categories_search_vector ON categories;
+DROP TRIGGER IF EXISTS trg_product_categories_search_vector ON product_categories;
+DROP TRIGGER IF EXISTS trg_product_search_vector ON product;
+DROP FUNCTION IF EXISTS categories_search_vector_trigger();
+DROP FUNCTION IF EXISTS product_categories_search_vector_trigger();
+DROP FUNCTION IF EXISTS product_search_vector_trigger();
+DROP FUNCTION IF EXISTS product_search_vector(BIGINT, TEXT, TEXT, TEXT);
+ALTER TABLE product DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,94 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS customer_group (
+    id bigserial NOT NULL,
+    code VARCHAR NOT NULL,
+    name VARCHAR NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT customer_group_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_customer_group_code UNIQUE (code)
+);
+
+ALTER TABLE customer ADD COLUMN IF NOT EXISTS customer_group_id BIGINT;
+ALTER TABLE customer ADD CONSTRAINT fk_customer_customer_group FOREIGN KEY (customer_group_id) REFERENCES customer_group (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_customer_customer_group_id ON customer (customer_group_id);
+
+-- price list: retail (default), reseller, grosir, dst
+CREATE TABLE IF NOT EXISTS price_list (
+    id bigserial NOT NULL,
+    code VARCHAR NOT NULL,
+    name VARCHAR NOT NULL,
+    description TEXT,
+    priority INTEGER NOT NULL DEFAULT 0, -- makin besar makin diutamakan
+    is_default BOOLEAN NOT NULL DEFAULT FALSE, -- berlaku untuk semua pelanggan (termasuk guest)
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    valid_from TIMESTAMP,
+    valid_to TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT price_list_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_price_list_code UNIQUE (code),
+    CONSTRAINT chk_price_list_validity CHECK (valid_to IS NULL OR valid_from IS NULL OR valid_to > valid_from)
+);
+
+-- price list di-assign ke pelanggan tertentu atau ke grup pelanggan
+CREATE TABLE IF NOT EXISTS price_list_assignment (
+    id bigserial NOT NULL,
+    price_list_id BIGINT NOT NULL REFERENCES price_list(id) ON DELETE CASCADE,
+    customer_id BIGINT REFERENCES customer(id) ON DELETE CASCADE,
+    customer_group_id BIGINT REFERENCES customer_group(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT price_list_assignment_pkey PRIMARY KEY (id),
+    CONSTRAINT chk_price_list_assignment_target CHECK ((customer_id IS NULL) <> (customer_group_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_price_list_assignment_customer ON price_list_assignment (price_list_id, customer_id) WHERE customer_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_price_list_assignment_group ON price_list_assignment (price_list_id, customer_group_id) WHERE customer_group_id IS NOT NULL;
+
+-- harga per produk / varian dengan tier minimal qty
+CREATE TABLE IF NOT EXISTS price_list_item (
+    id bigserial NOT NULL,
+    price_list_id BIGINT NOT NULL REFERENCES price_list(id) ON DELETE CASCADE,
+    product_id BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    product_varian_id BIGINT REFERENCES product_varian(id) ON DELETE CASCADE, -- NULL = berlaku untuk semua varian
+    min_qty INTEGER NOT NULL DEFAULT 1,
+    price NUMERIC(12, 2) NOT NULL,
+    valid_from TIMESTAMP,
+    valid_to TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT price_list_item_pkey PRIMARY KEY (id),
+    CONSTRAINT chk_price_list_item_min_qty CHECK (min_qty >= 1),
+    CONSTRAINT chk_price_list_item_price CHECK (price >= 0),
+    CONSTRAINT chk_price_list_item_validity CHECK (valid_to IS NULL OR valid_from IS NULL OR valid_to > valid_from)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_price_list_item_tier ON price_list_item (price_list_id, product_id, COALESCE(product_varian_id, 0), min_qty, COALESCE(valid_from, '-infinity'::timestamp));
+CREATE INDEX IF NOT EXISTS idx_price_list_item_product ON price_list_item (product_id, product_varian_id);
+
+INSERT INTO permissions (code, name, group_menu, action, created_by, updated_by) VALUES
+('price_list:create', 'Permission to create price list data (price_list-create)', 'price_list', 'create', 1, 1),
+('price_list:read', 'Permission to read price list data (price_list-read)', 'price_list', 'read', 1, 1),
+('price_list:update', 'Permission to update price list data (price_list-update)', 'price_list', 'update', 1, 1),
+('price_list:delete', 'Permission to delete price list data (price_list-delete)', 'price_list', 'delete', 1, 1);
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permissions_id IN (SELECT id FROM permissions WHERE group_menu = 'price_list');
+DELETE FROM permissions WHERE group_menu = 'price_list';
+DROP TABLE IF EXISTS price_list_item;
+DROP TABLE IF EXISTS price_list_assignment;
+DROP TABLE IF EXISTS price_list;
+DROP INDEX IF EXISTS idx_customer_customer_group_id;
+ALTER TABLE customer DROP CONSTRAINT IF EXISTS fk_customer_customer_group;
+ALTER TABLE customer DROP COLUMN IF EXISTS customer_group_id;
+DROP TABLE IF EXISTS customer_group;
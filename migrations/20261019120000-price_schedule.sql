@@ -0,0 +1,55 @@
+-- +migrate Up
+-- perubahan harga terjadwal, diterapkan scheduler saat effective_at tercapai
+CREATE TABLE IF NOT EXISTS price_change_schedule (
+    id bigserial NOT NULL,
+    product_id BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    product_varian_id BIGINT REFERENCES product_varian(id) ON DELETE CASCADE, -- NULL = harga produk
+    price NUMERIC(12, 2) NOT NULL,
+    cost_price NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    discount NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    effective_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    applied_at TIMESTAMP,
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT price_change_schedule_pkey PRIMARY KEY (id),
+    CONSTRAINT chk_price_change_schedule_status CHECK (status IN ('pending', 'applied', 'cancelled')),
+    CONSTRAINT chk_price_change_schedule_price CHECK (price >= 0 AND cost_price >= 0 AND discount BETWEEN 0 AND 100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_change_schedule_due ON price_change_schedule (effective_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_price_change_schedule_product ON price_change_schedule (product_id, product_varian_id);
+
+-- riwayat harga, hanya insert (tidak pernah diubah)
+CREATE TABLE IF NOT EXISTS price_history (
+    id bigserial NOT NULL,
+    product_id BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    product_varian_id BIGINT REFERENCES product_varian(id) ON DELETE CASCADE,
+    old_price NUMERIC(12, 2),
+    new_price NUMERIC(12, 2) NOT NULL,
+    old_cost_price NUMERIC(12, 2),
+    new_cost_price NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    old_discount NUMERIC(5, 2),
+    new_discount NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    source VARCHAR(20) NOT NULL, -- create, manual, schedule
+    schedule_id BIGINT REFERENCES price_change_schedule(id) ON DELETE SET NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    changed_by INTEGER,
+    CONSTRAINT price_history_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_history_product ON price_history (product_id, product_varian_id, changed_at DESC);
+
+-- harga awal produk & varian yang sudah ada
+INSERT INTO price_history (product_id, new_price, new_cost_price, new_discount, source, changed_at, changed_by)
+SELECT id, price, COALESCE(cost_price, 0), COALESCE(discount, 0), 'create', COALESCE(updated_at, CURRENT_TIMESTAMP), updated_by FROM product;
+
+INSERT INTO price_history (product_id, product_varian_id, new_price, new_cost_price, new_discount, source, changed_at, changed_by)
+SELECT product_id, id, price, COALESCE(cost_price, 0), COALESCE(discount, 0), 'create', COALESCE(updated_at, CURRENT_TIMESTAMP), updated_by FROM product_varian;
+
+-- +migrate Down
+DROP TABLE IF EXISTS price_history;
+DROP TABLE IF EXISTS price_change_schedule;
@@ -0,0 +1,53 @@
+-- +migrate Up
+-- aturan promosi, kondisi & aksi disimpan sebagai JSON dan dievaluasi oleh pkg/promotion
+CREATE TABLE IF NOT EXISTS promotion (
+    id bigserial NOT NULL,
+    code VARCHAR NOT NULL,
+    name VARCHAR NOT NULL,
+    description TEXT,
+    priority INTEGER NOT NULL DEFAULT 0, -- makin besar makin dulu dievaluasi
+    stackable BOOLEAN NOT NULL DEFAULT TRUE, -- false = tidak bisa digabung dengan promosi lain
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    usage_limit INTEGER NOT NULL DEFAULT 0, -- 0 = tanpa batas
+    per_customer_limit INTEGER NOT NULL DEFAULT 0, -- 0 = tanpa batas
+    usage_count INTEGER NOT NULL DEFAULT 0,
+    conditions JSONB NOT NULL DEFAULT '[]',
+    actions JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT promotion_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_promotion_code UNIQUE (code),
+    CONSTRAINT chk_promotion_period CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at),
+    CONSTRAINT chk_promotion_usage CHECK (usage_limit >= 0 AND per_customer_limit >= 0 AND usage_count >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotion_active ON promotion (priority DESC) WHERE is_active;
+
+-- pemakaian promosi per transaksi, dasar batas pemakaian per pelanggan
+CREATE TABLE IF NOT EXISTS promotion_usage (
+    id bigserial NOT NULL,
+    promotion_id BIGINT NOT NULL REFERENCES promotion(id) ON DELETE CASCADE,
+    customer_id BIGINT REFERENCES customer(id) ON DELETE SET NULL,
+    reference VARCHAR, -- nomor transaksi / order
+    discount_amount NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    used_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT promotion_usage_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotion_usage_customer ON promotion_usage (promotion_id, customer_id);
+
+INSERT INTO permissions (code, name, group_menu, action, created_by, updated_by) VALUES
+('promotion:create', 'Permission to create promotion data (promotion-create)', 'promotion', 'create', 1, 1),
+('promotion:read', 'Permission to read promotion data (promotion-read)', 'promotion', 'read', 1, 1),
+('promotion:update', 'Permission to update promotion data (promotion-update)', 'promotion', 'update', 1, 1),
+('promotion:delete', 'Permission to delete promotion data (promotion-delete)', 'promotion', 'delete', 1, 1);
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permissions_id IN (SELECT id FROM permissions WHERE group_menu = 'promotion');
+DELETE FROM permissions WHERE group_menu = 'promotion';
+DROP TABLE IF EXISTS promotion_usage;
+DROP TABLE IF EXISTS promotion;
@@ -0,0 +1,72 @@
+-- +migrate Up
+-- voucher: definisi potongan, kode yang bisa dipakai ada di voucher_code
+CREATE TABLE IF NOT EXISTS voucher (
+    id bigserial NOT NULL,
+    name VARCHAR NOT NULL,
+    description TEXT,
+    discount_type VARCHAR(10) NOT NULL, -- percent, fixed
+    value NUMERIC(12, 2) NOT NULL,
+    max_discount NUMERIC(12, 2) NOT NULL DEFAULT 0, -- 0 = tanpa batas (untuk percent)
+    min_spend NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    product_ids BIGINT[] NOT NULL DEFAULT '{}', -- kosong = semua produk
+    category_ids BIGINT[] NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    usage_limit INTEGER NOT NULL DEFAULT 0, -- batas redeem total, 0 = tanpa batas
+    per_customer_limit INTEGER NOT NULL DEFAULT 0, -- 0 = tanpa batas
+    usage_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT voucher_pkey PRIMARY KEY (id),
+    CONSTRAINT chk_voucher_discount CHECK (discount_type IN ('percent', 'fixed') AND value > 0 AND (discount_type <> 'percent' OR value <= 100)),
+    CONSTRAINT chk_voucher_amount CHECK (max_discount >= 0 AND min_spend >= 0),
+    CONSTRAINT chk_voucher_period CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at),
+    CONSTRAINT chk_voucher_usage CHECK (usage_limit >= 0 AND per_customer_limit >= 0 AND (usage_limit = 0 OR usage_count <= usage_limit))
+);
+
+-- kode voucher, disimpan uppercase. max_redemptions 1 = kode sekali pakai (hasil generate)
+CREATE TABLE IF NOT EXISTS voucher_code (
+    id bigserial NOT NULL,
+    voucher_id BIGINT NOT NULL REFERENCES voucher(id) ON DELETE CASCADE,
+    code VARCHAR(32) NOT NULL,
+    max_redemptions INTEGER NOT NULL DEFAULT 0, -- 0 = mengikuti batas voucher
+    redeemed_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    CONSTRAINT voucher_code_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_voucher_code UNIQUE (code),
+    CONSTRAINT chk_voucher_code_redemption CHECK (max_redemptions >= 0 AND (max_redemptions = 0 OR redeemed_count <= max_redemptions))
+);
+
+CREATE INDEX IF NOT EXISTS idx_voucher_code_voucher_id ON voucher_code (voucher_id);
+
+-- customer_seq = pemakaian ke-n oleh pelanggan, unique index mencegah redeem ganda saat bersamaan
+CREATE TABLE IF NOT EXISTS voucher_redemption (
+    id bigserial NOT NULL,
+    voucher_id BIGINT NOT NULL REFERENCES voucher(id) ON DELETE CASCADE,
+    voucher_code_id BIGINT NOT NULL REFERENCES voucher_code(id) ON DELETE CASCADE,
+    customer_id BIGINT REFERENCES customer(id) ON DELETE SET NULL,
+    customer_seq INTEGER,
+    reference VARCHAR, -- nomor transaksi / order
+    discount_amount NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    redeemed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT voucher_redemption_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_voucher_redemption_customer_seq ON voucher_redemption (voucher_id, customer_id, customer_seq) WHERE customer_id IS NOT NULL;
+
+INSERT INTO permissions (code, name, group_menu, action, created_by, updated_by) VALUES
+('voucher:create', 'Permission to create voucher data (voucher-create)', 'voucher', 'create', 1, 1),
+('voucher:read', 'Permission to read voucher data (voucher-read)', 'voucher', 'read', 1, 1),
+('voucher:update', 'Permission to update voucher data (voucher-update)', 'voucher', 'update', 1, 1),
+('voucher:delete', 'Permission to delete voucher data (voucher-delete)', 'voucher', 'delete', 1, 1);
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permissions_id IN (SELECT id FROM permissions WHERE group_menu = 'voucher');
+DELETE FROM permissions WHERE group_menu = 'voucher';
+DROP TABLE IF EXISTS voucher_redemption;
+DROP TABLE IF EXISTS voucher_code;
+DROP TABLE IF EXISTS voucher;
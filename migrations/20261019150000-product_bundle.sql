@@ -0,0 +1,41 @@
+-- +migrate Up
+-- stok disimpan per produk (tanpa varian) atau per varian, produk bundle tidak punya stok sendiri
+ALTER TABLE product ADD COLUMN IF NOT EXISTS product_type VARCHAR(16) NOT NULL DEFAULT 'single';
+ALTER TABLE product ADD COLUMN IF NOT EXISTS bundle_pricing VARCHAR(16) NOT NULL DEFAULT 'fixed';
+ALTER TABLE product ADD COLUMN IF NOT EXISTS stock INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE product ADD CONSTRAINT chk_product_type CHECK (product_type IN ('single', 'bundle'));
+ALTER TABLE product ADD CONSTRAINT chk_product_bundle_pricing CHECK (bundle_pricing IN ('fixed', 'sum'));
+ALTER TABLE product ADD CONSTRAINT chk_product_stock CHECK (stock >= 0);
+
+ALTER TABLE product_varian ADD COLUMN IF NOT EXISTS stock INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE product_varian ADD CONSTRAINT chk_product_varian_stock CHECK (stock >= 0);
+
+-- komponen bundle: produk / varian + jumlah per satu bundle
+CREATE TABLE IF NOT EXISTS product_bundle_item (
+    id bigserial NOT NULL,
+    bundle_product_id BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    component_product_id BIGINT NOT NULL,
+    component_varian_id BIGINT,
+    quantity INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    CONSTRAINT product_bundle_item_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_product_bundle_item_component FOREIGN KEY (component_product_id) REFERENCES product(id) ON DELETE RESTRICT,
+    CONSTRAINT fk_product_bundle_item_varian FOREIGN KEY (component_varian_id) REFERENCES product_varian(id) ON DELETE RESTRICT,
+    CONSTRAINT chk_product_bundle_item_quantity CHECK (quantity > 0),
+    CONSTRAINT chk_product_bundle_item_self CHECK (bundle_product_id <> component_product_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_bundle_item_component ON product_bundle_item (bundle_product_id, component_product_id, COALESCE(component_varian_id, 0));
+CREATE INDEX IF NOT EXISTS idx_product_bundle_item_component_product ON product_bundle_item (component_product_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS product_bundle_item;
+ALTER TABLE product_varian DROP CONSTRAINT IF EXISTS chk_product_varian_stock;
+ALTER TABLE product_varian DROP COLUMN IF EXISTS stock;
+ALTER TABLE product DROP CONSTRAINT IF EXISTS chk_product_stock;
+ALTER TABLE product DROP CONSTRAINT IF EXISTS chk_product_bundle_pricing;
+ALTER TABLE product DROP CONSTRAINT IF EXISTS chk_product_type;
+ALTER TABLE product DROP COLUMN IF EXISTS stock;
+ALTER TABLE product DROP COLUMN IF EXISTS bundle_pricing;
+ALTER TABLE product DROP COLUMN IF EXISTS product_type;
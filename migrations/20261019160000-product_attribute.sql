@@ -0,0 +1,44 @@
+-- +migrate Up
+-- definisi atribut per kategori, berlaku juga untuk produk di sub kategori
+CREATE TABLE IF NOT EXISTS attribute_definition (
+    id bigserial NOT NULL,
+    category_id BIGINT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL, -- dipakai sebagai filter: attr.<code>
+    name VARCHAR NOT NULL,
+    data_type VARCHAR(16) NOT NULL,
+    allowed_values TEXT[] NOT NULL DEFAULT '{}', -- khusus enum
+    unit VARCHAR(20),
+    is_required BOOLEAN NOT NULL DEFAULT FALSE,
+    is_filterable BOOLEAN NOT NULL DEFAULT TRUE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT attribute_definition_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_attribute_definition_code UNIQUE (category_id, code),
+    CONSTRAINT chk_attribute_definition_type CHECK (data_type IN ('text', 'number', 'boolean', 'enum'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_attribute_definition_code ON attribute_definition (code);
+
+-- nilai atribut per produk / varian. value_text selalu terisi (bentuk kanonik),
+-- value_number hanya untuk tipe number agar bisa difilter dengan rentang
+CREATE TABLE IF NOT EXISTS product_attribute_value (
+    id bigserial NOT NULL,
+    product_id BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    product_varian_id BIGINT REFERENCES product_varian(id) ON DELETE CASCADE, -- NULL = nilai produk
+    attribute_id BIGINT NOT NULL REFERENCES attribute_definition(id) ON DELETE CASCADE,
+    value_text VARCHAR(500) NOT NULL,
+    value_number NUMERIC(18, 4),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    CONSTRAINT product_attribute_value_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_attribute_value_unique ON product_attribute_value (product_id, COALESCE(product_varian_id, 0), attribute_id);
+CREATE INDEX IF NOT EXISTS idx_product_attribute_value_filter ON product_attribute_value (attribute_id, value_text);
+
+-- +migrate Down
+DROP TABLE IF EXISTS product_attribute_value;
+DROP TABLE IF EXISTS attribute_definition;
@@ -0,0 +1,28 @@
+-- +migrate Up
+-- gambar produk, file asli dan thumbnail disimpan di storage (local / S3), tabel ini hanya menyimpan key
+CREATE TABLE IF NOT EXISTS product_media (
+    id bigserial NOT NULL,
+    product_id BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    storage_key VARCHAR(255) NOT NULL,
+    content_type VARCHAR(64) NOT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    width INTEGER NOT NULL DEFAULT 0,
+    height INTEGER NOT NULL DEFAULT 0,
+    thumbnails JSONB NOT NULL DEFAULT '{}',
+    alt_text VARCHAR(255) NOT NULL DEFAULT '',
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT product_media_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_product_media_storage_key UNIQUE (storage_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_media_product ON product_media (product_id, sort_order);
+-- satu produk hanya boleh punya satu gambar utama
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_media_primary ON product_media (product_id) WHERE is_primary;
+
+-- +migrate Down
+DROP TABLE IF EXISTS product_media;
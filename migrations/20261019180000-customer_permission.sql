@@ -0,0 +1,7 @@
+-- +migrate Up
+INSERT INTO permissions (code, name, group_menu, action, created_by, updated_by) VALUES
+('customer:read', 'Permission to read customer data (customer-read)', 'customer', 'read', 1, 1);
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permissions_id IN (SELECT id FROM permissions WHERE group_menu = 'customer');
+DELETE FROM permissions WHERE group_menu = 'customer';
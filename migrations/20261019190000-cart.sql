@@ -0,0 +1,34 @@
+-- +migrate Up
+-- keranjang belanja, data utama ada di Redis (dengan TTL), tabel ini salinan untuk recovery
+-- bila key Redis hilang. Satu keranjang per user login atau per guest (guest_id dari guest token)
+CREATE TABLE IF NOT EXISTS cart (
+    id bigserial NOT NULL,
+    user_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    guest_id VARCHAR(64),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT cart_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_cart_user UNIQUE (user_id),
+    CONSTRAINT unique_cart_guest UNIQUE (guest_id),
+    CONSTRAINT chk_cart_owner CHECK ((user_id IS NULL) <> (guest_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_cart_expires_at ON cart (expires_at);
+
+-- product_varian_id 0 = produk tanpa varian
+CREATE TABLE IF NOT EXISTS cart_item (
+    id bigserial NOT NULL,
+    cart_id BIGINT NOT NULL REFERENCES cart(id) ON DELETE CASCADE,
+    product_id BIGINT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    product_varian_id BIGINT NOT NULL DEFAULT 0,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT cart_item_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_cart_item UNIQUE (cart_id, product_id, product_varian_id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS cart_item;
+DROP TABLE IF EXISTS cart;
@@ -0,0 +1,75 @@
+-- +migrate Up
+-- order pelanggan, total dihitung saat order dibuat dan tidak berubah walaupun harga produk berubah
+CREATE TABLE IF NOT EXISTS orders (
+    id bigserial NOT NULL,
+    order_number VARCHAR(32) NOT NULL,
+    customer_id BIGINT NOT NULL REFERENCES customer(id),
+    status VARCHAR(32) NOT NULL,
+    subtotal NUMERIC(15,2) NOT NULL DEFAULT 0,
+    discount_total NUMERIC(15,2) NOT NULL DEFAULT 0,
+    shipping_total NUMERIC(15,2) NOT NULL DEFAULT 0,
+    tax_total NUMERIC(15,2) NOT NULL DEFAULT 0,
+    grand_total NUMERIC(15,2) NOT NULL DEFAULT 0,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT orders_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_order_number UNIQUE (order_number),
+    CONSTRAINT chk_order_status CHECK (status IN ('pending_payment', 'paid', 'processing', 'shipped', 'delivered', 'completed', 'cancelled', 'refunded'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_customer ON orders (customer_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_status ON orders (status, created_at DESC);
+
+-- snapshot produk saat order dibuat (nama, kode, harga, diskon, harga pokok),
+-- product_id tidak memakai foreign key supaya produk tetap bisa dihapus
+CREATE TABLE IF NOT EXISTS order_item (
+    id bigserial NOT NULL,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    product_id BIGINT NOT NULL,
+    product_varian_id BIGINT NOT NULL DEFAULT 0,
+    product_name VARCHAR NOT NULL,
+    product_code VARCHAR NOT NULL DEFAULT '',
+    varian_name VARCHAR NOT NULL DEFAULT '',
+    varian_code VARCHAR NOT NULL DEFAULT '',
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    price NUMERIC(15,2) NOT NULL DEFAULT 0,
+    discount NUMERIC(5,2) NOT NULL DEFAULT 0,
+    unit_price NUMERIC(15,2) NOT NULL DEFAULT 0,
+    cost_price NUMERIC(15,2) NOT NULL DEFAULT 0,
+    discount_amount NUMERIC(15,2) NOT NULL DEFAULT 0,
+    line_total NUMERIC(15,2) NOT NULL DEFAULT 0,
+    price_source VARCHAR(32) NOT NULL DEFAULT '',
+    CONSTRAINT order_item_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_item_order ON order_item (order_id);
+
+-- riwayat perubahan status, actor_type: user (dashboard), customer (storefront), system
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id bigserial NOT NULL,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(32) NOT NULL DEFAULT '',
+    to_status VARCHAR(32) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    actor_type VARCHAR(16) NOT NULL,
+    actor_id BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT order_status_history_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order ON order_status_history (order_id, created_at);
+
+INSERT INTO permissions (code, name, group_menu, action, created_by, updated_by) VALUES
+('order:create', 'Permission to create order data (order-create)', 'order', 'create', 1, 1),
+('order:read', 'Permission to read order data (order-read)', 'order', 'read', 1, 1),
+('order:update', 'Permission to update order data (order-update)', 'order', 'update', 1, 1);
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permissions_id IN (SELECT id FROM permissions WHERE group_menu = 'order');
+DELETE FROM permissions WHERE group_menu = 'order';
+DROP TABLE IF EXISTS order_status_history;
+DROP TABLE IF EXISTS order_item;
+DROP TABLE IF EXISTS orders;
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- order dari checkout storefront: snapshot alamat kirim, voucher yang dipakai dan idempotency key
+-- dari client (mencegah order ganda saat tombol checkout ditekan berulang)
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR(64);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS voucher_code VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_address JSONB;
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_order_idempotency_key ON orders (customer_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS unique_order_idempotency_key;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_address;
+ALTER TABLE orders DROP COLUMN IF EXISTS voucher_code;
+ALTER TABLE orders DROP COLUMN IF EXISTS idempotency_key;
@@ -0,0 +1,27 @@
+-- +migrate Up
+-- buku alamat pelanggan: alamat milik user login (user_id) atau customer guest (customer_id, user_id kosong),
+-- satu alamat utama per jenis alamat
+UPDATE addresses SET address_type = 'shipping' WHERE address_type IS NULL;
+UPDATE addresses SET is_default = FALSE WHERE is_default IS NULL;
+ALTER TABLE addresses ALTER COLUMN address_type SET DEFAULT 'shipping';
+ALTER TABLE addresses ALTER COLUMN address_type SET NOT NULL;
+ALTER TABLE addresses ALTER COLUMN is_default SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_addresses_user ON addresses (user_id);
+CREATE INDEX IF NOT EXISTS idx_addresses_customer ON addresses (customer_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_addresses_default_user ON addresses (user_id, address_type) WHERE is_default AND user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_addresses_default_customer ON addresses (customer_id, address_type) WHERE is_default AND user_id IS NULL;
+
+INSERT INTO permissions (code, name, group_menu, action, created_by, updated_by) VALUES
+('customer:update', 'Permission to update customer data (customer-update)', 'customer', 'update', 1, 1);
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permissions_id IN (SELECT id FROM permissions WHERE code = 'customer:update');
+DELETE FROM permissions WHERE code = 'customer:update';
+DROP INDEX IF EXISTS idx_addresses_default_customer;
+DROP INDEX IF EXISTS idx_addresses_default_user;
+DROP INDEX IF EXISTS idx_addresses_customer;
+DROP INDEX IF EXISTS idx_addresses_user;
+ALTER TABLE addresses ALTER COLUMN is_default DROP NOT NULL;
+ALTER TABLE addresses ALTER COLUMN address_type DROP NOT NULL;
+ALTER TABLE addresses ALTER COLUMN address_type DROP DEFAULT;
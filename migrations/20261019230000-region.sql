@@ -0,0 +1,65 @@
+-- +migrate Up
+-- data wilayah administratif (kode Kemendagri tanpa titik, mis. 31 / 3173 / 317301 / 3173011001),
+-- diisi seeder dari dataset bawaan
+CREATE TABLE IF NOT EXISTS region_province (
+    id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    CONSTRAINT region_province_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS region_regency (
+    id BIGINT NOT NULL,
+    province_id BIGINT NOT NULL REFERENCES region_province(id),
+    name VARCHAR(100) NOT NULL,
+    CONSTRAINT region_regency_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS region_district (
+    id BIGINT NOT NULL,
+    regency_id BIGINT NOT NULL REFERENCES region_regency(id),
+    name VARCHAR(100) NOT NULL,
+    CONSTRAINT region_district_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS region_village (
+    id BIGINT NOT NULL,
+    district_id BIGINT NOT NULL REFERENCES region_district(id),
+    name VARCHAR(100) NOT NULL,
+    postal_code VARCHAR(5) NOT NULL DEFAULT '',
+    CONSTRAINT region_village_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_region_regency_province ON region_regency (province_id);
+CREATE INDEX IF NOT EXISTS idx_region_district_regency ON region_district (regency_id);
+CREATE INDEX IF NOT EXISTS idx_region_village_district ON region_village (district_id);
+CREATE INDEX IF NOT EXISTS idx_region_village_postal_code ON region_village (postal_code);
+-- autocomplete memakai pencarian awalan nama
+CREATE INDEX IF NOT EXISTS idx_region_district_name ON region_district (lower(name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_region_village_name ON region_village (lower(name) text_pattern_ops);
+
+-- checksum dataset terakhir yang di-seed, seeder dilewati bila dataset tidak berubah
+CREATE TABLE IF NOT EXISTS region_dataset (
+    id SMALLINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    seeded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT region_dataset_pkey PRIMARY KEY (id)
+);
+
+-- alamat menyimpan id wilayah, kolom teks (province, city, subdistrict, village) diisi dari nama wilayah
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS province_id BIGINT REFERENCES region_province(id);
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS regency_id BIGINT REFERENCES region_regency(id);
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS district_id BIGINT REFERENCES region_district(id);
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS village_id BIGINT REFERENCES region_village(id);
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS village VARCHAR(100) NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE addresses DROP COLUMN IF EXISTS village;
+ALTER TABLE addresses DROP COLUMN IF EXISTS village_id;
+ALTER TABLE addresses DROP COLUMN IF EXISTS district_id;
+ALTER TABLE addresses DROP COLUMN IF EXISTS regency_id;
+ALTER TABLE addresses DROP COLUMN IF EXISTS province_id;
+DROP TABLE IF EXISTS region_dataset;
+DROP TABLE IF EXISTS region_village;
+DROP TABLE IF EXISTS region_district;
+DROP TABLE IF EXISTS region_regency;
+DROP TABLE IF EXISTS region_province;
@@ -0,0 +1,59 @@
+-- +migrate Up
+-- berat (gram) dan dimensi (cm) per unit untuk ongkir, varian bernilai 0 mengikuti produk
+ALTER TABLE product ADD COLUMN IF NOT EXISTS weight_gram INTEGER NOT NULL DEFAULT 0 CHECK (weight_gram >= 0);
+ALTER TABLE product ADD COLUMN IF NOT EXISTS length_cm NUMERIC(8,2) NOT NULL DEFAULT 0 CHECK (length_cm >= 0);
+ALTER TABLE product ADD COLUMN IF NOT EXISTS width_cm NUMERIC(8,2) NOT NULL DEFAULT 0 CHECK (width_cm >= 0);
+ALTER TABLE product ADD COLUMN IF NOT EXISTS height_cm NUMERIC(8,2) NOT NULL DEFAULT 0 CHECK (height_cm >= 0);
+
+ALTER TABLE product_varian ADD COLUMN IF NOT EXISTS weight_gram INTEGER NOT NULL DEFAULT 0 CHECK (weight_gram >= 0);
+ALTER TABLE product_varian ADD COLUMN IF NOT EXISTS length_cm NUMERIC(8,2) NOT NULL DEFAULT 0 CHECK (length_cm >= 0);
+ALTER TABLE product_varian ADD COLUMN IF NOT EXISTS width_cm NUMERIC(8,2) NOT NULL DEFAULT 0 CHECK (width_cm >= 0);
+ALTER TABLE product_varian ADD COLUMN IF NOT EXISTS height_cm NUMERIC(8,2) NOT NULL DEFAULT 0 CHECK (height_cm >= 0);
+
+-- snapshot berat item untuk membuat pengiriman setelah order dibuat
+ALTER TABLE order_item ADD COLUMN IF NOT EXISTS weight_gram INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE order_item ADD COLUMN IF NOT EXISTS length_cm NUMERIC(8,2) NOT NULL DEFAULT 0;
+ALTER TABLE order_item ADD COLUMN IF NOT EXISTS width_cm NUMERIC(8,2) NOT NULL DEFAULT 0;
+ALTER TABLE order_item ADD COLUMN IF NOT EXISTS height_cm NUMERIC(8,2) NOT NULL DEFAULT 0;
+
+-- layanan kirim yang dipilih saat checkout
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_courier VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_service VARCHAR(32) NOT NULL DEFAULT '';
+
+-- satu pengiriman per order, nomor resi dari provider (flat / agregator)
+CREATE TABLE IF NOT EXISTS shipment (
+    id bigserial NOT NULL,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    provider VARCHAR(32) NOT NULL,
+    courier VARCHAR(32) NOT NULL,
+    service VARCHAR(32) NOT NULL DEFAULT '',
+    tracking_number VARCHAR(64) NOT NULL,
+    cost NUMERIC(15,2) NOT NULL DEFAULT 0,
+    weight_gram INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(32) NOT NULL DEFAULT '',
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT shipment_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_shipment_order UNIQUE (order_id),
+    CONSTRAINT unique_shipment_tracking_number UNIQUE (provider, courier, tracking_number)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS shipment;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_service;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_courier;
+ALTER TABLE order_item DROP COLUMN IF EXISTS height_cm;
+ALTER TABLE order_item DROP COLUMN IF EXISTS width_cm;
+ALTER TABLE order_item DROP COLUMN IF EXISTS length_cm;
+ALTER TABLE order_item DROP COLUMN IF EXISTS weight_gram;
+ALTER TABLE product_varian DROP COLUMN IF EXISTS height_cm;
+ALTER TABLE product_varian DROP COLUMN IF EXISTS width_cm;
+ALTER TABLE product_varian DROP COLUMN IF EXISTS length_cm;
+ALTER TABLE product_varian DROP COLUMN IF EXISTS weight_gram;
+ALTER TABLE product DROP COLUMN IF EXISTS height_cm;
+ALTER TABLE product DROP COLUMN IF EXISTS width_cm;
+ALTER TABLE product DROP COLUMN IF EXISTS length_cm;
+ALTER TABLE product DROP COLUMN IF EXISTS weight_gram;
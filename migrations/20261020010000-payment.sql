@@ -0,0 +1,61 @@
+-- +migrate Up
+-- percobaan bayar per order, order yang pembayarannya kedaluwarsa / gagal bisa dibayar ulang
+-- dengan reference baru. Hanya satu pembayaran pending / paid per order.
+CREATE TABLE IF NOT EXISTS payment (
+    id bigserial NOT NULL,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    provider VARCHAR(32) NOT NULL,
+    method VARCHAR(32) NOT NULL,
+    channel VARCHAR(32) NOT NULL DEFAULT '',
+    reference VARCHAR(64) NOT NULL,
+    transaction_id VARCHAR(128) NOT NULL DEFAULT '',
+    amount NUMERIC(15,2) NOT NULL CHECK (amount > 0),
+    refunded_amount NUMERIC(15,2) NOT NULL DEFAULT 0 CHECK (refunded_amount >= 0),
+    status VARCHAR(32) NOT NULL,
+    va_number VARCHAR(64) NOT NULL DEFAULT '',
+    qr_string TEXT NOT NULL DEFAULT '',
+    redirect_url TEXT NOT NULL DEFAULT '',
+    bank_name VARCHAR(64) NOT NULL DEFAULT '',
+    bank_account_number VARCHAR(64) NOT NULL DEFAULT '',
+    bank_account_name VARCHAR(128) NOT NULL DEFAULT '',
+    proof_key VARCHAR(255) NOT NULL DEFAULT '',
+    proof_uploaded_at TIMESTAMP,
+    confirmed_by BIGINT NOT NULL DEFAULT 0,
+    confirm_note TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP,
+    paid_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT payment_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_payment_reference UNIQUE (provider, reference),
+    CONSTRAINT chk_payment_status CHECK (status IN ('pending', 'paid', 'failed', 'expired', 'refunded')),
+    CONSTRAINT chk_payment_refunded_amount CHECK (refunded_amount <= amount)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_payment_order_active ON payment (order_id) WHERE status IN ('pending', 'paid');
+CREATE INDEX IF NOT EXISTS idx_payment_order ON payment (order_id, created_at DESC);
+
+-- notifikasi webhook yang sudah diproses, notifikasi ulang dengan event_key yang sama diabaikan
+CREATE TABLE IF NOT EXISTS payment_webhook_event (
+    id bigserial NOT NULL,
+    provider VARCHAR(32) NOT NULL,
+    event_key VARCHAR(255) NOT NULL,
+    payment_id BIGINT REFERENCES payment(id) ON DELETE SET NULL,
+    status VARCHAR(32) NOT NULL DEFAULT '',
+    payload JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT payment_webhook_event_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_payment_webhook_event UNIQUE (provider, event_key)
+);
+
+INSERT INTO permissions (code, name, group_menu, action, created_by, updated_by) VALUES
+('payment:read', 'Permission to read payment data (payment-read)', 'payment', 'read', 1, 1),
+('payment:update', 'Permission to confirm and refund payment (payment-update)', 'payment', 'update', 1, 1);
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permissions_id IN (SELECT id FROM permissions WHERE group_menu = 'payment');
+DELETE FROM permissions WHERE group_menu = 'payment';
+DROP TABLE IF EXISTS payment_webhook_event;
+DROP TABLE IF EXISTS payment;
@@ -0,0 +1,94 @@
+-- +migrate Up
+-- kelas pajak (standar PPN, bebas PPN, ...), produk tanpa kelas mengikuti kategori lalu kelas default
+CREATE TABLE IF NOT EXISTS tax_class (
+    id bigserial NOT NULL,
+    code VARCHAR(40) NOT NULL,
+    name VARCHAR NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT tax_class_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_tax_class_code UNIQUE (code)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_class_default ON tax_class (is_default) WHERE is_default;
+
+-- tarif berlaku mulai effective_from sampai digantikan tarif dengan effective_from berikutnya
+CREATE TABLE IF NOT EXISTS tax_rate (
+    id bigserial NOT NULL,
+    tax_class_id BIGINT NOT NULL REFERENCES tax_class(id) ON DELETE CASCADE,
+    name VARCHAR NOT NULL,
+    rate NUMERIC(5,2) NOT NULL CHECK (rate >= 0 AND rate <= 100),
+    effective_from TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT tax_rate_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_tax_rate_effective UNIQUE (tax_class_id, effective_from)
+);
+
+ALTER TABLE product ADD COLUMN IF NOT EXISTS tax_class_id BIGINT;
+ALTER TABLE product ADD CONSTRAINT fk_product_tax_class FOREIGN KEY (tax_class_id) REFERENCES tax_class (id) ON DELETE RESTRICT;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS tax_class_id BIGINT;
+ALTER TABLE categories ADD CONSTRAINT fk_categories_tax_class FOREIGN KEY (tax_class_id) REFERENCES tax_class (id) ON DELETE RESTRICT;
+
+-- snapshot pajak order: mode harga saat order dibuat, pajak per item dan rekap per tarif
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_mode VARCHAR(16) NOT NULL DEFAULT 'exclusive';
+
+ALTER TABLE order_item ADD COLUMN IF NOT EXISTS tax_class_code VARCHAR(40) NOT NULL DEFAULT '';
+ALTER TABLE order_item ADD COLUMN IF NOT EXISTS tax_rate NUMERIC(5,2) NOT NULL DEFAULT 0;
+ALTER TABLE order_item ADD COLUMN IF NOT EXISTS taxable_amount NUMERIC(15,2) NOT NULL DEFAULT 0;
+ALTER TABLE order_item ADD COLUMN IF NOT EXISTS tax_amount NUMERIC(15,2) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS order_tax (
+    id bigserial NOT NULL,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    tax_class_id BIGINT NOT NULL DEFAULT 0,
+    tax_class_code VARCHAR(40) NOT NULL DEFAULT '',
+    tax_class_name VARCHAR NOT NULL DEFAULT '',
+    rate_name VARCHAR NOT NULL DEFAULT '',
+    rate NUMERIC(5,2) NOT NULL DEFAULT 0,
+    taxable_amount NUMERIC(15,2) NOT NULL DEFAULT 0,
+    tax_amount NUMERIC(15,2) NOT NULL DEFAULT 0,
+    CONSTRAINT order_tax_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_tax_order ON order_tax (order_id);
+
+-- PPN 11% sejak 1 April 2022 (UU HPP), sebelumnya 10%
+INSERT INTO tax_class (code, name, description, is_default, created_by, updated_by) VALUES
+('STANDARD', 'PPN Standar', 'Barang kena pajak dengan tarif PPN umum', TRUE, 1, 1),
+('EXEMPT', 'Bebas PPN', 'Barang yang dibebaskan dari PPN', FALSE, 1, 1);
+
+INSERT INTO tax_rate (tax_class_id, name, rate, effective_from, created_by, updated_by)
+SELECT id, 'PPN 10%', 10, '2000-01-01 00:00:00', 1, 1 FROM tax_class WHERE code = 'STANDARD'
+UNION ALL
+SELECT id, 'PPN 11%', 11, '2022-04-01 00:00:00', 1, 1 FROM tax_class WHERE code = 'STANDARD'
+UNION ALL
+SELECT id, 'Bebas PPN', 0, '2000-01-01 00:00:00', 1, 1 FROM tax_class WHERE code = 'EXEMPT';
+
+INSERT INTO permissions (code, name, group_menu, action, created_by, updated_by) VALUES
+('tax:create', 'Permission to create tax class data (tax-create)', 'tax', 'create', 1, 1),
+('tax:read', 'Permission to read tax class data (tax-read)', 'tax', 'read', 1, 1),
+('tax:update', 'Permission to update tax class data (tax-update)', 'tax', 'update', 1, 1),
+('tax:delete', 'Permission to delete tax class data (tax-delete)', 'tax', 'delete', 1, 1);
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permissions_id IN (SELECT id FROM permissions WHERE group_menu = 'tax');
+DELETE FROM permissions WHERE group_menu = 'tax';
+DROP TABLE IF EXISTS order_tax;
+ALTER TABLE order_item DROP COLUMN IF EXISTS tax_amount;
+ALTER TABLE order_item DROP COLUMN IF EXISTS taxable_amount;
+ALTER TABLE order_item DROP COLUMN IF EXISTS tax_rate;
+ALTER TABLE order_item DROP COLUMN IF EXISTS tax_class_code;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_mode;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS fk_categories_tax_class;
+ALTER TABLE categories DROP COLUMN IF EXISTS tax_class_id;
+ALTER TABLE product DROP CONSTRAINT IF EXISTS fk_product_tax_class;
+ALTER TABLE product DROP COLUMN IF EXISTS tax_class_id;
+DROP TABLE IF EXISTS tax_rate;
+DROP TABLE IF EXISTS tax_class;
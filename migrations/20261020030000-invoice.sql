@@ -0,0 +1,60 @@
+-- +migrate Up
+-- nomor urut terakhir per jenis dokumen, cabang dan tahun. Baris dikunci saat nomor diambil di dalam
+-- transaksi penerbitan dokumen, rollback ikut mengembalikan nomor sehingga tidak ada nomor yang loncat
+CREATE TABLE IF NOT EXISTS invoice_sequence (
+    document_type VARCHAR(16) NOT NULL,
+    branch_id BIGINT NOT NULL DEFAULT 0,
+    year INTEGER NOT NULL,
+    last_number BIGINT NOT NULL DEFAULT 0,
+    CONSTRAINT invoice_sequence_pkey PRIMARY KEY (document_type, branch_id, year)
+);
+
+-- invoice (satu per order) dan nota kredit atas refund, data pelanggan & nominal disalin saat terbit
+CREATE TABLE IF NOT EXISTS invoice (
+    id bigserial NOT NULL,
+    document_type VARCHAR(16) NOT NULL,
+    number VARCHAR(40) NOT NULL,
+    branch_id BIGINT NOT NULL DEFAULT 0,
+    year INTEGER NOT NULL,
+    sequence BIGINT NOT NULL,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE RESTRICT,
+    invoice_id BIGINT REFERENCES invoice(id) ON DELETE RESTRICT,
+    payment_id BIGINT REFERENCES payment(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    tax_mode VARCHAR(16) NOT NULL DEFAULT 'exclusive',
+    customer_name VARCHAR NOT NULL DEFAULT '',
+    customer_phone VARCHAR NOT NULL DEFAULT '',
+    customer_email VARCHAR NOT NULL DEFAULT '',
+    customer_address TEXT NOT NULL DEFAULT '',
+    subtotal NUMERIC(15,2) NOT NULL DEFAULT 0,
+    discount_total NUMERIC(15,2) NOT NULL DEFAULT 0,
+    shipping_total NUMERIC(15,2) NOT NULL DEFAULT 0,
+    tax_total NUMERIC(15,2) NOT NULL DEFAULT 0,
+    grand_total NUMERIC(15,2) NOT NULL DEFAULT 0,
+    issued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    print_count INTEGER NOT NULL DEFAULT 0,
+    last_printed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER,
+    CONSTRAINT invoice_pkey PRIMARY KEY (id),
+    CONSTRAINT unique_invoice_number UNIQUE (number),
+    CONSTRAINT unique_invoice_sequence UNIQUE (document_type, branch_id, year, sequence),
+    CONSTRAINT chk_invoice_document_type CHECK (document_type IN ('invoice', 'credit_note')),
+    CONSTRAINT chk_invoice_credit_note_ref CHECK ((document_type = 'credit_note') = (invoice_id IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoice_order ON invoice (order_id) WHERE document_type = 'invoice';
+CREATE INDEX IF NOT EXISTS idx_invoice_invoice_id ON invoice (invoice_id);
+
+INSERT INTO permissions (code, name, group_menu, action, created_by, updated_by) VALUES
+('invoice:create', 'Permission to issue invoice (invoice-create)', 'invoice', 'create', 1, 1),
+('invoice:read', 'Permission to read and download invoice (invoice-read)', 'invoice', 'read', 1, 1),
+('invoice:update', 'Permission to reprint invoice (invoice-update)', 'invoice', 'update', 1, 1);
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permissions_id IN (SELECT id FROM permissions WHERE group_menu = 'invoice');
+DELETE FROM permissions WHERE group_menu = 'invoice';
+DROP TABLE IF EXISTS invoice;
+DROP TABLE IF EXISTS invoice_sequence;
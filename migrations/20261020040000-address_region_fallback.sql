@@ -0,0 +1,9 @@
+-- +migrate Up
+-- kabupaten / kecamatan yang belum di-seed tetap bisa dipakai di alamat (nama dari teks bebas),
+-- kode wilayah dicek di aplikasi. Provinsi dan kelurahan tetap memakai foreign key.
+ALTER TABLE addresses DROP CONSTRAINT IF EXISTS addresses_regency_id_fkey;
+ALTER TABLE addresses DROP CONSTRAINT IF EXISTS addresses_district_id_fkey;
+
+-- +migrate Down
+ALTER TABLE addresses ADD CONSTRAINT addresses_regency_id_fkey FOREIGN KEY (regency_id) REFERENCES region_regency(id);
+ALTER TABLE addresses ADD CONSTRAINT addresses_district_id_fkey FOREIGN KEY (district_id) REFERENCES region_district(id);
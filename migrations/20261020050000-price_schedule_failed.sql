@@ -0,0 +1,11 @@
+-- +migrate Up
+-- jadwal yang gagal diterapkan scheduler ditandai failed beserta alasannya agar tidak menahan jadwal lain
+ALTER TABLE price_change_schedule ADD COLUMN IF NOT EXISTS error_message TEXT NOT NULL DEFAULT '';
+ALTER TABLE price_change_schedule DROP CONSTRAINT IF EXISTS chk_price_change_schedule_status;
+ALTER TABLE price_change_schedule ADD CONSTRAINT chk_price_change_schedule_status CHECK (status IN ('pending', 'applied', 'cancelled', 'failed'));
+
+-- +migrate Down
+UPDATE price_change_schedule SET status = 'pending' WHERE status = 'failed';
+ALTER TABLE price_change_schedule DROP CONSTRAINT IF EXISTS chk_price_change_schedule_status;
+ALTER TABLE price_change_schedule ADD CONSTRAINT chk_price_change_schedule_status CHECK (status IN ('pending', 'applied', 'cancelled'));
+ALTER TABLE price_change_schedule DROP COLUMN IF EXISTS error_message;
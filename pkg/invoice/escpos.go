@@ -0,0 +1,211 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Jumlah karakter per baris font A (12 x 24) printer thermal
+const (
+	receiptColumns58 = 32
+	receiptColumns80 = 48
+)
+
+// Perintah ESC/POS
+var (
+	escInit        = []byte{0x1B, 0x40}
+	escAlignLeft   = []byte{0x1B, 0x61, 0x00}
+	escAlignCenter = []byte{0x1B, 0x61, 0x01}
+	escBoldOn      = []byte{0x1B, 0x45, 0x01}
+	escBoldOff     = []byte{0x1B, 0x45, 0x00}
+	escDoubleOn    = []byte{0x1D, 0x21, 0x11} // lebar & tinggi 2x
+	escDoubleOff   = []byte{0x1D, 0x21, 0x00}
+	escFeedCut     = []byte{0x1D, 0x56, 0x42, 0x03} // feed 3 baris lalu potong sebagian
+)
+
+// RenderReceipt struk ESC/POS untuk printer thermal dengan lebar columns karakter.
+// Karakter di luar ASCII diganti '?' karena code page printer berbeda-beda.
+func RenderReceipt(company Company, doc Document, columns int) ([]byte, error) {
+	r := receipt{columns: columns}
+	r.write(escInit)
+
+	r.write(escAlignCenter)
+	r.write(escBoldOn)
+	r.text(company.Name)
+	r.write(escBoldOff)
+	for _, line := range wrap(company.Address, columns) {
+		r.text(line)
+	}
+	if company.Phone != "" {
+		r.text("Telp " + company.Phone)
+	}
+	if company.NPWP != "" {
+		r.text("NPWP " + company.NPWP)
+	}
+	r.feed()
+
+	r.write(escDoubleOn)
+	r.text(doc.Title())
+	r.write(escDoubleOff)
+	if label := doc.CopyLabel(); label != "" {
+		r.text("*** " + label + " ***")
+	}
+
+	r.write(escAlignLeft)
+	r.separator('=')
+	r.pair("No", doc.Number)
+	r.pair("Tanggal", doc.IssuedAt.Format("02/01/2006 15:04"))
+	if doc.OrderNumber != "" {
+		r.pair("Order", doc.OrderNumber)
+	}
+	if doc.Reference != "" {
+		r.pair("Ref", doc.Reference)
+	}
+	if doc.CustomerName != "" {
+		r.pair("Pelanggan", doc.CustomerName)
+	}
+	r.separator('-')
+
+	for _, line := range doc.Lines {
+		for _, name := range wrap(line.Name, columns) {
+			r.text(name)
+		}
+		r.pair(fmt.Sprintf("  %d x %s", line.Quantity, FormatAmount(line.UnitPrice)), FormatAmount(line.GrossAmount()))
+		if line.Discount > 0 {
+			r.pair("  Diskon", "-"+FormatAmount(line.Discount))
+		}
+	}
+	r.separator('-')
+
+	r.totals(doc)
+
+	if len(doc.Taxes) > 0 {
+		r.separator('-')
+		for _, t := range doc.Taxes {
+			r.pair(fmt.Sprintf("%s DPP %s", truncate(t.Name, columns/2), FormatAmount(t.TaxableAmount)), FormatAmount(t.TaxAmount))
+		}
+	}
+
+	if doc.Note != "" {
+		r.separator('-')
+		for _, line := range wrap(doc.Note, columns) {
+			r.text(line)
+		}
+	}
+
+	r.feed()
+	r.write(escAlignCenter)
+	if doc.Type == TypeCreditNote {
+		r.text("Nota kredit atas " + doc.Reference)
+	} else {
+		r.text("Terima kasih")
+	}
+	r.write(escFeedCut)
+	return r.buf.Bytes(), nil
+}
+
+type receipt struct {
+	buf     bytes.Buffer
+	columns int
+}
+
+func (r *receipt) write(cmd []byte) {
+	r.buf.Write(cmd)
+}
+
+func (r *receipt) feed() {
+	r.buf.WriteByte('\n')
+}
+
+func (r *receipt) text(s string) {
+	r.buf.WriteString(truncate(ascii(s), r.columns))
+	r.buf.WriteByte('\n')
+}
+
+func (r *receipt) separator(c byte) {
+	r.buf.Write(bytes.Repeat([]byte{c}, r.columns))
+	r.buf.WriteByte('\n')
+}
+
+// pair teks kiri dan kanan rata tepi, teks kiri dipotong bila tidak cukup
+func (r *receipt) pair(left, right string) {
+	left, right = ascii(left), ascii(right)
+	space := r.columns - utf8.RuneCountInString(right) - 1
+	if space < 1 {
+		r.text(right)
+		return
+	}
+	left = truncate(left, space)
+	r.buf.WriteString(left)
+	r.buf.WriteString(strings.Repeat(" ", r.columns-len(left)-len(right)))
+	r.buf.WriteString(right)
+	r.buf.WriteByte('\n')
+}
+
+func (r *receipt) totals(doc Document) {
+	r.pair("Subtotal", FormatAmount(doc.Subtotal))
+	if doc.DiscountTotal > 0 {
+		r.pair("Diskon", "-"+FormatAmount(doc.DiscountTotal))
+	}
+	if doc.TaxInclusive {
+		r.pair("Pajak (termasuk)", FormatAmount(doc.TaxTotal))
+	} else {
+		r.pair("Pajak", FormatAmount(doc.TaxTotal))
+	}
+	if doc.ShippingTotal > 0 {
+		r.pair("Ongkos kirim", FormatAmount(doc.ShippingTotal))
+	}
+	r.write(escBoldOn)
+	r.pair("TOTAL", FormatAmount(doc.GrandTotal))
+	r.write(escBoldOff)
+}
+
+// ascii mengganti karakter non ASCII / kontrol dengan '?' agar tidak terbaca sebagai perintah printer
+func ascii(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch {
+		case c == '\n' || c == '\r' || c == '\t':
+			b.WriteByte(' ')
+		case c < 0x20 || c > 0x7E:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// wrap memecah teks per kata menjadi baris dengan lebar maksimal width
+func wrap(s string, width int) []string {
+	var (
+		lines []string
+		line  string
+	)
+	for _, word := range strings.Fields(s) {
+		for len([]rune(word)) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			r := []rune(word)
+			lines = append(lines, string(r[:width]))
+			word = string(r[width:])
+		}
+		switch {
+		case line == "":
+			line = word
+		case len([]rune(line))+1+len([]rune(word)) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,285 @@
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Jenis dokumen
+const (
+	TypeInvoice    = "invoice"
+	TypeCreditNote = "credit_note" // nota kredit atas refund invoice
+)
+
+type Format string
+
+const (
+	FormatPDF       Format = "pdf"      // A4
+	FormatReceipt58 Format = "escpos58" // struk printer thermal 58mm
+	FormatReceipt80 Format = "escpos80" // struk printer thermal 80mm
+)
+
+const (
+	ContentTypePDF    = "application/pdf"
+	ContentTypeESCPOS = "application/octet-stream"
+)
+
+var (
+	ErrUnknownFormat = errors.New("format dokumen tidak dikenali (pdf, escpos58, escpos80)")
+	ErrEmptyDocument = errors.New("dokumen tidak memiliki baris")
+	ErrNotConfigured = errors.New("INVOICE_COMPANY_NAME kosong, cetak / unduh invoice dinonaktifkan")
+)
+
+var prefixRegex = regexp.MustCompile(`^[A-Z0-9]{1,8}$`)
+
+func (f Format) Valid() bool {
+	switch f {
+	case FormatPDF, FormatReceipt58, FormatReceipt80:
+		return true
+	}
+	return false
+}
+
+func (f Format) ContentType() string {
+	if f == FormatPDF {
+		return ContentTypePDF
+	}
+	return ContentTypeESCPOS
+}
+
+// Extension struk ESC/POS dikirim apa adanya ke printer, umum disimpan sebagai .prn
+func (f Format) Extension() string {
+	if f == FormatPDF {
+		return ".pdf"
+	}
+	return ".prn"
+}
+
+// Company identitas penjual yang dicetak di kepala dokumen
+type Company struct {
+	Name    string
+	Address string
+	Phone   string
+	Email   string
+	NPWP    string
+}
+
+// Config identitas penjual dan prefix penomoran dokumen
+type Config struct {
+	Company          Company
+	InvoicePrefix    string
+	CreditNotePrefix string
+}
+
+var Default Config
+
+// InitInvoice membaca identitas penjual (INVOICE_COMPANY_*) dan prefix nomor dokumen
+// (INVOICE_PREFIX, CREDIT_NOTE_PREFIX). Tanpa nama perusahaan invoice tetap diterbitkan
+// (penomoran tidak terputus), hanya cetak / unduh yang ditolak; ErrNotConfigured sebagai peringatan.
+func InitInvoice() error {
+	cfg, err := loadConfig()
+	if err != nil && !errors.Is(err, ErrNotConfigured) {
+		return err
+	}
+	Default = cfg
+	return err
+}
+
+func loadConfig() (Config, error) {
+	cfg := Config{
+		Company: Company{
+			Name:    os.Getenv("INVOICE_COMPANY_NAME"),
+			Address: os.Getenv("INVOICE_COMPANY_ADDRESS"),
+			Phone:   os.Getenv("INVOICE_COMPANY_PHONE"),
+			Email:   os.Getenv("INVOICE_COMPANY_EMAIL"),
+			NPWP:    os.Getenv("INVOICE_COMPANY_NPWP"),
+		},
+		InvoicePrefix:    "INV",
+		CreditNotePrefix: "CN",
+	}
+	if prefix := os.Getenv("INVOICE_PREFIX"); prefix != "" {
+		cfg.InvoicePrefix = prefix
+	}
+	if prefix := os.Getenv("CREDIT_NOTE_PREFIX"); prefix != "" {
+		cfg.CreditNotePrefix = prefix
+	}
+
+	if !prefixRegex.MatchString(cfg.InvoicePrefix) {
+		return Config{}, fmt.Errorf("INVOICE_PREFIX tidak valid: %q (huruf besar / angka, maks 8 karakter)", cfg.InvoicePrefix)
+	}
+	if !prefixRegex.MatchString(cfg.CreditNotePrefix) {
+		return Config{}, fmt.Errorf("CREDIT_NOTE_PREFIX tidak valid: %q (huruf besar / angka, maks 8 karakter)", cfg.CreditNotePrefix)
+	}
+	if cfg.InvoicePrefix == cfg.CreditNotePrefix {
+		return Config{}, errors.New("INVOICE_PREFIX dan CREDIT_NOTE_PREFIX tidak boleh sama")
+	}
+	if cfg.Company.Name == "" {
+		return cfg, ErrNotConfigured
+	}
+	return cfg, nil
+}
+
+// Number nomor dokumen PREFIX/CABANG/TAHUN/URUT, mis. INV/01/2026/000123. Nomor urut berjalan
+// per jenis dokumen, cabang dan tahun.
+func (c Config) Number(docType string, branchID int64, year int, sequence int64) string {
+	prefix := c.InvoicePrefix
+	if docType == TypeCreditNote {
+		prefix = c.CreditNotePrefix
+	}
+	return fmt.Sprintf("%s/%02d/%d/%06d", prefix, branchID, year, sequence)
+}
+
+// Document data yang dicetak pada invoice / nota kredit. Nominal positif, nota kredit
+// mengurangi invoice yang dirujuk.
+type Document struct {
+	Type        string
+	Number      string
+	IssuedAt    time.Time
+	OrderNumber string
+	Reference   string // nomor invoice yang dikreditkan (nota kredit)
+	Note        string
+	Copy        int // cetak ulang ke-n, 0 = asli
+
+	CustomerName    string
+	CustomerPhone   string
+	CustomerEmail   string
+	CustomerAddress string
+
+	TaxInclusive  bool // harga baris sudah termasuk pajak
+	Lines         []Line
+	Taxes         []Tax
+	Subtotal      float64
+	DiscountTotal float64
+	ShippingTotal float64
+	TaxTotal      float64
+	GrandTotal    float64
+}
+
+// Line satu baris dokumen, Amount = Quantity x UnitPrice - Discount
+type Line struct {
+	Name      string
+	Code      string
+	Quantity  int
+	UnitPrice float64
+	Discount  float64
+	Amount    float64
+}
+
+// GrossAmount nilai baris sebelum diskon
+func (l Line) GrossAmount() float64 {
+	return round2(float64(l.Quantity) * l.UnitPrice)
+}
+
+// Tax rekap pajak per tarif
+type Tax struct {
+	Name          string
+	Rate          float64
+	TaxableAmount float64
+	TaxAmount     float64
+}
+
+// Title judul dokumen
+func (d Document) Title() string {
+	if d.Type == TypeCreditNote {
+		return "NOTA KREDIT"
+	}
+	return "INVOICE"
+}
+
+// CopyLabel penanda cetak ulang, kosong untuk cetakan asli
+func (d Document) CopyLabel() string {
+	if d.Copy <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("SALINAN KE-%d", d.Copy)
+}
+
+// Render menghasilkan dokumen sesuai format yang diminta
+func Render(format Format, company Company, doc Document) ([]byte, error) {
+	if len(doc.Lines) == 0 {
+		return nil, ErrEmptyDocument
+	}
+
+	switch format {
+	case FormatPDF:
+		return RenderPDF(company, doc)
+	case FormatReceipt58:
+		return RenderReceipt(company, doc, receiptColumns58)
+	case FormatReceipt80:
+		return RenderReceipt(company, doc, receiptColumns80)
+	}
+	return nil, ErrUnknownFormat
+}
+
+// ScaleTaxes rekap pajak invoice untuk nota kredit sebesar taxTotal, dibagi sesuai porsi pajak
+// tiap tarif; selisih pembulatan masuk ke tarif terakhir yang memiliki pajak
+func ScaleTaxes(taxes []Tax, ratio, taxTotal float64) []Tax {
+	result := make([]Tax, len(taxes))
+	var allocated float64
+	last := -1
+	for i, t := range taxes {
+		result[i] = Tax{
+			Name:          t.Name,
+			Rate:          t.Rate,
+			TaxableAmount: round2(t.TaxableAmount * ratio),
+			TaxAmount:     round2(t.TaxAmount * ratio),
+		}
+		allocated += result[i].TaxAmount
+		if t.TaxAmount > 0 {
+			last = i
+		}
+	}
+	if last >= 0 {
+		result[last].TaxAmount = round2(result[last].TaxAmount + taxTotal - allocated)
+	}
+	return result
+}
+
+// FormatAmount nominal dengan pemisah ribuan titik dan dua desimal, mis. 1.234.567,50
+func FormatAmount(amount float64) string {
+	cents := int64(math.Round(amount * 100))
+	negative := cents < 0
+	if negative {
+		cents = -cents
+	}
+
+	digits := fmt.Sprintf("%d", cents/100)
+	var b strings.Builder
+	if negative {
+		b.WriteByte('-')
+	}
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+	fmt.Fprintf(&b, ",%02d", cents%100)
+	return b.String()
+}
+
+// FormatRate persen tarif tanpa nol di belakang, mis. 11% / 2,5%
+func FormatRate(rate float64) string {
+	s := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", rate), "0"), ".")
+	return strings.Replace(s, ".", ",", 1) + "%"
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	if max <= 3 {
+		return string(r[:max])
+	}
+	return string(r[:max-3]) + "..."
+}
@@ -0,0 +1,178 @@
+package invoice
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testDocument() Document {
+	return Document{
+		Type:          TypeInvoice,
+		Number:        "INV/01/2026/000042",
+		IssuedAt:      time.Date(2026, 10, 19, 14, 30, 0, 0, time.UTC),
+		OrderNumber:   "ORD-20261019-ABC123",
+		CustomerName:  "Budi Santoso",
+		CustomerPhone: "08123456789",
+		Lines: []Line{
+			{Name: "Kaos Polos Katun Combed 30s Lengan Pendek Warna Hitam", Code: "KP-001", Quantity: 2, UnitPrice: 75000, Discount: 5000, Amount: 145000},
+			{Name: "Topi", Quantity: 1, UnitPrice: 50000, Amount: 50000},
+		},
+		Taxes:         []Tax{{Name: "PPN 11%", Rate: 11, TaxableAmount: 195000, TaxAmount: 21450}},
+		Subtotal:      200000,
+		DiscountTotal: 5000,
+		ShippingTotal: 15000,
+		TaxTotal:      21450,
+		GrandTotal:    231450,
+	}
+}
+
+func TestConfigNumber(t *testing.T) {
+	cfg := Config{InvoicePrefix: "INV", CreditNotePrefix: "CN"}
+
+	if got := cfg.Number(TypeInvoice, 1, 2026, 42); got != "INV/01/2026/000042" {
+		t.Errorf("Number invoice = %q", got)
+	}
+	if got := cfg.Number(TypeCreditNote, 0, 2027, 1); got != "CN/00/2027/000001" {
+		t.Errorf("Number credit note = %q", got)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("INVOICE_COMPANY_NAME", "PT Pleasurelove")
+	t.Setenv("INVOICE_PREFIX", "")
+	t.Setenv("CREDIT_NOTE_PREFIX", "")
+	cfg, err := loadConfig()
+	if err != nil || cfg.InvoicePrefix != "INV" || cfg.CreditNotePrefix != "CN" {
+		t.Errorf("default = %+v, %v", cfg, err)
+	}
+
+	t.Setenv("CREDIT_NOTE_PREFIX", "INV")
+	if _, err := loadConfig(); err == nil {
+		t.Error("prefix invoice dan nota kredit sama harus error")
+	}
+
+	t.Setenv("CREDIT_NOTE_PREFIX", "cn/x")
+	if _, err := loadConfig(); err == nil {
+		t.Error("prefix tidak valid harus error")
+	}
+
+	t.Setenv("CREDIT_NOTE_PREFIX", "")
+	t.Setenv("INVOICE_COMPANY_NAME", "")
+	if cfg, err := loadConfig(); !errors.Is(err, ErrNotConfigured) || cfg.InvoicePrefix != "INV" {
+		t.Errorf("tanpa nama perusahaan = %+v, %v, want ErrNotConfigured", cfg, err)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := map[float64]string{
+		0:          "0,00",
+		950:        "950,00",
+		1234567.5:  "1.234.567,50",
+		-21450.005: "-21.450,01",
+	}
+	for amount, want := range tests {
+		if got := FormatAmount(amount); got != want {
+			t.Errorf("FormatAmount(%v) = %q, want %q", amount, got, want)
+		}
+	}
+
+	if got := FormatRate(11); got != "11%" {
+		t.Errorf("FormatRate(11) = %q", got)
+	}
+	if got := FormatRate(2.5); got != "2,5%" {
+		t.Errorf("FormatRate(2.5) = %q", got)
+	}
+}
+
+func TestScaleTaxes(t *testing.T) {
+	taxes := []Tax{
+		{Name: "PPN 11%", Rate: 11, TaxableAmount: 100, TaxAmount: 11},
+		{Name: "Bebas PPN", Rate: 0, TaxableAmount: 50, TaxAmount: 0},
+		{Name: "PPN 12%", Rate: 12, TaxableAmount: 100, TaxAmount: 12},
+	}
+	// refund sepertiga: 23 / 3 = 7.67, selisih pembulatan masuk ke PPN 12%
+	got := ScaleTaxes(taxes, 1.0/3, 7.67)
+
+	var sum float64
+	for _, tax := range got {
+		sum += tax.TaxAmount
+	}
+	if round2(sum) != 7.67 {
+		t.Errorf("jumlah pajak = %v, want 7.67 (%+v)", round2(sum), got)
+	}
+	if got[0].TaxAmount != 3.67 || got[1].TaxAmount != 0 || got[2].TaxAmount != 4 {
+		t.Errorf("ScaleTaxes = %+v", got)
+	}
+}
+
+func TestRenderReceipt(t *testing.T) {
+	doc := testDocument()
+	doc.Copy = 1
+	doc.CustomerName = "Budi Séntoso"
+
+	for _, format := range []Format{FormatReceipt58, FormatReceipt80} {
+		columns := receiptColumns58
+		if format == FormatReceipt80 {
+			columns = receiptColumns80
+		}
+
+		out, err := Render(format, Company{Name: "Pleasurelove", Address: "Jl. Merdeka No. 1, Bandung"}, doc)
+		if err != nil {
+			t.Fatalf("Render(%s): %v", format, err)
+		}
+		if !bytes.HasPrefix(out, escInit) || !bytes.HasSuffix(out, escFeedCut) {
+			t.Errorf("%s: struk harus diawali init dan diakhiri potong kertas", format)
+		}
+
+		text := string(out)
+		for _, want := range []string{"INVOICE", "SALINAN KE-1", "INV/01/2026/000042", "Budi S?ntoso", "231.450,00"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("%s: struk tidak memuat %q", format, want)
+			}
+		}
+
+		// setiap baris teks (tanpa perintah ESC/POS) tidak melebihi lebar kertas
+		for _, line := range strings.Split(text, "\n") {
+			line = stripCommands(line)
+			if len(line) > columns {
+				t.Errorf("%s: baris %q lebih dari %d karakter", format, line, columns)
+			}
+		}
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	doc := testDocument()
+	for i := 0; i < 60; i++ {
+		doc.Lines = append(doc.Lines, Line{Name: "Barang tambahan", Quantity: 1, UnitPrice: 1000, Amount: 1000})
+	}
+	doc.Type = TypeCreditNote
+	doc.Reference = "INV/01/2026/000041"
+	doc.Note = "Refund barang rusak"
+
+	out, err := Render(FormatPDF, Company{Name: "Pleasurelove", NPWP: "01.234.567.8-901.000"}, doc)
+	if err != nil {
+		t.Fatalf("Render pdf: %v", err)
+	}
+	if !bytes.HasPrefix(out, []byte("%PDF-")) {
+		t.Error("hasil bukan file PDF")
+	}
+
+	if _, err := Render(FormatPDF, Company{}, Document{}); err != ErrEmptyDocument {
+		t.Errorf("dokumen kosong = %v, want ErrEmptyDocument", err)
+	}
+	if _, err := Render("html", Company{}, testDocument()); err != ErrUnknownFormat {
+		t.Errorf("format html = %v, want ErrUnknownFormat", err)
+	}
+}
+
+// stripCommands menghapus perintah ESC/POS yang dipakai struk dari satu baris
+func stripCommands(line string) string {
+	for _, cmd := range [][]byte{escInit, escAlignLeft, escAlignCenter, escBoldOn, escBoldOff, escDoubleOn, escDoubleOff, escFeedCut} {
+		line = strings.ReplaceAll(line, string(cmd), "")
+	}
+	return line
+}
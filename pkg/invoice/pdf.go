@@ -0,0 +1,255 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Layout A4 portrait dalam mm
+const (
+	pdfMargin       = 15.0
+	pdfPageWidth    = 210.0
+	pdfContentWidth = pdfPageWidth - 2*pdfMargin
+	pdfPageBottom   = 297.0 - 20 // batas bawah baris tabel sebelum pindah halaman
+	pdfRowHeight    = 6.0
+	pdfMaxNameChars = 60
+)
+
+// Kolom tabel baris: No, Produk, Qty, Harga, Diskon, Jumlah
+var pdfColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"No", 10, "C"},
+	{"Produk", 68, "L"},
+	{"Qty", 14, "R"},
+	{"Harga", 30, "R"},
+	{"Diskon", 28, "R"},
+	{"Jumlah", 30, "R"},
+}
+
+// RenderPDF invoice / nota kredit A4, tabel baris berlanjut ke halaman berikutnya bila penuh
+func RenderPDF(company Company, doc Document) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetTitle(doc.Title()+" "+doc.Number, true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 7)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(pdfContentWidth/2, 4, tr(doc.Number), "", 0, "L", false, 0, "")
+		pdf.CellFormat(pdfContentWidth/2, 4, fmt.Sprintf("Halaman %d", pdf.PageNo()), "", 0, "R", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+
+	pdf.AddPage()
+	drawPDFHeader(pdf, tr, company, doc)
+	drawPDFCustomer(pdf, tr, doc)
+
+	drawPDFTableHeader(pdf, tr)
+	for i, line := range doc.Lines {
+		if pdf.GetY()+pdfRowHeight > pdfPageBottom {
+			pdf.AddPage()
+			drawPDFTableHeader(pdf, tr)
+		}
+		name := truncate(line.Name, pdfMaxNameChars)
+		if line.Code != "" {
+			name = truncate(line.Name, pdfMaxNameChars-len(line.Code)-3) + " (" + line.Code + ")"
+		}
+		values := []string{
+			fmt.Sprintf("%d", i+1),
+			name,
+			fmt.Sprintf("%d", line.Quantity),
+			FormatAmount(line.UnitPrice),
+			FormatAmount(line.Discount),
+			FormatAmount(line.Amount),
+		}
+		pdf.SetFont("Helvetica", "", 8)
+		for c, col := range pdfColumns {
+			pdf.CellFormat(col.width, pdfRowHeight, tr(values[c]), "B", 0, col.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	drawPDFTotals(pdf, tr, doc)
+	drawPDFTaxes(pdf, tr, doc)
+
+	if doc.Note != "" {
+		ensurePDFSpace(pdf, 14)
+		pdf.Ln(4)
+		pdf.SetFont("Helvetica", "B", 8)
+		pdf.CellFormat(pdfContentWidth, 5, "Catatan", "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.MultiCell(pdfContentWidth, 4, tr(doc.Note), "", "L", false)
+	}
+
+	ensurePDFSpace(pdf, 10)
+	pdf.Ln(6)
+	pdf.SetFont("Helvetica", "I", 7)
+	pdf.CellFormat(pdfContentWidth, 4, "Dokumen ini dibuat secara elektronik dan sah tanpa tanda tangan.", "", 1, "L", false, 0, "")
+
+	if err := pdf.Error(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func drawPDFHeader(pdf *fpdf.Fpdf, tr func(string) string, company Company, doc Document) {
+	top := pdf.GetY()
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(pdfContentWidth/2, 7, tr(company.Name), "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 8)
+	if company.Address != "" {
+		pdf.MultiCell(pdfContentWidth/2, 4, tr(company.Address), "", "L", false)
+	}
+	for _, info := range []string{labelled("Telp", company.Phone), labelled("Email", company.Email), labelled("NPWP", company.NPWP)} {
+		if info != "" {
+			pdf.CellFormat(pdfContentWidth/2, 4, tr(info), "", 2, "L", false, 0, "")
+		}
+	}
+	bottom := pdf.GetY()
+
+	right := pdfMargin + pdfContentWidth/2
+	pdf.SetXY(right, top)
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(pdfContentWidth/2, 8, doc.Title(), "", 2, "R", false, 0, "")
+	if label := doc.CopyLabel(); label != "" {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetTextColor(200, 0, 0)
+		pdf.CellFormat(pdfContentWidth/2, 5, label, "", 2, "R", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	}
+
+	pdf.SetFont("Helvetica", "", 9)
+	info := [][2]string{
+		{"Nomor", doc.Number},
+		{"Tanggal", doc.IssuedAt.Format("02/01/2006")},
+		{"No. Order", doc.OrderNumber},
+	}
+	if doc.Reference != "" {
+		info = append(info, [2]string{"Atas Invoice", doc.Reference})
+	}
+	for _, row := range info {
+		pdf.SetX(right)
+		pdf.CellFormat(pdfContentWidth/4, 5, row[0], "", 0, "R", false, 0, "")
+		pdf.CellFormat(pdfContentWidth/4, 5, tr(row[1]), "", 1, "R", false, 0, "")
+	}
+
+	pdf.SetY(max(bottom, pdf.GetY()) + 3)
+	pdf.Line(pdfMargin, pdf.GetY(), pdfMargin+pdfContentWidth, pdf.GetY())
+	pdf.Ln(3)
+}
+
+func drawPDFCustomer(pdf *fpdf.Fpdf, tr func(string) string, doc Document) {
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.CellFormat(pdfContentWidth, 5, "Kepada", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	for _, info := range []string{doc.CustomerName, doc.CustomerPhone, doc.CustomerEmail} {
+		if info != "" {
+			pdf.CellFormat(pdfContentWidth, 5, tr(info), "", 1, "L", false, 0, "")
+		}
+	}
+	if doc.CustomerAddress != "" {
+		pdf.MultiCell(pdfContentWidth/2, 4, tr(doc.CustomerAddress), "", "L", false)
+	}
+	pdf.Ln(4)
+}
+
+func drawPDFTableHeader(pdf *fpdf.Fpdf, tr func(string) string) {
+	pdf.SetFont("Helvetica", "B", 8)
+	pdf.SetFillColor(235, 235, 235)
+	for _, col := range pdfColumns {
+		pdf.CellFormat(col.width, pdfRowHeight, tr(col.title), "TB", 0, col.align, true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+func drawPDFTotals(pdf *fpdf.Fpdf, tr func(string) string, doc Document) {
+	rows := [][2]string{{"Subtotal", FormatAmount(doc.Subtotal)}}
+	if doc.DiscountTotal > 0 {
+		rows = append(rows, [2]string{"Diskon", "-" + FormatAmount(doc.DiscountTotal)})
+	}
+	if doc.TaxInclusive {
+		rows = append(rows, [2]string{"Pajak (termasuk dalam harga)", FormatAmount(doc.TaxTotal)})
+	} else {
+		rows = append(rows, [2]string{"Pajak", FormatAmount(doc.TaxTotal)})
+	}
+	if doc.ShippingTotal > 0 {
+		rows = append(rows, [2]string{"Ongkos kirim", FormatAmount(doc.ShippingTotal)})
+	}
+
+	ensurePDFSpace(pdf, float64(len(rows)+2)*pdfRowHeight)
+	pdf.Ln(2)
+	labelWidth, valueWidth := 60.0, 30.0
+	left := pdfMargin + pdfContentWidth - labelWidth - valueWidth
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, row := range rows {
+		pdf.SetX(left)
+		pdf.CellFormat(labelWidth, pdfRowHeight, tr(row[0]), "", 0, "R", false, 0, "")
+		pdf.CellFormat(valueWidth, pdfRowHeight, row[1], "", 1, "R", false, 0, "")
+	}
+
+	pdf.SetX(left)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(labelWidth, pdfRowHeight+1, "Total", "T", 0, "R", false, 0, "")
+	pdf.CellFormat(valueWidth, pdfRowHeight+1, FormatAmount(doc.GrandTotal), "T", 1, "R", false, 0, "")
+}
+
+// drawPDFTaxes rekap pajak per tarif (DPP dan pajak)
+func drawPDFTaxes(pdf *fpdf.Fpdf, tr func(string) string, doc Document) {
+	if len(doc.Taxes) == 0 {
+		return
+	}
+
+	ensurePDFSpace(pdf, float64(len(doc.Taxes)+3)*pdfRowHeight)
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.CellFormat(pdfContentWidth, 5, "Rincian Pajak", "", 1, "L", false, 0, "")
+
+	widths := []float64{70, 20, 40, 40}
+	pdf.SetFont("Helvetica", "B", 8)
+	pdf.SetFillColor(235, 235, 235)
+	for i, title := range []string{"Pajak", "Tarif", "DPP", "Nilai Pajak"} {
+		align := "R"
+		if i == 0 {
+			align = "L"
+		}
+		pdf.CellFormat(widths[i], pdfRowHeight, title, "TB", 0, align, true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 8)
+	for _, t := range doc.Taxes {
+		pdf.CellFormat(widths[0], pdfRowHeight, tr(t.Name), "B", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], pdfRowHeight, FormatRate(t.Rate), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[2], pdfRowHeight, FormatAmount(t.TaxableAmount), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], pdfRowHeight, FormatAmount(t.TaxAmount), "B", 1, "R", false, 0, "")
+	}
+}
+
+// ensurePDFSpace pindah halaman bila sisa halaman kurang dari height
+func ensurePDFSpace(pdf *fpdf.Fpdf, height float64) {
+	if pdf.GetY()+height > pdfPageBottom {
+		pdf.AddPage()
+	}
+}
+
+func labelled(label, value string) string {
+	if value == "" {
+		return ""
+	}
+	return label + ": " + value
+}
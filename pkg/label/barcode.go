@@ -0,0 +1,82 @@
+package label
+
+import (
+	"fmt"
+	"image"
+	"regexp"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/ean"
+	"github.com/boombuler/barcode/qr"
+)
+
+var eanRegex = regexp.MustCompile(`^[0-9]{12,13}$`)
+
+// ValidateCode memastikan isi barcode bisa di-encode dengan symbology yang dipilih
+func ValidateCode(symbology Symbology, code string) error {
+	if code == "" {
+		return fmt.Errorf("kode barcode tidak boleh kosong")
+	}
+
+	switch symbology {
+	case SymbologyEAN13:
+		if !eanRegex.MatchString(code) {
+			return fmt.Errorf("kode %q tidak valid untuk EAN-13 (harus 12 atau 13 digit angka)", code)
+		}
+		if _, err := ean.Encode(code); err != nil {
+			return fmt.Errorf("kode %q tidak valid untuk EAN-13 (check digit salah)", code)
+		}
+	case SymbologyCode128:
+		for _, r := range code {
+			if r > 127 {
+				return fmt.Errorf("kode %q tidak valid untuk Code128 (hanya karakter ASCII)", code)
+			}
+		}
+	case SymbologyQR:
+	default:
+		return ErrUnknownSymbology
+	}
+
+	return nil
+}
+
+// EncodeBarcode meng-encode code dan men-scale hasilnya ke ukuran pixel yang diminta
+func EncodeBarcode(symbology Symbology, code string, width, height int) (image.Image, error) {
+	if err := ValidateCode(symbology, code); err != nil {
+		return nil, err
+	}
+
+	var (
+		bc  barcode.Barcode
+		err error
+	)
+	switch symbology {
+	case SymbologyCode128:
+		bc, err = code128.Encode(code)
+	case SymbologyEAN13:
+		bc, err = ean.Encode(code)
+	case SymbologyQR:
+		bc, err = qr.Encode(code, qr.M, qr.Auto)
+		// QR harus persegi
+		if width > height {
+			width = height
+		} else {
+			height = width
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal encode barcode %q: %w", code, err)
+	}
+
+	// ukuran minimal harus >= jumlah modul barcode
+	bounds := bc.Bounds()
+	if width < bounds.Dx() {
+		width = bounds.Dx()
+	}
+	if symbology == SymbologyQR && height < bounds.Dy() {
+		height = bounds.Dy()
+	}
+
+	return barcode.Scale(bc, width, height)
+}
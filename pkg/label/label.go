@@ -0,0 +1,129 @@
+package label
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+type Symbology string
+
+const (
+	SymbologyCode128 Symbology = "code128"
+	SymbologyEAN13   Symbology = "ean13"
+	SymbologyQR      Symbology = "qr"
+)
+
+type Format string
+
+const (
+	FormatPNG Format = "png"
+	FormatPDF Format = "pdf"
+	FormatZPL Format = "zpl"
+)
+
+const (
+	ContentTypePNG = "image/png"
+	ContentTypePDF = "application/pdf"
+	ContentTypeZPL = "application/zpl"
+)
+
+var (
+	ErrUnknownSymbology = errors.New("jenis barcode tidak dikenali (code128, ean13, qr)")
+	ErrUnknownFormat    = errors.New("format label tidak dikenali (png, pdf, zpl)")
+	ErrEmptyLabel       = errors.New("tidak ada label yang akan dicetak")
+	ErrTooManyPNGLabels = fmt.Errorf("label png maksimal %d per permintaan, gunakan format pdf atau zpl", MaxPNGLabels)
+)
+
+// Label berisi data yang dicetak pada satu label rak
+type Label struct {
+	Name  string
+	Code  string // isi barcode (kode / barcode produk)
+	Price float64
+}
+
+func (s Symbology) Valid() bool {
+	switch s {
+	case SymbologyCode128, SymbologyEAN13, SymbologyQR:
+		return true
+	}
+	return false
+}
+
+func (f Format) Valid() bool {
+	switch f {
+	case FormatPNG, FormatPDF, FormatZPL:
+		return true
+	}
+	return false
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatPNG:
+		return ContentTypePNG
+	case FormatPDF:
+		return ContentTypePDF
+	case FormatZPL:
+		return ContentTypeZPL
+	}
+	return "application/octet-stream"
+}
+
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// Render menghasilkan dokumen label sesuai format yang diminta
+func Render(format Format, symbology Symbology, labels []Label) ([]byte, error) {
+	if len(labels) == 0 {
+		return nil, ErrEmptyLabel
+	}
+
+	if !symbology.Valid() {
+		return nil, ErrUnknownSymbology
+	}
+
+	switch format {
+	case FormatPNG:
+		return RenderPNG(symbology, labels)
+	case FormatPDF:
+		return RenderPDF(symbology, labels)
+	case FormatZPL:
+		return RenderZPL(symbology, labels)
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+// FormatRupiah memformat harga ke format "Rp 150.000"
+func FormatRupiah(amount float64) string {
+	value := int64(math.Round(amount))
+	negative := value < 0
+	if negative {
+		value = -value
+	}
+
+	digits := fmt.Sprintf("%d", value)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+
+	if negative {
+		return "-Rp " + b.String()
+	}
+	return "Rp " + b.String()
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-3]) + "..."
+}
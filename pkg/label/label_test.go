@@ -0,0 +1,149 @@
+package label
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestValidateCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		symbology Symbology
+		code      string
+		wantErr   bool
+	}{
+		{"code128 ascii", SymbologyCode128, "KP-001", false},
+		{"code128 non ascii", SymbologyCode128, "KAOS-É", true},
+		{"ean13 13 digit", SymbologyEAN13, "8991234567891", false},
+		{"ean13 12 digit", SymbologyEAN13, "899123456789", false},
+		{"ean13 check digit salah", SymbologyEAN13, "8991234567890", true},
+		{"ean13 huruf", SymbologyEAN13, "89912345678A", true},
+		{"qr bebas", SymbologyQR, "https://pleasurelove.id/p/kaos", false},
+		{"kosong", SymbologyQR, "", true},
+		{"symbology tidak dikenal", "upc", "123", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCode(tt.symbology, tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCode(%s, %q) = %v, wantErr %v", tt.symbology, tt.code, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatRupiah(t *testing.T) {
+	tests := map[float64]string{
+		0:         "Rp 0",
+		950:       "Rp 950",
+		150000:    "Rp 150.000",
+		1234567.6: "Rp 1.234.568",
+		-2500:     "-Rp 2.500",
+	}
+	for amount, want := range tests {
+		if got := FormatRupiah(amount); got != want {
+			t.Errorf("FormatRupiah(%v) = %q, want %q", amount, got, want)
+		}
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+	labels := []Label{
+		{Name: "Kaos Polos", Code: "KP-001", Price: 75000},
+		{Name: "Topi", Code: "TP-001", Price: 50000},
+		{Name: "Celana", Code: "CL-001", Price: 120000},
+		{Name: "Jaket", Code: "JK-001", Price: 250000},
+	}
+	out, err := Render(FormatPNG, SymbologyCode128, labels)
+	if err != nil {
+		t.Fatalf("Render png: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("hasil bukan PNG: %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(3*pngLabelWidth, 2*pngLabelHeight) {
+		t.Errorf("ukuran = %v, want 3 kolom x 2 baris", got)
+	}
+
+	many := make([]Label, MaxPNGLabels+1)
+	for i := range many {
+		many[i] = labels[0]
+	}
+	if _, err := Render(FormatPNG, SymbologyCode128, many); !errors.Is(err, ErrTooManyPNGLabels) {
+		t.Errorf("err = %v, want ErrTooManyPNGLabels", err)
+	}
+}
+
+func TestRenderPNGLongCode128(t *testing.T) {
+	// kode 80 karakter lebih lebar dari label (1 pixel per modul), harus diperkecil
+	long := strings.Repeat("AB-", 26) + "XY"
+	out, err := RenderPNG(SymbologyCode128, []Label{
+		{Name: "Kode panjang", Code: long, Price: 1000},
+		{Name: "Kode pendek", Code: "A1", Price: 1000},
+	})
+	if err != nil {
+		t.Fatalf("RenderPNG: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// padding kiri label kedua harus tetap putih (barcode label pertama tidak melewati batas)
+	y := pngPadding + 24 + pngBarcodeHeight/2
+	for x := pngLabelWidth + 1; x < pngLabelWidth+pngPadding; x++ {
+		if r, g, b, _ := img.At(x, y).RGBA(); r != 0xffff || g != 0xffff || b != 0xffff {
+			t.Fatalf("pixel (%d, %d) tidak putih, barcode keluar dari label", x, y)
+		}
+	}
+
+	// barcode label pertama tetap tergambar
+	var dark int
+	for x := pngPadding; x < pngLabelWidth-pngPadding; x++ {
+		if r, _, _, _ := img.At(x, y).RGBA(); r == 0 {
+			dark++
+		}
+	}
+	if dark == 0 {
+		t.Error("barcode label pertama tidak tergambar")
+	}
+}
+
+func TestRenderPDFAndZPL(t *testing.T) {
+	labels := make([]Label, pdfColumns*pdfRows+1)
+	for i := range labels {
+		labels[i] = Label{Name: "Kaos_Polos^", Code: "8991234567891", Price: 75000}
+	}
+
+	out, err := Render(FormatPDF, SymbologyEAN13, labels)
+	if err != nil {
+		t.Fatalf("Render pdf: %v", err)
+	}
+	if !bytes.HasPrefix(out, []byte("%PDF-")) {
+		t.Error("hasil bukan file PDF")
+	}
+
+	out, err = Render(FormatZPL, SymbologyEAN13, labels[:2])
+	if err != nil {
+		t.Fatalf("Render zpl: %v", err)
+	}
+	zpl := string(out)
+	if strings.Count(zpl, "^XA") != 2 || strings.Count(zpl, "^XZ") != 2 {
+		t.Errorf("zpl harus berisi 2 label:\n%s", zpl)
+	}
+	if !strings.Contains(zpl, "^FD899123456789^FS") || !strings.Contains(zpl, "Kaos_5FPolos_5E") {
+		t.Errorf("zpl tidak memuat barcode 12 digit / nama ter-escape:\n%s", zpl)
+	}
+
+	if _, err := Render(FormatPDF, SymbologyQR, nil); !errors.Is(err, ErrEmptyLabel) {
+		t.Errorf("tanpa label = %v, want ErrEmptyLabel", err)
+	}
+	if _, err := Render("svg", SymbologyQR, labels[:1]); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("format svg = %v, want ErrUnknownFormat", err)
+	}
+}
@@ -0,0 +1,89 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Layout lembar A4 3 x 8 label (70 x 37 mm), umum dipakai untuk sticker label rak
+const (
+	pdfColumns       = 3
+	pdfRows          = 8
+	pdfLabelWidth    = 70.0
+	pdfLabelHeight   = 37.0
+	pdfMarginTop     = 0.5
+	pdfPadding       = 3.0
+	pdfBarcodeWidth  = pdfLabelWidth - 2*pdfPadding
+	pdfBarcodeHeight = 16.0
+	pdfQRSize        = 20.0
+	pdfMaxNameChars  = 40
+	pdfPixelPerMM    = 12 // resolusi barcode sebelum ditempel ke PDF (~300dpi)
+)
+
+// RenderPDF menyusun label ke lembar A4, otomatis menambah halaman bila penuh
+func RenderPDF(symbology Symbology, labels []Label) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	perPage := pdfColumns * pdfRows
+	for i, l := range labels {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+
+		pos := i % perPage
+		x := float64(pos%pdfColumns) * pdfLabelWidth
+		y := pdfMarginTop + float64(pos/pdfColumns)*pdfLabelHeight
+
+		if err := drawPDFLabel(pdf, tr, fmt.Sprintf("bc-%d", i), x, y, symbology, l); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func drawPDFLabel(pdf *fpdf.Fpdf, tr func(string) string, imageName string, x, y float64, symbology Symbology, l Label) error {
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.Rect(x, y, pdfLabelWidth, pdfLabelHeight, "D")
+
+	pdf.SetFont("Helvetica", "B", 8)
+	pdf.Text(x+pdfPadding, y+pdfPadding+3, tr(truncate(l.Name, pdfMaxNameChars)))
+
+	w, h := pdfBarcodeWidth, pdfBarcodeHeight
+	if symbology == SymbologyQR {
+		w, h = pdfQRSize, pdfQRSize
+	}
+
+	bc, err := EncodeBarcode(symbology, l.Code, int(w*pdfPixelPerMM), int(h*pdfPixelPerMM))
+	if err != nil {
+		return err
+	}
+
+	var img bytes.Buffer
+	if err := png.Encode(&img, bc); err != nil {
+		return err
+	}
+
+	pdf.RegisterImageOptionsReader(imageName, fpdf.ImageOptions{ImageType: "PNG"}, &img)
+	pdf.ImageOptions(imageName, x+(pdfLabelWidth-w)/2, y+pdfPadding+5, w, h, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	bottom := y + pdfLabelHeight - pdfPadding
+	pdf.SetFont("Helvetica", "", 7)
+	pdf.Text(x+pdfPadding, bottom, tr(l.Code))
+
+	price := tr(FormatRupiah(l.Price))
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.Text(x+pdfLabelWidth-pdfPadding-pdf.GetStringWidth(price), bottom, price)
+
+	return pdf.Error()
+}
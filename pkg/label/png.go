@@ -0,0 +1,117 @@
+package label
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	pngLabelWidth    = 360
+	pngLabelHeight   = 220
+	pngPadding       = 12
+	pngColumns       = 3
+	pngBarcodeWidth  = pngLabelWidth - 2*pngPadding
+	pngBarcodeHeight = 110
+	pngMaxNameChars  = 48
+)
+
+// MaxPNGLabels batas label dalam satu gambar PNG (3 x 10 label, 1080 x 2200 px), jumlah besar
+// memakai PDF / ZPL yang dibagi per halaman
+const MaxPNGLabels = 30
+
+// RenderPNG menyusun semua label dalam satu gambar grid (3 kolom)
+func RenderPNG(symbology Symbology, labels []Label) ([]byte, error) {
+	if len(labels) > MaxPNGLabels {
+		return nil, ErrTooManyPNGLabels
+	}
+
+	columns := pngColumns
+	if len(labels) < columns {
+		columns = len(labels)
+	}
+	rows := (len(labels) + columns - 1) / columns
+
+	sheet := image.NewRGBA(image.Rect(0, 0, columns*pngLabelWidth, rows*pngLabelHeight))
+	draw.Draw(sheet, sheet.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for i, l := range labels {
+		x := (i % columns) * pngLabelWidth
+		y := (i / columns) * pngLabelHeight
+
+		if err := drawPNGLabel(sheet, image.Pt(x, y), symbology, l); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, sheet); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func drawPNGLabel(dst *image.RGBA, origin image.Point, symbology Symbology, l Label) error {
+	tile := image.Rect(origin.X, origin.Y, origin.X+pngLabelWidth, origin.Y+pngLabelHeight)
+	drawBorder(dst, tile, color.Gray{Y: 200})
+
+	drawText(dst, origin.X+pngPadding, origin.Y+pngPadding+13, truncate(l.Name, pngMaxNameChars))
+
+	bc, err := EncodeBarcode(symbology, l.Code, pngBarcodeWidth, pngBarcodeHeight)
+	if err != nil {
+		return err
+	}
+
+	// Code128 kode panjang bisa lebih lebar dari label (1 pixel per modul), diperkecil agar muat
+	bcBounds := bc.Bounds()
+	width, height := bcBounds.Dx(), bcBounds.Dy()
+	if width > pngBarcodeWidth {
+		width = pngBarcodeWidth
+	}
+	if height > pngBarcodeHeight {
+		height = pngBarcodeHeight
+	}
+	bcX := origin.X + (pngLabelWidth-width)/2
+	bcY := origin.Y + pngPadding + 24
+	target := image.Rect(bcX, bcY, bcX+width, bcY+height).Intersect(tile)
+	if width == bcBounds.Dx() && height == bcBounds.Dy() {
+		draw.Draw(dst, target, bc, bcBounds.Min, draw.Src)
+	} else {
+		xdraw.NearestNeighbor.Scale(dst, target, bc, bcBounds, draw.Src, nil)
+	}
+
+	bottom := origin.Y + pngLabelHeight - pngPadding
+	drawText(dst, origin.X+pngPadding, bottom, l.Code)
+	price := FormatRupiah(l.Price)
+	drawText(dst, origin.X+pngLabelWidth-pngPadding-len(price)*7, bottom, price)
+
+	return nil
+}
+
+func drawText(dst draw.Image, x, y int, text string) {
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.Black,
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+func drawBorder(dst *image.RGBA, r image.Rectangle, c color.Color) {
+	for x := r.Min.X; x < r.Max.X; x++ {
+		dst.Set(x, r.Min.Y, c)
+		dst.Set(x, r.Max.Y-1, c)
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		dst.Set(r.Min.X, y, c)
+		dst.Set(r.Max.X-1, y, c)
+	}
+}
@@ -0,0 +1,54 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Ukuran label thermal 50 x 30 mm pada printer 203 dpi (8 dot/mm)
+const (
+	zplLabelWidth   = 400
+	zplLabelHeight  = 240
+	zplPadding      = 16
+	zplMaxNameChars = 32
+)
+
+// RenderZPL menghasilkan perintah ZPL II, barcode dirender langsung oleh printer
+func RenderZPL(symbology Symbology, labels []Label) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for _, l := range labels {
+		if err := ValidateCode(symbology, l.Code); err != nil {
+			return nil, err
+		}
+
+		buf.WriteString("^XA\n^CI28\n")
+		fmt.Fprintf(&buf, "^PW%d\n^LL%d\n", zplLabelWidth, zplLabelHeight)
+
+		fmt.Fprintf(&buf, "^FO%d,%d^A0N,24,24^FH^FD%s^FS\n", zplPadding, zplPadding, zplEscape(truncate(l.Name, zplMaxNameChars)))
+
+		switch symbology {
+		case SymbologyCode128:
+			fmt.Fprintf(&buf, "^FO%d,52^BY2^BCN,100,N,N,N^FH^FD%s^FS\n", zplPadding, zplEscape(l.Code))
+		case SymbologyEAN13:
+			// ^BE menghitung check digit sendiri, cukup kirim 12 digit
+			fmt.Fprintf(&buf, "^FO%d,52^BY2^BEN,100,N,N^FD%s^FS\n", zplPadding, l.Code[:12])
+		case SymbologyQR:
+			fmt.Fprintf(&buf, "^FO%d,44^BQN,2,4^FH^FDMA,%s^FS\n", zplPadding, zplEscape(l.Code))
+		}
+
+		fmt.Fprintf(&buf, "^FO%d,%d^A0N,20,20^FH^FD%s^FS\n", zplPadding, zplLabelHeight-zplPadding-20, zplEscape(l.Code))
+		fmt.Fprintf(&buf, "^FO%d,%d^A0N,28,28^FB%d,1,0,R^FD%s^FS\n",
+			zplLabelWidth/2, zplLabelHeight-zplPadding-28, zplLabelWidth/2-zplPadding, FormatRupiah(l.Price))
+
+		buf.WriteString("^XZ\n")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// zplEscape meng-escape karakter kontrol ZPL memakai notasi hex ^FH
+func zplEscape(s string) string {
+	return strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E").Replace(s)
+}
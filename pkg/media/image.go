@@ -0,0 +1,145 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeGIF  = "image/gif"
+	ContentTypeWEBP = "image/webp"
+
+	// MaxUploadSize batas ukuran file asli yang diupload (5 MB)
+	MaxUploadSize = 5 << 20
+	// MaxPixels batas resolusi gambar supaya decode tidak menghabiskan memori (decompression bomb)
+	MaxPixels   = 40_000_000
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedType = errors.New("tipe file tidak didukung (jpeg, png, gif, webp)")
+	ErrImageTooLarge   = errors.New("resolusi gambar terlalu besar")
+	ErrInvalidImage    = errors.New("file gambar tidak valid")
+)
+
+// Extensions ekstensi file per content type yang diterima
+var Extensions = map[string]string{
+	ContentTypeJPEG: ".jpg",
+	ContentTypePNG:  ".png",
+	ContentTypeGIF:  ".gif",
+	ContentTypeWEBP: ".webp",
+}
+
+// Size ukuran thumbnail, gambar diperkecil sampai sisi terpanjang = MaxSide
+type Size struct {
+	Name    string
+	MaxSide int
+}
+
+var ThumbnailSizes = []Size{
+	{Name: "small", MaxSide: 160},
+	{Name: "medium", MaxSide: 480},
+	{Name: "large", MaxSide: 1024},
+}
+
+type Image struct {
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// DetectContentType menentukan tipe dari isi file (bukan dari nama / header klien)
+func DetectContentType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := Extensions[contentType]; !ok {
+		return "", ErrUnsupportedType
+	}
+	return contentType, nil
+}
+
+// Decode membaca ukuran gambar terlebih dahulu lalu decode penuh bila masih dalam batas
+func Decode(data []byte, contentType string) (image.Image, error) {
+	var (
+		cfg image.Config
+		err error
+	)
+	decode := func() (image.Image, error) { return nil, ErrUnsupportedType }
+
+	r := bytes.NewReader(data)
+	switch contentType {
+	case ContentTypeJPEG:
+		cfg, err = jpeg.DecodeConfig(r)
+		decode = func() (image.Image, error) { return jpeg.Decode(bytes.NewReader(data)) }
+	case ContentTypePNG:
+		cfg, err = png.DecodeConfig(r)
+		decode = func() (image.Image, error) { return png.Decode(bytes.NewReader(data)) }
+	case ContentTypeGIF:
+		cfg, err = gif.DecodeConfig(r)
+		decode = func() (image.Image, error) { return gif.Decode(bytes.NewReader(data)) }
+	case ContentTypeWEBP:
+		cfg, err = webp.DecodeConfig(r)
+		decode = func() (image.Image, error) { return webp.Decode(bytes.NewReader(data)) }
+	default:
+		return nil, ErrUnsupportedType
+	}
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, err := decode()
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	return img, nil
+}
+
+// Thumbnail memperkecil gambar (tidak pernah memperbesar) dengan menjaga rasio.
+// PNG / GIF disimpan sebagai PNG supaya transparansi tetap ada, selain itu JPEG.
+func Thumbnail(src image.Image, sourceType string, maxSide int) (Image, error) {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > maxSide || h > maxSide {
+		if w >= h {
+			h = max(1, h*maxSide/w)
+			w = maxSide
+		} else {
+			w = max(1, w*maxSide/h)
+			h = maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+
+	var buf bytes.Buffer
+	contentType := ContentTypeJPEG
+	var err error
+	if sourceType == ContentTypePNG || sourceType == ContentTypeGIF {
+		contentType = ContentTypePNG
+		err = png.Encode(&buf, dst)
+	} else {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return Image{}, err
+	}
+
+	return Image{Data: buf.Bytes(), ContentType: contentType, Width: w, Height: h}, nil
+}
@@ -0,0 +1,152 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	return img
+}
+
+func encodeImage(t *testing.T, contentType string, img image.Image) []byte {
+	t.Helper()
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	switch contentType {
+	case ContentTypeJPEG:
+		err = jpeg.Encode(&buf, img, nil)
+	case ContentTypePNG:
+		err = png.Encode(&buf, img)
+	case ContentTypeGIF:
+		err = gif.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		t.Fatalf("encode %s: %v", contentType, err)
+	}
+	return buf.Bytes()
+}
+
+// gifHeader header GIF (tanpa data gambar) dengan ukuran layar yang ditentukan
+func gifHeader(w, h uint16) []byte {
+	data := []byte("GIF89a")
+	data = binary.LittleEndian.AppendUint16(data, w)
+	data = binary.LittleEndian.AppendUint16(data, h)
+	return append(data, 0, 0, 0)
+}
+
+func TestDetectContentType(t *testing.T) {
+	img := testImage(4, 4)
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr error
+	}{
+		{"jpeg", encodeImage(t, ContentTypeJPEG, img), ContentTypeJPEG, nil},
+		{"png", encodeImage(t, ContentTypePNG, img), ContentTypePNG, nil},
+		{"gif", encodeImage(t, ContentTypeGIF, img), ContentTypeGIF, nil},
+		{"webp", []byte("RIFF\x1a\x00\x00\x00WEBPVP8 "), ContentTypeWEBP, nil},
+		{"svg ditolak", []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), "", ErrUnsupportedType},
+		{"pdf ditolak", []byte("%PDF-1.4\n"), "", ErrUnsupportedType},
+		{"kosong", nil, "", ErrUnsupportedType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectContentType(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DetectContentType = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	img := testImage(8, 6)
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		wantErr     error
+	}{
+		{"jpeg", encodeImage(t, ContentTypeJPEG, img), ContentTypeJPEG, nil},
+		{"png", encodeImage(t, ContentTypePNG, img), ContentTypePNG, nil},
+		{"gif", encodeImage(t, ContentTypeGIF, img), ContentTypeGIF, nil},
+		{"resolusi melebihi MaxPixels", gifHeader(10000, 10000), ContentTypeGIF, ErrImageTooLarge},
+		{"ukuran nol", gifHeader(0, 10), ContentTypeGIF, ErrInvalidImage},
+		{"isi tidak sesuai tipe", encodeImage(t, ContentTypePNG, img), ContentTypeJPEG, ErrInvalidImage},
+		{"data rusak", []byte("bukan gambar"), ContentTypePNG, ErrInvalidImage},
+		{"tipe tidak didukung", encodeImage(t, ContentTypePNG, img), "image/svg+xml", ErrUnsupportedType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.data, tt.contentType)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.Bounds().Size() != image.Pt(8, 6) {
+				t.Errorf("ukuran = %v, want 8x6", got.Bounds().Size())
+			}
+		})
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		height     int
+		sourceType string
+		maxSide    int
+		wantWidth  int
+		wantHeight int
+		wantType   string
+	}{
+		{"landscape diperkecil", 1000, 500, ContentTypeJPEG, 480, 480, 240, ContentTypeJPEG},
+		{"portrait diperkecil", 300, 900, ContentTypeWEBP, 160, 53, 160, ContentTypeJPEG},
+		{"persegi", 600, 600, ContentTypePNG, 480, 480, 480, ContentTypePNG},
+		{"tidak diperbesar", 100, 50, ContentTypeJPEG, 480, 100, 50, ContentTypeJPEG},
+		{"sisi pendek minimal 1 pixel", 2000, 2, ContentTypeGIF, 160, 160, 1, ContentTypePNG},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumb, err := Thumbnail(testImage(tt.width, tt.height), tt.sourceType, tt.maxSide)
+			if err != nil {
+				t.Fatalf("Thumbnail: %v", err)
+			}
+			if thumb.Width != tt.wantWidth || thumb.Height != tt.wantHeight {
+				t.Errorf("ukuran = %dx%d, want %dx%d", thumb.Width, thumb.Height, tt.wantWidth, tt.wantHeight)
+			}
+			if thumb.ContentType != tt.wantType {
+				t.Errorf("content type = %q, want %q", thumb.ContentType, tt.wantType)
+			}
+
+			// isi file harus sesuai content type dan ukuran yang dilaporkan
+			img, err := Decode(thumb.Data, thumb.ContentType)
+			if err != nil {
+				t.Fatalf("hasil thumbnail tidak bisa dibaca: %v", err)
+			}
+			if got := img.Bounds().Size(); got != image.Pt(tt.wantWidth, tt.wantHeight) {
+				t.Errorf("ukuran file = %v, want %dx%d", got, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
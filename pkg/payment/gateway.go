@@ -0,0 +1,349 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	gatewayTimeLayout = "2006-01-02 15:04:05"
+	gatewayMaxBody    = 1 << 20
+)
+
+// waktu pada response dan notifikasi gateway dalam WIB
+var gatewayLocation = time.FixedZone("WIB", 7*60*60)
+
+var (
+	ErrGatewayConfig = errors.New("konfigurasi payment gateway belum lengkap (PAYMENT_GATEWAY_URL, PAYMENT_SERVER_KEY)")
+	ErrGateway       = errors.New("payment gateway mengembalikan error")
+)
+
+// Channel yang didukung gateway per metode
+var (
+	GatewayBanks    = []string{"bca", "bni", "bri", "permata", "cimb"}
+	GatewayEWallets = []string{"gopay", "shopeepay"}
+)
+
+type GatewayConfig struct {
+	BaseURL   string // contoh: https://api.sandbox.midtrans.com/v2
+	ServerKey string // basic auth (username) dan kunci signature notifikasi
+	Timeout   time.Duration
+}
+
+// GatewayProvider adapter payment gateway bergaya Midtrans Core API (charge, status, refund,
+// notifikasi HTTP dengan signature_key SHA512).
+type GatewayProvider struct {
+	cfg    GatewayConfig
+	client *http.Client
+}
+
+func NewGatewayProvider(cfg GatewayConfig) (*GatewayProvider, error) {
+	if cfg.BaseURL == "" || cfg.ServerKey == "" {
+		return nil, ErrGatewayConfig
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+
+	return &GatewayProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+func (p *GatewayProvider) Name() string {
+	return DriverGateway
+}
+
+func (p *GatewayProvider) Methods() []string {
+	return []string{MethodVirtualAccount, MethodQRIS, MethodEWallet}
+}
+
+type gatewayChargeRequest struct {
+	PaymentType        string                    `json:"payment_type"`
+	TransactionDetails gatewayTransactionDetails `json:"transaction_details"`
+	CustomerDetails    gatewayCustomerDetails    `json:"customer_details"`
+	BankTransfer       *gatewayBankTransfer      `json:"bank_transfer,omitempty"`
+	CustomExpiry       *gatewayCustomExpiry      `json:"custom_expiry,omitempty"`
+}
+
+type gatewayTransactionDetails struct {
+	OrderID     string `json:"order_id"`
+	GrossAmount int64  `json:"gross_amount"`
+}
+
+type gatewayCustomerDetails struct {
+	FirstName string `json:"first_name"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+}
+
+type gatewayBankTransfer struct {
+	Bank string `json:"bank"`
+}
+
+type gatewayCustomExpiry struct {
+	ExpiryDuration int    `json:"expiry_duration"`
+	Unit           string `json:"unit"`
+}
+
+type gatewayRefundRequest struct {
+	RefundKey string `json:"refund_key"`
+	Amount    int64  `json:"amount"`
+	Reason    string `json:"reason"`
+}
+
+// gatewayTransaction response charge / status dan isi notifikasi
+type gatewayTransaction struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionID     string `json:"transaction_id"`
+	OrderID           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	PaymentType       string `json:"payment_type"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	SettlementTime    string `json:"settlement_time"`
+	ExpiryTime        string `json:"expiry_time"`
+	SignatureKey      string `json:"signature_key"`
+	RefundAmount      string `json:"refund_amount"`
+	PermataVANumber   string `json:"permata_va_number"`
+	QRString          string `json:"qr_string"`
+	VANumbers         []struct {
+		Bank     string `json:"bank"`
+		VANumber string `json:"va_number"`
+	} `json:"va_numbers"`
+	Actions []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"actions"`
+}
+
+type gatewayRefundResponse struct {
+	StatusCode    string `json:"status_code"`
+	StatusMessage string `json:"status_message"`
+	RefundKey     string `json:"refund_key"`
+	RefundAmount  string `json:"refund_amount"`
+}
+
+func (p *GatewayProvider) Charge(ctx context.Context, req ChargeRequest) (Charge, error) {
+	amount := gatewayAmount(req.Amount)
+	if amount <= 0 {
+		return Charge{}, ErrInvalidAmount
+	}
+
+	body := gatewayChargeRequest{
+		TransactionDetails: gatewayTransactionDetails{OrderID: req.Reference, GrossAmount: amount},
+		CustomerDetails: gatewayCustomerDetails{
+			FirstName: req.Customer.Name,
+			Email:     req.Customer.Email,
+			Phone:     req.Customer.Phone,
+		},
+	}
+	channel := strings.ToLower(req.Channel)
+	switch req.Method {
+	case MethodVirtualAccount:
+		if !slices.Contains(GatewayBanks, channel) {
+			return Charge{}, ErrUnsupportedChannel
+		}
+		body.PaymentType = "bank_transfer"
+		body.BankTransfer = &gatewayBankTransfer{Bank: channel}
+	case MethodQRIS:
+		body.PaymentType = "qris"
+		channel = "qris"
+	case MethodEWallet:
+		if !slices.Contains(GatewayEWallets, channel) {
+			return Charge{}, ErrUnsupportedChannel
+		}
+		body.PaymentType = channel
+	default:
+		return Charge{}, ErrUnsupportedMethod
+	}
+	if !req.ExpiresAt.IsZero() {
+		minutes := int(math.Ceil(time.Until(req.ExpiresAt).Minutes()))
+		body.CustomExpiry = &gatewayCustomExpiry{ExpiryDuration: max(minutes, 1), Unit: "minute"}
+	}
+
+	var res gatewayTransaction
+	if err := p.do(ctx, http.MethodPost, "/charge", body, &res); err != nil {
+		return Charge{}, err
+	}
+
+	charge := Charge{
+		Reference:     req.Reference,
+		TransactionID: res.TransactionID,
+		Method:        req.Method,
+		Channel:       channel,
+		Status:        gatewayStatus(res.TransactionStatus, res.FraudStatus),
+		Amount:        float64(amount),
+		QRString:      res.QRString,
+		VANumber:      res.PermataVANumber,
+		ExpiresAt:     req.ExpiresAt,
+	}
+	for _, va := range res.VANumbers {
+		charge.VANumber = va.VANumber
+	}
+	for _, action := range res.Actions {
+		if action.Name == "deeplink-redirect" || (charge.RedirectURL == "" && action.Name == "generate-qr-code") {
+			charge.RedirectURL = action.URL
+		}
+	}
+	if at, err := time.ParseInLocation(gatewayTimeLayout, res.ExpiryTime, gatewayLocation); err == nil {
+		charge.ExpiresAt = at
+	}
+	return charge, nil
+}
+
+func (p *GatewayProvider) Status(ctx context.Context, reference string) (Notification, error) {
+	var res gatewayTransaction
+	if err := p.do(ctx, http.MethodGet, "/"+reference+"/status", nil, &res); err != nil {
+		return Notification{}, err
+	}
+	return toNotification(res, nil), nil
+}
+
+func (p *GatewayProvider) Refund(ctx context.Context, req RefundRequest) (Refund, error) {
+	amount := gatewayAmount(req.Amount)
+	if amount <= 0 {
+		return Refund{}, ErrInvalidAmount
+	}
+
+	var res gatewayRefundResponse
+	body := gatewayRefundRequest{RefundKey: req.RefundKey, Amount: amount, Reason: req.Reason}
+	if err := p.do(ctx, http.MethodPost, "/"+req.Reference+"/refund", body, &res); err != nil {
+		return Refund{}, err
+	}
+
+	refunded, _ := strconv.ParseFloat(res.RefundAmount, 64)
+	if refunded == 0 {
+		refunded = float64(amount)
+	}
+	return Refund{RefundID: res.RefundKey, Amount: refunded}, nil
+}
+
+// VerifyWebhook signature_key = SHA512(order_id + status_code + gross_amount + server key)
+func (p *GatewayProvider) VerifyWebhook(ctx context.Context, header http.Header, body []byte) (Notification, error) {
+	var res gatewayTransaction
+	if err := json.Unmarshal(body, &res); err != nil {
+		return Notification{}, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	expected := p.signature(res.OrderID, res.StatusCode, res.GrossAmount)
+	if res.SignatureKey == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(strings.ToLower(res.SignatureKey))) != 1 {
+		return Notification{}, ErrInvalidSignature
+	}
+	return toNotification(res, body), nil
+}
+
+func (p *GatewayProvider) signature(orderID, statusCode, grossAmount string) string {
+	sum := sha512.Sum512([]byte(orderID + statusCode + grossAmount + p.cfg.ServerKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// do kirim request JSON dengan basic auth server key, status_code di body selain 2xx = error
+func (p *GatewayProvider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, p.cfg.BaseURL+path, payload)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.SetBasicAuth(p.cfg.ServerKey, "")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, gatewayMaxBody))
+	if err != nil {
+		return err
+	}
+
+	var status struct {
+		StatusCode    string `json:"status_code"`
+		StatusMessage string `json:"status_message"`
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return fmt.Errorf("%w (%d): %s", ErrGateway, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	code, _ := strconv.Atoi(status.StatusCode)
+	if code == 0 {
+		code = resp.StatusCode
+	}
+	if code == http.StatusNotFound {
+		return ErrPaymentNotFound
+	}
+	if code < 200 || code > 299 {
+		return fmt.Errorf("%w (%d): %s", ErrGateway, code, status.StatusMessage)
+	}
+	return json.Unmarshal(data, out)
+}
+
+func toNotification(res gatewayTransaction, raw []byte) Notification {
+	amount, _ := strconv.ParseFloat(res.GrossAmount, 64)
+	refunded, _ := strconv.ParseFloat(res.RefundAmount, 64)
+	n := Notification{
+		EventID:        strings.Join([]string{res.TransactionID, res.TransactionStatus, res.RefundAmount}, ":"),
+		Reference:      res.OrderID,
+		TransactionID:  res.TransactionID,
+		Status:         gatewayStatus(res.TransactionStatus, res.FraudStatus),
+		Amount:         amount,
+		RefundedAmount: refunded,
+		Raw:            raw,
+	}
+	if at, err := time.ParseInLocation(gatewayTimeLayout, res.SettlementTime, gatewayLocation); err == nil {
+		n.PaidAt = &at
+	}
+	return n
+}
+
+// gatewayStatus transaction_status gateway ke status pembayaran, capture yang masih ditinjau
+// fraud detection (challenge) dianggap belum dibayar
+func gatewayStatus(transactionStatus, fraudStatus string) string {
+	switch transactionStatus {
+	case "settlement":
+		return StatusPaid
+	case "capture":
+		if fraudStatus == "challenge" {
+			return StatusPending
+		}
+		return StatusPaid
+	case "expire":
+		return StatusExpired
+	case "cancel", "deny", "failure":
+		return StatusFailed
+	case "refund", "partial_refund":
+		return StatusRefunded
+	}
+	return StatusPending
+}
+
+// gatewayAmount nominal rupiah tanpa desimal
+func gatewayAmount(amount float64) int64 {
+	return int64(math.Round(amount))
+}
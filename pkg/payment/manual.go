@@ -0,0 +1,78 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const manualTransactionPrefix = "TRF-"
+
+var ErrManualConfig = errors.New("rekening transfer manual belum lengkap (PAYMENT_BANK_NAME, PAYMENT_BANK_ACCOUNT_NUMBER, PAYMENT_BANK_ACCOUNT_NAME)")
+
+type ManualConfig struct {
+	BankName      string
+	AccountNumber string
+	AccountName   string
+}
+
+// ManualProvider transfer ke rekening toko. Pelanggan mengunggah bukti transfer lalu staf
+// mengonfirmasi dari dashboard, tidak ada webhook maupun cek status ke pihak luar.
+type ManualProvider struct {
+	cfg ManualConfig
+}
+
+func NewManualProvider(cfg ManualConfig) (*ManualProvider, error) {
+	if cfg.BankName == "" || cfg.AccountNumber == "" || cfg.AccountName == "" {
+		return nil, ErrManualConfig
+	}
+	return &ManualProvider{cfg: cfg}, nil
+}
+
+func (p *ManualProvider) Name() string {
+	return DriverManual
+}
+
+func (p *ManualProvider) Methods() []string {
+	return []string{MethodBankTransfer}
+}
+
+func (p *ManualProvider) Charge(ctx context.Context, req ChargeRequest) (Charge, error) {
+	if req.Method != MethodBankTransfer {
+		return Charge{}, ErrUnsupportedMethod
+	}
+	if req.Amount <= 0 {
+		return Charge{}, ErrInvalidAmount
+	}
+
+	return Charge{
+		Reference:         req.Reference,
+		TransactionID:     manualTransactionPrefix + strings.ToUpper(req.Reference),
+		Method:            MethodBankTransfer,
+		Channel:           strings.ToLower(p.cfg.BankName),
+		Status:            StatusPending,
+		Amount:            req.Amount,
+		BankName:          p.cfg.BankName,
+		BankAccountNumber: p.cfg.AccountNumber,
+		BankAccountName:   p.cfg.AccountName,
+		ExpiresAt:         req.ExpiresAt,
+	}, nil
+}
+
+// Status tidak ada data di luar, status transfer manual hanya dari konfirmasi staf
+func (p *ManualProvider) Status(ctx context.Context, reference string) (Notification, error) {
+	return Notification{}, ErrNotSupported
+}
+
+// Refund dana dikembalikan staf lewat transfer, provider hanya mencatat nominalnya
+func (p *ManualProvider) Refund(ctx context.Context, req RefundRequest) (Refund, error) {
+	if req.Amount <= 0 {
+		return Refund{}, ErrInvalidAmount
+	}
+	return Refund{RefundID: manualTransactionPrefix + "REF-" + strings.ToUpper(req.Reference), Amount: req.Amount}, nil
+}
+
+func (p *ManualProvider) VerifyWebhook(ctx context.Context, header http.Header, body []byte) (Notification, error) {
+	return Notification{}, ErrNotSupported
+}
@@ -0,0 +1,219 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DriverGateway = "gateway"
+	DriverManual  = "manual"
+)
+
+// Metode pembayaran, channel = kode bank (VA / transfer) atau nama e-wallet
+const (
+	MethodVirtualAccount = "virtual_account"
+	MethodQRIS           = "qris"
+	MethodEWallet        = "ewallet"
+	MethodBankTransfer   = "bank_transfer"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusPaid     = "paid"
+	StatusFailed   = "failed"
+	StatusExpired  = "expired"
+	StatusRefunded = "refunded"
+)
+
+// DefaultExpiry batas waktu bayar bila PAYMENT_EXPIRY tidak diisi
+const DefaultExpiry = 24 * time.Hour
+
+var (
+	ErrUnknownDriver      = errors.New("PAYMENT_PROVIDERS tidak dikenali (manual, gateway)")
+	ErrUnsupportedMethod  = errors.New("metode pembayaran tidak tersedia")
+	ErrUnsupportedChannel = errors.New("channel pembayaran tidak tersedia")
+	ErrNotSupported       = errors.New("fitur tidak didukung provider pembayaran")
+	ErrPaymentNotFound    = errors.New("transaksi pembayaran tidak ditemukan")
+	ErrInvalidSignature   = errors.New("signature notifikasi pembayaran tidak valid")
+	ErrInvalidAmount      = errors.New("nominal pembayaran tidak valid")
+	ErrNotConfigured      = errors.New("provider pembayaran dinonaktifkan")
+)
+
+// Provider payment gateway / transfer manual. Reference = id transaksi dari sisi toko, unik per
+// percobaan bayar (order yang pembayarannya kedaluwarsa dibayar ulang dengan reference baru).
+type Provider interface {
+	Name() string
+	Methods() []string
+	Charge(ctx context.Context, req ChargeRequest) (Charge, error)
+	Status(ctx context.Context, reference string) (Notification, error)
+	Refund(ctx context.Context, req RefundRequest) (Refund, error)
+	// VerifyWebhook memeriksa signature notifikasi lalu mengembalikan isinya
+	VerifyWebhook(ctx context.Context, header http.Header, body []byte) (Notification, error)
+}
+
+type Customer struct {
+	Name  string
+	Email string
+	Phone string
+}
+
+type ChargeRequest struct {
+	Reference string
+	Amount    float64
+	Method    string
+	Channel   string
+	Customer  Customer
+	ExpiresAt time.Time
+}
+
+// Charge instruksi bayar untuk pelanggan, field yang terisi tergantung metode
+type Charge struct {
+	Reference         string
+	TransactionID     string
+	Method            string
+	Channel           string
+	Status            string
+	Amount            float64
+	VANumber          string // virtual account
+	QRString          string // QRIS
+	RedirectURL       string // deeplink / checkout e-wallet
+	BankName          string // transfer manual
+	BankAccountNumber string
+	BankAccountName   string
+	ExpiresAt         time.Time
+}
+
+// Notification status transaksi dari webhook atau cek status. EventID unik per kejadian,
+// notifikasi yang dikirim ulang provider memakai EventID yang sama.
+type Notification struct {
+	EventID        string
+	Reference      string
+	TransactionID  string
+	Status         string
+	Amount         float64
+	RefundedAmount float64 // total dana yang sudah dikembalikan (kumulatif)
+	PaidAt         *time.Time
+	Raw            []byte
+}
+
+// RefundRequest RefundKey unik per refund, request ulang dengan key yang sama tidak mengembalikan dana dua kali
+type RefundRequest struct {
+	Reference     string
+	TransactionID string
+	RefundKey     string
+	Amount        float64
+	Reason        string
+}
+
+type Refund struct {
+	RefundID string
+	Amount   float64
+}
+
+// Config provider yang aktif, metode pembayaran dilayani provider pertama yang mendukungnya
+type Config struct {
+	Providers []Provider
+	Expiry    time.Duration
+}
+
+var Default Config
+
+// ProviderFor provider untuk metode pembayaran
+func (c Config) ProviderFor(method string) (Provider, error) {
+	for _, p := range c.Providers {
+		if slices.Contains(p.Methods(), method) {
+			return p, nil
+		}
+	}
+	return nil, ErrUnsupportedMethod
+}
+
+// Provider provider berdasarkan nama, dipakai webhook dan transaksi yang sudah dibuat
+func (c Config) Provider(name string) (Provider, error) {
+	for _, p := range c.Providers {
+		if p.Name() == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownDriver, name)
+}
+
+// Methods seluruh metode pembayaran yang tersedia
+func (c Config) Methods() []string {
+	var methods []string
+	for _, p := range c.Providers {
+		for _, m := range p.Methods() {
+			if !slices.Contains(methods, m) {
+				methods = append(methods, m)
+			}
+		}
+	}
+	return methods
+}
+
+// InitPayment memilih provider dari env PAYMENT_PROVIDERS dipisah koma (default manual). Provider
+// yang konfigurasinya belum lengkap dinonaktifkan, alasannya dikembalikan sebagai ErrNotConfigured
+// (peringatan, provider lain tetap dipakai).
+func InitPayment() error {
+	cfg, err := loadConfig()
+	if err != nil && !errors.Is(err, ErrNotConfigured) {
+		return err
+	}
+	Default = cfg
+	return err
+}
+
+func loadConfig() (Config, error) {
+	cfg := Config{Expiry: DefaultExpiry}
+	if expiry := os.Getenv("PAYMENT_EXPIRY"); expiry != "" {
+		d, err := time.ParseDuration(expiry)
+		if err != nil || d <= 0 {
+			return Config{}, fmt.Errorf("PAYMENT_EXPIRY tidak valid: %q", expiry)
+		}
+		cfg.Expiry = d
+	}
+
+	drivers := os.Getenv("PAYMENT_PROVIDERS")
+	if drivers == "" {
+		drivers = DriverManual
+	}
+	var disabled []error
+	for _, driver := range strings.Split(drivers, ",") {
+		switch strings.TrimSpace(driver) {
+		case DriverManual:
+			manual, err := NewManualProvider(ManualConfig{
+				BankName:      os.Getenv("PAYMENT_BANK_NAME"),
+				AccountNumber: os.Getenv("PAYMENT_BANK_ACCOUNT_NUMBER"),
+				AccountName:   os.Getenv("PAYMENT_BANK_ACCOUNT_NAME"),
+			})
+			if err != nil {
+				disabled = append(disabled, err)
+				continue
+			}
+			cfg.Providers = append(cfg.Providers, manual)
+		case DriverGateway:
+			gateway, err := NewGatewayProvider(GatewayConfig{
+				BaseURL:   os.Getenv("PAYMENT_GATEWAY_URL"),
+				ServerKey: os.Getenv("PAYMENT_SERVER_KEY"),
+			})
+			if err != nil {
+				disabled = append(disabled, err)
+				continue
+			}
+			cfg.Providers = append(cfg.Providers, gateway)
+		default:
+			return Config{}, fmt.Errorf("%w: %s", ErrUnknownDriver, driver)
+		}
+	}
+	if len(disabled) > 0 {
+		return cfg, fmt.Errorf("%w: %w", ErrNotConfigured, errors.Join(disabled...))
+	}
+	return cfg, nil
+}
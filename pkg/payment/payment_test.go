@@ -0,0 +1,339 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testServerKey = "SB-server-key"
+
+// fakeGateway stand-in payment gateway: memeriksa basic auth lalu menjawab seperti Core API
+type fakeGateway struct {
+	chargeReq gatewayChargeRequest
+	refundReq gatewayRefundRequest
+	status    string
+}
+
+func (f *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, _, ok := r.BasicAuth(); !ok || user != testServerKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"status_code":"401","status_message":"Access denied"}`))
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	switch {
+	case r.URL.Path == "/charge":
+		_ = json.Unmarshal(body, &f.chargeReq)
+		res := map[string]interface{}{
+			"status_code":        "201",
+			"transaction_id":     "trx-1",
+			"order_id":           f.chargeReq.TransactionDetails.OrderID,
+			"gross_amount":       fmt.Sprintf("%d.00", f.chargeReq.TransactionDetails.GrossAmount),
+			"payment_type":       f.chargeReq.PaymentType,
+			"transaction_status": "pending",
+			"expiry_time":        "2026-10-20 10:00:00",
+		}
+		switch f.chargeReq.PaymentType {
+		case "bank_transfer":
+			res["va_numbers"] = []map[string]string{{"bank": f.chargeReq.BankTransfer.Bank, "va_number": "12345678901"}}
+		case "qris":
+			res["qr_string"] = "00020101021126"
+			res["actions"] = []map[string]string{{"name": "generate-qr-code", "url": "https://gw.example/qr.png"}}
+		default:
+			res["actions"] = []map[string]string{
+				{"name": "generate-qr-code", "url": "https://gw.example/qr.png"},
+				{"name": "deeplink-redirect", "url": "gojek://pay"},
+			}
+		}
+		writeJSON(w, res)
+	case r.URL.Path == "/ORD-1-1/status":
+		writeJSON(w, map[string]string{
+			"status_code":        "200",
+			"transaction_id":     "trx-1",
+			"order_id":           "ORD-1-1",
+			"gross_amount":       "150000.00",
+			"transaction_status": f.status,
+			"settlement_time":    "2026-10-19 12:30:00",
+		})
+	case r.URL.Path == "/ORD-1-1/refund":
+		_ = json.Unmarshal(body, &f.refundReq)
+		writeJSON(w, map[string]string{
+			"status_code":   "200",
+			"refund_key":    f.refundReq.RefundKey,
+			"refund_amount": fmt.Sprintf("%d.00", f.refundReq.Amount),
+		})
+	default:
+		writeJSON(w, map[string]string{"status_code": "404", "status_message": "Transaction doesn't exist."})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestGateway(t *testing.T, serverKey string) (*GatewayProvider, *fakeGateway) {
+	t.Helper()
+	fake := &fakeGateway{status: "settlement"}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	p, err := NewGatewayProvider(GatewayConfig{BaseURL: server.URL + "/", ServerKey: serverKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, fake
+}
+
+func TestGatewayChargeVirtualAccount(t *testing.T) {
+	p, fake := newTestGateway(t, testServerKey)
+
+	charge, err := p.Charge(context.Background(), ChargeRequest{
+		Reference: "ORD-1-1",
+		Amount:    150000.4,
+		Method:    MethodVirtualAccount,
+		Channel:   "BCA",
+		Customer:  Customer{Name: "Budi", Email: "budi@example.com"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.chargeReq.PaymentType != "bank_transfer" || fake.chargeReq.BankTransfer.Bank != "bca" {
+		t.Fatalf("unexpected charge request: %+v", fake.chargeReq)
+	}
+	if fake.chargeReq.TransactionDetails.GrossAmount != 150000 {
+		t.Fatalf("gross amount = %d, want 150000", fake.chargeReq.TransactionDetails.GrossAmount)
+	}
+	if fake.chargeReq.CustomExpiry == nil || fake.chargeReq.CustomExpiry.ExpiryDuration != 60 {
+		t.Fatalf("custom expiry = %+v, want 60 minute", fake.chargeReq.CustomExpiry)
+	}
+	if charge.VANumber != "12345678901" || charge.Status != StatusPending || charge.Amount != 150000 {
+		t.Fatalf("unexpected charge: %+v", charge)
+	}
+	want := time.Date(2026, 10, 20, 10, 0, 0, 0, gatewayLocation)
+	if !charge.ExpiresAt.Equal(want) {
+		t.Fatalf("expires at = %v, want %v", charge.ExpiresAt, want)
+	}
+}
+
+func TestGatewayChargeQRISAndEWallet(t *testing.T) {
+	p, _ := newTestGateway(t, testServerKey)
+
+	qris, err := p.Charge(context.Background(), ChargeRequest{Reference: "ORD-2-1", Amount: 50000, Method: MethodQRIS})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qris.QRString == "" || qris.RedirectURL != "https://gw.example/qr.png" || qris.Channel != "qris" {
+		t.Fatalf("unexpected qris charge: %+v", qris)
+	}
+
+	wallet, err := p.Charge(context.Background(), ChargeRequest{Reference: "ORD-3-1", Amount: 50000, Method: MethodEWallet, Channel: "gopay"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wallet.RedirectURL != "gojek://pay" {
+		t.Fatalf("redirect url = %q, want deeplink", wallet.RedirectURL)
+	}
+
+	_, err = p.Charge(context.Background(), ChargeRequest{Reference: "ORD-4-1", Amount: 50000, Method: MethodEWallet, Channel: "ovo"})
+	if !errors.Is(err, ErrUnsupportedChannel) {
+		t.Fatalf("err = %v, want ErrUnsupportedChannel", err)
+	}
+	_, err = p.Charge(context.Background(), ChargeRequest{Reference: "ORD-4-1", Amount: 50000, Method: MethodBankTransfer})
+	if !errors.Is(err, ErrUnsupportedMethod) {
+		t.Fatalf("err = %v, want ErrUnsupportedMethod", err)
+	}
+}
+
+func TestGatewayStatusAndRefund(t *testing.T) {
+	p, fake := newTestGateway(t, testServerKey)
+
+	n, err := p.Status(context.Background(), "ORD-1-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Status != StatusPaid || n.Amount != 150000 || n.PaidAt == nil {
+		t.Fatalf("unexpected status: %+v", n)
+	}
+
+	fake.status = "expire"
+	if n, _ = p.Status(context.Background(), "ORD-1-1"); n.Status != StatusExpired {
+		t.Fatalf("status = %q, want expired", n.Status)
+	}
+
+	if _, err := p.Status(context.Background(), "ORD-9-1"); !errors.Is(err, ErrPaymentNotFound) {
+		t.Fatalf("err = %v, want ErrPaymentNotFound", err)
+	}
+
+	refund, err := p.Refund(context.Background(), RefundRequest{Reference: "ORD-1-1", RefundKey: "ORD-1-1-R1", Amount: 50000, Reason: "barang rusak"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refund.RefundID != "ORD-1-1-R1" || refund.Amount != 50000 || fake.refundReq.Reason != "barang rusak" {
+		t.Fatalf("unexpected refund: %+v (request %+v)", refund, fake.refundReq)
+	}
+}
+
+func TestGatewayWrongServerKey(t *testing.T) {
+	p, _ := newTestGateway(t, "wrong-key")
+
+	_, err := p.Charge(context.Background(), ChargeRequest{Reference: "ORD-1-1", Amount: 1000, Method: MethodQRIS})
+	if !errors.Is(err, ErrGateway) || !strings.Contains(err.Error(), "Access denied") {
+		t.Fatalf("err = %v, want ErrGateway access denied", err)
+	}
+}
+
+func TestGatewayVerifyWebhook(t *testing.T) {
+	p, _ := newTestGateway(t, testServerKey)
+
+	notification := func(signature string) []byte {
+		b, _ := json.Marshal(map[string]string{
+			"order_id":           "ORD-1-1",
+			"status_code":        "200",
+			"gross_amount":       "150000.00",
+			"signature_key":      signature,
+			"transaction_id":     "trx-1",
+			"transaction_status": "settlement",
+			"settlement_time":    "2026-10-19 12:30:00",
+		})
+		return b
+	}
+
+	valid := p.signature("ORD-1-1", "200", "150000.00")
+	n, err := p.VerifyWebhook(context.Background(), http.Header{}, notification(valid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Reference != "ORD-1-1" || n.Status != StatusPaid || n.Amount != 150000 || n.EventID != "trx-1:settlement:" {
+		t.Fatalf("unexpected notification: %+v", n)
+	}
+
+	// signature dihitung ulang dengan nominal berbeda = isi notifikasi diubah
+	tampered := p.signature("ORD-1-1", "200", "1.00")
+	if _, err := p.VerifyWebhook(context.Background(), http.Header{}, notification(tampered)); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("err = %v, want ErrInvalidSignature", err)
+	}
+	if _, err := p.VerifyWebhook(context.Background(), http.Header{}, notification("")); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("err = %v, want ErrInvalidSignature", err)
+	}
+	if _, err := p.VerifyWebhook(context.Background(), http.Header{}, []byte("not json")); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("err = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestGatewayStatusMapping(t *testing.T) {
+	cases := []struct {
+		status, fraud, want string
+	}{
+		{"settlement", "", StatusPaid},
+		{"capture", "accept", StatusPaid},
+		{"capture", "challenge", StatusPending},
+		{"pending", "", StatusPending},
+		{"expire", "", StatusExpired},
+		{"deny", "", StatusFailed},
+		{"cancel", "", StatusFailed},
+		{"partial_refund", "", StatusRefunded},
+	}
+	for _, c := range cases {
+		if got := gatewayStatus(c.status, c.fraud); got != c.want {
+			t.Errorf("gatewayStatus(%q, %q) = %q, want %q", c.status, c.fraud, got, c.want)
+		}
+	}
+}
+
+func TestManualProvider(t *testing.T) {
+	if _, err := NewManualProvider(ManualConfig{BankName: "BCA"}); !errors.Is(err, ErrManualConfig) {
+		t.Fatalf("err = %v, want ErrManualConfig", err)
+	}
+
+	p, err := NewManualProvider(ManualConfig{BankName: "BCA", AccountNumber: "0123456789", AccountName: "PT Pleasure Love"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	charge, err := p.Charge(context.Background(), ChargeRequest{Reference: "ord-1-1", Amount: 150000, Method: MethodBankTransfer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if charge.TransactionID != "TRF-ORD-1-1" || charge.BankAccountNumber != "0123456789" || charge.Status != StatusPending {
+		t.Fatalf("unexpected charge: %+v", charge)
+	}
+
+	if _, err := p.Charge(context.Background(), ChargeRequest{Reference: "ord-1-1", Amount: 150000, Method: MethodQRIS}); !errors.Is(err, ErrUnsupportedMethod) {
+		t.Fatalf("err = %v, want ErrUnsupportedMethod", err)
+	}
+	if _, err := p.Status(context.Background(), "ord-1-1"); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("err = %v, want ErrNotSupported", err)
+	}
+	if _, err := p.VerifyWebhook(context.Background(), http.Header{}, nil); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("err = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestConfigProviderFor(t *testing.T) {
+	manual, _ := NewManualProvider(ManualConfig{BankName: "BCA", AccountNumber: "0123456789", AccountName: "PT Pleasure Love"})
+	gateway, _ := NewGatewayProvider(GatewayConfig{BaseURL: "http://localhost", ServerKey: testServerKey})
+	cfg := Config{Providers: []Provider{manual, gateway}}
+
+	if p, err := cfg.ProviderFor(MethodBankTransfer); err != nil || p.Name() != DriverManual {
+		t.Fatalf("bank transfer provider = %v, %v", p, err)
+	}
+	if p, err := cfg.ProviderFor(MethodQRIS); err != nil || p.Name() != DriverGateway {
+		t.Fatalf("qris provider = %v, %v", p, err)
+	}
+	if _, err := (Config{Providers: []Provider{manual}}).ProviderFor(MethodQRIS); !errors.Is(err, ErrUnsupportedMethod) {
+		t.Fatalf("err = %v, want ErrUnsupportedMethod", err)
+	}
+	if _, err := cfg.Provider("unknown"); !errors.Is(err, ErrUnknownDriver) {
+		t.Fatalf("err = %v, want ErrUnknownDriver", err)
+	}
+	if got := cfg.Methods(); len(got) != 4 {
+		t.Fatalf("methods = %v, want 4 methods", got)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("PAYMENT_PROVIDERS", "manual, gateway")
+	t.Setenv("PAYMENT_EXPIRY", "2h")
+	t.Setenv("PAYMENT_BANK_NAME", "BCA")
+	t.Setenv("PAYMENT_BANK_ACCOUNT_NUMBER", "0123456789")
+	t.Setenv("PAYMENT_BANK_ACCOUNT_NAME", "PT Pleasure Love")
+	t.Setenv("PAYMENT_GATEWAY_URL", "http://localhost")
+	t.Setenv("PAYMENT_SERVER_KEY", testServerKey)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Providers) != 2 || cfg.Expiry != 2*time.Hour {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+
+	// rekening belum diisi: manual dinonaktifkan, gateway tetap dipakai
+	t.Setenv("PAYMENT_BANK_ACCOUNT_NUMBER", "")
+	cfg, err = loadConfig()
+	if !errors.Is(err, ErrNotConfigured) || !errors.Is(err, ErrManualConfig) {
+		t.Fatalf("err = %v, want ErrNotConfigured + ErrManualConfig", err)
+	}
+	if len(cfg.Providers) != 1 || cfg.Providers[0].Name() != DriverGateway {
+		t.Fatalf("providers = %+v, want gateway only", cfg.Providers)
+	}
+	if _, err := cfg.ProviderFor(MethodBankTransfer); err == nil {
+		t.Error("bank_transfer manual harus tidak tersedia")
+	}
+
+	t.Setenv("PAYMENT_PROVIDERS", "paypal")
+	if _, err := loadConfig(); !errors.Is(err, ErrUnknownDriver) {
+		t.Fatalf("err = %v, want ErrUnknownDriver", err)
+	}
+}
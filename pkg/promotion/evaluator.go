@@ -0,0 +1,393 @@
+package promotion
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ReasonNotStarted      = "promosi belum dimulai"
+	ReasonEnded           = "promosi sudah berakhir"
+	ReasonUsageLimit      = "kuota promosi sudah habis"
+	ReasonCustomerLimit   = "batas pemakaian per pelanggan sudah tercapai"
+	ReasonNotStackable    = "tidak bisa digabung dengan promosi lain"
+	ReasonExclusiveActive = "promosi lain yang tidak bisa digabung sudah diterapkan"
+	ReasonNoDiscount      = "tidak ada item yang mendapat potongan"
+)
+
+// Evaluate menerapkan rules ke cart berdasarkan prioritas dan aturan stacking.
+// Rule dievaluasi dari prioritas tertinggi, potongan dihitung dari sisa harga tiap item
+// sehingga total potongan tidak pernah melebihi harga item. Rule yang tidak bisa digabung
+// hanya diterapkan bila belum ada promosi lain, dan menghentikan evaluasi rule berikutnya.
+func Evaluate(cart Cart, rules []Rule) Result {
+	if cart.At.IsZero() {
+		cart.At = time.Now()
+	}
+
+	result := Result{
+		Subtotal: cart.Subtotal(),
+		Applied:  []AppliedDiscount{},
+		Skipped:  []SkippedRule{},
+	}
+
+	remaining := make([]float64, len(cart.Items))
+	for i, item := range cart.Items {
+		remaining[i] = round2(item.UnitPrice * float64(item.Quantity))
+	}
+
+	sorted := make([]Rule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	exclusive := false
+	for _, rule := range sorted {
+		skip := func(reason string) {
+			result.Skipped = append(result.Skipped, SkippedRule{
+				RuleID:   rule.ID,
+				Source:   rule.Source,
+				RuleCode: rule.Code,
+				RuleName: rule.Name,
+				Reason:   reason,
+			})
+		}
+
+		if exclusive {
+			skip(ReasonExclusiveActive)
+			continue
+		}
+		if reason := rule.availability(cart.At); reason != "" {
+			skip(reason)
+			continue
+		}
+		if reason := checkConditions(cart, result.Subtotal, rule.Conditions); reason != "" {
+			skip(reason)
+			continue
+		}
+		if !rule.Stackable && len(result.Applied) > 0 {
+			skip(ReasonNotStackable)
+			continue
+		}
+
+		trial := make([]float64, len(remaining))
+		copy(trial, remaining)
+
+		var explanations []string
+		for _, action := range rule.Actions {
+			discounts, explanation := applyAction(cart, trial, action)
+			if sumOf(discounts) == 0 {
+				continue
+			}
+			for i, amount := range discounts {
+				trial[i] = round2(trial[i] - amount)
+			}
+			explanations = append(explanations, explanation)
+		}
+
+		applied := AppliedDiscount{
+			RuleID:      rule.ID,
+			Source:      rule.Source,
+			RuleCode:    rule.Code,
+			RuleName:    rule.Name,
+			Explanation: strings.Join(explanations, "; "),
+			Lines:       []LineDiscount{},
+		}
+		for i := range remaining {
+			amount := round2(remaining[i] - trial[i])
+			if amount <= 0 {
+				continue
+			}
+			applied.Lines = append(applied.Lines, LineDiscount{Index: i, Amount: amount})
+			applied.Amount += amount
+		}
+		applied.Amount = round2(applied.Amount)
+
+		if applied.Amount == 0 {
+			skip(ReasonNoDiscount)
+			continue
+		}
+
+		remaining = trial
+		result.Applied = append(result.Applied, applied)
+		result.TotalDiscount += applied.Amount
+		if !rule.Stackable {
+			exclusive = true
+		}
+	}
+
+	result.TotalDiscount = round2(result.TotalDiscount)
+	result.Total = round2(result.Subtotal - result.TotalDiscount)
+	return result
+}
+
+// availability mengembalikan alasan rule tidak berlaku pada waktu at, kosong bila berlaku
+func (r Rule) availability(at time.Time) string {
+	if r.StartsAt != nil && at.Before(*r.StartsAt) {
+		return ReasonNotStarted
+	}
+	if r.EndsAt != nil && !at.Before(*r.EndsAt) {
+		return ReasonEnded
+	}
+	if r.UsageLimit > 0 && r.UsageCount >= r.UsageLimit {
+		return ReasonUsageLimit
+	}
+	if r.PerCustomerLimit > 0 && r.CustomerUsage >= r.PerCustomerLimit {
+		return ReasonCustomerLimit
+	}
+	return ""
+}
+
+func checkConditions(cart Cart, subtotal float64, conditions []Condition) string {
+	for _, condition := range conditions {
+		switch condition.Type {
+		case ConditionMinSubtotal:
+			if subtotal < condition.Value {
+				return fmt.Sprintf("minimal belanja %s belum terpenuhi", formatAmount(condition.Value))
+			}
+		case ConditionMinQuantity:
+			var qty int
+			for _, item := range cart.Items {
+				if condition.match(item) {
+					qty += item.Quantity
+				}
+			}
+			if float64(qty) < condition.Value {
+				return fmt.Sprintf("minimal pembelian %s item belum terpenuhi", formatAmount(condition.Value))
+			}
+		case ConditionProductIn:
+			if !anyItem(cart, func(item CartItem) bool { return containsID(condition.ProductIDs, item.ProductID) }) {
+				return "tidak ada produk yang sesuai di keranjang"
+			}
+		case ConditionCategoryIn:
+			target := Target{CategoryIDs: condition.CategoryIDs}
+			if !anyItem(cart, target.match) {
+				return "tidak ada kategori yang sesuai di keranjang"
+			}
+		default:
+			return fmt.Sprintf("kondisi %q tidak dikenali", condition.Type)
+		}
+	}
+	return ""
+}
+
+// applyAction menghitung potongan per item dari sisa harga remaining
+func applyAction(cart Cart, remaining []float64, action Action) ([]float64, string) {
+	discounts := make([]float64, len(remaining))
+	var explanation string
+
+	switch action.Type {
+	case ActionPercentOff:
+		for i, item := range cart.Items {
+			if action.match(item) {
+				discounts[i] = round2(remaining[i] * action.Percent / 100)
+			}
+		}
+		explanation = fmt.Sprintf("diskon %s%% untuk %s", formatAmount(action.Percent), action.targetLabel())
+
+	case ActionFixedOff:
+		weights := make([]float64, len(remaining))
+		for i, item := range cart.Items {
+			if action.match(item) {
+				weights[i] = remaining[i]
+			}
+		}
+		discounts = distribute(math.Min(action.Amount, sumOf(weights)), weights)
+		explanation = fmt.Sprintf("potongan %s untuk %s", formatAmount(action.Amount), action.targetLabel())
+
+	case ActionBuyXGetY:
+		discounts = applyBuyXGetY(cart, remaining, action)
+		if action.Percent == 0 || action.Percent == 100 {
+			explanation = fmt.Sprintf("beli %d gratis %d untuk %s", action.BuyQty, action.GetQty, action.targetLabel())
+		} else {
+			explanation = fmt.Sprintf("beli %d diskon %s%% untuk %d item berikutnya (%s)",
+				action.BuyQty, formatAmount(action.Percent), action.GetQty, action.targetLabel())
+		}
+
+	case ActionBundle:
+		var sets int
+		discounts, sets = applyBundle(cart, remaining, action)
+		explanation = fmt.Sprintf("paket bundle %d set dengan harga %s per set", sets, formatAmount(action.BundlePrice))
+
+	default:
+		return discounts, ""
+	}
+
+	if action.MaxDiscount > 0 && sumOf(discounts) > action.MaxDiscount {
+		discounts = distribute(action.MaxDiscount, discounts)
+		explanation += fmt.Sprintf(" (maksimal %s)", formatAmount(action.MaxDiscount))
+	}
+
+	return discounts, explanation
+}
+
+type unit struct {
+	index int
+	value float64
+}
+
+// applyBuyXGetY mengelompokkan unit target dari harga termahal, tiap kelompok BuyQty+GetQty
+// mendapat potongan untuk GetQty unit termurah di kelompok tersebut
+func applyBuyXGetY(cart Cart, remaining []float64, action Action) []float64 {
+	discounts := make([]float64, len(remaining))
+	percent := action.Percent
+	if percent == 0 {
+		percent = 100
+	}
+
+	var units []unit
+	for i, item := range cart.Items {
+		if !action.match(item) || item.Quantity <= 0 {
+			continue
+		}
+		value := remaining[i] / float64(item.Quantity)
+		for q := 0; q < item.Quantity; q++ {
+			units = append(units, unit{index: i, value: value})
+		}
+	}
+	sort.SliceStable(units, func(i, j int) bool {
+		return units[i].value > units[j].value
+	})
+
+	groupSize := action.BuyQty + action.GetQty
+	for start := 0; start+groupSize <= len(units); start += groupSize {
+		for _, u := range units[start+action.BuyQty : start+groupSize] {
+			discounts[u.index] += u.value * percent / 100
+		}
+	}
+
+	for i := range discounts {
+		discounts[i] = math.Min(round2(discounts[i]), remaining[i])
+	}
+	return discounts
+}
+
+// applyBundle menghitung jumlah set lengkap BundleProductIDs di keranjang, selisih harga normal
+// dengan BundlePrice dibagi proporsional ke item yang masuk set
+func applyBundle(cart Cart, remaining []float64, action Action) ([]float64, int) {
+	discounts := make([]float64, len(remaining))
+	productIDs := uniqueIDs(action.BundleProductIDs)
+
+	sets := math.MaxInt
+	for _, productID := range productIDs {
+		var qty int
+		for _, item := range cart.Items {
+			if item.ProductID == productID {
+				qty += item.Quantity
+			}
+		}
+		if qty < sets {
+			sets = qty
+		}
+	}
+	if sets <= 0 {
+		return discounts, 0
+	}
+
+	// ambil unit termahal tiap produk sebanyak jumlah set
+	weights := make([]float64, len(remaining))
+	var regular float64
+	for _, productID := range productIDs {
+		var units []unit
+		for i, item := range cart.Items {
+			if item.ProductID != productID || item.Quantity <= 0 {
+				continue
+			}
+			units = append(units, unit{index: i, value: remaining[i] / float64(item.Quantity)})
+		}
+		sort.SliceStable(units, func(i, j int) bool {
+			return units[i].value > units[j].value
+		})
+
+		need := sets
+		for _, u := range units {
+			qty := cart.Items[u.index].Quantity
+			if qty > need {
+				qty = need
+			}
+			weights[u.index] += u.value * float64(qty)
+			regular += u.value * float64(qty)
+			need -= qty
+			if need == 0 {
+				break
+			}
+		}
+	}
+
+	discount := round2(regular - action.BundlePrice*float64(sets))
+	if discount <= 0 {
+		return discounts, sets
+	}
+	return distribute(discount, weights), sets
+}
+
+// distribute membagi amount secara proporsional terhadap weights,
+// sisa pembulatan dibebankan ke item terakhir yang memiliki bobot
+func distribute(amount float64, weights []float64) []float64 {
+	result := make([]float64, len(weights))
+	total := sumOf(weights)
+	if amount <= 0 || total <= 0 {
+		return result
+	}
+	if amount > total {
+		amount = total
+	}
+
+	last := -1
+	var allocated float64
+	for i, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		result[i] = round2(amount * weight / total)
+		allocated += result[i]
+		last = i
+	}
+	if last >= 0 {
+		result[last] = round2(result[last] + amount - allocated)
+	}
+	return result
+}
+
+func (a Action) targetLabel() string {
+	switch {
+	case a.Target.empty():
+		return "semua item"
+	case len(a.CategoryIDs) > 0 && len(a.ProductIDs) == 0:
+		return "kategori terpilih"
+	default:
+		return "produk terpilih"
+	}
+}
+
+func anyItem(cart Cart, fn func(CartItem) bool) bool {
+	for _, item := range cart.Items {
+		if fn(item) {
+			return true
+		}
+	}
+	return false
+}
+
+func sumOf(values []float64) float64 {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return round2(total)
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,441 @@
+package promotion
+
+import (
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func at(d time.Duration) *time.Time {
+	t := now.Add(d)
+	return &t
+}
+
+// cart contoh: kaos (kategori 10) 3 x 100, celana (kategori 20) 1 x 250, topi (kategori 10) 2 x 50
+func sampleCart() Cart {
+	return Cart{
+		At: now,
+		Items: []CartItem{
+			{ProductID: 1, CategoryIDs: []int64{10}, Quantity: 3, UnitPrice: 100},
+			{ProductID: 2, CategoryIDs: []int64{20}, Quantity: 1, UnitPrice: 250},
+			{ProductID: 3, CategoryIDs: []int64{10}, Quantity: 2, UnitPrice: 50},
+		},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name         string
+		cart         Cart
+		rules        []Rule
+		wantDiscount float64
+		wantApplied  []int64
+		wantSkipped  map[int64]string
+		wantLines    map[int]float64 // total potongan per index item
+	}{
+		{
+			name:         "tanpa rule",
+			cart:         sampleCart(),
+			wantDiscount: 0,
+		},
+		{
+			name: "persen per kategori",
+			cart: sampleCart(),
+			rules: []Rule{{
+				ID: 1, Stackable: true,
+				Actions: []Action{{Type: ActionPercentOff, Percent: 10, Target: Target{CategoryIDs: []int64{10}}}},
+			}},
+			wantDiscount: 40,
+			wantApplied:  []int64{1},
+			wantLines:    map[int]float64{0: 30, 2: 10},
+		},
+		{
+			name: "persen dengan batas maksimal",
+			cart: sampleCart(),
+			rules: []Rule{{
+				ID: 1, Stackable: true,
+				Actions: []Action{{Type: ActionPercentOff, Percent: 50, MaxDiscount: 100}},
+			}},
+			wantDiscount: 100,
+			wantApplied:  []int64{1},
+			wantLines:    map[int]float64{0: 46.15, 1: 38.46, 2: 15.39},
+		},
+		{
+			name: "potongan nominal di atas minimal belanja",
+			cart: sampleCart(),
+			rules: []Rule{{
+				ID: 1, Stackable: true,
+				Conditions: []Condition{{Type: ConditionMinSubtotal, Value: 500}},
+				Actions:    []Action{{Type: ActionFixedOff, Amount: 65}},
+			}},
+			wantDiscount: 65,
+			wantApplied:  []int64{1},
+			wantLines:    map[int]float64{0: 30, 1: 25, 2: 10},
+		},
+		{
+			name: "minimal belanja belum terpenuhi",
+			cart: sampleCart(),
+			rules: []Rule{{
+				ID: 1, Stackable: true,
+				Conditions: []Condition{{Type: ConditionMinSubtotal, Value: 1000}},
+				Actions:    []Action{{Type: ActionFixedOff, Amount: 100}},
+			}},
+			wantSkipped: map[int64]string{1: "minimal belanja 1000 belum terpenuhi"},
+		},
+		{
+			name: "potongan nominal tidak melebihi total",
+			cart: Cart{At: now, Items: []CartItem{{ProductID: 1, Quantity: 1, UnitPrice: 30}}},
+			rules: []Rule{{
+				ID: 1, Stackable: true,
+				Actions: []Action{{Type: ActionFixedOff, Amount: 50}},
+			}},
+			wantDiscount: 30,
+			wantApplied:  []int64{1},
+		},
+		{
+			name: "beli 2 gratis 1 memilih unit termurah",
+			cart: Cart{At: now, Items: []CartItem{
+				{ProductID: 1, CategoryIDs: []int64{10}, Quantity: 2, UnitPrice: 100},
+				{ProductID: 3, CategoryIDs: []int64{10}, Quantity: 1, UnitPrice: 50},
+			}},
+			rules: []Rule{{
+				ID: 1, Stackable: true,
+				Actions: []Action{{Type: ActionBuyXGetY, BuyQty: 2, GetQty: 1, Target: Target{CategoryIDs: []int64{10}}}},
+			}},
+			wantDiscount: 50,
+			wantApplied:  []int64{1},
+			wantLines:    map[int]float64{1: 50},
+		},
+		{
+			name: "beli 1 diskon 50% item kedua, kelompok tidak lengkap diabaikan",
+			cart: Cart{At: now, Items: []CartItem{{ProductID: 1, Quantity: 5, UnitPrice: 100}}},
+			rules: []Rule{{
+				ID: 1, Stackable: true,
+				Actions: []Action{{Type: ActionBuyXGetY, BuyQty: 1, GetQty: 1, Percent: 50}},
+			}},
+			wantDiscount: 100,
+			wantApplied:  []int64{1},
+		},
+		{
+			name: "beli x gratis y tanpa unit cukup",
+			cart: Cart{At: now, Items: []CartItem{{ProductID: 1, Quantity: 2, UnitPrice: 100}}},
+			rules: []Rule{{
+				ID: 1, Stackable: true,
+				Actions: []Action{{Type: ActionBuyXGetY, BuyQty: 2, GetQty: 1}},
+			}},
+			wantSkipped: map[int64]string{1: ReasonNoDiscount},
+		},
+		{
+			name: "bundle dua set",
+			cart: Cart{At: now, Items: []CartItem{
+				{ProductID: 1, Quantity: 2, UnitPrice: 100},
+				{ProductID: 2, Quantity: 3, UnitPrice: 50},
+			}},
+			rules: []Rule{{
+				ID: 1, Stackable: true,
+				Actions: []Action{{Type: ActionBundle, BundleProductIDs: []int64{1, 2}, BundlePrice: 120}},
+			}},
+			wantDiscount: 60,
+			wantApplied:  []int64{1},
+			wantLines:    map[int]float64{0: 40, 1: 20},
+		},
+		{
+			name: "bundle tidak lengkap",
+			cart: Cart{At: now, Items: []CartItem{{ProductID: 1, Quantity: 2, UnitPrice: 100}}},
+			rules: []Rule{{
+				ID: 1, Stackable: true,
+				Actions: []Action{{Type: ActionBundle, BundleProductIDs: []int64{1, 2}, BundlePrice: 120}},
+			}},
+			wantSkipped: map[int64]string{1: ReasonNoDiscount},
+		},
+		{
+			name: "flash sale aktif",
+			cart: sampleCart(),
+			rules: []Rule{{
+				ID: 1, Stackable: true, StartsAt: at(-time.Hour), EndsAt: at(time.Hour),
+				Actions: []Action{{Type: ActionPercentOff, Percent: 20, Target: Target{ProductIDs: []int64{2}}}},
+			}},
+			wantDiscount: 50,
+			wantApplied:  []int64{1},
+		},
+		{
+			name: "flash sale belum mulai dan sudah berakhir",
+			cart: sampleCart(),
+			rules: []Rule{
+				{ID: 1, StartsAt: at(time.Minute), Actions: []Action{{Type: ActionPercentOff, Percent: 20}}},
+				{ID: 2, EndsAt: at(0), Actions: []Action{{Type: ActionPercentOff, Percent: 20}}},
+			},
+			wantSkipped: map[int64]string{1: ReasonNotStarted, 2: ReasonEnded},
+		},
+		{
+			name: "kuota habis",
+			cart: sampleCart(),
+			rules: []Rule{
+				{ID: 1, UsageLimit: 10, UsageCount: 10, Actions: []Action{{Type: ActionFixedOff, Amount: 10}}},
+				{ID: 2, PerCustomerLimit: 1, CustomerUsage: 1, Actions: []Action{{Type: ActionFixedOff, Amount: 10}}},
+				{ID: 3, Stackable: true, UsageLimit: 10, UsageCount: 9, Actions: []Action{{Type: ActionFixedOff, Amount: 10}}},
+			},
+			wantDiscount: 10,
+			wantApplied:  []int64{3},
+			wantSkipped:  map[int64]string{1: ReasonUsageLimit, 2: ReasonCustomerLimit},
+		},
+		{
+			name: "stacking dihitung dari sisa harga sesuai prioritas",
+			cart: Cart{At: now, Items: []CartItem{{ProductID: 1, Quantity: 1, UnitPrice: 200}}},
+			rules: []Rule{
+				{ID: 1, Priority: 1, Stackable: true, Actions: []Action{{Type: ActionFixedOff, Amount: 50}}},
+				{ID: 2, Priority: 5, Stackable: true, Actions: []Action{{Type: ActionPercentOff, Percent: 10}}},
+			},
+			wantDiscount: 70,
+			wantApplied:  []int64{2, 1},
+		},
+		{
+			name: "rule eksklusif prioritas tertinggi menghentikan rule lain",
+			cart: sampleCart(),
+			rules: []Rule{
+				{ID: 1, Priority: 1, Stackable: true, Actions: []Action{{Type: ActionFixedOff, Amount: 50}}},
+				{ID: 2, Priority: 9, Actions: []Action{{Type: ActionPercentOff, Percent: 10}}},
+			},
+			wantDiscount: 65,
+			wantApplied:  []int64{2},
+			wantSkipped:  map[int64]string{1: ReasonExclusiveActive},
+		},
+		{
+			name: "rule eksklusif tidak digabung dengan promosi yang sudah diterapkan",
+			cart: sampleCart(),
+			rules: []Rule{
+				{ID: 1, Priority: 9, Stackable: true, Actions: []Action{{Type: ActionFixedOff, Amount: 50}}},
+				{ID: 2, Priority: 1, Actions: []Action{{Type: ActionPercentOff, Percent: 10}}},
+			},
+			wantDiscount: 50,
+			wantApplied:  []int64{1},
+			wantSkipped:  map[int64]string{2: ReasonNotStackable},
+		},
+		{
+			name: "rule eksklusif yang syaratnya gagal tidak menghalangi rule lain",
+			cart: sampleCart(),
+			rules: []Rule{
+				{
+					ID: 1, Priority: 9,
+					Conditions: []Condition{{Type: ConditionProductIn, Target: Target{ProductIDs: []int64{99}}}},
+					Actions:    []Action{{Type: ActionPercentOff, Percent: 50}},
+				},
+				{ID: 2, Priority: 1, Stackable: true, Actions: []Action{{Type: ActionFixedOff, Amount: 50}}},
+			},
+			wantDiscount: 50,
+			wantApplied:  []int64{2},
+			wantSkipped:  map[int64]string{1: "tidak ada produk yang sesuai di keranjang"},
+		},
+		{
+			name: "minimal jumlah item per kategori",
+			cart: sampleCart(),
+			rules: []Rule{
+				{
+					ID: 1, Stackable: true,
+					Conditions: []Condition{{Type: ConditionMinQuantity, Value: 5, Target: Target{CategoryIDs: []int64{10}}}},
+					Actions:    []Action{{Type: ActionFixedOff, Amount: 10}},
+				},
+				{
+					ID: 2, Stackable: true,
+					Conditions: []Condition{{Type: ConditionMinQuantity, Value: 2, Target: Target{CategoryIDs: []int64{20}}}},
+					Actions:    []Action{{Type: ActionFixedOff, Amount: 10}},
+				},
+			},
+			wantDiscount: 10,
+			wantApplied:  []int64{1},
+			wantSkipped:  map[int64]string{2: "minimal pembelian 2 item belum terpenuhi"},
+		},
+		{
+			name: "syarat kategori",
+			cart: sampleCart(),
+			rules: []Rule{
+				{
+					ID: 1, Stackable: true,
+					Conditions: []Condition{{Type: ConditionCategoryIn, Target: Target{CategoryIDs: []int64{20}}}},
+					Actions:    []Action{{Type: ActionFixedOff, Amount: 10}},
+				},
+				{
+					ID: 2, Stackable: true,
+					Conditions: []Condition{{Type: ConditionCategoryIn, Target: Target{CategoryIDs: []int64{30}}}},
+					Actions:    []Action{{Type: ActionFixedOff, Amount: 10}},
+				},
+			},
+			wantDiscount: 10,
+			wantApplied:  []int64{1},
+			wantSkipped:  map[int64]string{2: "tidak ada kategori yang sesuai di keranjang"},
+		},
+		{
+			name: "total potongan tidak melebihi harga item",
+			cart: Cart{At: now, Items: []CartItem{{ProductID: 1, Quantity: 1, UnitPrice: 100}}},
+			rules: []Rule{
+				{ID: 1, Priority: 2, Stackable: true, Actions: []Action{{Type: ActionPercentOff, Percent: 100}}},
+				{ID: 2, Priority: 1, Stackable: true, Actions: []Action{{Type: ActionFixedOff, Amount: 10}}},
+			},
+			wantDiscount: 100,
+			wantApplied:  []int64{1},
+			wantSkipped:  map[int64]string{2: ReasonNoDiscount},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Evaluate(tt.cart, tt.rules)
+
+			if result.TotalDiscount != tt.wantDiscount {
+				t.Errorf("TotalDiscount = %v, want %v", result.TotalDiscount, tt.wantDiscount)
+			}
+			if result.Total != round2(result.Subtotal-tt.wantDiscount) {
+				t.Errorf("Total = %v, want %v", result.Total, round2(result.Subtotal-tt.wantDiscount))
+			}
+
+			if len(result.Applied) != len(tt.wantApplied) {
+				t.Fatalf("Applied = %+v, want rule %v", result.Applied, tt.wantApplied)
+			}
+			var applied float64
+			for i, id := range tt.wantApplied {
+				if result.Applied[i].RuleID != id {
+					t.Errorf("Applied[%d].RuleID = %d, want %d", i, result.Applied[i].RuleID, id)
+				}
+				if result.Applied[i].Explanation == "" {
+					t.Errorf("Applied[%d].Explanation kosong", i)
+				}
+				applied += result.Applied[i].Amount
+			}
+			if round2(applied) != tt.wantDiscount {
+				t.Errorf("jumlah Applied = %v, want %v", round2(applied), tt.wantDiscount)
+			}
+
+			skipped := make(map[int64]string, len(result.Skipped))
+			for _, s := range result.Skipped {
+				skipped[s.RuleID] = s.Reason
+			}
+			for id, reason := range tt.wantSkipped {
+				if skipped[id] != reason {
+					t.Errorf("Skipped[%d] = %q, want %q", id, skipped[id], reason)
+				}
+			}
+			if len(result.Skipped) != len(tt.wantSkipped) {
+				t.Errorf("Skipped = %+v, want %v", result.Skipped, tt.wantSkipped)
+			}
+
+			if tt.wantLines != nil {
+				lines := make(map[int]float64)
+				for _, a := range result.Applied {
+					for _, l := range a.Lines {
+						lines[l.Index] = round2(lines[l.Index] + l.Amount)
+					}
+				}
+				for index, want := range tt.wantLines {
+					if lines[index] != want {
+						t.Errorf("potongan item %d = %v, want %v", index, lines[index], want)
+					}
+				}
+				if len(lines) != len(tt.wantLines) {
+					t.Errorf("lines = %v, want %v", lines, tt.wantLines)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr error
+	}{
+		{
+			name:    "tanpa aksi",
+			rule:    Rule{},
+			wantErr: ErrNoAction,
+		},
+		{
+			name:    "periode terbalik",
+			rule:    Rule{StartsAt: at(time.Hour), EndsAt: at(0), Actions: []Action{{Type: ActionFixedOff, Amount: 10}}},
+			wantErr: ErrInvalidPeriod,
+		},
+		{
+			name:    "persen di luar batas",
+			rule:    Rule{Actions: []Action{{Type: ActionPercentOff, Percent: 120}}},
+			wantErr: ErrInvalidPercent,
+		},
+		{
+			name:    "nominal nol",
+			rule:    Rule{Actions: []Action{{Type: ActionFixedOff}}},
+			wantErr: ErrInvalidAmount,
+		},
+		{
+			name:    "beli x gratis y tanpa qty",
+			rule:    Rule{Actions: []Action{{Type: ActionBuyXGetY, BuyQty: 2}}},
+			wantErr: ErrInvalidBuyGetQty,
+		},
+		{
+			name:    "bundle satu produk",
+			rule:    Rule{Actions: []Action{{Type: ActionBundle, BundleProductIDs: []int64{1, 1}, BundlePrice: 10}}},
+			wantErr: ErrInvalidBundle,
+		},
+		{
+			name:    "aksi tidak dikenali",
+			rule:    Rule{Actions: []Action{{Type: "cashback"}}},
+			wantErr: ErrUnknownAction,
+		},
+		{
+			name:    "kondisi tidak dikenali",
+			rule:    Rule{Conditions: []Condition{{Type: "weekday"}}, Actions: []Action{{Type: ActionFixedOff, Amount: 10}}},
+			wantErr: ErrUnknownCondition,
+		},
+		{
+			name:    "kondisi produk tanpa id",
+			rule:    Rule{Conditions: []Condition{{Type: ConditionProductIn}}, Actions: []Action{{Type: ActionFixedOff, Amount: 10}}},
+			wantErr: ErrInvalidConditionID,
+		},
+		{
+			name: "valid",
+			rule: Rule{
+				StartsAt:   at(0),
+				EndsAt:     at(time.Hour),
+				Conditions: []Condition{{Type: ConditionMinSubtotal, Value: 100}},
+				Actions: []Action{
+					{Type: ActionBuyXGetY, BuyQty: 2, GetQty: 1},
+					{Type: ActionBundle, BundleProductIDs: []int64{1, 2}, BundlePrice: 10},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDistribute(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  float64
+		weights []float64
+		want    []float64
+	}{
+		{name: "proporsional", amount: 30, weights: []float64{100, 200}, want: []float64{10, 20}},
+		{name: "sisa pembulatan ke item terakhir", amount: 10, weights: []float64{100, 100, 100}, want: []float64{3.33, 3.33, 3.34}},
+		{name: "bobot nol dilewati", amount: 10, weights: []float64{0, 50, 0}, want: []float64{0, 10, 0}},
+		{name: "melebihi total bobot", amount: 50, weights: []float64{10, 20}, want: []float64{10, 20}},
+		{name: "tanpa bobot", amount: 10, weights: []float64{0, 0}, want: []float64{0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := distribute(tt.amount, tt.weights)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("distribute() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
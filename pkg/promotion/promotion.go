@@ -0,0 +1,253 @@
+package promotion
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type ConditionType string
+
+const (
+	ConditionMinSubtotal ConditionType = "min_subtotal" // subtotal keranjang >= Value
+	ConditionMinQuantity ConditionType = "min_quantity" // jumlah item yang cocok dengan target >= Value
+	ConditionProductIn   ConditionType = "product_in"   // ada item dengan produk di ProductIDs
+	ConditionCategoryIn  ConditionType = "category_in"  // ada item dengan kategori di CategoryIDs
+)
+
+type ActionType string
+
+const (
+	ActionPercentOff ActionType = "percent_off" // potongan persen untuk item target (kategori / produk / semua)
+	ActionFixedOff   ActionType = "fixed_off"   // potongan nominal dari total keranjang
+	ActionBuyXGetY   ActionType = "buy_x_get_y" // beli BuyQty gratis / diskon GetQty unit termurah
+	ActionBundle     ActionType = "bundle"      // satu set BundleProductIDs dengan harga BundlePrice
+)
+
+const (
+	SourcePromotion = "promotion"
+	SourceVoucher   = "voucher"
+)
+
+var (
+	ErrNoAction           = errors.New("promosi harus memiliki minimal satu aksi")
+	ErrInvalidPeriod      = errors.New("waktu berakhir promosi harus setelah waktu mulai")
+	ErrUnknownCondition   = errors.New("jenis kondisi promosi tidak dikenali")
+	ErrUnknownAction      = errors.New("jenis aksi promosi tidak dikenali")
+	ErrInvalidPercent     = errors.New("persentase diskon harus di antara 0 dan 100")
+	ErrInvalidAmount      = errors.New("nominal diskon harus lebih dari 0")
+	ErrInvalidBuyGetQty   = errors.New("buy_qty dan get_qty harus lebih dari 0")
+	ErrInvalidBundle      = errors.New("bundle minimal berisi 2 produk berbeda dengan harga bundle lebih dari 0")
+	ErrInvalidConditionID = errors.New("kondisi produk / kategori membutuhkan minimal satu id")
+)
+
+// Target membatasi item keranjang yang terkena kondisi / aksi, kosong = semua item
+type Target struct {
+	ProductIDs  []int64 `json:"product_ids,omitempty"`
+	CategoryIDs []int64 `json:"category_ids,omitempty"`
+}
+
+type Condition struct {
+	Type  ConditionType `json:"type"`
+	Value float64       `json:"value,omitempty"`
+	Target
+}
+
+type Action struct {
+	Type        ActionType `json:"type"`
+	Percent     float64    `json:"percent,omitempty"`
+	Amount      float64    `json:"amount,omitempty"`
+	MaxDiscount float64    `json:"max_discount,omitempty"` // batas potongan per aksi, 0 = tanpa batas
+	Target
+
+	BuyQty int `json:"buy_qty,omitempty"`
+	GetQty int `json:"get_qty,omitempty"`
+
+	BundleProductIDs []int64 `json:"bundle_product_ids,omitempty"`
+	BundlePrice      float64 `json:"bundle_price,omitempty"`
+}
+
+// Rule satu aturan promosi. Flash sale = rule dengan periode StartsAt - EndsAt yang singkat
+type Rule struct {
+	ID         int64
+	Source     string // asal rule (promotion / voucher), ID unik per source
+	Code       string
+	Name       string
+	Priority   int  // makin besar makin dulu dievaluasi
+	Stackable  bool // false = tidak bisa digabung dengan promosi lain
+	StartsAt   *time.Time
+	EndsAt     *time.Time
+	Conditions []Condition
+	Actions    []Action
+
+	UsageLimit       int // batas pemakaian total, 0 = tanpa batas
+	UsageCount       int
+	PerCustomerLimit int // batas pemakaian per pelanggan, 0 = tanpa batas
+	CustomerUsage    int // pemakaian oleh pelanggan keranjang ini
+}
+
+type CartItem struct {
+	ProductID   int64
+	VarianID    int64
+	CategoryIDs []int64
+	Quantity    int
+	UnitPrice   float64
+}
+
+type Cart struct {
+	Items []CartItem
+	At    time.Time
+}
+
+type LineDiscount struct {
+	Index  int     `json:"index"` // index item pada Cart.Items
+	Amount float64 `json:"amount"`
+}
+
+type AppliedDiscount struct {
+	RuleID      int64          `json:"rule_id"`
+	Source      string         `json:"source"`
+	RuleCode    string         `json:"rule_code"`
+	RuleName    string         `json:"rule_name"`
+	Amount      float64        `json:"amount"`
+	Explanation string         `json:"explanation"`
+	Lines       []LineDiscount `json:"lines"`
+}
+
+type SkippedRule struct {
+	RuleID   int64  `json:"rule_id"`
+	Source   string `json:"source"`
+	RuleCode string `json:"rule_code"`
+	RuleName string `json:"rule_name"`
+	Reason   string `json:"reason"`
+}
+
+type Result struct {
+	Subtotal      float64           `json:"subtotal"`
+	TotalDiscount float64           `json:"total_discount"`
+	Total         float64           `json:"total"`
+	Applied       []AppliedDiscount `json:"applied"`
+	Skipped       []SkippedRule     `json:"skipped"`
+}
+
+func (t Target) empty() bool {
+	return len(t.ProductIDs) == 0 && len(t.CategoryIDs) == 0
+}
+
+func (t Target) match(item CartItem) bool {
+	if t.empty() {
+		return true
+	}
+	if containsID(t.ProductIDs, item.ProductID) {
+		return true
+	}
+	for _, categoryID := range item.CategoryIDs {
+		if containsID(t.CategoryIDs, categoryID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Condition) Validate() error {
+	switch c.Type {
+	case ConditionMinSubtotal, ConditionMinQuantity:
+		if c.Value <= 0 {
+			return fmt.Errorf("nilai kondisi %s harus lebih dari 0", c.Type)
+		}
+	case ConditionProductIn:
+		if len(c.ProductIDs) == 0 {
+			return ErrInvalidConditionID
+		}
+	case ConditionCategoryIn:
+		if len(c.CategoryIDs) == 0 {
+			return ErrInvalidConditionID
+		}
+	default:
+		return ErrUnknownCondition
+	}
+	return nil
+}
+
+func (a Action) Validate() error {
+	if a.MaxDiscount < 0 {
+		return ErrInvalidAmount
+	}
+
+	switch a.Type {
+	case ActionPercentOff:
+		if a.Percent <= 0 || a.Percent > 100 {
+			return ErrInvalidPercent
+		}
+	case ActionFixedOff:
+		if a.Amount <= 0 {
+			return ErrInvalidAmount
+		}
+	case ActionBuyXGetY:
+		if a.BuyQty <= 0 || a.GetQty <= 0 {
+			return ErrInvalidBuyGetQty
+		}
+		// percent 0 diartikan gratis (100%)
+		if a.Percent < 0 || a.Percent > 100 {
+			return ErrInvalidPercent
+		}
+	case ActionBundle:
+		if len(uniqueIDs(a.BundleProductIDs)) < 2 || a.BundlePrice <= 0 {
+			return ErrInvalidBundle
+		}
+	default:
+		return ErrUnknownAction
+	}
+	return nil
+}
+
+// Validate memastikan rule bisa dievaluasi, dipakai sebelum disimpan
+func (r Rule) Validate() error {
+	if len(r.Actions) == 0 {
+		return ErrNoAction
+	}
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return ErrInvalidPeriod
+	}
+	for _, condition := range r.Conditions {
+		if err := condition.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, action := range r.Actions {
+		if err := action.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c Cart) Subtotal() float64 {
+	var subtotal float64
+	for _, item := range c.Items {
+		subtotal += item.UnitPrice * float64(item.Quantity)
+	}
+	return round2(subtotal)
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// incrWithLimitScript menaikkan counter bila belum mencapai limit (limit 0 = tanpa batas).
+// Counter yang belum ada diinisialisasi dari ARGV[2] (jumlah di database) dengan TTL ARGV[3] detik.
+var incrWithLimitScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	if tonumber(ARGV[3]) > 0 then
+		redis.call('SET', KEYS[1], ARGV[2], 'EX', ARGV[3])
+	else
+		redis.call('SET', KEYS[1], ARGV[2])
+	end
+end
+local current = tonumber(redis.call('GET', KEYS[1]))
+local limit = tonumber(ARGV[1])
+if limit > 0 and current >= limit then
+	return 0
+end
+redis.call('INCR', KEYS[1])
+return 1
+`)
+
+// IncrWithLimit menaikkan counter key secara atomik selama belum mencapai limit,
+// false bila limit sudah tercapai. initial dipakai bila counter belum ada di Redis.
+func IncrWithLimit(ctx context.Context, key string, limit, initial int64, ttl time.Duration) (bool, error) {
+	res, err := incrWithLimitScript.Run(ctx, RDB, []string{key}, limit, initial, int64(ttl.Seconds())).Int()
+	if err != nil {
+		return false, err
+	}
+	return res == 1, nil
+}
+
+// DecrCounter mengembalikan counter yang sudah dinaikkan IncrWithLimit (mis. transaksi gagal)
+func DecrCounter(ctx context.Context, key string) error {
+	return RDB.Decr(ctx, key).Err()
+}
@@ -57,3 +57,8 @@ func GetFromRedis(ctx context.Context, key string) (string, error) {
 func DeleteFromRedis(ctx context.Context, key string) error {
     return RDB.Del(ctx, key).Err()
 }
+
+// SetIfNotExistsWithTTL hanya menyimpan bila key belum ada, true bila tersimpan
+func SetIfNotExistsWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+    return RDB.SetNX(ctx, key, value, ttl).Result()
+}
@@ -0,0 +1,359 @@
+package shipping
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	aggregatorKeyHeader  = "key"
+	aggregatorTimeLayout = "2006-01-02 15:04"
+	aggregatorMaxBody    = 10 << 20
+)
+
+var (
+	ErrAggregatorConfig = errors.New("konfigurasi agregator ongkir belum lengkap (SHIPPING_AGGREGATOR_URL, SHIPPING_AGGREGATOR_KEY, SHIPPING_COURIERS)")
+	ErrAggregator       = errors.New("agregator ongkir mengembalikan error")
+)
+
+type AggregatorConfig struct {
+	BaseURL  string   // contoh: https://api.ongkir.example/v1
+	APIKey   string   // dikirim lewat header "key"
+	Couriers []string // kode kurir, contoh: jne, sicepat
+	Timeout  time.Duration
+}
+
+// AggregatorProvider adapter agregator ongkir bergaya RajaOngkir (cek ongkir multi kurir, buat resi,
+// lacak resi, cetak label). Seluruh response dibungkus {"status": {"code", "description"}, ...}.
+type AggregatorProvider struct {
+	cfg    AggregatorConfig
+	client *http.Client
+}
+
+func NewAggregatorProvider(cfg AggregatorConfig) (*AggregatorProvider, error) {
+	if cfg.BaseURL == "" || cfg.APIKey == "" || len(cfg.Couriers) == 0 {
+		return nil, ErrAggregatorConfig
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 15 * time.Second
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+
+	return &AggregatorProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+func (p *AggregatorProvider) Name() string {
+	return DriverAggregator
+}
+
+type aggregatorStatus struct {
+	Code        int    `json:"code"`
+	Description string `json:"description"`
+}
+
+type aggregatorContact struct {
+	Name       string `json:"name"`
+	Phone      string `json:"phone"`
+	Address    string `json:"address"`
+	DistrictID int64  `json:"district_id"`
+	PostalCode string `json:"postal_code"`
+}
+
+type aggregatorItem struct {
+	Name     string  `json:"name"`
+	Quantity int     `json:"quantity"`
+	Weight   int     `json:"weight"`
+	Value    float64 `json:"value"`
+}
+
+type aggregatorCostRequest struct {
+	Origin                int64   `json:"origin"`
+	OriginPostalCode      string  `json:"origin_postal_code"`
+	Destination           int64   `json:"destination"`
+	DestinationPostalCode string  `json:"destination_postal_code"`
+	Weight                int     `json:"weight"`
+	ItemValue             float64 `json:"item_value"`
+	Courier               string  `json:"courier"` // dipisah ":", contoh: jne:sicepat
+}
+
+type aggregatorCostResponse struct {
+	Status  aggregatorStatus `json:"status"`
+	Results []struct {
+		Code  string `json:"code"`
+		Name  string `json:"name"`
+		Costs []struct {
+			Service     string `json:"service"`
+			Description string `json:"description"`
+			Cost        []struct {
+				Value float64 `json:"value"`
+				Etd   string  `json:"etd"`
+			} `json:"cost"`
+		} `json:"costs"`
+	} `json:"results"`
+}
+
+type aggregatorShipmentRequest struct {
+	Reference string            `json:"reference"`
+	Courier   string            `json:"courier"`
+	Service   string            `json:"service"`
+	Weight    int               `json:"weight"`
+	ItemValue float64           `json:"item_value"`
+	Sender    aggregatorContact `json:"sender"`
+	Recipient aggregatorContact `json:"recipient"`
+	Items     []aggregatorItem  `json:"items"`
+}
+
+type aggregatorShipmentResponse struct {
+	Status aggregatorStatus `json:"status"`
+	Result struct {
+		Waybill string  `json:"waybill"`
+		Courier string  `json:"courier"`
+		Service string  `json:"service"`
+		Cost    float64 `json:"cost"`
+	} `json:"result"`
+}
+
+type aggregatorWaybillRequest struct {
+	Waybill string `json:"waybill"`
+	Courier string `json:"courier"`
+}
+
+type aggregatorWaybillResponse struct {
+	Status aggregatorStatus `json:"status"`
+	Result struct {
+		Delivered bool `json:"delivered"`
+		Summary   struct {
+			Status string `json:"status"`
+		} `json:"summary"`
+		Manifest []struct {
+			Description string `json:"manifest_description"`
+			Date        string `json:"manifest_date"`
+			Time        string `json:"manifest_time"`
+			CityName    string `json:"city_name"`
+			Code        string `json:"manifest_code"`
+		} `json:"manifest"`
+	} `json:"result"`
+}
+
+func (p *AggregatorProvider) Quote(ctx context.Context, req QuoteRequest) ([]Rate, error) {
+	if req.Origin.IsEmpty() || req.Destination.IsEmpty() {
+		return nil, ErrInvalidLocation
+	}
+
+	weight := max(ChargeableWeight(req.Items), 1)
+	body := aggregatorCostRequest{
+		Origin:                req.Origin.DistrictID,
+		OriginPostalCode:      req.Origin.PostalCode,
+		Destination:           req.Destination.DistrictID,
+		DestinationPostalCode: req.Destination.PostalCode,
+		Weight:                weight,
+		ItemValue:             itemValue(req.Items),
+		Courier:               strings.Join(p.cfg.Couriers, ":"),
+	}
+
+	var res aggregatorCostResponse
+	if err := p.do(ctx, http.MethodPost, "/cost", body, &res); err != nil {
+		return nil, err
+	}
+
+	var rates []Rate
+	for _, courier := range res.Results {
+		for _, service := range courier.Costs {
+			if len(service.Cost) == 0 {
+				continue
+			}
+			rates = append(rates, Rate{
+				Provider:    DriverAggregator,
+				Courier:     strings.ToLower(courier.Code),
+				CourierName: courier.Name,
+				Service:     service.Service,
+				Description: service.Description,
+				Cost:        service.Cost[0].Value,
+				Etd:         service.Cost[0].Etd,
+				WeightGram:  weight,
+			})
+		}
+	}
+	if len(rates) == 0 {
+		return nil, ErrNoRate
+	}
+	return rates, nil
+}
+
+func (p *AggregatorProvider) CreateShipment(ctx context.Context, req ShipmentRequest) (Shipment, error) {
+	items := make([]aggregatorItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, aggregatorItem{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Weight:   item.WeightGram,
+			Value:    item.Value,
+		})
+	}
+
+	body := aggregatorShipmentRequest{
+		Reference: req.Reference,
+		Courier:   req.Courier,
+		Service:   req.Service,
+		Weight:    max(ChargeableWeight(req.Items), 1),
+		ItemValue: itemValue(req.Items),
+		Sender:    toAggregatorContact(req.Sender),
+		Recipient: toAggregatorContact(req.Recipient),
+		Items:     items,
+	}
+
+	var res aggregatorShipmentResponse
+	if err := p.do(ctx, http.MethodPost, "/shipment", body, &res); err != nil {
+		return Shipment{}, err
+	}
+	if res.Result.Waybill == "" {
+		return Shipment{}, fmt.Errorf("%w: nomor resi kosong", ErrAggregator)
+	}
+
+	return Shipment{
+		TrackingNumber: res.Result.Waybill,
+		Courier:        strings.ToLower(res.Result.Courier),
+		Service:        res.Result.Service,
+		Cost:           res.Result.Cost,
+	}, nil
+}
+
+func (p *AggregatorProvider) Track(ctx context.Context, courier, trackingNumber string) (Tracking, error) {
+	var res aggregatorWaybillResponse
+	err := p.do(ctx, http.MethodPost, "/waybill", aggregatorWaybillRequest{Waybill: trackingNumber, Courier: courier}, &res)
+	if err != nil {
+		return Tracking{}, err
+	}
+
+	tracking := Tracking{
+		TrackingNumber: trackingNumber,
+		Courier:        courier,
+		Status:         res.Result.Summary.Status,
+		Delivered:      res.Result.Delivered,
+		Events:         make([]TrackingEvent, 0, len(res.Result.Manifest)),
+	}
+	for _, m := range res.Result.Manifest {
+		at, _ := time.ParseInLocation(aggregatorTimeLayout, m.Date+" "+m.Time, time.Local)
+		tracking.Events = append(tracking.Events, TrackingEvent{
+			Time:        at,
+			Status:      m.Code,
+			Description: m.Description,
+			Location:    m.CityName,
+		})
+	}
+	return tracking, nil
+}
+
+// Label PDF label resi dari kurir
+func (p *AggregatorProvider) Label(ctx context.Context, req LabelRequest) (Label, error) {
+	query := url.Values{"waybill": {req.TrackingNumber}, "courier": {req.Courier}}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.BaseURL+"/label?"+query.Encode(), nil)
+	if err != nil {
+		return Label{}, err
+	}
+	httpReq.Header.Set(aggregatorKeyHeader, p.cfg.APIKey)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return Label{}, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, aggregatorMaxBody))
+	if err != nil {
+		return Label{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Label{}, aggregatorError(resp.StatusCode, data)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = ContentTypePDF
+	}
+	return Label{ContentType: contentType, Data: data}, nil
+}
+
+// do kirim request JSON lalu decode response, status code non 200 (HTTP maupun status.code) = error
+func (p *AggregatorProvider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, p.cfg.BaseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(aggregatorKeyHeader, p.cfg.APIKey)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, aggregatorMaxBody))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return aggregatorError(resp.StatusCode, data)
+	}
+
+	var status struct {
+		Status aggregatorStatus `json:"status"`
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return fmt.Errorf("%w: %v", ErrAggregator, err)
+	}
+	if status.Status.Code != http.StatusOK {
+		return aggregatorError(status.Status.Code, data)
+	}
+	return json.Unmarshal(data, out)
+}
+
+func aggregatorError(code int, data []byte) error {
+	if code == http.StatusNotFound {
+		return ErrShipmentNotFound
+	}
+
+	var res struct {
+		Status aggregatorStatus `json:"status"`
+	}
+	msg := strings.TrimSpace(string(data))
+	if json.Unmarshal(data, &res) == nil && res.Status.Description != "" {
+		msg = res.Status.Description
+	}
+	return fmt.Errorf("%w (%d): %s", ErrAggregator, code, msg)
+}
+
+func toAggregatorContact(c Contact) aggregatorContact {
+	return aggregatorContact{
+		Name:       c.Name,
+		Phone:      c.Phone,
+		Address:    c.Address,
+		DistrictID: c.Location.DistrictID,
+		PostalCode: c.Location.PostalCode,
+	}
+}
+
+func itemValue(items []Item) float64 {
+	var total float64
+	for _, item := range items {
+		total += item.Value * float64(item.Quantity)
+	}
+	return total
+}
@@ -0,0 +1,29 @@
+package shipping
+
+import "context"
+
+const DriverDisabled = "disabled"
+
+// disabledProvider dipakai selama gudang asal belum dikonfigurasi: aplikasi tetap jalan,
+// setiap permintaan ongkir / pengiriman mengembalikan ErrNotConfigured
+type disabledProvider struct{}
+
+func (disabledProvider) Name() string {
+	return DriverDisabled
+}
+
+func (disabledProvider) Quote(ctx context.Context, req QuoteRequest) ([]Rate, error) {
+	return nil, ErrNotConfigured
+}
+
+func (disabledProvider) CreateShipment(ctx context.Context, req ShipmentRequest) (Shipment, error) {
+	return Shipment{}, ErrNotConfigured
+}
+
+func (disabledProvider) Track(ctx context.Context, courier, trackingNumber string) (Tracking, error) {
+	return Tracking{}, ErrNotConfigured
+}
+
+func (disabledProvider) Label(ctx context.Context, req LabelRequest) (Label, error) {
+	return Label{}, ErrNotConfigured
+}
@@ -0,0 +1,162 @@
+package shipping
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	CourierFlat     = "flat"
+	ServiceFlat     = "REG"
+	flatTrackPrefix = "FLT-"
+)
+
+// Cakupan zona relatif terhadap lokasi asal. Digit pertama kode provinsi = kelompok pulau
+// (1 Sumatera, 3 Jawa, 5 Bali & Nusa Tenggara, 6 Kalimantan, 7 Sulawesi, 8 Maluku, 9 Papua).
+const (
+	ZoneScopeRegency  = "regency"
+	ZoneScopeProvince = "province"
+	ZoneScopeIsland   = "island"
+	ZoneScopeAll      = "all"
+)
+
+var ErrInvalidZone = errors.New("konfigurasi zona ongkir tidak valid")
+
+// Zone tarif per kg untuk tujuan tertentu. Tujuan cocok bila termasuk RegencyIDs / ProvinceIDs
+// atau sesuai Scope, zona pertama yang cocok yang dipakai.
+type Zone struct {
+	Name        string  `json:"name"`
+	Scope       string  `json:"scope"`
+	ProvinceIDs []int64 `json:"province_ids"`
+	RegencyIDs  []int64 `json:"regency_ids"`
+	FirstKg     float64 `json:"first_kg"` // tarif kg pertama
+	NextKg      float64 `json:"next_kg"`  // tarif tiap kg berikutnya
+	Etd         string  `json:"etd"`
+}
+
+func (z Zone) match(origin, destination Location) bool {
+	if slices.Contains(z.RegencyIDs, destination.RegencyID) || slices.Contains(z.ProvinceIDs, destination.ProvinceID) {
+		return true
+	}
+	switch z.Scope {
+	case ZoneScopeRegency:
+		return origin.RegencyID == destination.RegencyID
+	case ZoneScopeProvince:
+		return origin.ProvinceID == destination.ProvinceID
+	case ZoneScopeIsland:
+		return origin.ProvinceID/10 == destination.ProvinceID/10
+	case ZoneScopeAll:
+		return true
+	}
+	return false
+}
+
+func (z Zone) cost(weightGram int) float64 {
+	return z.FirstKg + float64(BillableKg(weightGram)-1)*z.NextKg
+}
+
+// DefaultZones tarif bawaan bila SHIPPING_ZONES_FILE tidak diisi
+func DefaultZones() []Zone {
+	return []Zone{
+		{Name: "Dalam Kota", Scope: ZoneScopeRegency, FirstKg: 10000, NextKg: 8000, Etd: "1-2"},
+		{Name: "Dalam Provinsi", Scope: ZoneScopeProvince, FirstKg: 15000, NextKg: 12000, Etd: "2-3"},
+		{Name: "Satu Pulau", Scope: ZoneScopeIsland, FirstKg: 22000, NextKg: 18000, Etd: "3-5"},
+		{Name: "Luar Pulau", Scope: ZoneScopeAll, FirstKg: 40000, NextKg: 35000, Etd: "5-8"},
+	}
+}
+
+// LoadZones baca zona dari file JSON (array Zone)
+func LoadZones(file string) ([]Zone, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var zones []Zone
+	if err := json.Unmarshal(b, &zones); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidZone, err)
+	}
+	for _, z := range zones {
+		if z.Name == "" || z.FirstKg < 0 || z.NextKg < 0 {
+			return nil, fmt.Errorf("%w: zona %q", ErrInvalidZone, z.Name)
+		}
+	}
+	return zones, nil
+}
+
+// FlatProvider ongkir berdasarkan zona wilayah dan berat, dikirim sendiri / kurir tanpa integrasi.
+// Nomor resi dibuat dari nomor order, status pengiriman dicatat manual.
+type FlatProvider struct {
+	zones []Zone
+}
+
+func NewFlatProvider(zones []Zone) *FlatProvider {
+	return &FlatProvider{zones: zones}
+}
+
+func (p *FlatProvider) Name() string {
+	return DriverFlat
+}
+
+func (p *FlatProvider) Quote(ctx context.Context, req QuoteRequest) ([]Rate, error) {
+	if req.Origin.IsEmpty() || req.Destination.IsEmpty() {
+		return nil, ErrInvalidLocation
+	}
+
+	weight := ChargeableWeight(req.Items)
+	for _, z := range p.zones {
+		if !z.match(req.Origin, req.Destination) {
+			continue
+		}
+		return []Rate{{
+			Provider:    DriverFlat,
+			Courier:     CourierFlat,
+			CourierName: "Kurir Toko",
+			Service:     ServiceFlat,
+			Description: z.Name,
+			Cost:        z.cost(weight),
+			Etd:         z.Etd,
+			WeightGram:  weight,
+		}}, nil
+	}
+	return nil, ErrNoRate
+}
+
+func (p *FlatProvider) CreateShipment(ctx context.Context, req ShipmentRequest) (Shipment, error) {
+	if req.Reference == "" {
+		return Shipment{}, errors.New("nomor order wajib diisi")
+	}
+
+	rates, err := p.Quote(ctx, QuoteRequest{
+		Origin:      req.Sender.Location,
+		Destination: req.Recipient.Location,
+		Items:       req.Items,
+	})
+	if err != nil {
+		return Shipment{}, err
+	}
+
+	return Shipment{
+		TrackingNumber: flatTrackPrefix + strings.ToUpper(req.Reference),
+		Courier:        CourierFlat,
+		Service:        ServiceFlat,
+		Cost:           rates[0].Cost,
+	}, nil
+}
+
+// Track tidak ada data kurir, status diambil dari catatan pengiriman
+func (p *FlatProvider) Track(ctx context.Context, courier, trackingNumber string) (Tracking, error) {
+	return Tracking{}, ErrNotSupported
+}
+
+func (p *FlatProvider) Label(ctx context.Context, req LabelRequest) (Label, error) {
+	data, err := renderLabelPDF(req)
+	if err != nil {
+		return Label{}, err
+	}
+	return Label{ContentType: ContentTypePDF, Data: data}, nil
+}
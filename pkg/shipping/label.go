@@ -0,0 +1,85 @@
+package shipping
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"pleasurelove/pkg/label"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const ContentTypePDF = "application/pdf"
+
+// Label pengiriman 100 x 150 mm (ukuran umum printer thermal resi)
+const (
+	labelWidth         = 100.0
+	labelHeight        = 150.0
+	labelPadding       = 5.0
+	labelBarcodeHeight = 20.0
+	labelPixelPerMM    = 12
+)
+
+// renderLabelPDF label resi berisi kurir, barcode nomor resi, pengirim dan penerima
+func renderLabelPDF(req LabelRequest) ([]byte, error) {
+	if req.TrackingNumber == "" {
+		return nil, ErrShipmentNotFound
+	}
+
+	pdf := fpdf.NewCustom(&fpdf.InitType{
+		OrientationStr: "P",
+		UnitStr:        "mm",
+		Size:           fpdf.SizeType{Wd: labelWidth, Ht: labelHeight},
+	})
+	pdf.SetMargins(labelPadding, labelPadding, labelPadding)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	contentWidth := labelWidth - 2*labelPadding
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(contentWidth, 8, tr(strings.ToUpper(req.Courier+" "+req.Service)), "", 1, "L", false, 0, "")
+
+	bc, err := label.EncodeBarcode(label.SymbologyCode128, req.TrackingNumber, int(contentWidth*labelPixelPerMM), int(labelBarcodeHeight*labelPixelPerMM))
+	if err != nil {
+		return nil, err
+	}
+	var img bytes.Buffer
+	if err := png.Encode(&img, bc); err != nil {
+		return nil, err
+	}
+	pdf.RegisterImageOptionsReader("resi", fpdf.ImageOptions{ImageType: "PNG"}, &img)
+	pdf.ImageOptions("resi", labelPadding, pdf.GetY()+2, contentWidth, labelBarcodeHeight, true, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(contentWidth, 6, tr(req.TrackingNumber), "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.CellFormat(contentWidth, 5, tr(fmt.Sprintf("Order: %s   Berat: %d gr", req.Reference, req.WeightGram)), "B", 1, "L", false, 0, "")
+
+	writeLabelContact(pdf, tr, contentWidth, "PENERIMA", req.Recipient)
+	writeLabelContact(pdf, tr, contentWidth, "PENGIRIM", req.Sender)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeLabelContact(pdf *fpdf.Fpdf, tr func(string) string, width float64, title string, c Contact) {
+	pdf.Ln(2)
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.CellFormat(width, 5, title, "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.CellFormat(width, 5, tr(c.Name+"  "+c.Phone), "", 1, "L", false, 0, "")
+
+	address := c.Address
+	if c.City != "" {
+		address += ", " + c.City
+	}
+	if c.Location.PostalCode != "" {
+		address += " " + c.Location.PostalCode
+	}
+	pdf.MultiCell(width, 4.5, tr(address), "", "L", false)
+}
@@ -0,0 +1,242 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DriverFlat       = "flat"
+	DriverAggregator = "aggregator"
+)
+
+// VolumetricDivisor pembagi berat volume (cm3 / 6000 = kg) yang umum dipakai kurir
+const VolumetricDivisor = 6000.0
+
+var (
+	ErrUnknownDriver     = errors.New("SHIPPING_PROVIDER tidak dikenali (flat, aggregator)")
+	ErrNoRate            = errors.New("tidak ada layanan pengiriman ke alamat tujuan")
+	ErrNotSupported      = errors.New("fitur tidak didukung provider pengiriman")
+	ErrShipmentNotFound  = errors.New("resi pengiriman tidak ditemukan")
+	ErrInvalidLocation   = errors.New("lokasi asal / tujuan pengiriman belum lengkap")
+	ErrInvalidDistrictID = errors.New("SHIPPING_ORIGIN_DISTRICT_ID harus kode kecamatan 6 digit")
+	ErrNotConfigured     = errors.New("pengiriman belum dikonfigurasi (SHIPPING_ORIGIN_DISTRICT_ID kosong)")
+)
+
+// Provider kurir / agregator ongkir. Lokasi memakai kode wilayah Kemendagri (lihat models.RegionPath).
+type Provider interface {
+	Name() string
+	Quote(ctx context.Context, req QuoteRequest) ([]Rate, error)
+	CreateShipment(ctx context.Context, req ShipmentRequest) (Shipment, error)
+	Track(ctx context.Context, courier, trackingNumber string) (Tracking, error)
+	Label(ctx context.Context, req LabelRequest) (Label, error)
+}
+
+type Location struct {
+	ProvinceID int64
+	RegencyID  int64
+	DistrictID int64
+	VillageID  int64 // opsional
+	PostalCode string
+}
+
+func (l Location) IsEmpty() bool {
+	return l.DistrictID == 0
+}
+
+// LocationFromDistrictID kode kecamatan 6 digit memuat kode kabupaten (4 digit) dan provinsi (2 digit)
+func LocationFromDistrictID(districtID int64, postalCode string) (Location, error) {
+	if districtID < 110000 || districtID > 999999 {
+		return Location{}, ErrInvalidDistrictID
+	}
+	return Location{
+		ProvinceID: districtID / 10000,
+		RegencyID:  districtID / 100,
+		DistrictID: districtID,
+		PostalCode: postalCode,
+	}, nil
+}
+
+type Contact struct {
+	Name     string
+	Phone    string
+	Address  string
+	City     string
+	Location Location
+}
+
+// Item satu baris barang, berat per unit dalam gram dan dimensi per unit dalam cm
+type Item struct {
+	Name       string
+	Quantity   int
+	WeightGram int
+	LengthCm   float64
+	WidthCm    float64
+	HeightCm   float64
+	Value      float64 // nilai barang per unit, untuk asuransi
+}
+
+type QuoteRequest struct {
+	Origin      Location
+	Destination Location
+	Items       []Item
+}
+
+type Rate struct {
+	Provider    string  `json:"provider"`
+	Courier     string  `json:"courier"`
+	CourierName string  `json:"courier_name"`
+	Service     string  `json:"service"`
+	Description string  `json:"description"`
+	Cost        float64 `json:"cost"`
+	Etd         string  `json:"etd"` // perkiraan hari, contoh: 2-3
+	WeightGram  int     `json:"weight_gram"`
+}
+
+type ShipmentRequest struct {
+	Reference string // nomor order
+	Courier   string
+	Service   string
+	Sender    Contact
+	Recipient Contact
+	Items     []Item
+}
+
+type Shipment struct {
+	TrackingNumber string
+	Courier        string
+	Service        string
+	Cost           float64
+}
+
+type TrackingEvent struct {
+	Time        time.Time `json:"time"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+}
+
+type Tracking struct {
+	TrackingNumber string          `json:"tracking_number"`
+	Courier        string          `json:"courier"`
+	Status         string          `json:"status"`
+	Delivered      bool            `json:"delivered"`
+	Events         []TrackingEvent `json:"events"`
+}
+
+type LabelRequest struct {
+	Reference      string
+	TrackingNumber string
+	Courier        string
+	Service        string
+	Sender         Contact
+	Recipient      Contact
+	WeightGram     int
+}
+
+type Label struct {
+	ContentType string
+	Data        []byte
+}
+
+// Config provider terpilih beserta data pengirim (gudang asal)
+type Config struct {
+	Provider Provider
+	Sender   Contact
+}
+
+var Default Config
+
+// InitShipping memilih provider dari env SHIPPING_PROVIDER (default flat). Tanpa
+// SHIPPING_ORIGIN_DISTRICT_ID pengiriman dinonaktifkan dan ErrNotConfigured dikembalikan
+// sebagai peringatan, aplikasi tetap bisa berjalan.
+func InitShipping() error {
+	cfg, err := loadConfig()
+	if err != nil && !errors.Is(err, ErrNotConfigured) {
+		return err
+	}
+	Default = cfg
+	return err
+}
+
+func loadConfig() (Config, error) {
+	if os.Getenv("SHIPPING_ORIGIN_DISTRICT_ID") == "" {
+		return Config{Provider: disabledProvider{}}, ErrNotConfigured
+	}
+	districtID, _ := strconv.ParseInt(os.Getenv("SHIPPING_ORIGIN_DISTRICT_ID"), 10, 64)
+	origin, err := LocationFromDistrictID(districtID, os.Getenv("SHIPPING_ORIGIN_POSTAL_CODE"))
+	if err != nil {
+		return Config{}, err
+	}
+	sender := Contact{
+		Name:     os.Getenv("SHIPPING_SENDER_NAME"),
+		Phone:    os.Getenv("SHIPPING_SENDER_PHONE"),
+		Address:  os.Getenv("SHIPPING_SENDER_ADDRESS"),
+		Location: origin,
+	}
+
+	driver := os.Getenv("SHIPPING_PROVIDER")
+	switch driver {
+	case "", DriverFlat:
+		zones := DefaultZones()
+		if file := os.Getenv("SHIPPING_ZONES_FILE"); file != "" {
+			zones, err = LoadZones(file)
+			if err != nil {
+				return Config{}, err
+			}
+		}
+		return Config{Provider: NewFlatProvider(zones), Sender: sender}, nil
+	case DriverAggregator:
+		var couriers []string
+		for _, c := range strings.Split(os.Getenv("SHIPPING_COURIERS"), ",") {
+			if c = strings.TrimSpace(c); c != "" {
+				couriers = append(couriers, strings.ToLower(c))
+			}
+		}
+		aggregator, err := NewAggregatorProvider(AggregatorConfig{
+			BaseURL:  os.Getenv("SHIPPING_AGGREGATOR_URL"),
+			APIKey:   os.Getenv("SHIPPING_AGGREGATOR_KEY"),
+			Couriers: couriers,
+		})
+		if err != nil {
+			return Config{}, err
+		}
+		return Config{Provider: aggregator, Sender: sender}, nil
+	}
+	return Config{}, fmt.Errorf("%w: %s", ErrUnknownDriver, driver)
+}
+
+// ActualWeight total berat barang dalam gram
+func ActualWeight(items []Item) int {
+	var total int
+	for _, item := range items {
+		total += item.WeightGram * item.Quantity
+	}
+	return total
+}
+
+// VolumetricWeight total berat volume dalam gram (dibulatkan ke atas)
+func VolumetricWeight(items []Item) int {
+	var volume float64
+	for _, item := range items {
+		volume += item.LengthCm * item.WidthCm * item.HeightCm * float64(item.Quantity)
+	}
+	return int(math.Ceil(volume / VolumetricDivisor * 1000))
+}
+
+// ChargeableWeight berat yang ditagihkan kurir: yang terbesar dari berat aktual dan berat volume
+func ChargeableWeight(items []Item) int {
+	return max(ActualWeight(items), VolumetricWeight(items))
+}
+
+// BillableKg berat dalam kg yang dibulatkan ke atas, minimal 1 kg
+func BillableKg(weightGram int) int {
+	kg := int(math.Ceil(float64(weightGram) / 1000))
+	return max(kg, 1)
+}
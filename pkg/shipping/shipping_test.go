@@ -0,0 +1,289 @@
+package shipping
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testAPIKey = "secret-key"
+
+var (
+	jakartaPusat = Location{ProvinceID: 31, RegencyID: 3173, DistrictID: 317301, PostalCode: "10110"}
+	jakartaUtara = Location{ProvinceID: 31, RegencyID: 3175, DistrictID: 317501, PostalCode: "14310"}
+	bandung      = Location{ProvinceID: 32, RegencyID: 3273, DistrictID: 327301, PostalCode: "40111"}
+	medan        = Location{ProvinceID: 12, RegencyID: 1275, DistrictID: 127501, PostalCode: "20111"}
+)
+
+// fakeAggregator stand-in agregator ongkir: memeriksa API key lalu menjawab seperti agregator asli
+type fakeAggregator struct {
+	costReq     aggregatorCostRequest
+	shipmentReq aggregatorShipmentRequest
+}
+
+func (f *fakeAggregator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(aggregatorKeyHeader) != testAPIKey {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+			"status": aggregatorStatus{Code: http.StatusUnauthorized, Description: "Invalid key"},
+		})
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	switch r.URL.Path {
+	case "/cost":
+		_ = json.Unmarshal(body, &f.costReq)
+		w.Write([]byte(`{"status":{"code":200,"description":"OK"},"results":[
+			{"code":"jne","name":"JNE","costs":[
+				{"service":"REG","description":"Layanan Reguler","cost":[{"value":18000,"etd":"2-3"}]},
+				{"service":"YES","description":"Yakin Esok Sampai","cost":[{"value":30000,"etd":"1"}]}]},
+			{"code":"sicepat","name":"SiCepat","costs":[
+				{"service":"BEST","description":"Besok Sampai Tujuan","cost":[]}]}]}`))
+	case "/shipment":
+		_ = json.Unmarshal(body, &f.shipmentReq)
+		w.Write([]byte(`{"status":{"code":200,"description":"OK"},"result":{"waybill":"JNE0001","courier":"JNE","service":"REG","cost":18000}}`))
+	case "/waybill":
+		var req aggregatorWaybillRequest
+		_ = json.Unmarshal(body, &req)
+		if req.Waybill != "JNE0001" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"status": aggregatorStatus{Code: http.StatusBadRequest, Description: "Invalid waybill"},
+			})
+			return
+		}
+		w.Write([]byte(`{"status":{"code":200,"description":"OK"},"result":{"delivered":true,"summary":{"status":"DELIVERED"},"manifest":[
+			{"manifest_code":"1","manifest_description":"Manifested","manifest_date":"2026-10-18","manifest_time":"09:00","city_name":"JAKARTA"},
+			{"manifest_code":"3","manifest_description":"Delivered to BUDI","manifest_date":"2026-10-19","manifest_time":"14:30","city_name":"BANDUNG"}]}}`))
+	case "/label":
+		if r.URL.Query().Get("waybill") != "JNE0001" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", ContentTypePDF)
+		w.Write([]byte("%PDF-1.4 label"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestAggregator(t *testing.T, apiKey string) (*AggregatorProvider, *fakeAggregator) {
+	fake := &fakeAggregator{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	p, err := NewAggregatorProvider(AggregatorConfig{BaseURL: server.URL + "/", APIKey: apiKey, Couriers: []string{"jne", "sicepat"}})
+	if err != nil {
+		t.Fatalf("NewAggregatorProvider: %v", err)
+	}
+	return p, fake
+}
+
+func TestAggregatorQuote(t *testing.T) {
+	p, fake := newTestAggregator(t, testAPIKey)
+
+	items := []Item{
+		{Name: "Kaos", Quantity: 2, WeightGram: 300, LengthCm: 30, WidthCm: 20, HeightCm: 5, Value: 100000}, // berat volume 1 kg
+	}
+	rates, err := p.Quote(context.Background(), QuoteRequest{Origin: jakartaPusat, Destination: bandung, Items: items})
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+
+	// layanan tanpa tarif (sicepat BEST) dilewati
+	if len(rates) != 2 {
+		t.Fatalf("rates = %+v, want 2", rates)
+	}
+	if r := rates[0]; r.Courier != "jne" || r.Service != "REG" || r.Cost != 18000 || r.Etd != "2-3" || r.WeightGram != 1000 {
+		t.Errorf("rate[0] = %+v", r)
+	}
+
+	req := fake.costReq
+	if req.Origin != 317301 || req.Destination != 327301 || req.Weight != 1000 || req.Courier != "jne:sicepat" || req.ItemValue != 200000 {
+		t.Errorf("cost request = %+v", req)
+	}
+}
+
+func TestAggregatorShipmentTrackingLabel(t *testing.T) {
+	p, fake := newTestAggregator(t, testAPIKey)
+	ctx := context.Background()
+
+	shipment, err := p.CreateShipment(ctx, ShipmentRequest{
+		Reference: "ORD-1",
+		Courier:   "jne",
+		Service:   "REG",
+		Sender:    Contact{Name: "Toko", Location: jakartaPusat},
+		Recipient: Contact{Name: "Budi", Location: bandung},
+		Items:     []Item{{Name: "Kaos", Quantity: 1, WeightGram: 1200, Value: 100000}},
+	})
+	if err != nil {
+		t.Fatalf("CreateShipment: %v", err)
+	}
+	if shipment.TrackingNumber != "JNE0001" || shipment.Courier != "jne" || shipment.Cost != 18000 {
+		t.Errorf("shipment = %+v", shipment)
+	}
+	if fake.shipmentReq.Recipient.DistrictID != 327301 || fake.shipmentReq.Weight != 1200 || len(fake.shipmentReq.Items) != 1 {
+		t.Errorf("shipment request = %+v", fake.shipmentReq)
+	}
+
+	tracking, err := p.Track(ctx, "jne", "JNE0001")
+	if err != nil {
+		t.Fatalf("Track: %v", err)
+	}
+	if !tracking.Delivered || tracking.Status != "DELIVERED" || len(tracking.Events) != 2 {
+		t.Fatalf("tracking = %+v", tracking)
+	}
+	if e := tracking.Events[1]; e.Location != "BANDUNG" || e.Time.Hour() != 14 || e.Time.Minute() != 30 {
+		t.Errorf("event = %+v", e)
+	}
+
+	if _, err := p.Track(ctx, "jne", "XXX"); !errors.Is(err, ErrAggregator) {
+		t.Errorf("Track resi salah err = %v, want ErrAggregator", err)
+	}
+
+	label, err := p.Label(ctx, LabelRequest{TrackingNumber: "JNE0001", Courier: "jne"})
+	if err != nil || label.ContentType != ContentTypePDF || !bytes.HasPrefix(label.Data, []byte("%PDF")) {
+		t.Errorf("label = %q (%s), err %v", label.Data, label.ContentType, err)
+	}
+	if _, err := p.Label(ctx, LabelRequest{TrackingNumber: "XXX", Courier: "jne"}); !errors.Is(err, ErrShipmentNotFound) {
+		t.Errorf("Label resi salah err = %v, want ErrShipmentNotFound", err)
+	}
+}
+
+func TestAggregatorWrongKey(t *testing.T) {
+	p, _ := newTestAggregator(t, "wrong")
+	_, err := p.Quote(context.Background(), QuoteRequest{Origin: jakartaPusat, Destination: bandung})
+	if !errors.Is(err, ErrAggregator) {
+		t.Fatalf("err = %v, want ErrAggregator", err)
+	}
+}
+
+func TestFlatQuoteZones(t *testing.T) {
+	p := NewFlatProvider(DefaultZones())
+	items := []Item{{Quantity: 1, WeightGram: 2500}}
+
+	tests := []struct {
+		destination Location
+		zone        string
+		cost        float64
+	}{
+		{jakartaPusat, "Dalam Kota", 10000 + 2*8000},
+		{jakartaUtara, "Dalam Provinsi", 15000 + 2*12000},
+		{bandung, "Satu Pulau", 22000 + 2*18000},
+		{medan, "Luar Pulau", 40000 + 2*35000},
+	}
+	for _, tt := range tests {
+		rates, err := p.Quote(context.Background(), QuoteRequest{Origin: jakartaPusat, Destination: tt.destination, Items: items})
+		if err != nil {
+			t.Fatalf("Quote %d: %v", tt.destination.DistrictID, err)
+		}
+		if rates[0].Description != tt.zone || rates[0].Cost != tt.cost {
+			t.Errorf("Quote %d = %s %v, want %s %v", tt.destination.DistrictID, rates[0].Description, rates[0].Cost, tt.zone, tt.cost)
+		}
+	}
+}
+
+func TestFlatExplicitZoneAndNoRate(t *testing.T) {
+	p := NewFlatProvider([]Zone{
+		{Name: "Bandung", RegencyIDs: []int64{3273}, FirstKg: 9000, NextKg: 5000},
+		{Name: "Jakarta", Scope: ZoneScopeProvince, FirstKg: 12000, NextKg: 6000},
+	})
+
+	rates, err := p.Quote(context.Background(), QuoteRequest{Origin: jakartaPusat, Destination: bandung})
+	if err != nil || rates[0].Description != "Bandung" || rates[0].Cost != 9000 {
+		t.Fatalf("rates = %+v, err %v", rates, err)
+	}
+
+	if _, err := p.Quote(context.Background(), QuoteRequest{Origin: jakartaPusat, Destination: medan}); !errors.Is(err, ErrNoRate) {
+		t.Errorf("err = %v, want ErrNoRate", err)
+	}
+}
+
+func TestFlatShipmentLabel(t *testing.T) {
+	p := NewFlatProvider(DefaultZones())
+	ctx := context.Background()
+
+	shipment, err := p.CreateShipment(ctx, ShipmentRequest{
+		Reference: "ord-1",
+		Sender:    Contact{Name: "Toko", Location: jakartaPusat},
+		Recipient: Contact{Name: "Budi", Location: bandung},
+		Items:     []Item{{Quantity: 1, WeightGram: 800}},
+	})
+	if err != nil || shipment.TrackingNumber != "FLT-ORD-1" || shipment.Cost != 22000 {
+		t.Fatalf("shipment = %+v, err %v", shipment, err)
+	}
+
+	if _, err := p.Track(ctx, CourierFlat, shipment.TrackingNumber); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Track err = %v, want ErrNotSupported", err)
+	}
+
+	label, err := p.Label(ctx, LabelRequest{
+		Reference:      "ORD-1",
+		TrackingNumber: shipment.TrackingNumber,
+		Courier:        CourierFlat,
+		Service:        ServiceFlat,
+		Sender:         Contact{Name: "Toko", Address: "Jl. Gambir 1", City: "Jakarta Pusat", Location: jakartaPusat},
+		Recipient:      Contact{Name: "Budi", Address: "Jl. Braga 2", City: "Bandung", Location: bandung},
+		WeightGram:     800,
+	})
+	if err != nil || !bytes.HasPrefix(label.Data, []byte("%PDF")) {
+		t.Errorf("label err %v, data %d byte", err, len(label.Data))
+	}
+}
+
+func TestWeight(t *testing.T) {
+	items := []Item{
+		{Quantity: 2, WeightGram: 100, LengthCm: 40, WidthCm: 30, HeightCm: 10}, // volume 24000 cm3 = 4 kg
+		{Quantity: 1, WeightGram: 500},
+	}
+	if got := ActualWeight(items); got != 700 {
+		t.Errorf("ActualWeight = %d", got)
+	}
+	if got := ChargeableWeight(items); got != 4000 {
+		t.Errorf("ChargeableWeight = %d", got)
+	}
+	if BillableKg(0) != 1 || BillableKg(1001) != 2 || BillableKg(2000) != 2 {
+		t.Errorf("BillableKg salah")
+	}
+
+	loc, err := LocationFromDistrictID(317301, "10110")
+	if err != nil || loc.ProvinceID != 31 || loc.RegencyID != 3173 {
+		t.Errorf("LocationFromDistrictID = %+v, err %v", loc, err)
+	}
+	if _, err := LocationFromDistrictID(3173, ""); !errors.Is(err, ErrInvalidDistrictID) {
+		t.Errorf("err = %v, want ErrInvalidDistrictID", err)
+	}
+}
+
+func TestLoadConfigNotConfigured(t *testing.T) {
+	t.Setenv("SHIPPING_ORIGIN_DISTRICT_ID", "")
+	cfg, err := loadConfig()
+	if !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("err = %v, want ErrNotConfigured", err)
+	}
+	if _, err := cfg.Provider.Quote(context.Background(), QuoteRequest{}); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("Quote err = %v, want ErrNotConfigured", err)
+	}
+
+	t.Setenv("SHIPPING_ORIGIN_DISTRICT_ID", "3173")
+	if _, err := loadConfig(); !errors.Is(err, ErrInvalidDistrictID) {
+		t.Errorf("err = %v, want ErrInvalidDistrictID", err)
+	}
+
+	t.Setenv("SHIPPING_ORIGIN_DISTRICT_ID", "317306")
+	t.Setenv("SHIPPING_PROVIDER", "")
+	t.Setenv("SHIPPING_ZONES_FILE", "")
+	if cfg, err := loadConfig(); err != nil || cfg.Provider.Name() != DriverFlat {
+		t.Errorf("flat = %v, err %v", cfg.Provider, err)
+	}
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var (
+	ErrInvalidKey    = errors.New("key file tidak valid")
+	ErrUnknownDriver = errors.New("STORAGE_DRIVER tidak dikenali (local, s3)")
+)
+
+// Storage tempat penyimpanan file (gambar produk, dll). Key memakai pemisah "/"
+// tanpa awalan, contoh: product/12/abc.jpg
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+var Store Storage
+
+// InitStorage memilih implementasi storage dari env STORAGE_DRIVER (default local)
+func InitStorage() error {
+	driver := os.Getenv("STORAGE_DRIVER")
+	switch driver {
+	case "", DriverLocal:
+		dir := getEnvDefault("STORAGE_LOCAL_DIR", "./uploads")
+		Store = NewLocalStorage(dir, getEnvDefault("STORAGE_PUBLIC_URL", LocalURLPrefix))
+	case DriverS3:
+		s3, err := NewS3Storage(S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    getEnvDefault("S3_REGION", "us-east-1"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PublicURL: os.Getenv("STORAGE_PUBLIC_URL"),
+		})
+		if err != nil {
+			return err
+		}
+		Store = s3
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownDriver, driver)
+	}
+	return nil
+}
+
+// cleanKey menolak key kosong / absolut / keluar dari root (..)
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || strings.HasPrefix(cleaned, "../") || cleaned == ".." {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
+
+func joinURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + key
+}
+
+func getEnvDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
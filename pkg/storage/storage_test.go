@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testBucket    = "media"
+	testRegion    = "ap-southeast-1"
+)
+
+// fakeS3 stand-in S3 di memori: memeriksa signature V4 lalu menyimpan / menghapus object
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if err := verifySignature(r, body); err != "" {
+		http.Error(w, err, http.StatusForbidden)
+		return
+	}
+
+	prefix := "/" + testBucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[key] = fakeObject{data: body, contentType: r.Header.Get("Content-Type")}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verifySignature menghitung ulang signature dari request yang diterima server
+func verifySignature(r *http.Request, body []byte) string {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, s3Algorithm+" ") {
+		return "missing authorization"
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	date, err := time.Parse(s3AmzDateFormat, amzDate)
+	if err != nil {
+		return "invalid x-amz-date"
+	}
+	if r.Header.Get("X-Amz-Content-Sha256") != sha256Hex(body) {
+		return "payload hash mismatch"
+	}
+
+	scope := date.Format(s3DateFormat) + "/" + testRegion + "/s3/aws4_request"
+	canonical := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		r.URL.RawQuery,
+		"host:" + r.Host + "\nx-amz-content-sha256:" + sha256Hex(body) + "\nx-amz-date:" + amzDate + "\n",
+		"host;x-amz-content-sha256;x-amz-date",
+		sha256Hex(body),
+	}, "\n")
+	stringToSign := s3Algorithm + "\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonical))
+
+	key := hmacSHA256([]byte("AWS4"+testSecretKey), date.Format(s3DateFormat))
+	key = hmacSHA256(key, testRegion)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	want := "Credential=" + testAccessKey + "/" + scope + ", SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=" +
+		hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	if strings.TrimPrefix(auth, s3Algorithm+" ") != want {
+		return "SignatureDoesNotMatch"
+	}
+	return ""
+}
+
+func newTestS3(t *testing.T) (*S3Storage, *fakeS3) {
+	t.Helper()
+	fake := &fakeS3{objects: map[string]fakeObject{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	s, err := NewS3Storage(S3Config{
+		Endpoint:  srv.URL,
+		Region:    testRegion,
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, fake
+}
+
+func TestS3Storage(t *testing.T) {
+	ctx := context.Background()
+	s, fake := newTestS3(t)
+
+	keys := []string{"product/1/abc.jpg", "product/1/nama file+spasi.png"}
+	for _, key := range keys {
+		if err := s.Put(ctx, key, []byte("isi "+key), "image/jpeg"); err != nil {
+			t.Fatalf("put %q: %v", key, err)
+		}
+		obj, ok := fake.objects[key]
+		if !ok {
+			t.Fatalf("object %q tidak tersimpan", key)
+		}
+		if string(obj.data) != "isi "+key || obj.contentType != "image/jpeg" {
+			t.Errorf("object %q = %q (%s)", key, obj.data, obj.contentType)
+		}
+	}
+
+	if got, want := s.URL(keys[1]), s.cfg.Endpoint+"/media/product/1/nama%20file%2Bspasi.png"; got != want {
+		t.Errorf("URL = %q, want %q", got, want)
+	}
+
+	for _, key := range keys {
+		if err := s.Delete(ctx, key); err != nil {
+			t.Fatalf("delete %q: %v", key, err)
+		}
+	}
+	if len(fake.objects) != 0 {
+		t.Errorf("object tersisa: %v", fake.objects)
+	}
+
+	// object yang sudah tidak ada tetap berhasil dihapus
+	if err := s.Delete(ctx, keys[0]); err != nil {
+		t.Errorf("delete ulang: %v", err)
+	}
+}
+
+func TestS3StorageWrongSecret(t *testing.T) {
+	s, _ := newTestS3(t)
+	s.cfg.SecretKey = "salah"
+
+	err := s.Put(context.Background(), "product/1/abc.jpg", []byte("x"), "image/jpeg")
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("err = %v, want status 403", err)
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := NewLocalStorage(dir, "/media/")
+
+	if err := s.Put(ctx, "product/1/abc.jpg", []byte("data"), "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "product", "1", "abc.jpg"))
+	if err != nil || string(got) != "data" {
+		t.Fatalf("isi file = %q, err %v", got, err)
+	}
+	if url := s.URL("product/1/abc.jpg"); url != "/media/product/1/abc.jpg" {
+		t.Errorf("URL = %q", url)
+	}
+
+	if err := s.Delete(ctx, "product/1/abc.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, "product/1/abc.jpg"); err != nil {
+		t.Errorf("delete ulang: %v", err)
+	}
+}
+
+func TestInvalidKey(t *testing.T) {
+	s := NewLocalStorage(t.TempDir(), LocalURLPrefix)
+	for _, key := range []string{"", "/etc/passwd", "../secret", "product/../../secret", "product//a.jpg", `product\a.jpg`} {
+		if err := s.Put(context.Background(), key, []byte("x"), ""); err != ErrInvalidKey {
+			t.Errorf("Put(%q) err = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
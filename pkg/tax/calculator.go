@@ -0,0 +1,153 @@
+package tax
+
+import "math"
+
+// Line satu baris order. Amount = nilai baris setelah diskon, sudah / belum termasuk pajak sesuai Config.Mode
+type Line struct {
+	Amount float64
+	Rate   ClassRate
+}
+
+// LineTax pajak satu baris, Net = dasar pengenaan pajak (DPP), Gross = Net + Tax
+type LineTax struct {
+	Net   float64
+	Tax   float64
+	Gross float64
+}
+
+// Breakdown rekap pajak per kelas dan tarif untuk order / invoice
+type Breakdown struct {
+	ClassID       int64
+	ClassCode     string
+	ClassName     string
+	RateName      string
+	Percent       float64
+	TaxableAmount float64
+	TaxAmount     float64
+}
+
+type Result struct {
+	Lines      []LineTax   // urutan sama dengan lines
+	Breakdown  []Breakdown // urutan kemunculan kelas / tarif pertama
+	NetTotal   float64
+	TaxTotal   float64
+	GrossTotal float64 // total yang dibayar pelanggan sebelum ongkir
+}
+
+// Calculate menghitung pajak seluruh baris. Dengan RoundingOrder pajak per tarif dibulatkan sekali
+// lalu dibagi ke baris secara proporsional, sehingga jumlah pajak baris selalu sama dengan rekap.
+func Calculate(cfg Config, lines []Line) Result {
+	res := Result{Lines: make([]LineTax, len(lines))}
+
+	groups := map[ClassRate]int{}
+	var members [][]int
+	for i, line := range lines {
+		idx, ok := groups[line.Rate]
+		if !ok {
+			idx = len(res.Breakdown)
+			groups[line.Rate] = idx
+			members = append(members, nil)
+			res.Breakdown = append(res.Breakdown, Breakdown{
+				ClassID:   line.Rate.ClassID,
+				ClassCode: line.Rate.ClassCode,
+				ClassName: line.Rate.ClassName,
+				RateName:  line.Rate.RateName,
+				Percent:   line.Rate.Percent,
+			})
+		}
+		members[idx] = append(members[idx], i)
+	}
+
+	for g, idxs := range members {
+		percent := res.Breakdown[g].Percent
+		taxes := make([]float64, len(idxs))
+
+		if cfg.Rounding == RoundingLine {
+			for k, i := range idxs {
+				taxes[k] = round2(taxOf(cfg.Mode, positive(lines[i].Amount), percent))
+			}
+		} else {
+			weights := make([]float64, len(idxs))
+			var total float64
+			for k, i := range idxs {
+				weights[k] = positive(lines[i].Amount)
+				total += weights[k]
+			}
+			taxes = distribute(round2(taxOf(cfg.Mode, total, percent)), weights)
+		}
+
+		for k, i := range idxs {
+			amount := round2(lines[i].Amount)
+			lt := LineTax{Tax: taxes[k]}
+			if cfg.Mode == ModeInclusive {
+				lt.Gross = amount
+				lt.Net = round2(amount - lt.Tax)
+			} else {
+				lt.Net = amount
+				lt.Gross = round2(amount + lt.Tax)
+			}
+			res.Lines[i] = lt
+
+			res.Breakdown[g].TaxableAmount += lt.Net
+			res.Breakdown[g].TaxAmount += lt.Tax
+		}
+		res.Breakdown[g].TaxableAmount = round2(res.Breakdown[g].TaxableAmount)
+		res.Breakdown[g].TaxAmount = round2(res.Breakdown[g].TaxAmount)
+	}
+
+	for _, lt := range res.Lines {
+		res.NetTotal += lt.Net
+		res.TaxTotal += lt.Tax
+		res.GrossTotal += lt.Gross
+	}
+	res.NetTotal = round2(res.NetTotal)
+	res.TaxTotal = round2(res.TaxTotal)
+	res.GrossTotal = round2(res.GrossTotal)
+	return res
+}
+
+// taxOf pajak dari nilai belum dibulatkan, harga inklusif: pajak = harga x tarif / (100 + tarif)
+func taxOf(mode string, amount, percent float64) float64 {
+	if amount <= 0 || percent <= 0 {
+		return 0
+	}
+	if mode == ModeInclusive {
+		return amount * percent / (100 + percent)
+	}
+	return amount * percent / 100
+}
+
+// distribute membagi amount sesuai bobot, selisih pembulatan masuk ke baris terakhir
+func distribute(amount float64, weights []float64) []float64 {
+	result := make([]float64, len(weights))
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	if amount <= 0 || total <= 0 {
+		return result
+	}
+
+	last := -1
+	var allocated float64
+	for i, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		result[i] = round2(amount * weight / total)
+		allocated += result[i]
+		last = i
+	}
+	if last >= 0 {
+		result[last] = round2(result[last] + amount - allocated)
+	}
+	return result
+}
+
+func positive(v float64) float64 {
+	return math.Max(v, 0)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
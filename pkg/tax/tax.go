@@ -0,0 +1,101 @@
+package tax
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// Mode harga jual produk
+const (
+	ModeExclusive = "exclusive" // harga belum termasuk pajak, pajak ditambahkan di atas harga
+	ModeInclusive = "inclusive" // harga sudah termasuk pajak, pajak dihitung mundur dari harga
+)
+
+// Rounding titik pembulatan pajak
+const (
+	RoundingLine  = "line"  // pajak dibulatkan per baris lalu dijumlahkan
+	RoundingOrder = "order" // pajak dihitung dari total per tarif lalu dibulatkan sekali
+)
+
+// Config mode harga dan pembulatan pajak toko
+type Config struct {
+	Mode     string
+	Rounding string
+}
+
+var Default Config
+
+// InitTax membaca TAX_PRICE_MODE (exclusive / inclusive) dan TAX_ROUNDING (order / line)
+func InitTax() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	Default = cfg
+	return nil
+}
+
+func loadConfig() (Config, error) {
+	cfg := Config{Mode: ModeExclusive, Rounding: RoundingOrder}
+	if mode := os.Getenv("TAX_PRICE_MODE"); mode != "" {
+		cfg.Mode = mode
+	}
+	if rounding := os.Getenv("TAX_ROUNDING"); rounding != "" {
+		cfg.Rounding = rounding
+	}
+
+	if cfg.Mode != ModeExclusive && cfg.Mode != ModeInclusive {
+		return Config{}, fmt.Errorf("TAX_PRICE_MODE tidak valid: %q (exclusive, inclusive)", cfg.Mode)
+	}
+	if cfg.Rounding != RoundingOrder && cfg.Rounding != RoundingLine {
+		return Config{}, fmt.Errorf("TAX_ROUNDING tidak valid: %q (order, line)", cfg.Rounding)
+	}
+	return cfg, nil
+}
+
+// Rate tarif kelas pajak yang berlaku mulai EffectiveFrom sampai digantikan tarif berikutnya
+type Rate struct {
+	Name          string
+	Percent       float64
+	EffectiveFrom time.Time
+}
+
+// Class kelas pajak (mis. standar PPN, bebas PPN) beserta riwayat tarifnya
+type Class struct {
+	ID    int64
+	Code  string
+	Name  string
+	Rates []Rate
+}
+
+// ClassRate tarif satu kelas pajak pada waktu tertentu
+type ClassRate struct {
+	ClassID   int64
+	ClassCode string
+	ClassName string
+	RateName  string
+	Percent   float64
+}
+
+// RateAt tarif yang berlaku pada waktu at, false bila belum ada tarif yang berlaku
+func (c Class) RateAt(at time.Time) (ClassRate, bool) {
+	rates := append([]Rate(nil), c.Rates...)
+	sort.SliceStable(rates, func(i, j int) bool {
+		return rates[i].EffectiveFrom.After(rates[j].EffectiveFrom)
+	})
+
+	for _, r := range rates {
+		if !r.EffectiveFrom.After(at) {
+			return ClassRate{
+				ClassID:   c.ID,
+				ClassCode: c.Code,
+				ClassName: c.Name,
+				RateName:  r.Name,
+				Percent:   r.Percent,
+			}, true
+		}
+	}
+	return ClassRate{}, false
+}
@@ -0,0 +1,160 @@
+package tax
+
+import (
+	"testing"
+	"time"
+)
+
+var (
+	ppn    = ClassRate{ClassID: 1, ClassCode: "STANDARD", ClassName: "Standar", RateName: "PPN 11%", Percent: 11}
+	exempt = ClassRate{ClassID: 2, ClassCode: "EXEMPT", ClassName: "Bebas PPN", RateName: "Bebas PPN", Percent: 0}
+)
+
+func TestCalculateRounding(t *testing.T) {
+	lines := []Line{
+		{Amount: 10.05, Rate: ppn},
+		{Amount: 10.05, Rate: ppn},
+		{Amount: 10.05, Rate: ppn},
+	}
+
+	tests := []struct {
+		name      string
+		rounding  string
+		wantTax   float64
+		wantLines []float64
+	}{
+		// 3 x 1.1055 dibulatkan per baris = 3 x 1.11
+		{name: "per baris", rounding: RoundingLine, wantTax: 3.33, wantLines: []float64{1.11, 1.11, 1.11}},
+		// 30.15 x 11% = 3.3165 dibulatkan sekali, selisih masuk ke baris terakhir
+		{name: "per order", rounding: RoundingOrder, wantTax: 3.32, wantLines: []float64{1.11, 1.11, 1.10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Calculate(Config{Mode: ModeExclusive, Rounding: tt.rounding}, lines)
+			if res.TaxTotal != tt.wantTax {
+				t.Errorf("TaxTotal = %v, want %v", res.TaxTotal, tt.wantTax)
+			}
+			var sum float64
+			for i, lt := range res.Lines {
+				if lt.Tax != tt.wantLines[i] {
+					t.Errorf("line[%d].Tax = %v, want %v", i, lt.Tax, tt.wantLines[i])
+				}
+				sum += lt.Tax
+			}
+			if round2(sum) != res.TaxTotal {
+				t.Errorf("jumlah pajak baris %v != TaxTotal %v", round2(sum), res.TaxTotal)
+			}
+			if res.NetTotal != 30.15 || res.GrossTotal != round2(30.15+tt.wantTax) {
+				t.Errorf("NetTotal = %v, GrossTotal = %v", res.NetTotal, res.GrossTotal)
+			}
+		})
+	}
+}
+
+func TestCalculateInclusive(t *testing.T) {
+	lines := []Line{
+		{Amount: 1000, Rate: ppn},
+		{Amount: 2500, Rate: ppn},
+		{Amount: 500, Rate: exempt},
+	}
+	res := Calculate(Config{Mode: ModeInclusive, Rounding: RoundingOrder}, lines)
+
+	// 3500 x 11 / 111 = 346.85
+	if res.TaxTotal != 346.85 {
+		t.Errorf("TaxTotal = %v, want 346.85", res.TaxTotal)
+	}
+	if res.GrossTotal != 4000 || res.NetTotal != 3653.15 {
+		t.Errorf("GrossTotal = %v, NetTotal = %v", res.GrossTotal, res.NetTotal)
+	}
+	if lt := res.Lines[0]; lt.Tax != 99.10 || lt.Net != 900.90 || lt.Gross != 1000 {
+		t.Errorf("line[0] = %+v", lt)
+	}
+	if lt := res.Lines[2]; lt.Tax != 0 || lt.Net != 500 {
+		t.Errorf("line bebas pajak = %+v", lt)
+	}
+
+	if len(res.Breakdown) != 2 {
+		t.Fatalf("breakdown = %+v, want 2", res.Breakdown)
+	}
+	if b := res.Breakdown[0]; b.ClassCode != "STANDARD" || b.TaxableAmount != 3153.15 || b.TaxAmount != 346.85 {
+		t.Errorf("breakdown PPN = %+v", b)
+	}
+	if b := res.Breakdown[1]; b.ClassCode != "EXEMPT" || b.TaxableAmount != 500 || b.TaxAmount != 0 {
+		t.Errorf("breakdown bebas PPN = %+v", b)
+	}
+}
+
+func TestCalculateExclusiveBreakdownPerRate(t *testing.T) {
+	ppn12 := ppn
+	ppn12.RateName, ppn12.Percent = "PPN 12%", 12
+
+	res := Calculate(Config{Mode: ModeExclusive, Rounding: RoundingOrder}, []Line{
+		{Amount: 100000, Rate: ppn},
+		{Amount: 50000, Rate: ppn12},
+		{Amount: -10, Rate: ppn}, // baris dengan diskon melebihi harga tidak dikenai pajak
+	})
+
+	if len(res.Breakdown) != 2 {
+		t.Fatalf("breakdown = %+v, want 2 (tarif berbeda dipisah)", res.Breakdown)
+	}
+	if res.Breakdown[0].TaxAmount != 11000 || res.Breakdown[1].TaxAmount != 6000 {
+		t.Errorf("breakdown = %+v", res.Breakdown)
+	}
+	if res.TaxTotal != 17000 || res.Lines[2].Tax != 0 {
+		t.Errorf("TaxTotal = %v, line[2] = %+v", res.TaxTotal, res.Lines[2])
+	}
+}
+
+func TestClassRateAt(t *testing.T) {
+	class := Class{
+		ID: 1, Code: "STANDARD", Name: "Standar",
+		Rates: []Rate{
+			{Name: "PPN 11%", Percent: 11, EffectiveFrom: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)},
+			{Name: "PPN 10%", Percent: 10, EffectiveFrom: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{Name: "PPN 12%", Percent: 12, EffectiveFrom: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	tests := []struct {
+		at   time.Time
+		want float64
+		ok   bool
+	}{
+		{at: time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC), ok: false},
+		{at: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), want: 10, ok: true},
+		{at: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC), want: 11, ok: true},
+		{at: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), want: 11, ok: true},
+		{at: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), want: 12, ok: true},
+	}
+	for _, tt := range tests {
+		rate, ok := class.RateAt(tt.at)
+		if ok != tt.ok || rate.Percent != tt.want {
+			t.Errorf("RateAt(%s) = %+v, %v, want %v, %v", tt.at.Format("2006-01-02"), rate, ok, tt.want, tt.ok)
+		}
+		if ok && rate.ClassCode != "STANDARD" {
+			t.Errorf("RateAt(%s).ClassCode = %q", tt.at.Format("2006-01-02"), rate.ClassCode)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("TAX_PRICE_MODE", "")
+	t.Setenv("TAX_ROUNDING", "")
+	cfg, err := loadConfig()
+	if err != nil || cfg.Mode != ModeExclusive || cfg.Rounding != RoundingOrder {
+		t.Errorf("default = %+v, %v", cfg, err)
+	}
+
+	t.Setenv("TAX_PRICE_MODE", ModeInclusive)
+	t.Setenv("TAX_ROUNDING", RoundingLine)
+	cfg, err = loadConfig()
+	if err != nil || cfg.Mode != ModeInclusive || cfg.Rounding != RoundingLine {
+		t.Errorf("env = %+v, %v", cfg, err)
+	}
+
+	t.Setenv("TAX_PRICE_MODE", "gross")
+	if _, err := loadConfig(); err == nil {
+		t.Error("TAX_PRICE_MODE tidak valid harus error")
+	}
+}